@@ -0,0 +1,160 @@
+package cre
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrCommandNotAllowed is returned by ReadOnlyView.Run for CLI commands which are not known to
+// be read-only.
+var ErrCommandNotAllowed = errors.New("cre: command not allowed in read-only view")
+
+// ErrNoCLI is returned by ReadOnlyView.Run when the runner has no CLI configured.
+var ErrNoCLI = errors.New("cre: no CLI runner configured")
+
+// ReadOnlyView is a read-only view of CRE, which can inspect workflows and registries without
+// being able to deploy or change them.
+type ReadOnlyView interface {
+	// Run executes a read-only CLI command with optional per-invocation env vars. The leading
+	// arguments must match one of the allowed commands, otherwise ErrCommandNotAllowed is returned
+	// without running the CLI.
+	Run(ctx context.Context, env map[string]string, args ...string) (*CallResult, error)
+	// ContextRegistries returns workflow registries defined from domain.yaml.
+	ContextRegistries() []ContextRegistryEntry
+	// WithNamedAPIKey returns a ReadOnlyView that uses the API key registered under the given
+	// name. See CLIRunner.WithNamedAPIKey.
+	WithNamedAPIKey(name string) (ReadOnlyView, error)
+}
+
+// DefaultReadOnlyCommands returns the CLI commands allowed by NewReadOnlyView when none are given.
+// Each command is the list of leading arguments of the invocation.
+func DefaultReadOnlyCommands() [][]string {
+	return [][]string{
+		{"version"},
+		{"whoami"},
+		{"workflow", "list"},
+		{"workflow", "status"},
+		{"secrets", "list"},
+	}
+}
+
+// readOnlyView is the default ReadOnlyView implementation.
+type readOnlyView struct {
+	cli *readOnlyCLI
+}
+
+var _ ReadOnlyView = (*readOnlyView)(nil)
+
+// NewReadOnlyView returns a ReadOnlyView of the runner which only allows the given CLI commands,
+// or DefaultReadOnlyCommands if none are given. It returns nil if the runner is nil.
+//
+// The Go API client of the runner is not exposed, since it cannot be restricted to reads.
+func NewReadOnlyView(r Runner, commands ...[]string) ReadOnlyView {
+	if r == nil {
+		return nil
+	}
+
+	return &readOnlyView{cli: newReadOnlyCLI(r.CLI(), commands)}
+}
+
+// Run implements ReadOnlyView.
+func (v *readOnlyView) Run(ctx context.Context, env map[string]string, args ...string) (*CallResult, error) {
+	return v.cli.Run(ctx, env, args...)
+}
+
+// ContextRegistries implements ReadOnlyView.
+func (v *readOnlyView) ContextRegistries() []ContextRegistryEntry {
+	return v.cli.ContextRegistries()
+}
+
+// WithNamedAPIKey implements ReadOnlyView.
+func (v *readOnlyView) WithNamedAPIKey(name string) (ReadOnlyView, error) {
+	cli, err := v.cli.withNamedAPIKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readOnlyView{cli: cli}, nil
+}
+
+// NewReadOnlyRunner returns a Runner whose CLI only allows the given commands, or
+// DefaultReadOnlyCommands if none are given, and which has no Go API client. It returns nil if the
+// runner is nil.
+//
+// Use it in place of the runner of environments which must not change CRE, such as dry runs.
+func NewReadOnlyRunner(r Runner, commands ...[]string) Runner {
+	if r == nil {
+		return nil
+	}
+
+	return &runner{cli: newReadOnlyCLI(r.CLI(), commands)}
+}
+
+// readOnlyCLI is a CLIRunner which only runs the allowed commands.
+type readOnlyCLI struct {
+	cli      CLIRunner
+	commands [][]string
+}
+
+var _ CLIRunner = (*readOnlyCLI)(nil)
+
+// newReadOnlyCLI returns a readOnlyCLI of the CLI which only allows the given commands, or
+// DefaultReadOnlyCommands if none are given.
+func newReadOnlyCLI(cli CLIRunner, commands [][]string) *readOnlyCLI {
+	if len(commands) == 0 {
+		commands = DefaultReadOnlyCommands()
+	}
+
+	return &readOnlyCLI{cli: cli, commands: commands}
+}
+
+// Run implements CLIRunner.
+func (c *readOnlyCLI) Run(ctx context.Context, env map[string]string, args ...string) (*CallResult, error) {
+	if !c.allowed(args) {
+		return nil, fmt.Errorf("%w: %q", ErrCommandNotAllowed, strings.Join(args, " "))
+	}
+	if c.cli == nil {
+		return nil, ErrNoCLI
+	}
+
+	return c.cli.Run(ctx, env, args...)
+}
+
+// allowed returns whether the leading arguments match an allowed command.
+func (c *readOnlyCLI) allowed(args []string) bool {
+	return slices.ContainsFunc(c.commands, func(cmd []string) bool {
+		return len(cmd) > 0 && len(args) >= len(cmd) && slices.Equal(args[:len(cmd)], cmd)
+	})
+}
+
+// ContextRegistries implements CLIRunner.
+func (c *readOnlyCLI) ContextRegistries() []ContextRegistryEntry {
+	if c.cli == nil {
+		return nil
+	}
+
+	return c.cli.ContextRegistries()
+}
+
+// WithNamedAPIKey implements CLIRunner.
+func (c *readOnlyCLI) WithNamedAPIKey(name string) (CLIRunner, error) {
+	return c.withNamedAPIKey(name)
+}
+
+// withNamedAPIKey returns a readOnlyCLI with the same allowed commands that uses the API key
+// registered under the given name.
+func (c *readOnlyCLI) withNamedAPIKey(name string) (*readOnlyCLI, error) {
+	if c.cli == nil {
+		return nil, ErrNoCLI
+	}
+
+	cli, err := c.cli.WithNamedAPIKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readOnlyCLI{cli: cli, commands: c.commands}, nil
+}
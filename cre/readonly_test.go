@@ -0,0 +1,154 @@
+package cre_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
+	cremocks "github.com/smartcontractkit/chainlink-deployments-framework/cre/mocks"
+)
+
+func TestNewReadOnlyView_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		commands [][]string
+		args     []string
+		wantErr  error
+	}{
+		{
+			name: "default read-only command",
+			args: []string{"workflow", "list", "--output", "json"},
+		},
+		{
+			name:    "mutating command",
+			args:    []string{"workflow", "deploy", "my-workflow"},
+			wantErr: cre.ErrCommandNotAllowed,
+		},
+		{
+			name:    "prefix of an allowed command",
+			args:    []string{"workflow"},
+			wantErr: cre.ErrCommandNotAllowed,
+		},
+		{
+			name:    "no arguments",
+			wantErr: cre.ErrCommandNotAllowed,
+		},
+		{
+			name:     "custom commands",
+			commands: [][]string{{"workflow", "describe"}},
+			args:     []string{"workflow", "describe", "my-workflow"},
+		},
+		{
+			name:     "custom commands replace the defaults",
+			commands: [][]string{{"workflow", "describe"}},
+			args:     []string{"version"},
+			wantErr:  cre.ErrCommandNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cli := cremocks.NewMockCLIRunner(t)
+			want := &cre.CallResult{Stdout: []byte("ok")}
+			if tt.wantErr == nil {
+				cli.EXPECT().Run(mock.Anything, map[string]string{"K": "V"}, mock.Anything).Return(want, nil).Once()
+			}
+
+			view := cre.NewReadOnlyView(cre.NewRunner(cre.WithCLI(cli)), tt.commands...)
+			got, err := view.Run(t.Context(), map[string]string{"K": "V"}, tt.args...)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestNewReadOnlyView_ContextRegistries(t *testing.T) {
+	t.Parallel()
+
+	registries := []cre.ContextRegistryEntry{{ID: "r1", Label: "Registry", Type: cre.RegistryTypeOnChain}}
+
+	cli := cremocks.NewMockCLIRunner(t)
+	cli.EXPECT().ContextRegistries().Return(registries).Once()
+
+	view := cre.NewReadOnlyView(cre.NewRunner(cre.WithCLI(cli)))
+	assert.Equal(t, registries, view.ContextRegistries())
+}
+
+func TestNewReadOnlyView_WithNamedAPIKey(t *testing.T) {
+	t.Parallel()
+
+	named := cremocks.NewMockCLIRunner(t)
+	named.EXPECT().Run(mock.Anything, mock.Anything, []string{"whoami"}).Return(&cre.CallResult{}, nil).Once()
+
+	cli := cremocks.NewMockCLIRunner(t)
+	cli.EXPECT().WithNamedAPIKey("prod").Return(named, nil).Once()
+	cli.EXPECT().WithNamedAPIKey("missing").Return(nil, errors.New("unknown key")).Once()
+
+	view := cre.NewReadOnlyView(cre.NewRunner(cre.WithCLI(cli)))
+
+	prod, err := view.WithNamedAPIKey("prod")
+	require.NoError(t, err)
+	_, err = prod.Run(t.Context(), nil, "whoami")
+	require.NoError(t, err)
+
+	// The restrictions still apply with the named key
+	_, err = prod.Run(t.Context(), nil, "workflow", "delete")
+	require.ErrorIs(t, err, cre.ErrCommandNotAllowed)
+
+	_, err = view.WithNamedAPIKey("missing")
+	require.EqualError(t, err, "unknown key")
+}
+
+func TestNewReadOnlyView_NoCLI(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, cre.NewReadOnlyView(nil))
+
+	view := cre.NewReadOnlyView(cre.NewRunner())
+	_, err := view.Run(t.Context(), nil, "version")
+	require.ErrorIs(t, err, cre.ErrNoCLI)
+	assert.Nil(t, view.ContextRegistries())
+
+	_, err = view.WithNamedAPIKey("prod")
+	require.ErrorIs(t, err, cre.ErrNoCLI)
+}
+
+func TestNewReadOnlyRunner(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, cre.NewReadOnlyRunner(nil))
+
+	named := cremocks.NewMockCLIRunner(t)
+	named.EXPECT().Run(mock.Anything, mock.Anything, []string{"whoami"}).Return(&cre.CallResult{}, nil).Once()
+
+	cli := cremocks.NewMockCLIRunner(t)
+	cli.EXPECT().Run(mock.Anything, mock.Anything, []string{"workflow", "list"}).Return(&cre.CallResult{}, nil).Once()
+	cli.EXPECT().WithNamedAPIKey("prod").Return(named, nil).Once()
+
+	r := cre.NewReadOnlyRunner(cre.NewRunner(cre.WithCLI(cli), cre.WithClient(struct{}{})))
+	assert.Nil(t, r.Client())
+
+	_, err := r.CLI().Run(t.Context(), nil, "workflow", "list")
+	require.NoError(t, err)
+	_, err = r.CLI().Run(t.Context(), nil, "workflow", "deploy", "my-workflow")
+	require.ErrorIs(t, err, cre.ErrCommandNotAllowed)
+
+	prod, err := r.CLI().WithNamedAPIKey("prod")
+	require.NoError(t, err)
+	_, err = prod.Run(t.Context(), nil, "whoami")
+	require.NoError(t, err)
+	_, err = prod.Run(t.Context(), nil, "workflow", "delete")
+	require.ErrorIs(t, err, cre.ErrCommandNotAllowed)
+}
@@ -12,7 +12,10 @@ type Transactional interface {
 	// supplies a lambda containing the operations, which the calling context being plumbed
 	// through. Starting and committing the transaction is automated, and if an error is
 	// returned, the transaction is rolled-back instead.
-	WithTransaction(ctx context.Context, fn TransactionLogic) error
+	//
+	// By default the lambda is run once. Use WithRetryOnConflict to re-run it in a new
+	// transaction, with exponential backoff, when it fails with a ConflictError.
+	WithTransaction(ctx context.Context, fn TransactionLogic, opts ...TransactionOption) error
 
 	// These are not publicly available in the API yet, pending further discussion around
 	// exposing them.
@@ -44,9 +47,10 @@ type BaseCatalogStore interface {
 }
 
 // CatalogStore is a fully featured data store, with read/write access to all of the individual
-// stores (tables), as well as supporting transaction handling.
+// stores (tables), as well as supporting transaction handling and change streaming.
 type CatalogStore interface {
 	Transactional
+	Watcher
 	BaseCatalogStore
 }
 
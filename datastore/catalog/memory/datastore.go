@@ -44,8 +44,18 @@ func NewMemoryCatalogDataStore() (*memoryCatalogDataStore, error) {
 	}, nil
 }
 
-// WithTransaction wraps the provided function in a transaction.
-func (m memoryCatalogDataStore) WithTransaction(ctx context.Context, fn datastore.TransactionLogic) (err error) {
+// WithTransaction wraps the provided function in a transaction, retrying it on conflict if
+// configured by the options.
+func (m memoryCatalogDataStore) WithTransaction(
+	ctx context.Context, fn datastore.TransactionLogic, opts ...datastore.TransactionOption,
+) error {
+	return datastore.RunTransaction(ctx, func() error {
+		return m.runTransaction(ctx, fn)
+	}, opts...)
+}
+
+// runTransaction runs the provided function in a single transaction.
+func (m memoryCatalogDataStore) runTransaction(ctx context.Context, fn datastore.TransactionLogic) (err error) {
 	tx := m.storage.beginTransaction()
 
 	// Create a new context with the transaction
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
@@ -170,3 +171,39 @@ func TestMemoryDatastore_WithTransaction_Panic(t *testing.T) {
 	_, err = store.Addresses().Get(ctx, addressKey)
 	assert.ErrorIs(t, err, datastore.ErrAddressRefNotFound)
 }
+
+func TestMemoryDatastore_WithTransaction_RetryOnConflict(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	version := semver.MustParse("1.0.0")
+
+	// The first attempt conflicts after writing, and must be rolled back before the retry
+	attempts := 0
+	err = store.WithTransaction(ctx, func(txCtx context.Context, catalog datastore.BaseCatalogStore) error {
+		attempts++
+		if addErr := catalog.Addresses().Add(txCtx, datastore.AddressRef{
+			ChainSelector: 1,
+			Address:       "0x123",
+			Type:          "TestContract",
+			Version:       version,
+		}); addErr != nil {
+			return addErr
+		}
+
+		if attempts == 1 {
+			return &datastore.ConflictError{Op: "add address ref", Err: errors.New("aborted")}
+		}
+
+		return nil
+	}, datastore.WithRetryOnConflict(3), datastore.WithConflictBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	ref, err := store.Addresses().Get(ctx, datastore.NewAddressRefKey(1, "TestContract", version, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "0x123", ref.Address)
+}
@@ -17,6 +17,17 @@ type memoryStorage struct {
 	contractMetadata map[string]datastore.ContractMetadata
 	envMetadata      *datastore.EnvMetadata
 	transactions     map[*transaction]*transactionData
+
+	// changes is the log of the most recently committed changes, in commit order, used to serve
+	// watchers. It holds at most 2*changeLogLimit and at least changeLogLimit changes once that
+	// many were committed.
+	changes []datastore.ChangeEvent
+	// changesOffset is the number of changes which were dropped from the front of the log.
+	changesOffset int
+	// changeLogLimit is the number of most recent changes the log is guaranteed to retain.
+	changeLogLimit int
+	// changeNotify is closed and replaced whenever a change is recorded.
+	changeNotify chan struct{}
 }
 
 // transactionData holds the changes made during a transaction
@@ -39,6 +50,8 @@ func newMemoryStorage() *memoryStorage {
 		chainMetadata:    make(map[uint64]datastore.ChainMetadata),
 		contractMetadata: make(map[string]datastore.ContractMetadata),
 		transactions:     make(map[*transaction]*transactionData),
+		changeNotify:     make(chan struct{}),
+		changeLogLimit:   defaultChangeLogLimit,
 	}
 }
 
@@ -72,21 +85,27 @@ func (s *memoryStorage) commitTransaction(tx *transaction) error {
 	// Apply address ref changes
 	for key, ref := range data.addressRefs {
 		s.addressRefs[key] = ref
+		s.recordChangeLocked(newChangeEvent(datastore.ChangeKindAddressRef, ref))
 	}
 
 	// Apply chain metadata changes
 	for key, metadata := range data.chainMetadata {
 		s.chainMetadata[key] = metadata
+		s.recordChangeLocked(newChangeEvent(datastore.ChangeKindChainMetadata, metadata))
 	}
 
 	// Apply contract metadata changes
 	for key, metadata := range data.contractMetadata {
 		s.contractMetadata[key] = metadata
+		s.recordChangeLocked(newChangeEvent(datastore.ChangeKindContractMetadata, metadata))
 	}
 
 	// Apply env metadata changes
 	if data.envMetadataSet {
 		s.envMetadata = data.envMetadata
+		if data.envMetadata != nil {
+			s.recordChangeLocked(newChangeEvent(datastore.ChangeKindEnvMetadata, *data.envMetadata))
+		}
 	}
 
 	delete(s.transactions, tx)
@@ -171,6 +190,7 @@ func (s *memoryStorage) setAddressRef(ctx context.Context, key string, ref datas
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.addressRefs[key] = ref
+	s.recordChangeLocked(newChangeEvent(datastore.ChangeKindAddressRef, ref))
 
 	return nil
 }
@@ -230,6 +250,7 @@ func (s *memoryStorage) setChainMetadata(ctx context.Context, chainSelector uint
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.chainMetadata[chainSelector] = metadata
+	s.recordChangeLocked(newChangeEvent(datastore.ChangeKindChainMetadata, metadata))
 
 	return nil
 }
@@ -289,6 +310,7 @@ func (s *memoryStorage) setContractMetadata(ctx context.Context, key string, met
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.contractMetadata[key] = metadata
+	s.recordChangeLocked(newChangeEvent(datastore.ChangeKindContractMetadata, metadata))
 
 	return nil
 }
@@ -329,6 +351,7 @@ func (s *memoryStorage) setEnvMetadata(ctx context.Context, metadata datastore.E
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.envMetadata = &metadata
+	s.recordChangeLocked(newChangeEvent(datastore.ChangeKindEnvMetadata, metadata))
 
 	return nil
 }
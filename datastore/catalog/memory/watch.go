@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// watchBufferSize is the capacity of the channel handed to each watcher.
+const watchBufferSize = 64
+
+// defaultChangeLogLimit is the number of most recent changes the store retains to serve watchers.
+const defaultChangeLogLimit = 10_000
+
+// Watch streams changes committed to the in-memory catalog. The store retains a bounded log of
+// the most recent changes, so a cursor can be used to resume the stream as long as the change it
+// refers to is still retained. Resuming from an older cursor fails with datastore.ErrInvalidCursor,
+// as does a watcher which falls so far behind that the changes it has yet to deliver are dropped.
+func (m memoryCatalogDataStore) Watch(ctx context.Context, filter datastore.WatchFilter) (<-chan datastore.ChangeEvent, error) {
+	return m.storage.watch(ctx, filter)
+}
+
+// newChangeEvent builds an upsert event for the given record kind. The cursor is assigned when
+// the event is recorded.
+func newChangeEvent(kind datastore.ChangeKind, record any) datastore.ChangeEvent {
+	event := datastore.ChangeEvent{
+		Kind:      kind,
+		Operation: datastore.ChangeOperationUpsert,
+	}
+
+	switch r := record.(type) {
+	case datastore.AddressRef:
+		event.AddressRef = &r
+	case datastore.ChainMetadata:
+		event.ChainMetadata = &r
+	case datastore.ContractMetadata:
+		event.ContractMetadata = &r
+	case datastore.EnvMetadata:
+		event.EnvMetadata = &r
+	}
+
+	return event
+}
+
+// recordChangeLocked appends the event to the change log and wakes up all watchers. Once the log
+// holds twice the limit, the oldest changes are dropped so that the log holds the limit again.
+// Must only be called when the caller holds the mu write lock.
+func (s *memoryStorage) recordChangeLocked(event datastore.ChangeEvent) {
+	event.Cursor = datastore.Cursor(strconv.Itoa(s.changesOffset + len(s.changes) + 1))
+	s.changes = append(s.changes, event)
+
+	if len(s.changes) >= 2*s.changeLogLimit {
+		drop := len(s.changes) - s.changeLogLimit
+		s.changes = slices.Clone(s.changes[drop:])
+		s.changesOffset += drop
+	}
+
+	close(s.changeNotify)
+	s.changeNotify = make(chan struct{})
+}
+
+// parseCursor converts a cursor into the position in the change stream from which to resume,
+// which is the number of changes that precede the next change to deliver.
+func (s *memoryStorage) parseCursor(cursor datastore.Cursor) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if cursor == "" {
+		return s.changesOffset + len(s.changes), nil
+	}
+
+	pos, err := strconv.Atoi(string(cursor))
+	if err != nil || pos < s.changesOffset || pos > s.changesOffset+len(s.changes) {
+		return 0, fmt.Errorf("%w: %q", datastore.ErrInvalidCursor, cursor)
+	}
+
+	return pos, nil
+}
+
+// pendingChanges returns the changes recorded from the given position onwards, along with the
+// channel which is closed when the next change is recorded. It fails if some of those changes were
+// already dropped from the log.
+func (s *memoryStorage) pendingChanges(from int) ([]datastore.ChangeEvent, <-chan struct{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if from < s.changesOffset {
+		return nil, nil, fmt.Errorf("%w: changes after position %d are no longer retained", datastore.ErrInvalidCursor, from)
+	}
+
+	pending := slices.Clone(s.changes[from-s.changesOffset:])
+
+	return pending, s.changeNotify, nil
+}
+
+func (s *memoryStorage) watch(ctx context.Context, filter datastore.WatchFilter) (<-chan datastore.ChangeEvent, error) {
+	next, err := s.parseCursor(filter.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan datastore.ChangeEvent, watchBufferSize)
+
+	go func() {
+		defer close(out)
+
+		for {
+			pending, notify, err := s.pendingChanges(next)
+			if err != nil {
+				select {
+				case out <- datastore.ChangeEvent{Cursor: datastore.Cursor(strconv.Itoa(next)), Err: err}:
+				case <-ctx.Done():
+				}
+
+				return
+			}
+
+			for _, event := range pending {
+				next++
+				if !filter.Matches(event) {
+					continue
+				}
+
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if len(pending) > 0 {
+				continue
+			}
+
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
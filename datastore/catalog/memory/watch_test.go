@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+func receiveEvent(t *testing.T, ch <-chan datastore.ChangeEvent) datastore.ChangeEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-ch:
+		require.True(t, ok, "watch channel closed unexpectedly")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for change event")
+	}
+
+	return datastore.ChangeEvent{}
+}
+
+func TestMemoryDatastore_Watch(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	// Changes made before Watch is called are not delivered with an empty cursor
+	require.NoError(t, store.ChainMetadata().Add(ctx, datastore.ChainMetadata{ChainSelector: 1, Metadata: "before"}))
+
+	events, err := store.Watch(ctx, datastore.WatchFilter{})
+	require.NoError(t, err)
+
+	ref := datastore.AddressRef{
+		ChainSelector: 1,
+		Address:       "0x123",
+		Type:          "Router",
+		Version:       semver.MustParse("1.0.0"),
+	}
+	require.NoError(t, store.Addresses().Add(ctx, ref))
+	require.NoError(t, store.EnvMetadata().Set(ctx, "env"))
+
+	event := receiveEvent(t, events)
+	assert.Equal(t, datastore.ChangeKindAddressRef, event.Kind)
+	assert.Equal(t, datastore.ChangeOperationUpsert, event.Operation)
+	require.NotNil(t, event.AddressRef)
+	assert.Equal(t, ref.Address, event.AddressRef.Address)
+	assert.Equal(t, datastore.Cursor("2"), event.Cursor)
+
+	event = receiveEvent(t, events)
+	assert.Equal(t, datastore.ChangeKindEnvMetadata, event.Kind)
+	require.NotNil(t, event.EnvMetadata)
+	assert.Equal(t, "env", event.EnvMetadata.Metadata)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok, "channel should be closed after the context is cancelled")
+}
+
+func TestMemoryDatastore_Watch_Filter(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	events, err := store.Watch(ctx, datastore.WatchFilter{
+		Kinds:          []datastore.ChangeKind{datastore.ChangeKindContractMetadata},
+		ChainSelectors: []uint64{2},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.ContractMetadata().Add(ctx, datastore.ContractMetadata{ChainSelector: 1, Address: "0x1", Metadata: "a"}))
+	require.NoError(t, store.ChainMetadata().Add(ctx, datastore.ChainMetadata{ChainSelector: 2, Metadata: "b"}))
+	require.NoError(t, store.ContractMetadata().Add(ctx, datastore.ContractMetadata{ChainSelector: 2, Address: "0x2", Metadata: "c"}))
+
+	event := receiveEvent(t, events)
+	assert.Equal(t, datastore.ChangeKindContractMetadata, event.Kind)
+	require.NotNil(t, event.ContractMetadata)
+	assert.Equal(t, "0x2", event.ContractMetadata.Address)
+	assert.Equal(t, datastore.Cursor("3"), event.Cursor)
+}
+
+func TestMemoryDatastore_Watch_Resume(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	for _, sel := range []uint64{1, 2, 3} {
+		require.NoError(t, store.ChainMetadata().Add(ctx, datastore.ChainMetadata{ChainSelector: sel, Metadata: "m"}))
+	}
+
+	events, err := store.Watch(ctx, datastore.WatchFilter{Cursor: "1"})
+	require.NoError(t, err)
+
+	event := receiveEvent(t, events)
+	require.NotNil(t, event.ChainMetadata)
+	assert.Equal(t, uint64(2), event.ChainMetadata.ChainSelector)
+
+	event = receiveEvent(t, events)
+	require.NotNil(t, event.ChainMetadata)
+	assert.Equal(t, uint64(3), event.ChainMetadata.ChainSelector)
+	assert.Equal(t, datastore.Cursor("3"), event.Cursor)
+}
+
+func TestMemoryDatastore_Watch_Transaction(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	events, err := store.Watch(ctx, datastore.WatchFilter{})
+	require.NoError(t, err)
+
+	// Rolled back changes are never delivered
+	err = store.WithTransaction(ctx, func(txCtx context.Context, catalog datastore.BaseCatalogStore) error {
+		if addErr := catalog.ChainMetadata().Add(txCtx, datastore.ChainMetadata{ChainSelector: 1, Metadata: "rolled back"}); addErr != nil {
+			return addErr
+		}
+
+		return errors.New("abort")
+	})
+	require.Error(t, err)
+
+	err = store.WithTransaction(ctx, func(txCtx context.Context, catalog datastore.BaseCatalogStore) error {
+		return catalog.ChainMetadata().Add(txCtx, datastore.ChainMetadata{ChainSelector: 2, Metadata: "committed"})
+	})
+	require.NoError(t, err)
+
+	event := receiveEvent(t, events)
+	require.NotNil(t, event.ChainMetadata)
+	assert.Equal(t, uint64(2), event.ChainMetadata.ChainSelector)
+	assert.Equal(t, "committed", event.ChainMetadata.Metadata)
+}
+
+func TestMemoryDatastore_Watch_InvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		cursor datastore.Cursor
+	}{
+		{name: "not a number", cursor: "abc"},
+		{name: "beyond the end of the log", cursor: "10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := store.Watch(t.Context(), datastore.WatchFilter{Cursor: tt.cursor})
+			require.ErrorIs(t, err, datastore.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestMemoryDatastore_Watch_ChangeLogLimit(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCatalogDataStore()
+	require.NoError(t, err)
+	store.storage.changeLogLimit = 2
+
+	ctx := t.Context()
+
+	for sel := uint64(1); sel <= 5; sel++ {
+		require.NoError(t, store.ChainMetadata().Add(ctx, datastore.ChainMetadata{ChainSelector: sel, Metadata: "m"}))
+	}
+
+	store.storage.mu.RLock()
+	retained := len(store.storage.changes)
+	store.storage.mu.RUnlock()
+	assert.LessOrEqual(t, retained, 2*store.storage.changeLogLimit)
+
+	// Cursors of dropped changes are rejected
+	_, err = store.Watch(ctx, datastore.WatchFilter{Cursor: "1"})
+	require.ErrorIs(t, err, datastore.ErrInvalidCursor)
+
+	// Cursors of retained changes keep resuming the stream
+	events, err := store.Watch(ctx, datastore.WatchFilter{Cursor: "4"})
+	require.NoError(t, err)
+
+	event := receiveEvent(t, events)
+	require.NotNil(t, event.ChainMetadata)
+	assert.Equal(t, uint64(5), event.ChainMetadata.ChainSelector)
+	assert.Equal(t, datastore.Cursor("5"), event.Cursor)
+}
@@ -3,6 +3,10 @@ package remote
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/status"
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 
@@ -13,11 +17,18 @@ type CatalogDataStoreConfig struct {
 	Domain      string
 	Environment string
 	Client      *CatalogClient
+	// WatchPollInterval is how often Watch polls the catalog for changes. Zero means
+	// DefaultWatchPollInterval.
+	WatchPollInterval time.Duration
 }
 
 var _ datastore.CatalogStore = &catalogDataStore{}
 
 type catalogDataStore struct {
+	domain                string
+	environment           string
+	watchPollInterval     time.Duration
+	changes               *changeLog
 	client                *CatalogClient
 	addressRefStore       *catalogAddressRefStore
 	chainMetadataStore    *catalogChainMetadataStore
@@ -31,9 +42,8 @@ func (s *catalogDataStore) beginTransaction() error {
 			BeginTransactionRequest: &pb.BeginTransactionRequest{},
 		},
 	}
-	_, err := ThrowAndCatch(s, request)
 
-	return err
+	return s.transactionRequest("begin transaction", request)
 }
 
 func (s *catalogDataStore) commitTransaction() error {
@@ -42,9 +52,28 @@ func (s *catalogDataStore) commitTransaction() error {
 			CommitTransactionRequest: &pb.CommitTransactionRequest{},
 		},
 	}
-	_, err := ThrowAndCatch(s, request)
 
-	return err
+	return s.transactionRequest("commit transaction", request)
+}
+
+// transactionRequest sends a transaction control request and checks the response status, if the
+// catalog set one. Aborted statuses are returned as a datastore.ConflictError so that
+// WithTransaction can retry them.
+func (s *catalogDataStore) transactionRequest(op string, request *pb.DataAccessRequest) error {
+	resp, err := ThrowAndCatch(s, request)
+	if err != nil {
+		return asConflictError(op, status.Code(err), err)
+	}
+
+	if resp.GetStatus() == nil {
+		return nil
+	}
+
+	if statusErr := parseResponseStatus(resp.GetStatus()); statusErr != nil {
+		return asConflictError(op, status.Code(statusErr), fmt.Errorf("%s failed: %w", op, statusErr))
+	}
+
+	return nil
 }
 
 func (s *catalogDataStore) rollbackTransaction() error {
@@ -58,7 +87,18 @@ func (s *catalogDataStore) rollbackTransaction() error {
 	return err
 }
 
-func (s *catalogDataStore) WithTransaction(ctx context.Context, fn datastore.TransactionLogic) (err error) {
+// WithTransaction runs the provided function in a catalog transaction, retrying it on conflict if
+// configured by the options.
+func (s *catalogDataStore) WithTransaction(
+	ctx context.Context, fn datastore.TransactionLogic, opts ...datastore.TransactionOption,
+) error {
+	return datastore.RunTransaction(ctx, func() error {
+		return s.runTransaction(ctx, fn)
+	}, opts...)
+}
+
+// runTransaction runs the provided function in a single catalog transaction.
+func (s *catalogDataStore) runTransaction(ctx context.Context, fn datastore.TransactionLogic) (err error) {
 	err = s.beginTransaction()
 	if err != nil {
 		return err
@@ -85,12 +125,29 @@ func (s *catalogDataStore) WithTransaction(ctx context.Context, fn datastore.Tra
 }
 
 func NewCatalogDataStore(config CatalogDataStoreConfig) *catalogDataStore {
+	watchPollInterval := config.WatchPollInterval
+	if watchPollInterval <= 0 {
+		watchPollInterval = DefaultWatchPollInterval
+	}
+
 	return &catalogDataStore{
-		client:                config.Client,
-		addressRefStore:       newCatalogAddressRefStore(catalogAddressRefStoreConfig(config)),
-		chainMetadataStore:    newCatalogChainMetadataStore(catalogChainMetadataStoreConfig(config)),
-		contractMetadataStore: newCatalogContractMetadataStore(catalogContractMetadataStoreConfig(config)),
-		envMetadataStore:      newCatalogEnvMetadataStore(catalogEnvMetadataStoreConfig(config)),
+		domain:            config.Domain,
+		environment:       config.Environment,
+		watchPollInterval: watchPollInterval,
+		changes:           newChangeLog(),
+		client:            config.Client,
+		addressRefStore: newCatalogAddressRefStore(catalogAddressRefStoreConfig{
+			Domain: config.Domain, Environment: config.Environment, Client: config.Client,
+		}),
+		chainMetadataStore: newCatalogChainMetadataStore(catalogChainMetadataStoreConfig{
+			Domain: config.Domain, Environment: config.Environment, Client: config.Client,
+		}),
+		contractMetadataStore: newCatalogContractMetadataStore(catalogContractMetadataStoreConfig{
+			Domain: config.Domain, Environment: config.Environment, Client: config.Client,
+		}),
+		envMetadataStore: newCatalogEnvMetadataStore(catalogEnvMetadataStoreConfig{
+			Domain: config.Domain, Environment: config.Environment, Client: config.Client,
+		}),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/smartcontractkit/chainlink-protos/op-catalog/v1/datastore"
 )
@@ -66,7 +67,8 @@ func semanticsLabel(s pb.EditSemantics) (string, error) {
 type errorMapper func(statusErr error, code codes.Code) error
 
 // executeEdit handles the open-stream / send / recv / status-check sequence
-// shared by every *EditRequest call. R must be the concrete edit-response
+// shared by every *EditRequest call. Aborted statuses are wrapped in a
+// datastore.ConflictError after mapping. R must be the concrete edit-response
 // pointer type (e.g. *pb.AddressReferenceEditResponse); extract returns the
 // typed response from the DataAccessResponse oneof and executeEdit returns
 // "unexpected response type" when it is nil.
@@ -102,13 +104,13 @@ func executeEdit[R comparable](
 		if mapErr != nil {
 			st, parseErr := parseStatusError(statusErr)
 			if parseErr != nil {
-				return parseErr
+				return fmt.Errorf("%s failed: %w", opName, statusErr)
 			}
 
-			return mapErr(statusErr, st.Code())
+			return asConflictError(opName, st.Code(), mapErr(statusErr, st.Code()))
 		}
 
-		return fmt.Errorf("%s failed: %w", opName, statusErr)
+		return asConflictError(opName, status.Code(statusErr), fmt.Errorf("%s failed: %w", opName, statusErr))
 	}
 
 	var zero R
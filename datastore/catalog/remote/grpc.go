@@ -77,6 +77,28 @@ func (c *CatalogClient) DataAccess(req proto.Message) (grpc.BidiStreamingClient[
 	return c.cachedStream, c.streamInitErr
 }
 
+// fork returns a client which shares the underlying connection with c, but opens its own data
+// access stream. This allows long-running readers, such as watchers, to issue requests
+// concurrently with the parent client. The forked client does not own the connection, so
+// calling Close on it leaves the parent untouched.
+//
+// When HMAC authentication is configured, the forked client signs its requests with the KMS client
+// of the parent, so the AWS config is loaded at most once per connection.
+func (c *CatalogClient) fork(ctx context.Context) *CatalogClient {
+	forked := &CatalogClient{
+		ctx:         ctx,
+		protoClient: c.protoClient,
+		hmacConfig:  c.hmacConfig,
+	}
+
+	if c.hmacConfig != nil {
+		forked.kmsClient, forked.kmsClientErr = c.getKMSClient(ctx)
+		forked.kmsClientOnce.Do(func() {})
+	}
+
+	return forked
+}
+
 // CloseStream closes the current stream.
 func (c *CatalogClient) CloseStream() error {
 	if c.cachedStream == nil {
@@ -249,3 +249,19 @@ func TestCatalogClient_prepareHMACContextWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestCatalogClient_fork_SharesKMSClient(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockKMSClient{}
+	parent := &CatalogClient{
+		hmacConfig: &HMACAuthConfig{KeyID: "key", KeyRegion: "us-east-1", Authority: "catalog"},
+	}
+	parent.kmsClientOnce.Do(func() { parent.kmsClient = mockClient })
+
+	forked := parent.fork(t.Context())
+
+	got, err := forked.getKMSClient(t.Context())
+	require.NoError(t, err)
+	assert.Same(t, mockClient, got)
+}
@@ -8,6 +8,8 @@ import (
 	rpb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 )
 
 // parseResponseStatus converts a ResponseStatus to a standard gRPC error.
@@ -53,3 +55,14 @@ func parseStatusError(err error) (*status.Status, error) {
 
 	return st, nil
 }
+
+// asConflictError wraps err in a datastore.ConflictError if the code is Aborted, which the catalog
+// returns when a record version is stale or a transaction was aborted by a concurrent change.
+// Other errors are returned unchanged.
+func asConflictError(op string, code codes.Code, err error) error {
+	if code != codes.Aborted {
+		return err
+	}
+
+	return &datastore.ConflictError{Op: op, Err: err}
+}
@@ -2,6 +2,7 @@ package remote
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	pb "github.com/smartcontractkit/chainlink-protos/op-catalog/v1/datastore"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 )
 
 func TestParseResponseStatus(t *testing.T) {
@@ -123,3 +126,29 @@ func TestParseStatusError(t *testing.T) {
 		})
 	}
 }
+
+func TestAsConflictError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("aborted", func(t *testing.T) {
+		t.Parallel()
+
+		err := asConflictError("update chain metadata", codes.Aborted,
+			fmt.Errorf("%w: row version mismatch", datastore.ErrChainMetadataStale))
+
+		var conflictErr *datastore.ConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, "update chain metadata", conflictErr.Op)
+		require.ErrorIs(t, err, datastore.ErrConflict)
+		require.ErrorIs(t, err, datastore.ErrChainMetadataStale)
+	})
+
+	t.Run("other codes", func(t *testing.T) {
+		t.Parallel()
+
+		wrapped := errors.New("not found")
+		err := asConflictError("update chain metadata", codes.NotFound, wrapped)
+		require.Equal(t, wrapped, err)
+		require.False(t, datastore.IsConflict(err))
+	})
+}
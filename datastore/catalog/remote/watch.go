@@ -0,0 +1,364 @@
+package remote
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// DefaultWatchPollInterval is the interval at which Watch polls the catalog when the store was
+// created without an explicit WatchPollInterval.
+const DefaultWatchPollInterval = 5 * time.Second
+
+// watchBufferSize is the capacity of the channel handed to each watcher.
+const watchBufferSize = 64
+
+// envMetadataSnapshotKey is the snapshot key of the single env metadata record.
+const envMetadataSnapshotKey = "env_metadata"
+
+// defaultChangeLogLimit is the number of most recent changes the store retains to serve watchers.
+const defaultChangeLogLimit = 10_000
+
+// snapshotEntry is a single record captured by a snapshot, along with a hash of its serialized
+// form which is used to detect changes between snapshots.
+type snapshotEntry struct {
+	event datastore.ChangeEvent
+	hash  string
+}
+
+// snapshot is the full state of the catalog for the store's domain and environment, keyed by
+// record kind and primary key.
+type snapshot map[string]snapshotEntry
+
+// snapshotChange is a single change between two snapshots, along with the snapshot key of the
+// record it applies to.
+type snapshotChange struct {
+	key   string
+	event datastore.ChangeEvent
+}
+
+// Watch streams changes to the records in the store's domain and environment.
+//
+// The catalog service does not expose a change feed, so this is not the server stream the API was
+// designed for: changes are detected by polling the catalog every WatchPollInterval and diffing
+// the result against the previous poll. Polling uses its own data access stream, so the store
+// remains usable while a watch is active. Watch should be moved onto a server stream once the
+// catalog provides one.
+//
+// The detected changes are recorded in a bounded log shared by every watch of the store, in the
+// same way as the memory catalog. A cursor is the position of its event in that log, prefixed with
+// an identifier of the log, so issuing and parsing one costs the same regardless of the size of
+// the catalog. The log keeps the last polled state of the catalog between watches, so resuming
+// from a cursor also delivers the changes, deletes included, made while no watch was active.
+//
+// As the log is held in memory, a cursor can only be used to resume a watch of the store which
+// issued it, and only as long as the change it refers to is still retained. Resuming from any
+// other cursor fails with datastore.ErrInvalidCursor, as does a watcher which falls so far behind
+// that the changes it has yet to deliver are dropped.
+func (s *catalogDataStore) Watch(ctx context.Context, filter datastore.WatchFilter) (<-chan datastore.ChangeEvent, error) {
+	if s.client == nil {
+		return nil, errors.New("catalog client is not configured")
+	}
+
+	next := -1
+	if filter.Cursor != "" {
+		var err error
+		if next, err = s.changes.parseCursor(filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	client := s.client.fork(ctx)
+	watchStore := NewCatalogDataStore(CatalogDataStoreConfig{
+		Domain:      s.domain,
+		Environment: s.environment,
+		Client:      client,
+	})
+
+	out := make(chan datastore.ChangeEvent, watchBufferSize)
+
+	go func() {
+		defer close(out)
+		defer func() { _ = client.CloseStream() }()
+
+		ticker := time.NewTicker(s.watchPollInterval)
+		defer ticker.Stop()
+
+		// fail delivers the final event of a stream which terminated with an error. The event
+		// carries the cursor of the last event processed, so the consumer can resume from it.
+		fail := func(err error) {
+			event := datastore.ChangeEvent{Cursor: filter.Cursor, Err: err}
+			if next >= 0 {
+				event.Cursor = s.changes.cursor(next)
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+			}
+		}
+
+		for {
+			current, err := watchStore.snapshot(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					fail(err)
+				}
+
+				return
+			}
+
+			end := s.changes.update(current)
+			if next < 0 {
+				// A watch without a cursor starts after the changes detected by its first poll
+				next = end
+			}
+
+			pending, err := s.changes.pendingChanges(next)
+			if err != nil {
+				fail(err)
+
+				return
+			}
+
+			for _, event := range pending {
+				next++
+				if !filter.Matches(event) {
+					continue
+				}
+
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// changeLog is the log of the most recent changes detected by polling the catalog, in the order
+// they were detected, along with the state of the catalog at the last poll.
+type changeLog struct {
+	mu sync.Mutex
+	// id identifies the log in the cursors it issues, so that cursors issued by another store are
+	// rejected rather than misinterpreted.
+	id string
+	// state is the state of the catalog at the last poll, or nil before the first poll.
+	state snapshot
+	// changes holds at most 2*limit and at least limit changes once that many were detected.
+	changes []datastore.ChangeEvent
+	// offset is the number of changes which were dropped from the front of the log.
+	offset int
+	// limit is the number of most recent changes the log is guaranteed to retain.
+	limit int
+}
+
+// newChangeLog returns an empty change log with a random id.
+func newChangeLog() *changeLog {
+	return &changeLog{id: rand.Text(), limit: defaultChangeLogLimit}
+}
+
+// cursor returns the cursor of the position in the log, which is the number of changes that
+// precede the next change to deliver.
+func (l *changeLog) cursor(pos int) datastore.Cursor {
+	return datastore.Cursor(l.id + "." + strconv.Itoa(pos))
+}
+
+// parseCursor converts a cursor issued by the log into the position from which to resume.
+func (l *changeLog) parseCursor(cursor datastore.Cursor) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id, rawPos, ok := strings.Cut(string(cursor), ".")
+	if !ok || id != l.id {
+		return 0, fmt.Errorf("%w: %q was not issued by this store", datastore.ErrInvalidCursor, cursor)
+	}
+
+	pos, err := strconv.Atoi(rawPos)
+	if err != nil || pos < l.offset || pos > l.offset+len(l.changes) {
+		return 0, fmt.Errorf("%w: %q", datastore.ErrInvalidCursor, cursor)
+	}
+
+	return pos, nil
+}
+
+// update records the changes between the last polled state and current, and returns the position
+// at the end of the log. The first update only sets the state, as there is nothing to diff it
+// against. Once the log holds twice the limit, the oldest changes are dropped so that the log
+// holds the limit again.
+func (l *changeLog) update(current snapshot) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.state != nil {
+		for _, change := range diffSnapshots(l.state, current) {
+			event := change.event
+			event.Cursor = l.cursor(l.offset + len(l.changes) + 1)
+			l.changes = append(l.changes, event)
+		}
+
+		if len(l.changes) >= 2*l.limit {
+			drop := len(l.changes) - l.limit
+			l.changes = slices.Clone(l.changes[drop:])
+			l.offset += drop
+		}
+	}
+	l.state = current
+
+	return l.offset + len(l.changes)
+}
+
+// pendingChanges returns the changes recorded from the given position onwards. It fails if some of
+// those changes were already dropped from the log.
+func (l *changeLog) pendingChanges(from int) ([]datastore.ChangeEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if from < l.offset {
+		return nil, fmt.Errorf("%w: changes after position %d are no longer retained", datastore.ErrInvalidCursor, from)
+	}
+
+	return slices.Clone(l.changes[from-l.offset:]), nil
+}
+
+// snapshot fetches every record in the store's domain and environment.
+func (s *catalogDataStore) snapshot(ctx context.Context) (snapshot, error) {
+	snap := snapshot{}
+
+	refs, err := s.addressRefStore.Fetch(ctx)
+	if err != nil && !errors.Is(err, datastore.ErrAddressRefNotFound) {
+		return nil, fmt.Errorf("failed to fetch address refs: %w", err)
+	}
+	for _, ref := range refs {
+		if err = snap.add(datastore.ChangeEvent{Kind: datastore.ChangeKindAddressRef, AddressRef: &ref}); err != nil {
+			return nil, err
+		}
+	}
+
+	chainMetadata, err := s.chainMetadataStore.Fetch(ctx)
+	if err != nil && !errors.Is(err, datastore.ErrChainMetadataNotFound) {
+		return nil, fmt.Errorf("failed to fetch chain metadata: %w", err)
+	}
+	for _, record := range chainMetadata {
+		if err = snap.add(datastore.ChangeEvent{Kind: datastore.ChangeKindChainMetadata, ChainMetadata: &record}); err != nil {
+			return nil, err
+		}
+	}
+
+	contractMetadata, err := s.contractMetadataStore.Fetch(ctx)
+	if err != nil && !errors.Is(err, datastore.ErrContractMetadataNotFound) {
+		return nil, fmt.Errorf("failed to fetch contract metadata: %w", err)
+	}
+	for _, record := range contractMetadata {
+		if err = snap.add(datastore.ChangeEvent{Kind: datastore.ChangeKindContractMetadata, ContractMetadata: &record}); err != nil {
+			return nil, err
+		}
+	}
+
+	envMetadata, err := s.envMetadataStore.Get(ctx)
+	switch {
+	case errors.Is(err, datastore.ErrEnvMetadataNotSet):
+	case err != nil:
+		return nil, fmt.Errorf("failed to fetch env metadata: %w", err)
+	default:
+		if err = snap.add(datastore.ChangeEvent{Kind: datastore.ChangeKindEnvMetadata, EnvMetadata: &envMetadata}); err != nil {
+			return nil, err
+		}
+	}
+
+	return snap, nil
+}
+
+// add stores the record carried by the event under its snapshot key.
+func (s snapshot) add(event datastore.ChangeEvent) error {
+	var record any
+	switch {
+	case event.AddressRef != nil:
+		record = event.AddressRef
+	case event.ChainMetadata != nil:
+		record = event.ChainMetadata
+	case event.ContractMetadata != nil:
+		record = event.ContractMetadata
+	case event.EnvMetadata != nil:
+		record = event.EnvMetadata
+	}
+
+	key := snapshotKey(event)
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s record %q: %w", event.Kind, key, err)
+	}
+	sum := sha256.Sum256(raw)
+	s[key] = snapshotEntry{event: event, hash: hex.EncodeToString(sum[:16])}
+
+	return nil
+}
+
+// snapshotKey returns the key under which the record carried by the event is stored in a
+// snapshot.
+func snapshotKey(event datastore.ChangeEvent) string {
+	switch {
+	case event.AddressRef != nil:
+		return string(datastore.ChangeKindAddressRef) + ":" + event.AddressRef.Key().String()
+	case event.ChainMetadata != nil:
+		return string(datastore.ChangeKindChainMetadata) + ":" + event.ChainMetadata.Key().String()
+	case event.ContractMetadata != nil:
+		return string(datastore.ChangeKindContractMetadata) + ":" + event.ContractMetadata.Key().String()
+	default:
+		return envMetadataSnapshotKey
+	}
+}
+
+// diffSnapshots returns the changes which transform previous into current, ordered by key so the
+// output is deterministic. Delete events carry the record as it was in previous. Cursors are left
+// unset.
+func diffSnapshots(previous, current snapshot) []snapshotChange {
+	changes := make([]snapshotChange, 0)
+
+	for _, key := range slices.Sorted(maps.Keys(current)) {
+		entry := current[key]
+		if prev, ok := previous[key]; ok && prev.hash == entry.hash {
+			continue
+		}
+		event := entry.event
+		event.Operation = datastore.ChangeOperationUpsert
+		changes = append(changes, snapshotChange{key: key, event: event})
+	}
+
+	deleted := make([]string, 0)
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			deleted = append(deleted, key)
+		}
+	}
+	slices.Sort(deleted)
+
+	for _, key := range deleted {
+		event := previous[key].event
+		event.Operation = datastore.ChangeOperationDelete
+		changes = append(changes, snapshotChange{key: key, event: event})
+	}
+
+	return changes
+}
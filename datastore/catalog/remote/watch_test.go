@@ -0,0 +1,201 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	ref := datastore.AddressRef{
+		ChainSelector: 1,
+		Address:       "0x1",
+		Type:          "Router",
+		Version:       semver.MustParse("1.0.0"),
+	}
+	updatedRef := ref
+	updatedRef.Address = "0x2"
+
+	chainMeta := datastore.ChainMetadata{ChainSelector: 1, Metadata: "meta"}
+
+	build := func(t *testing.T, refs []datastore.AddressRef, chains []datastore.ChainMetadata) snapshot {
+		t.Helper()
+
+		snap := snapshot{}
+		for _, r := range refs {
+			require.NoError(t, snap.add(datastore.ChangeEvent{Kind: datastore.ChangeKindAddressRef, AddressRef: &r}))
+		}
+		for _, c := range chains {
+			require.NoError(t, snap.add(datastore.ChangeEvent{Kind: datastore.ChangeKindChainMetadata, ChainMetadata: &c}))
+		}
+
+		return snap
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		t.Parallel()
+
+		prev := build(t, []datastore.AddressRef{ref}, []datastore.ChainMetadata{chainMeta})
+		curr := build(t, []datastore.AddressRef{ref}, []datastore.ChainMetadata{chainMeta})
+
+		assert.Empty(t, diffSnapshots(prev, curr))
+	})
+
+	t.Run("added, updated and deleted records", func(t *testing.T) {
+		t.Parallel()
+
+		prev := build(t, []datastore.AddressRef{ref}, []datastore.ChainMetadata{chainMeta})
+		curr := build(t, []datastore.AddressRef{updatedRef}, nil)
+
+		changes := diffSnapshots(prev, curr)
+		require.Len(t, changes, 2)
+
+		assert.Equal(t, "address_ref:"+ref.Key().String(), changes[0].key)
+		assert.Equal(t, datastore.ChangeOperationUpsert, changes[0].event.Operation)
+		require.NotNil(t, changes[0].event.AddressRef)
+		assert.Equal(t, "0x2", changes[0].event.AddressRef.Address)
+
+		assert.Equal(t, "chain_metadata:"+chainMeta.Key().String(), changes[1].key)
+		assert.Equal(t, datastore.ChangeOperationDelete, changes[1].event.Operation)
+		require.NotNil(t, changes[1].event.ChainMetadata)
+		assert.Equal(t, uint64(1), changes[1].event.ChainMetadata.ChainSelector)
+	})
+
+	t.Run("replay from empty snapshot emits every record", func(t *testing.T) {
+		t.Parallel()
+
+		curr := build(t, []datastore.AddressRef{ref}, []datastore.ChainMetadata{chainMeta})
+
+		changes := diffSnapshots(snapshot{}, curr)
+		require.Len(t, changes, 2)
+		for _, change := range changes {
+			assert.Equal(t, datastore.ChangeOperationUpsert, change.event.Operation)
+		}
+	})
+}
+
+func TestChangeLog(t *testing.T) {
+	t.Parallel()
+
+	ref := datastore.AddressRef{
+		ChainSelector: 1,
+		Address:       "0x1",
+		Type:          "Router",
+		Version:       semver.MustParse("1.0.0"),
+		Qualifier:     "main",
+	}
+	contractMeta := datastore.ContractMetadata{ChainSelector: 2, Address: "0x2", Metadata: "meta"}
+
+	build := func(t *testing.T, events ...datastore.ChangeEvent) snapshot {
+		t.Helper()
+
+		snap := snapshot{}
+		for _, event := range events {
+			require.NoError(t, snap.add(event))
+		}
+
+		return snap
+	}
+	refEvent := datastore.ChangeEvent{Kind: datastore.ChangeKindAddressRef, AddressRef: &ref}
+	contractEvent := datastore.ChangeEvent{Kind: datastore.ChangeKindContractMetadata, ContractMetadata: &contractMeta}
+
+	t.Run("records changes between polls", func(t *testing.T) {
+		t.Parallel()
+
+		log := newChangeLog()
+
+		// The first poll only sets the state
+		assert.Equal(t, 0, log.update(build(t, refEvent)))
+		assert.Equal(t, 1, log.update(build(t, refEvent, contractEvent)))
+		assert.Equal(t, 2, log.update(build(t, contractEvent)))
+
+		changes, err := log.pendingChanges(0)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+
+		assert.Equal(t, datastore.ChangeOperationUpsert, changes[0].Operation)
+		assert.Equal(t, log.cursor(1), changes[0].Cursor)
+
+		// Deletes carry the record as it was before it was deleted
+		assert.Equal(t, datastore.ChangeOperationDelete, changes[1].Operation)
+		require.NotNil(t, changes[1].AddressRef)
+		assert.Equal(t, ref, *changes[1].AddressRef)
+		assert.Equal(t, log.cursor(2), changes[1].Cursor)
+
+		pos, err := log.parseCursor(changes[0].Cursor)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pos)
+
+		changes, err = log.pendingChanges(pos)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, datastore.ChangeOperationDelete, changes[0].Operation)
+	})
+
+	t.Run("drops the oldest changes beyond the limit", func(t *testing.T) {
+		t.Parallel()
+
+		log := newChangeLog()
+		log.limit = 1
+
+		log.update(snapshot{})
+		log.update(build(t, refEvent))
+		assert.Equal(t, 2, log.update(snapshot{}))
+
+		_, err := log.pendingChanges(0)
+		require.ErrorIs(t, err, datastore.ErrInvalidCursor)
+		_, err = log.parseCursor(log.cursor(0))
+		require.ErrorIs(t, err, datastore.ErrInvalidCursor)
+
+		changes, err := log.pendingChanges(1)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, datastore.ChangeOperationDelete, changes[0].Operation)
+	})
+
+	t.Run("invalid cursors", func(t *testing.T) {
+		t.Parallel()
+
+		log := newChangeLog()
+		log.update(snapshot{})
+
+		other := newChangeLog()
+		for _, c := range []datastore.Cursor{"42", log.cursor(1), datastore.Cursor(log.id + ".x"), other.cursor(0)} {
+			_, err := log.parseCursor(c)
+			require.ErrorIs(t, err, datastore.ErrInvalidCursor, c)
+		}
+	})
+}
+
+func TestCatalogDataStore_Watch_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing client", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewCatalogDataStore(CatalogDataStoreConfig{Domain: "test", Environment: "test"})
+		_, err := store.Watch(t.Context(), datastore.WatchFilter{})
+		require.ErrorContains(t, err, "catalog client is not configured")
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewCatalogDataStore(CatalogDataStoreConfig{Domain: "test", Environment: "test", Client: &CatalogClient{}})
+		_, err := store.Watch(t.Context(), datastore.WatchFilter{Cursor: "not-a-cursor"})
+		require.ErrorIs(t, err, datastore.ErrInvalidCursor)
+	})
+
+	t.Run("default poll interval", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewCatalogDataStore(CatalogDataStoreConfig{Domain: "test", Environment: "test"})
+		assert.Equal(t, DefaultWatchPollInterval, store.watchPollInterval)
+	})
+}
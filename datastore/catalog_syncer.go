@@ -15,7 +15,9 @@ import (
 // 2. Ongoing operations: merge changeset artifacts into catalog (incremental updates)
 //
 // The operation is transactional to prevent partial failures that could lead to data inconsistencies.
-func MergeDataStoreToCatalog(ctx context.Context, sourceDS DataStore, catalog CatalogStore) error {
+// The transaction options are passed to WithTransaction, e.g. WithRetryOnConflict to re-apply the
+// whole merge when it conflicts with a concurrent change to the catalog.
+func MergeDataStoreToCatalog(ctx context.Context, sourceDS DataStore, catalog CatalogStore, opts ...TransactionOption) error {
 	return catalog.WithTransaction(ctx, func(ctx context.Context, txCatalog BaseCatalogStore) error {
 		// Merge all address references to the catalog
 		addressRefs, err := sourceDS.Addresses().Fetch()
@@ -107,5 +109,5 @@ func MergeDataStoreToCatalog(ctx context.Context, sourceDS DataStore, catalog Ca
 		}
 
 		return nil
-	})
+	}, opts...)
 }
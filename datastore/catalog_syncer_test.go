@@ -72,7 +72,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction to execute the transaction logic
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -134,7 +134,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -170,7 +170,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -200,7 +200,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -234,7 +234,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -272,7 +272,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -321,7 +321,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -359,7 +359,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 
 		// Setup WithTransaction
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -430,7 +430,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 		require.NoError(t, sourceDS.ContractMetadataStore.RemoteDelete(contractKey))
 
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -463,7 +463,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 		require.NoError(t, sourceDS.AddressRefStore.RemoteDelete(addrKey))
 
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -489,7 +489,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 		require.NoError(t, sourceDS.ChainMetadataStore.RemoteDelete(chainKey))
 
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
@@ -515,7 +515,7 @@ func TestMergeDataStoreToCatalog(t *testing.T) {
 		require.NoError(t, sourceDS.ContractMetadataStore.RemoteDelete(contractKey))
 
 		mockCatalog.EXPECT().WithTransaction(ctx, mock.Anything).RunAndReturn(
-			func(ctx context.Context, fn TransactionLogic) error {
+			func(ctx context.Context, fn TransactionLogic, _ ...TransactionOption) error {
 				return fn(ctx, mockTxCatalog)
 			},
 		).Once()
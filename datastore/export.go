@@ -0,0 +1,466 @@
+package datastore
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportFormat is the output format of ExportAddressRefs, ExportChainMetadata,
+// ExportContractMetadata and ExportEnvMetadata.
+type ExportFormat string
+
+const (
+	ExportFormatCSV      ExportFormat = "csv"
+	ExportFormatYAML     ExportFormat = "yaml"
+	ExportFormatMarkdown ExportFormat = "markdown"
+)
+
+// ParseExportFormat parses the string representation of an ExportFormat.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch f := ExportFormat(strings.ToLower(s)); f {
+	case ExportFormatCSV, ExportFormatYAML, ExportFormatMarkdown:
+		return f, nil
+	case "md":
+		return ExportFormatMarkdown, nil
+	case "yml":
+		return ExportFormatYAML, nil
+	default:
+		return "", fmt.Errorf("invalid export format %q: must be one of csv, yaml, markdown", s)
+	}
+}
+
+// ExportColumn identifies a column of an exported address ref table. The same names are used as
+// the header of CSV files read by ImportAddressRefsCSV.
+type ExportColumn string
+
+const (
+	ExportColumnChainSelector ExportColumn = "chain_selector"
+	// ExportColumnChainName is the chain name resolved from the chain selector with chain-selectors.
+	// It is empty for selectors which are not known to chain-selectors.
+	ExportColumnChainName ExportColumn = "chain_name"
+	ExportColumnType      ExportColumn = "type"
+	ExportColumnVersion   ExportColumn = "version"
+	ExportColumnQualifier ExportColumn = "qualifier"
+	ExportColumnAddress   ExportColumn = "address"
+	ExportColumnLabels    ExportColumn = "labels"
+
+	// ExportColumnMetadata holds the metadata of exported chain, contract and env metadata
+	// records. It is written as JSON in CSV and Markdown output, and as a nested value in YAML
+	// output. It is not an address ref column.
+	ExportColumnMetadata ExportColumn = "metadata"
+)
+
+// DefaultExportColumns are the columns exported when no columns are selected.
+var DefaultExportColumns = []ExportColumn{
+	ExportColumnChainSelector,
+	ExportColumnChainName,
+	ExportColumnType,
+	ExportColumnVersion,
+	ExportColumnQualifier,
+	ExportColumnAddress,
+	ExportColumnLabels,
+}
+
+// DefaultLabelSeparator joins the labels of an address ref into a single CSV or Markdown cell.
+const DefaultLabelSeparator = "|"
+
+// ParseExportColumns parses a list of column names, rejecting unknown and duplicate columns.
+func ParseExportColumns(names []string) ([]ExportColumn, error) {
+	columns := make([]ExportColumn, 0, len(names))
+	seen := make(map[ExportColumn]bool, len(names))
+	for _, name := range names {
+		col := ExportColumn(strings.TrimSpace(strings.ToLower(name)))
+		if !isExportColumn(col) {
+			return nil, fmt.Errorf("invalid export column %q", name)
+		}
+		if seen[col] {
+			return nil, fmt.Errorf("duplicate export column %q", name)
+		}
+		seen[col] = true
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+func isExportColumn(col ExportColumn) bool {
+	return slices.Contains(DefaultExportColumns, col)
+}
+
+// ExportOptions configures ExportAddressRefs.
+type ExportOptions struct {
+	// Columns selects the exported columns and their order. Empty means DefaultExportColumns.
+	Columns []ExportColumn
+	// LabelSeparator joins labels into a single cell in CSV and Markdown output. YAML output
+	// keeps labels as a list. Empty means DefaultLabelSeparator.
+	LabelSeparator string
+	// Filters select the address refs to export. An empty list exports all address refs.
+	Filters []FilterFunc[AddressRefKey, AddressRef]
+}
+
+func (o ExportOptions) columns() []ExportColumn {
+	if len(o.Columns) == 0 {
+		return DefaultExportColumns
+	}
+
+	return o.Columns
+}
+
+func (o ExportOptions) labelSeparator() string {
+	if o.LabelSeparator == "" {
+		return DefaultLabelSeparator
+	}
+
+	return o.LabelSeparator
+}
+
+// ExportAddressRefs writes the address refs of the datastore to w in the given format. Records are
+// sorted with SortAddressRefs so that exports of the same datastore are stable.
+func ExportAddressRefs(w io.Writer, ds DataStore, format ExportFormat, opts ExportOptions) error {
+	if ds == nil {
+		return errors.New("datastore is required")
+	}
+
+	columns := opts.columns()
+	for _, col := range columns {
+		if !isExportColumn(col) {
+			return fmt.Errorf("invalid export column %q", col)
+		}
+	}
+
+	refs := ds.Addresses().Filter(opts.Filters...)
+	SortAddressRefs(refs)
+
+	table := newExportTable(columns...)
+	for _, ref := range refs {
+		text := make([]string, len(columns))
+		nodes := make([]*yaml.Node, len(columns))
+		for i, col := range columns {
+			text[i] = exportCell(ref, col, opts.labelSeparator())
+
+			switch col {
+			case ExportColumnLabels:
+				nodes[i] = &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+				for _, l := range ref.Labels.List() {
+					nodes[i].Content = append(nodes[i].Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: l})
+				}
+			case ExportColumnChainSelector:
+				nodes[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: text[i]}
+			default:
+				nodes[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: exportCell(ref, col, "")}
+			}
+		}
+		table.add(text, nodes)
+	}
+
+	return table.write(w, format)
+}
+
+// ExportChainMetadata writes the chain metadata of the datastore to w in the given format, with
+// the chain_selector, chain_name and metadata columns. Records are sorted by chain selector.
+func ExportChainMetadata(w io.Writer, ds DataStore, format ExportFormat, filters ...FilterFunc[ChainMetadataKey, ChainMetadata]) error {
+	if ds == nil {
+		return errors.New("datastore is required")
+	}
+
+	records := ds.ChainMetadata().Filter(filters...)
+	slices.SortFunc(records, func(a, b ChainMetadata) int {
+		return cmp.Compare(a.ChainSelector, b.ChainSelector)
+	})
+
+	table := newExportTable(ExportColumnChainSelector, ExportColumnChainName, ExportColumnMetadata)
+	for _, record := range records {
+		meta, err := newMetadataCell(record.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to export chain metadata %s: %w", record.Key(), err)
+		}
+
+		table.add(
+			[]string{strconv.FormatUint(record.ChainSelector, 10), exportChainName(record.ChainSelector), meta.text},
+			[]*yaml.Node{chainSelectorNode(record.ChainSelector), stringNode(exportChainName(record.ChainSelector)), meta.node},
+		)
+	}
+
+	return table.write(w, format)
+}
+
+// ExportContractMetadata writes the contract metadata of the datastore to w in the given format,
+// with the chain_selector, chain_name, address and metadata columns. Records are sorted by chain
+// selector and address.
+func ExportContractMetadata(w io.Writer, ds DataStore, format ExportFormat, filters ...FilterFunc[ContractMetadataKey, ContractMetadata]) error {
+	if ds == nil {
+		return errors.New("datastore is required")
+	}
+
+	records := ds.ContractMetadata().Filter(filters...)
+	slices.SortFunc(records, func(a, b ContractMetadata) int {
+		return cmp.Or(
+			cmp.Compare(a.ChainSelector, b.ChainSelector),
+			strings.Compare(a.Address, b.Address),
+		)
+	})
+
+	table := newExportTable(ExportColumnChainSelector, ExportColumnChainName, ExportColumnAddress, ExportColumnMetadata)
+	for _, record := range records {
+		meta, err := newMetadataCell(record.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to export contract metadata %s: %w", record.Key(), err)
+		}
+
+		chainName := exportChainName(record.ChainSelector)
+		table.add(
+			[]string{strconv.FormatUint(record.ChainSelector, 10), chainName, record.Address, meta.text},
+			[]*yaml.Node{chainSelectorNode(record.ChainSelector), stringNode(chainName), stringNode(record.Address), meta.node},
+		)
+	}
+
+	return table.write(w, format)
+}
+
+// ExportEnvMetadata writes the env metadata of the datastore to w in the given format, as a table
+// with a single metadata column. The table has no rows when the env metadata is not set.
+func ExportEnvMetadata(w io.Writer, ds DataStore, format ExportFormat) error {
+	if ds == nil {
+		return errors.New("datastore is required")
+	}
+
+	table := newExportTable(ExportColumnMetadata)
+
+	record, err := ds.EnvMetadata().Get()
+	switch {
+	case errors.Is(err, ErrEnvMetadataNotSet):
+	case err != nil:
+		return fmt.Errorf("failed to get env metadata: %w", err)
+	default:
+		meta, metaErr := newMetadataCell(record.Metadata)
+		if metaErr != nil {
+			return fmt.Errorf("failed to export env metadata: %w", metaErr)
+		}
+		table.add([]string{meta.text}, []*yaml.Node{meta.node})
+	}
+
+	return table.write(w, format)
+}
+
+// exportCell returns the flattened value of a column for an address ref.
+func exportCell(ref AddressRef, col ExportColumn, labelSeparator string) string {
+	switch col {
+	case ExportColumnChainSelector:
+		return strconv.FormatUint(ref.ChainSelector, 10)
+	case ExportColumnChainName:
+		return exportChainName(ref.ChainSelector)
+	case ExportColumnType:
+		return ref.Type.String()
+	case ExportColumnVersion:
+		if ref.Version == nil {
+			return ""
+		}
+
+		return ref.Version.String()
+	case ExportColumnQualifier:
+		return ref.Qualifier
+	case ExportColumnAddress:
+		return ref.Address
+	case ExportColumnLabels:
+		return strings.Join(ref.Labels.List(), labelSeparator)
+	default:
+		return ""
+	}
+}
+
+// exportChainName resolves the chain name of a chain selector, returning an empty string for
+// selectors which are not known to chain-selectors.
+func exportChainName(sel uint64) string {
+	name, err := chainsel.GetChainNameFromSelector(sel)
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func chainSelectorNode(sel uint64) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatUint(sel, 10)}
+}
+
+// metadataCell is the exported form of a metadata value.
+type metadataCell struct {
+	// text is the compact JSON encoding of the metadata, used in CSV and Markdown output.
+	text string
+	// node is the metadata as a YAML value.
+	node *yaml.Node
+}
+
+// newMetadataCell builds the exported form of a metadata value. The YAML value is built from the
+// JSON encoding of the metadata, so that its keys match the JSON field names of typed metadata.
+func newMetadataCell(metadata any) (metadataCell, error) {
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return metadataCell{}, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+
+	var generic any
+	if err = json.Unmarshal(raw, &generic); err != nil {
+		return metadataCell{}, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	node := &yaml.Node{}
+	if err = node.Encode(generic); err != nil {
+		return metadataCell{}, fmt.Errorf("failed to encode metadata as yaml: %w", err)
+	}
+
+	return metadataCell{text: string(raw), node: node}, nil
+}
+
+// exportTable is the tabular form of exported records, which is rendered in each export format.
+type exportTable struct {
+	columns []ExportColumn
+	// text holds the flattened value of each cell, as written to CSV and Markdown output.
+	text [][]string
+	// nodes holds the YAML value of each cell, in the same layout as text.
+	nodes [][]*yaml.Node
+}
+
+func newExportTable(columns ...ExportColumn) *exportTable {
+	return &exportTable{columns: columns}
+}
+
+// add appends a row to the table.
+func (t *exportTable) add(text []string, nodes []*yaml.Node) {
+	t.text = append(t.text, text)
+	t.nodes = append(t.nodes, nodes)
+}
+
+func (t *exportTable) header() []string {
+	header := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		header[i] = string(col)
+	}
+
+	return header
+}
+
+// write renders the table to w in the given format.
+func (t *exportTable) write(w io.Writer, format ExportFormat) error {
+	switch format {
+	case ExportFormatCSV:
+		return t.writeCSV(w)
+	case ExportFormatYAML:
+		return t.writeYAML(w)
+	case ExportFormatMarkdown:
+		return t.writeMarkdown(w)
+	default:
+		return fmt.Errorf("invalid export format %q", format)
+	}
+}
+
+func (t *exportTable) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(t.header()); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for i, row := range t.text {
+		escaped := make([]string, len(row))
+		for j, cell := range row {
+			escaped[j] = escapeCSVCell(cell)
+		}
+		if err := cw.Write(escaped); err != nil {
+			return fmt.Errorf("failed to write csv row %d: %w", i+1, err)
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// csvFormulaPrefixes are the leading characters which make spreadsheet applications evaluate a
+// cell as a formula.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVCell prefixes a cell which a spreadsheet application would evaluate as a formula with a
+// single quote, so that it is displayed as text. ImportAddressRefsCSV removes the prefix again.
+func escapeCSVCell(s string) string {
+	if s != "" && strings.ContainsRune(csvFormulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+// unescapeCSVCell reverses escapeCSVCell.
+func unescapeCSVCell(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(s[1])) {
+		return s[1:]
+	}
+
+	return s
+}
+
+func (t *exportTable) writeYAML(w io.Writer) error {
+	// A yaml.Node is built by hand so that the keys of each record follow the column order.
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range t.nodes {
+		record := &yaml.Node{Kind: yaml.MappingNode}
+		for i, col := range t.columns {
+			record.Content = append(record.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: string(col)}, row[i])
+		}
+		doc.Content = append(doc.Content, record)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode yaml: %w", err)
+	}
+
+	return enc.Close()
+}
+
+func (t *exportTable) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, c := range cells {
+			b.WriteString(" ")
+			b.WriteString(escapeMarkdownCell(c))
+			b.WriteString(" |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(t.header())
+	b.WriteString("|" + strings.Repeat(" --- |", len(t.columns)) + "\n")
+
+	for _, row := range t.text {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// escapeMarkdownCell escapes the characters which would break a Markdown table cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,253 @@
+package datastore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExportTestDataStore(t *testing.T) DataStore {
+	t.Helper()
+
+	ds := NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(AddressRef{
+		Address:       "0xrouter",
+		ChainSelector: chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector,
+		Type:          "Router",
+		Version:       semver.MustParse("1.2.0"),
+		Qualifier:     "main",
+		Labels:        NewLabelSet("b", "a"),
+	}))
+	require.NoError(t, ds.Addresses().Add(AddressRef{
+		Address:       "0xramp",
+		ChainSelector: 42,
+		Type:          "OnRamp",
+		Version:       semver.MustParse("1.0.0"),
+	}))
+
+	return ds.Seal()
+}
+
+func TestExportAddressRefs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format ExportFormat
+		opts   ExportOptions
+		want   string
+	}{
+		{
+			name:   "csv with default columns",
+			format: ExportFormatCSV,
+			want: "chain_selector,chain_name,type,version,qualifier,address,labels\n" +
+				"42,,OnRamp,1.0.0,,0xramp,\n" +
+				"16015286601757825753,ethereum-testnet-sepolia,Router,1.2.0,main,0xrouter,a|b\n",
+		},
+		{
+			name:   "csv with selected columns and label separator",
+			format: ExportFormatCSV,
+			opts: ExportOptions{
+				Columns:        []ExportColumn{ExportColumnAddress, ExportColumnLabels},
+				LabelSeparator: ";",
+			},
+			want: "address,labels\n" +
+				"0xramp,\n" +
+				"0xrouter,a;b\n",
+		},
+		{
+			name:   "markdown",
+			format: ExportFormatMarkdown,
+			opts: ExportOptions{
+				Columns: []ExportColumn{ExportColumnChainName, ExportColumnType, ExportColumnLabels},
+			},
+			want: "| chain_name | type | labels |\n" +
+				"| --- | --- | --- |\n" +
+				"|  | OnRamp |  |\n" +
+				"| ethereum-testnet-sepolia | Router | a\\|b |\n",
+		},
+		{
+			name:   "yaml",
+			format: ExportFormatYAML,
+			opts: ExportOptions{
+				Columns: []ExportColumn{ExportColumnChainSelector, ExportColumnVersion, ExportColumnLabels},
+				Filters: []FilterFunc[AddressRefKey, AddressRef]{AddressRefByType("Router")},
+			},
+			want: "- chain_selector: 16015286601757825753\n" +
+				"  version: 1.2.0\n" +
+				"  labels: [a, b]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			err := ExportAddressRefs(&buf, newExportTestDataStore(t), tt.format, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestExportAddressRefs_Errors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	err := ExportAddressRefs(&buf, nil, ExportFormatCSV, ExportOptions{})
+	require.ErrorContains(t, err, "datastore is required")
+
+	err = ExportAddressRefs(&buf, newExportTestDataStore(t), "xlsx", ExportOptions{})
+	require.ErrorContains(t, err, `invalid export format "xlsx"`)
+
+	err = ExportAddressRefs(&buf, newExportTestDataStore(t), ExportFormatCSV, ExportOptions{
+		Columns: []ExportColumn{"owner"},
+	})
+	require.ErrorContains(t, err, `invalid export column "owner"`)
+}
+
+func TestExportAddressRefs_EscapesFormulas(t *testing.T) {
+	t.Parallel()
+
+	ds := NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(AddressRef{
+		Address:       "0xrouter",
+		ChainSelector: 42,
+		Type:          "Router",
+		Version:       semver.MustParse("1.0.0"),
+		Qualifier:     "=HYPERLINK(\"http://example.com\")",
+		Labels:        NewLabelSet("@ops"),
+	}))
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportAddressRefs(&buf, ds.Seal(), ExportFormatCSV, ExportOptions{
+		Columns: []ExportColumn{ExportColumnChainSelector, ExportColumnType, ExportColumnVersion, ExportColumnQualifier, ExportColumnAddress, ExportColumnLabels},
+	}))
+	assert.Equal(t, "chain_selector,type,version,qualifier,address,labels\n"+
+		"42,Router,1.0.0,\"'=HYPERLINK(\"\"http://example.com\"\")\",0xrouter,'@ops\n", buf.String())
+
+	// The escaped export is imported with the original values
+	imported, err := ImportAddressRefsCSV(&buf, ImportOptions{})
+	require.NoError(t, err)
+	refs, err := imported.Addresses().Fetch()
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, "=HYPERLINK(\"http://example.com\")", refs[0].Qualifier)
+	assert.Equal(t, NewLabelSet("@ops"), refs[0].Labels)
+}
+
+func TestExportMetadata(t *testing.T) {
+	t.Parallel()
+
+	ds := NewMemoryDataStore()
+	require.NoError(t, ds.ChainMetadata().Add(ChainMetadata{
+		ChainSelector: chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector,
+		Metadata:      map[string]any{"feeToken": "LINK"},
+	}))
+	require.NoError(t, ds.ChainMetadata().Add(ChainMetadata{ChainSelector: 42, Metadata: "-1"}))
+	require.NoError(t, ds.ContractMetadata().Add(ContractMetadata{
+		ChainSelector: 42,
+		Address:       "0xrouter",
+		Metadata: struct {
+			Owner string `json:"owner"`
+		}{Owner: "timelock"},
+	}))
+	require.NoError(t, ds.EnvMetadata().Set(EnvMetadata{Metadata: map[string]any{"team": "ccip"}}))
+	sealed := ds.Seal()
+
+	t.Run("chain metadata as csv", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportChainMetadata(&buf, sealed, ExportFormatCSV))
+		assert.Equal(t, "chain_selector,chain_name,metadata\n"+
+			"42,,\"\"\"-1\"\"\"\n"+
+			"16015286601757825753,ethereum-testnet-sepolia,\"{\"\"feeToken\"\":\"\"LINK\"\"}\"\n", buf.String())
+	})
+
+	t.Run("chain metadata with filters", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportChainMetadata(&buf, sealed, ExportFormatMarkdown,
+			func(records []ChainMetadata) []ChainMetadata { return records[:0] },
+		))
+		assert.Equal(t, "| chain_selector | chain_name | metadata |\n| --- | --- | --- |\n", buf.String())
+	})
+
+	t.Run("contract metadata as yaml uses json field names", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportContractMetadata(&buf, sealed, ExportFormatYAML))
+		assert.Equal(t, "- chain_selector: 42\n"+
+			"  chain_name: \"\"\n"+
+			"  address: 0xrouter\n"+
+			"  metadata:\n"+
+			"    owner: timelock\n", buf.String())
+	})
+
+	t.Run("env metadata as markdown", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportEnvMetadata(&buf, sealed, ExportFormatMarkdown))
+		assert.Equal(t, "| metadata |\n| --- |\n| {\"team\":\"ccip\"} |\n", buf.String())
+	})
+
+	t.Run("unset env metadata has no rows", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportEnvMetadata(&buf, NewMemoryDataStore().Seal(), ExportFormatCSV))
+		assert.Equal(t, "metadata\n", buf.String())
+	})
+
+	t.Run("nil datastore", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.ErrorContains(t, ExportChainMetadata(&buf, nil, ExportFormatCSV), "datastore is required")
+		require.ErrorContains(t, ExportContractMetadata(&buf, nil, ExportFormatCSV), "datastore is required")
+		require.ErrorContains(t, ExportEnvMetadata(&buf, nil, ExportFormatCSV), "datastore is required")
+	})
+}
+
+func TestParseExportFormat(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]ExportFormat{
+		"csv":      ExportFormatCSV,
+		"YAML":     ExportFormatYAML,
+		"yml":      ExportFormatYAML,
+		"markdown": ExportFormatMarkdown,
+		"md":       ExportFormatMarkdown,
+	} {
+		got, err := ParseExportFormat(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseExportFormat("xlsx")
+	require.ErrorContains(t, err, "invalid export format")
+}
+
+func TestParseExportColumns(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseExportColumns([]string{"Address", " chain_name "})
+	require.NoError(t, err)
+	assert.Equal(t, []ExportColumn{ExportColumnAddress, ExportColumnChainName}, got)
+
+	_, err = ParseExportColumns([]string{"owner"})
+	require.ErrorContains(t, err, `invalid export column "owner"`)
+
+	_, err = ParseExportColumns([]string{"address", "address"})
+	require.ErrorContains(t, err, `duplicate export column "address"`)
+}
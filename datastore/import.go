@@ -0,0 +1,206 @@
+package datastore
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+)
+
+// ImportOptions configures ImportAddressRefsCSV.
+type ImportOptions struct {
+	// LabelSeparator splits the labels cell into individual labels. Empty means
+	// DefaultLabelSeparator.
+	LabelSeparator string
+}
+
+// ImportAddressRefsCSV builds a datastore from a CSV of address refs, such as one written by
+// ExportAddressRefs. It is intended for bootstrapping the datastore of an environment which was
+// deployed outside of CLDF.
+//
+// The first row is a header naming the columns, using the ExportColumn names. Columns may appear in
+// any order and unknown columns are rejected. The address, type and version columns are required,
+// as is at least one of chain_selector and chain_name. When both are present they must refer to the
+// same chain.
+//
+// Cells which ExportAddressRefs prefixed with a single quote to stop spreadsheet applications from
+// evaluating them as formulas are read without the prefix.
+//
+// Every row is validated before the datastore is returned, and all invalid rows are reported
+// together, identified by their line number.
+func ImportAddressRefsCSV(r io.Reader, opts ImportOptions) (MutableDataStore, error) {
+	labelSeparator := opts.LabelSeparator
+	if labelSeparator == "" {
+		labelSeparator = DefaultLabelSeparator
+	}
+
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv is empty: a header row is required")
+		}
+
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	names := make([]string, len(header))
+	for i, h := range header {
+		// Spreadsheet applications commonly prefix the first cell with a UTF-8 byte order mark
+		names[i] = strings.TrimPrefix(h, "\ufeff")
+	}
+	columns, err := ParseExportColumns(names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	index := make(map[ExportColumn]int, len(columns))
+	for i, col := range columns {
+		index[col] = i
+	}
+
+	var missing []string
+	for _, col := range []ExportColumn{ExportColumnAddress, ExportColumnType, ExportColumnVersion} {
+		if _, ok := index[col]; !ok {
+			missing = append(missing, string(col))
+		}
+	}
+	_, hasSelector := index[ExportColumnChainSelector]
+	_, hasName := index[ExportColumnChainName]
+	if !hasSelector && !hasName {
+		missing = append(missing, "chain_selector or chain_name")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("invalid csv header: missing columns: %s", strings.Join(missing, ", "))
+	}
+
+	ds := NewMemoryDataStore()
+
+	var errs []error
+	for {
+		row, readErr := cr.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			// Malformed CSV cannot be recovered from, so stop reading here. The csv.ParseError
+			// carries the line number.
+			errs = append(errs, fmt.Errorf("failed to read csv: %w", readErr))
+			break
+		}
+		line, _ := cr.FieldPos(0)
+
+		cell := func(col ExportColumn) string {
+			i, ok := index[col]
+			if !ok {
+				return ""
+			}
+
+			return strings.TrimSpace(unescapeCSVCell(row[i]))
+		}
+
+		ref, rowErr := parseImportRow(cell, labelSeparator)
+		if rowErr == nil {
+			rowErr = ds.Addresses().Add(ref)
+		}
+		if rowErr != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, rowErr))
+		}
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// parseImportRow builds and validates an address ref from the cells of a CSV row.
+func parseImportRow(cell func(ExportColumn) string, labelSeparator string) (AddressRef, error) {
+	var errs []error
+
+	sel, err := parseImportChainSelector(cell(ExportColumnChainSelector), cell(ExportColumnChainName))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	address := cell(ExportColumnAddress)
+	if address == "" {
+		errs = append(errs, errors.New("address is required"))
+	}
+
+	contractType := cell(ExportColumnType)
+	if contractType == "" {
+		errs = append(errs, errors.New("type is required"))
+	}
+
+	var version *semver.Version
+	if v := cell(ExportColumnVersion); v == "" {
+		errs = append(errs, ErrAddressRefVersionRequired)
+	} else if version, err = semver.NewVersion(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid version %q: %w", v, err))
+	}
+
+	labels := NewLabelSet()
+	if l := cell(ExportColumnLabels); l != "" {
+		for _, label := range strings.Split(l, labelSeparator) {
+			if label = strings.TrimSpace(label); label != "" {
+				labels.Add(label)
+			}
+		}
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return AddressRef{}, err
+	}
+
+	return AddressRef{
+		Address:       address,
+		ChainSelector: sel,
+		Labels:        labels,
+		Qualifier:     cell(ExportColumnQualifier),
+		Type:          ContractType(contractType),
+		Version:       version,
+	}, nil
+}
+
+// parseImportChainSelector resolves the chain selector of a row from its chain_selector and
+// chain_name cells, checking that they agree when both are set.
+func parseImportChainSelector(selector string, name string) (uint64, error) {
+	var (
+		sel    uint64
+		hasSel bool
+	)
+	if selector != "" {
+		parsed, err := strconv.ParseUint(selector, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid chain selector %q: %w", selector, err)
+		}
+		sel, hasSel = parsed, true
+	}
+
+	if name == "" {
+		if !hasSel {
+			return 0, errors.New("chain_selector or chain_name is required")
+		}
+
+		return sel, nil
+	}
+
+	details, err := chainsel.GetChainDetailsByNetworkName(name)
+	if err != nil {
+		return 0, fmt.Errorf("unknown chain name %q: %w", name, err)
+	}
+	if hasSel && details.ChainSelector != sel {
+		return 0, fmt.Errorf("chain selector %d does not match chain name %q (%d)", sel, name, details.ChainSelector)
+	}
+
+	return details.ChainSelector, nil
+}
@@ -0,0 +1,108 @@
+package datastore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportAddressRefsCSV(t *testing.T) {
+	t.Parallel()
+
+	sepolia := chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector
+
+	t.Run("resolves chain names and labels", func(t *testing.T) {
+		t.Parallel()
+
+		in := "\ufeffaddress,chain_name,type,version,labels,qualifier\n" +
+			"0xrouter, ethereum-testnet-sepolia,Router,1.2.0,a | b,main\n"
+
+		ds, err := ImportAddressRefsCSV(strings.NewReader(in), ImportOptions{})
+		require.NoError(t, err)
+
+		ref, err := ds.Addresses().Get(NewAddressRefKey(sepolia, "Router", semver.MustParse("1.2.0"), "main"))
+		require.NoError(t, err)
+		assert.Equal(t, "0xrouter", ref.Address)
+		assert.Equal(t, NewLabelSet("a", "b"), ref.Labels)
+	})
+
+	t.Run("round trips an export", func(t *testing.T) {
+		t.Parallel()
+
+		source := newExportTestDataStore(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportAddressRefs(&buf, source, ExportFormatCSV, ExportOptions{}))
+
+		ds, err := ImportAddressRefsCSV(&buf, ImportOptions{})
+		require.NoError(t, err)
+
+		want, err := source.Addresses().Fetch()
+		require.NoError(t, err)
+		got, err := ds.Addresses().Fetch()
+		require.NoError(t, err)
+		SortAddressRefs(want)
+		SortAddressRefs(got)
+		require.Len(t, got, len(want))
+		for i := range want {
+			assert.Equal(t, want[i].Key(), got[i].Key())
+			assert.Equal(t, want[i].Address, got[i].Address)
+			assert.Equal(t, want[i].Labels.List(), got[i].Labels.List())
+		}
+	})
+
+	t.Run("reports every invalid row", func(t *testing.T) {
+		t.Parallel()
+
+		in := "chain_selector,chain_name,type,version,address\n" +
+			"1,,Router,1.0.0,0xa\n" +
+			"abc,,Router,1.0.0,0xb\n" +
+			",,Router,1.0.0,0xc\n" +
+			"1,,,x.y,\n" +
+			"1,ethereum-testnet-sepolia,Router,1.0.0,0xd\n" +
+			",no-such-chain,Router,1.0.0,0xe\n" +
+			"1,,Router,1.0.0,0xf\n"
+
+		_, err := ImportAddressRefsCSV(strings.NewReader(in), ImportOptions{})
+		require.Error(t, err)
+
+		msg := err.Error()
+		assert.Contains(t, msg, `line 3: invalid chain selector "abc"`)
+		assert.Contains(t, msg, "line 4: chain_selector or chain_name is required")
+		assert.Contains(t, msg, "line 5: address is required")
+		assert.Contains(t, msg, "type is required")
+		assert.Contains(t, msg, `invalid version "x.y"`)
+		assert.Contains(t, msg, `line 6: chain selector 1 does not match chain name "ethereum-testnet-sepolia"`)
+		assert.Contains(t, msg, `line 7: unknown chain name "no-such-chain"`)
+		assert.Contains(t, msg, "line 8: "+ErrAddressRefExists.Error())
+		assert.NotContains(t, msg, "line 2:")
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ImportAddressRefsCSV(strings.NewReader(""), ImportOptions{})
+		require.ErrorContains(t, err, "a header row is required")
+
+		_, err = ImportAddressRefsCSV(strings.NewReader("address,owner\n"), ImportOptions{})
+		require.ErrorContains(t, err, `invalid export column "owner"`)
+
+		_, err = ImportAddressRefsCSV(strings.NewReader("address,labels\n"), ImportOptions{})
+		require.ErrorContains(t, err, "missing columns: type, version, chain_selector or chain_name")
+	})
+
+	t.Run("malformed csv", func(t *testing.T) {
+		t.Parallel()
+
+		in := "chain_selector,type,version,address\n" +
+			"1,Router,1.0.0\n"
+
+		_, err := ImportAddressRefsCSV(strings.NewReader(in), ImportOptions{})
+		require.ErrorContains(t, err, "wrong number of fields")
+	})
+}
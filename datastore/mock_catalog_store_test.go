@@ -222,16 +222,22 @@ func (_c *MockCatalogStore_EnvMetadata_Call) RunAndReturn(run func() MutableUnar
 }
 
 // WithTransaction provides a mock function for the type MockCatalogStore
-func (_mock *MockCatalogStore) WithTransaction(ctx context.Context, fn TransactionLogic) error {
-	ret := _mock.Called(ctx, fn)
+func (_mock *MockCatalogStore) WithTransaction(ctx context.Context, fn TransactionLogic, opts ...TransactionOption) error {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, fn, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, fn)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
 		panic("no return value specified for WithTransaction")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, TransactionLogic) error); ok {
-		r0 = returnFunc(ctx, fn)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, TransactionLogic, ...TransactionOption) error); ok {
+		r0 = returnFunc(ctx, fn, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -246,11 +252,13 @@ type MockCatalogStore_WithTransaction_Call struct {
 // WithTransaction is a helper method to define mock.On call
 //   - ctx context.Context
 //   - fn TransactionLogic
-func (_e *MockCatalogStore_Expecter) WithTransaction(ctx interface{}, fn interface{}) *MockCatalogStore_WithTransaction_Call {
-	return &MockCatalogStore_WithTransaction_Call{Call: _e.mock.On("WithTransaction", ctx, fn)}
+//   - opts ...TransactionOption
+func (_e *MockCatalogStore_Expecter) WithTransaction(ctx interface{}, fn interface{}, opts ...interface{}) *MockCatalogStore_WithTransaction_Call {
+	return &MockCatalogStore_WithTransaction_Call{Call: _e.mock.On("WithTransaction",
+		append([]interface{}{ctx, fn}, opts...)...)}
 }
 
-func (_c *MockCatalogStore_WithTransaction_Call) Run(run func(ctx context.Context, fn TransactionLogic)) *MockCatalogStore_WithTransaction_Call {
+func (_c *MockCatalogStore_WithTransaction_Call) Run(run func(ctx context.Context, fn TransactionLogic, opts ...TransactionOption)) *MockCatalogStore_WithTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -260,9 +268,16 @@ func (_c *MockCatalogStore_WithTransaction_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(TransactionLogic)
 		}
+		var arg2 []TransactionOption
+		var variadicArgs []TransactionOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]TransactionOption)
+		}
+		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
+			arg2...,
 		)
 	})
 	return _c
@@ -273,7 +288,75 @@ func (_c *MockCatalogStore_WithTransaction_Call) Return(err error) *MockCatalogS
 	return _c
 }
 
-func (_c *MockCatalogStore_WithTransaction_Call) RunAndReturn(run func(ctx context.Context, fn TransactionLogic) error) *MockCatalogStore_WithTransaction_Call {
+func (_c *MockCatalogStore_WithTransaction_Call) RunAndReturn(run func(ctx context.Context, fn TransactionLogic, opts ...TransactionOption) error) *MockCatalogStore_WithTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function for the type MockCatalogStore
+func (_mock *MockCatalogStore) Watch(ctx context.Context, filter WatchFilter) (<-chan ChangeEvent, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 <-chan ChangeEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WatchFilter) (<-chan ChangeEvent, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WatchFilter) <-chan ChangeEvent); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan ChangeEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, WatchFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCatalogStore_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type MockCatalogStore_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter WatchFilter
+func (_e *MockCatalogStore_Expecter) Watch(ctx interface{}, filter interface{}) *MockCatalogStore_Watch_Call {
+	return &MockCatalogStore_Watch_Call{Call: _e.mock.On("Watch", ctx, filter)}
+}
+
+func (_c *MockCatalogStore_Watch_Call) Run(run func(ctx context.Context, filter WatchFilter)) *MockCatalogStore_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 WatchFilter
+		if args[1] != nil {
+			arg1 = args[1].(WatchFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCatalogStore_Watch_Call) Return(changeEventCh <-chan ChangeEvent, err error) *MockCatalogStore_Watch_Call {
+	_c.Call.Return(changeEventCh, err)
+	return _c
+}
+
+func (_c *MockCatalogStore_Watch_Call) RunAndReturn(run func(ctx context.Context, filter WatchFilter) (<-chan ChangeEvent, error)) *MockCatalogStore_Watch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,310 @@
+package datastore
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// AddressPolicy determines how Promote treats the contract addresses of promoted records.
+type AddressPolicy int
+
+const (
+	// AddressPolicyDrop promotes the shape of the source records without their addresses. Address
+	// refs are not promoted and are reported as skipped, and contract metadata is re-keyed to the address of the contract with
+	// the same type, version and qualifier in the target datastore. Contract metadata for which no
+	// such contract exists in the target is skipped.
+	//
+	// This is the policy to use when promoting from testnet to mainnet, where the same contracts
+	// are deployed at different addresses.
+	AddressPolicyDrop AddressPolicy = iota
+	// AddressPolicyKeep promotes records with their addresses unchanged. This is useful when
+	// copying refs between domains of the same environment, or for contracts deployed at
+	// deterministic addresses.
+	AddressPolicyKeep
+)
+
+// String returns the string representation of an AddressPolicy.
+func (p AddressPolicy) String() string {
+	switch p {
+	case AddressPolicyDrop:
+		return "drop"
+	case AddressPolicyKeep:
+		return "keep"
+	default:
+		return fmt.Sprintf("AddressPolicy(%d)", int(p))
+	}
+}
+
+// ParseAddressPolicy parses the string representation of an AddressPolicy.
+func ParseAddressPolicy(s string) (AddressPolicy, error) {
+	switch s {
+	case "drop":
+		return AddressPolicyDrop, nil
+	case "keep":
+		return AddressPolicyKeep, nil
+	default:
+		return 0, fmt.Errorf("invalid address policy %q: must be one of drop, keep", s)
+	}
+}
+
+// PromoteOptions configures Promote.
+type PromoteOptions struct {
+	// ChainSelectorMapping maps source chain selectors to target chain selectors, e.g. a testnet
+	// chain to its mainnet counterpart. When empty, chain selectors are promoted unchanged. When
+	// set, records on chains which are not in the mapping are skipped.
+	ChainSelectorMapping map[uint64]uint64
+	// AddressPolicy determines whether contract addresses are promoted.
+	AddressPolicy AddressPolicy
+	// Kinds restricts promotion to the given record kinds. Empty means all kinds except env
+	// metadata. Env metadata describes the environment as a whole, so it is only promoted when
+	// ChangeKindEnvMetadata is listed explicitly, in which case it replaces the env metadata of
+	// the target when the result is merged.
+	Kinds []ChangeKind
+
+	// AddressRefFilters, ChainMetadataFilters and ContractMetadataFilters select the source
+	// records to promote. They are applied to the source records before chain selectors are
+	// remapped. An empty list selects all records of that kind.
+	//
+	// AddressRefFilters also select contract metadata: when set, contract metadata is only
+	// promoted if the address ref of its contract in the source is selected by them.
+	AddressRefFilters       []FilterFunc[AddressRefKey, AddressRef]
+	ChainMetadataFilters    []FilterFunc[ChainMetadataKey, ChainMetadata]
+	ContractMetadataFilters []FilterFunc[ContractMetadataKey, ContractMetadata]
+
+	// Target is the datastore of the environment being promoted to. It is required to promote
+	// contract metadata with AddressPolicyDrop, where it is used to resolve the target address of
+	// each contract.
+	Target DataStore
+}
+
+// PromoteSkip describes a source record which was selected for promotion but not promoted.
+type PromoteSkip struct {
+	Kind ChangeKind
+	// Key is the string form of the source record's primary key.
+	Key    string
+	Reason string
+}
+
+// PromoteResult is the outcome of Promote.
+type PromoteResult struct {
+	// DataStore holds the promoted records, keyed for the target environment.
+	DataStore MutableDataStore
+	// Skipped lists the selected records which could not be promoted.
+	Skipped []PromoteSkip
+}
+
+// Promote selects records from the source datastore and rewrites them for a target environment,
+// remapping chain selectors and dropping or keeping addresses according to the options. The
+// source is not modified. The promoted records are returned in a new datastore, which is
+// intended to be saved as a changeset artifact and reviewed before it is merged into the target.
+func Promote(source DataStore, opts PromoteOptions) (PromoteResult, error) {
+	if source == nil {
+		return PromoteResult{}, errors.New("source datastore is required")
+	}
+
+	p := promoter{opts: opts, result: PromoteResult{DataStore: NewMemoryDataStore()}}
+
+	if p.includes(ChangeKindAddressRef) {
+		if err := p.promoteAddressRefs(source); err != nil {
+			return PromoteResult{}, err
+		}
+	}
+	if p.includes(ChangeKindChainMetadata) {
+		if err := p.promoteChainMetadata(source); err != nil {
+			return PromoteResult{}, err
+		}
+	}
+	if p.includes(ChangeKindContractMetadata) {
+		if err := p.promoteContractMetadata(source); err != nil {
+			return PromoteResult{}, err
+		}
+	}
+	if p.includes(ChangeKindEnvMetadata) {
+		if err := p.promoteEnvMetadata(source); err != nil {
+			return PromoteResult{}, err
+		}
+	}
+
+	return p.result, nil
+}
+
+// promoter holds the state of a single Promote call.
+type promoter struct {
+	opts   PromoteOptions
+	result PromoteResult
+}
+
+func (p *promoter) includes(kind ChangeKind) bool {
+	if len(p.opts.Kinds) == 0 {
+		return kind != ChangeKindEnvMetadata
+	}
+
+	return slices.Contains(p.opts.Kinds, kind)
+}
+
+func (p *promoter) skip(kind ChangeKind, key string, reason string) {
+	p.result.Skipped = append(p.result.Skipped, PromoteSkip{Kind: kind, Key: key, Reason: reason})
+}
+
+// mapChain returns the target chain selector for a source chain selector, and false if the chain
+// is not part of the promotion.
+func (p *promoter) mapChain(sel uint64) (uint64, bool) {
+	if len(p.opts.ChainSelectorMapping) == 0 {
+		return sel, true
+	}
+
+	target, ok := p.opts.ChainSelectorMapping[sel]
+
+	return target, ok
+}
+
+func (p *promoter) promoteAddressRefs(source DataStore) error {
+	refs := source.Addresses().Filter(p.opts.AddressRefFilters...)
+
+	for _, ref := range refs {
+		if p.opts.AddressPolicy == AddressPolicyDrop {
+			p.skip(ChangeKindAddressRef, ref.Key().String(), "address refs are not promoted with the drop address policy")
+			continue
+		}
+
+		target, ok := p.mapChain(ref.ChainSelector)
+		if !ok {
+			p.skip(ChangeKindAddressRef, ref.Key().String(), fmt.Sprintf("chain %d is not mapped", ref.ChainSelector))
+			continue
+		}
+
+		promoted := ref.Clone()
+		promoted.ChainSelector = target
+		if err := p.result.DataStore.Addresses().Upsert(promoted); err != nil {
+			return fmt.Errorf("failed to promote address ref %s: %w", ref.Key(), err)
+		}
+	}
+
+	return nil
+}
+
+func (p *promoter) promoteChainMetadata(source DataStore) error {
+	records := source.ChainMetadata().Filter(p.opts.ChainMetadataFilters...)
+
+	for _, record := range records {
+		target, ok := p.mapChain(record.ChainSelector)
+		if !ok {
+			p.skip(ChangeKindChainMetadata, record.Key().String(), fmt.Sprintf("chain %d is not mapped", record.ChainSelector))
+			continue
+		}
+
+		promoted, err := record.Clone()
+		if err != nil {
+			return fmt.Errorf("failed to clone chain metadata %s: %w", record.Key(), err)
+		}
+		promoted.ChainSelector = target
+		if err = p.result.DataStore.ChainMetadata().Upsert(promoted); err != nil {
+			return fmt.Errorf("failed to promote chain metadata %s: %w", record.Key(), err)
+		}
+	}
+
+	return nil
+}
+
+func (p *promoter) promoteContractMetadata(source DataStore) error {
+	records := source.ContractMetadata().Filter(p.opts.ContractMetadataFilters...)
+	if len(p.opts.AddressRefFilters) > 0 {
+		records = selectByAddressRefs(records, source.Addresses().Filter(p.opts.AddressRefFilters...))
+	}
+
+	for _, record := range records {
+		target, ok := p.mapChain(record.ChainSelector)
+		if !ok {
+			p.skip(ChangeKindContractMetadata, record.Key().String(), fmt.Sprintf("chain %d is not mapped", record.ChainSelector))
+			continue
+		}
+
+		address := record.Address
+		if p.opts.AddressPolicy == AddressPolicyDrop {
+			resolved, reason := p.resolveTargetAddress(source, record, target)
+			if reason != "" {
+				p.skip(ChangeKindContractMetadata, record.Key().String(), reason)
+				continue
+			}
+			address = resolved
+		}
+
+		promoted, err := record.Clone()
+		if err != nil {
+			return fmt.Errorf("failed to clone contract metadata %s: %w", record.Key(), err)
+		}
+		promoted.ChainSelector = target
+		promoted.Address = address
+		if err = p.result.DataStore.ContractMetadata().Upsert(promoted); err != nil {
+			return fmt.Errorf("failed to promote contract metadata %s: %w", record.Key(), err)
+		}
+	}
+
+	return nil
+}
+
+// selectByAddressRefs returns the contract metadata records whose contract is one of the given
+// address refs.
+func selectByAddressRefs(records []ContractMetadata, refs []AddressRef) []ContractMetadata {
+	contracts := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		contracts[NewContractMetadataKey(ref.ChainSelector, ref.Address).String()] = true
+	}
+
+	selected := make([]ContractMetadata, 0, len(records))
+	for _, record := range records {
+		if contracts[record.Key().String()] {
+			selected = append(selected, record)
+		}
+	}
+
+	return selected
+}
+
+// resolveTargetAddress finds the address of the target contract which corresponds to the source
+// contract of the metadata record. Contracts correspond when they share type, version and
+// qualifier. A non-empty reason is returned when the address cannot be resolved.
+func (p *promoter) resolveTargetAddress(source DataStore, record ContractMetadata, targetSel uint64) (string, string) {
+	if p.opts.Target == nil {
+		return "", "a target datastore is required to resolve addresses"
+	}
+
+	sourceRefs := source.Addresses().Filter(
+		AddressRefByChainSelector(record.ChainSelector),
+		AddressRefByAddress(record.Address),
+	)
+	if len(sourceRefs) != 1 {
+		return "", fmt.Sprintf("expected 1 source address ref for %s, found %d", record.Address, len(sourceRefs))
+	}
+	sourceRef := sourceRefs[0]
+
+	targetRef, err := p.opts.Target.Addresses().Get(
+		NewAddressRefKey(targetSel, sourceRef.Type, sourceRef.Version, sourceRef.Qualifier),
+	)
+	if err != nil {
+		return "", fmt.Sprintf("no %s %s (qualifier %q) on target chain %d",
+			sourceRef.Type, sourceRef.Version, sourceRef.Qualifier, targetSel,
+		)
+	}
+
+	return targetRef.Address, ""
+}
+
+func (p *promoter) promoteEnvMetadata(source DataStore) error {
+	record, err := source.EnvMetadata().Get()
+	if err != nil {
+		if errors.Is(err, ErrEnvMetadataNotSet) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get env metadata: %w", err)
+	}
+
+	promoted, err := record.Clone()
+	if err != nil {
+		return fmt.Errorf("failed to clone env metadata: %w", err)
+	}
+
+	return p.result.DataStore.EnvMetadata().Set(promoted)
+}
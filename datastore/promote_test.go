@@ -0,0 +1,225 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromote(t *testing.T) {
+	t.Parallel()
+
+	const (
+		testnetA = uint64(100)
+		testnetB = uint64(200)
+		mainnetA = uint64(1)
+	)
+
+	var (
+		routerRef = AddressRef{
+			Address:       "0xtestrouter",
+			ChainSelector: testnetA,
+			Type:          "Router",
+			Version:       semver.MustParse("1.0.0"),
+			Qualifier:     "main",
+			Labels:        NewLabelSet("l1"),
+		}
+		rampRef = AddressRef{
+			Address:       "0xtestramp",
+			ChainSelector: testnetB,
+			Type:          "OnRamp",
+			Version:       semver.MustParse("1.0.0"),
+		}
+		routerMeta = ContractMetadata{
+			Address:       "0xtestrouter",
+			ChainSelector: testnetA,
+			Metadata:      map[string]any{"owner": "timelock"},
+		}
+		chainMeta = ChainMetadata{ChainSelector: testnetA, Metadata: map[string]any{"feeToken": "LINK"}}
+		envMeta   = EnvMetadata{Metadata: map[string]any{"env": "shape"}}
+	)
+
+	newSource := func(t *testing.T) DataStore {
+		t.Helper()
+
+		ds := NewMemoryDataStore()
+		require.NoError(t, ds.Addresses().Add(routerRef))
+		require.NoError(t, ds.Addresses().Add(rampRef))
+		require.NoError(t, ds.ContractMetadata().Add(routerMeta))
+		require.NoError(t, ds.ChainMetadata().Add(chainMeta))
+		require.NoError(t, ds.EnvMetadata().Set(envMeta))
+
+		return ds.Seal()
+	}
+
+	newTarget := func(t *testing.T) DataStore {
+		t.Helper()
+
+		ds := NewMemoryDataStore()
+		mainRouter := routerRef.Clone()
+		mainRouter.ChainSelector = mainnetA
+		mainRouter.Address = "0xmainrouter"
+		require.NoError(t, ds.Addresses().Add(mainRouter))
+
+		return ds.Seal()
+	}
+
+	t.Run("keep addresses and remap chains", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Promote(newSource(t), PromoteOptions{
+			ChainSelectorMapping: map[uint64]uint64{testnetA: mainnetA},
+			AddressPolicy:        AddressPolicyKeep,
+		})
+		require.NoError(t, err)
+
+		refs, err := got.DataStore.Addresses().Fetch()
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		assert.Equal(t, mainnetA, refs[0].ChainSelector)
+		assert.Equal(t, "0xtestrouter", refs[0].Address)
+		assert.Equal(t, routerRef.Labels, refs[0].Labels)
+
+		cm, err := got.DataStore.ContractMetadata().Get(NewContractMetadataKey(mainnetA, "0xtestrouter"))
+		require.NoError(t, err)
+		assert.Equal(t, routerMeta.Metadata, cm.Metadata)
+
+		chm, err := got.DataStore.ChainMetadata().Get(NewChainMetadataKey(mainnetA))
+		require.NoError(t, err)
+		assert.Equal(t, chainMeta.Metadata, chm.Metadata)
+
+		// Env metadata is only promoted when requested explicitly
+		_, err = got.DataStore.EnvMetadata().Get()
+		require.ErrorIs(t, err, ErrEnvMetadataNotSet)
+
+		// The OnRamp on testnetB is not mapped
+		require.Len(t, got.Skipped, 1)
+		assert.Equal(t, ChangeKindAddressRef, got.Skipped[0].Kind)
+		assert.Equal(t, rampRef.Key().String(), got.Skipped[0].Key)
+	})
+
+	t.Run("drop addresses resolves contract metadata against target", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Promote(newSource(t), PromoteOptions{
+			ChainSelectorMapping: map[uint64]uint64{testnetA: mainnetA},
+			AddressPolicy:        AddressPolicyDrop,
+			Target:               newTarget(t),
+		})
+		require.NoError(t, err)
+
+		refs, err := got.DataStore.Addresses().Fetch()
+		require.NoError(t, err)
+		assert.Empty(t, refs)
+
+		cm, err := got.DataStore.ContractMetadata().Get(NewContractMetadataKey(mainnetA, "0xmainrouter"))
+		require.NoError(t, err)
+		assert.Equal(t, routerMeta.Metadata, cm.Metadata)
+
+		// Every dropped address ref is reported
+		require.Len(t, got.Skipped, 2)
+		for _, skip := range got.Skipped {
+			assert.Equal(t, ChangeKindAddressRef, skip.Kind)
+			assert.Contains(t, skip.Reason, "drop address policy")
+		}
+	})
+
+	t.Run("address ref filters select contract metadata", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Promote(newSource(t), PromoteOptions{
+			ChainSelectorMapping: map[uint64]uint64{testnetA: mainnetA},
+			AddressPolicy:        AddressPolicyDrop,
+			Kinds:                []ChangeKind{ChangeKindContractMetadata},
+			AddressRefFilters:    []FilterFunc[AddressRefKey, AddressRef]{AddressRefByType("OnRamp")},
+			Target:               newTarget(t),
+		})
+		require.NoError(t, err)
+
+		cms, err := got.DataStore.ContractMetadata().Fetch()
+		require.NoError(t, err)
+		assert.Empty(t, cms)
+		assert.Empty(t, got.Skipped)
+	})
+
+	t.Run("env metadata is promoted when requested", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Promote(newSource(t), PromoteOptions{
+			AddressPolicy: AddressPolicyKeep,
+			Kinds:         []ChangeKind{ChangeKindEnvMetadata},
+		})
+		require.NoError(t, err)
+
+		em, err := got.DataStore.EnvMetadata().Get()
+		require.NoError(t, err)
+		assert.Equal(t, envMeta.Metadata, em.Metadata)
+	})
+
+	t.Run("drop addresses without target skips contract metadata", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Promote(newSource(t), PromoteOptions{
+			AddressPolicy: AddressPolicyDrop,
+			Kinds:         []ChangeKind{ChangeKindContractMetadata},
+		})
+		require.NoError(t, err)
+
+		cms, err := got.DataStore.ContractMetadata().Fetch()
+		require.NoError(t, err)
+		assert.Empty(t, cms)
+		require.Len(t, got.Skipped, 1)
+		assert.Equal(t, ChangeKindContractMetadata, got.Skipped[0].Kind)
+		assert.Contains(t, got.Skipped[0].Reason, "target datastore is required")
+	})
+
+	t.Run("kinds and filters select records", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Promote(newSource(t), PromoteOptions{
+			AddressPolicy:     AddressPolicyKeep,
+			Kinds:             []ChangeKind{ChangeKindAddressRef},
+			AddressRefFilters: []FilterFunc[AddressRefKey, AddressRef]{AddressRefByType("OnRamp")},
+		})
+		require.NoError(t, err)
+
+		refs, err := got.DataStore.Addresses().Fetch()
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		assert.Equal(t, rampRef.Address, refs[0].Address)
+		assert.Equal(t, testnetB, refs[0].ChainSelector)
+
+		chms, err := got.DataStore.ChainMetadata().Fetch()
+		require.NoError(t, err)
+		assert.Empty(t, chms)
+
+		_, err = got.DataStore.EnvMetadata().Get()
+		require.ErrorIs(t, err, ErrEnvMetadataNotSet)
+	})
+
+	t.Run("nil source", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Promote(nil, PromoteOptions{})
+		require.ErrorContains(t, err, "source datastore is required")
+	})
+}
+
+func TestParseAddressPolicy(t *testing.T) {
+	t.Parallel()
+
+	p, err := ParseAddressPolicy("keep")
+	require.NoError(t, err)
+	assert.Equal(t, AddressPolicyKeep, p)
+	assert.Equal(t, "keep", p.String())
+
+	p, err = ParseAddressPolicy("drop")
+	require.NoError(t, err)
+	assert.Equal(t, AddressPolicyDrop, p)
+	assert.Equal(t, "drop", p.String())
+
+	_, err = ParseAddressPolicy("other")
+	require.ErrorContains(t, err, "invalid address policy")
+}
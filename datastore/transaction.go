@@ -0,0 +1,125 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+)
+
+// ErrConflict is matched by errors.Is for any ConflictError, so callers can detect conflicts
+// without depending on the record type involved.
+var ErrConflict = errors.New("catalog conflict")
+
+// ConflictError is returned by a CatalogStore when an operation conflicts with a concurrent change,
+// either because a record was modified since it was read (optimistic concurrency) or because the
+// transaction was aborted by the catalog. Re-running the whole transaction against fresh data may
+// succeed.
+type ConflictError struct {
+	// Op describes the operation which conflicted, e.g. "upsert chain metadata".
+	Op string
+	// Err is the underlying error, which may wrap a more specific sentinel such as
+	// ErrChainMetadataStale.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("conflict during %s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// IsConflict reports whether err is, or wraps, a ConflictError.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+const (
+	// DefaultConflictInitialBackoff is the delay before the first retry of a conflicting
+	// transaction when WithRetryOnConflict is used.
+	DefaultConflictInitialBackoff = 100 * time.Millisecond
+	// DefaultConflictMaxBackoff caps the delay between retries of a conflicting transaction.
+	DefaultConflictMaxBackoff = 5 * time.Second
+)
+
+// TransactionOptions configures how WithTransaction runs the TransactionLogic.
+type TransactionOptions struct {
+	// MaxAttempts is the maximum number of times the TransactionLogic is run. Values below 1 are
+	// treated as 1, which disables retries.
+	MaxAttempts uint
+	// InitialBackoff is the delay before the first retry. It doubles after every attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// TransactionOption is a functional option for WithTransaction.
+type TransactionOption func(*TransactionOptions)
+
+// WithRetryOnConflict re-runs the TransactionLogic in a new transaction, up to maxAttempts times
+// in total, while it fails with a ConflictError. Retries are delayed with exponential backoff,
+// starting at DefaultConflictInitialBackoff.
+//
+// The TransactionLogic must be safe to re-run: it should read any state it depends on inside the
+// transaction rather than capturing it beforehand.
+func WithRetryOnConflict(maxAttempts uint) TransactionOption {
+	return func(o *TransactionOptions) {
+		o.MaxAttempts = maxAttempts
+	}
+}
+
+// WithConflictBackoff sets the initial and maximum delay between retries of a conflicting
+// transaction. It has no effect unless WithRetryOnConflict is also given.
+func WithConflictBackoff(initial, maxBackoff time.Duration) TransactionOption {
+	return func(o *TransactionOptions) {
+		o.InitialBackoff = initial
+		o.MaxBackoff = maxBackoff
+	}
+}
+
+// NewTransactionOptions returns the TransactionOptions with the given options applied over the
+// defaults.
+func NewTransactionOptions(opts ...TransactionOption) TransactionOptions {
+	options := TransactionOptions{
+		MaxAttempts:    1,
+		InitialBackoff: DefaultConflictInitialBackoff,
+		MaxBackoff:     DefaultConflictMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// RunTransaction calls attempt, which should run a single transaction, retrying it according to
+// the options while it fails with a ConflictError. It is intended for CatalogStore implementations
+// of WithTransaction. The error of the last attempt is returned, or the context error if the
+// context is done while waiting to retry.
+func RunTransaction(ctx context.Context, attempt func() error, opts ...TransactionOption) error {
+	options := NewTransactionOptions(opts...)
+	if options.MaxAttempts <= 1 {
+		return attempt()
+	}
+
+	return retry.Do(attempt,
+		retry.Context(ctx),
+		retry.Attempts(options.MaxAttempts),
+		retry.Delay(options.InitialBackoff),
+		retry.MaxDelay(options.MaxBackoff),
+		retry.DelayType(retry.BackOffDelay),
+		retry.RetryIf(IsConflict),
+		retry.LastErrorOnly(true),
+	)
+}
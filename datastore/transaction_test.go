@@ -0,0 +1,131 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflictError(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("merge failed: %w", &ConflictError{
+		Op:  "update chain metadata",
+		Err: fmt.Errorf("%w: row version 3", ErrChainMetadataStale),
+	})
+
+	require.ErrorIs(t, err, ErrConflict)
+	require.ErrorIs(t, err, ErrChainMetadataStale)
+	assert.True(t, IsConflict(err))
+	assert.Equal(t,
+		"merge failed: conflict during update chain metadata: the chain metadata record with the given key is stale and cannot be updated: row version 3",
+		err.Error(),
+	)
+
+	assert.False(t, IsConflict(ErrChainMetadataStale))
+	assert.False(t, IsConflict(nil))
+}
+
+func TestNewTransactionOptions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, TransactionOptions{
+		MaxAttempts:    1,
+		InitialBackoff: DefaultConflictInitialBackoff,
+		MaxBackoff:     DefaultConflictMaxBackoff,
+	}, NewTransactionOptions())
+
+	assert.Equal(t, TransactionOptions{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Second,
+	}, NewTransactionOptions(WithRetryOnConflict(4), WithConflictBackoff(time.Millisecond, time.Second)))
+}
+
+func TestRunTransaction(t *testing.T) {
+	t.Parallel()
+
+	conflict := &ConflictError{Op: "commit transaction", Err: errors.New("aborted")}
+	fastBackoff := WithConflictBackoff(time.Millisecond, 2*time.Millisecond)
+
+	tests := []struct {
+		name         string
+		opts         []TransactionOption
+		results      []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name:         "success without retries",
+			results:      []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "conflict without retry option",
+			results:      []error{conflict, nil},
+			wantAttempts: 1,
+			wantErr:      ErrConflict,
+		},
+		{
+			name:         "conflict then success",
+			opts:         []TransactionOption{WithRetryOnConflict(3), fastBackoff},
+			results:      []error{conflict, conflict, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "conflict exhausts attempts",
+			opts:         []TransactionOption{WithRetryOnConflict(2), fastBackoff},
+			results:      []error{conflict, conflict, nil},
+			wantAttempts: 2,
+			wantErr:      ErrConflict,
+		},
+		{
+			name:         "other errors are not retried",
+			opts:         []TransactionOption{WithRetryOnConflict(3), fastBackoff},
+			results:      []error{ErrAddressRefExists, nil},
+			wantAttempts: 1,
+			wantErr:      ErrAddressRefExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := 0
+			err := RunTransaction(t.Context(), func() error {
+				attempts++
+				return tt.results[attempts-1]
+			}, tt.opts...)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts)
+		})
+	}
+}
+
+func TestRunTransaction_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	attempts := 0
+	err := RunTransaction(ctx, func() error {
+		attempts++
+		cancel()
+
+		return &ConflictError{Op: "commit transaction", Err: errors.New("aborted")}
+	}, WithRetryOnConflict(5), WithConflictBackoff(time.Hour, time.Hour))
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,145 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// ErrInvalidCursor is returned by Watch when the supplied cursor was not issued by the store being
+// watched, or refers to a position the store no longer retains.
+var ErrInvalidCursor = errors.New("invalid watch cursor")
+
+// ChangeKind identifies the store (table) a ChangeEvent originates from.
+type ChangeKind string
+
+const (
+	ChangeKindAddressRef       ChangeKind = "address_ref"
+	ChangeKindChainMetadata    ChangeKind = "chain_metadata"
+	ChangeKindContractMetadata ChangeKind = "contract_metadata"
+	ChangeKindEnvMetadata      ChangeKind = "env_metadata"
+)
+
+// ChangeOperation describes what happened to the record carried by a ChangeEvent.
+type ChangeOperation string
+
+const (
+	// ChangeOperationUpsert is emitted when a record is added or updated. The event carries the
+	// new state of the record.
+	ChangeOperationUpsert ChangeOperation = "upsert"
+	// ChangeOperationDelete is emitted when a record is removed. The event carries the last known
+	// state of the record.
+	ChangeOperationDelete ChangeOperation = "delete"
+)
+
+// Cursor is an opaque position in a catalog change stream. Every ChangeEvent carries the cursor
+// that follows it, so a consumer can persist the cursor of the last event it processed and pass
+// it back via WatchFilter.Cursor to resume the stream after reconnecting.
+//
+// Cursors are only meaningful to the store that issued them.
+type Cursor string
+
+// ChangeEvent describes a single change to a catalog record. Exactly one of the record fields is
+// populated, matching Kind.
+type ChangeEvent struct {
+	// Cursor is the position of this event in the stream. Resuming from this cursor delivers the
+	// events that follow this one.
+	Cursor    Cursor
+	Kind      ChangeKind
+	Operation ChangeOperation
+
+	AddressRef       *AddressRef
+	ChainMetadata    *ChainMetadata
+	ContractMetadata *ContractMetadata
+	EnvMetadata      *EnvMetadata
+
+	// Err is set on the final event of a stream which was terminated by an error. No record field
+	// is populated in that case and the channel is closed after the event is delivered.
+	Err error
+}
+
+// chainSelector returns the chain selector of the record carried by the event, and false if the
+// record is not chain scoped.
+func (e ChangeEvent) chainSelector() (uint64, bool) {
+	switch {
+	case e.AddressRef != nil:
+		return e.AddressRef.ChainSelector, true
+	case e.ChainMetadata != nil:
+		return e.ChainMetadata.ChainSelector, true
+	case e.ContractMetadata != nil:
+		return e.ContractMetadata.ChainSelector, true
+	default:
+		return 0, false
+	}
+}
+
+// WatchFilter narrows the events delivered by Watch. The zero value matches every change,
+// starting from the current position of the stream.
+type WatchFilter struct {
+	// Kinds restricts the stream to the given record kinds. Empty means all kinds.
+	Kinds []ChangeKind
+	// ChainSelectors restricts chain scoped records to the given chains. Env metadata, which is
+	// not chain scoped, is unaffected by this filter. Empty means all chains.
+	ChainSelectors []uint64
+
+	// AddressRefFilters, ChainMetadataFilters and ContractMetadataFilters are applied to the
+	// record carried by an event of the matching kind, in the same way as with the Filter method
+	// of the corresponding store. An event is delivered only if its record survives all filters.
+	AddressRefFilters       []FilterFunc[AddressRefKey, AddressRef]
+	ChainMetadataFilters    []FilterFunc[ChainMetadataKey, ChainMetadata]
+	ContractMetadataFilters []FilterFunc[ContractMetadataKey, ContractMetadata]
+
+	// Cursor resumes the stream after the event which carried this cursor. An empty cursor starts
+	// the stream at the current position, delivering only changes made after Watch is called.
+	Cursor Cursor
+}
+
+// Matches reports whether the event passes the filter.
+func (f WatchFilter) Matches(event ChangeEvent) bool {
+	if event.Err != nil {
+		return true
+	}
+
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, event.Kind) {
+		return false
+	}
+
+	if sel, ok := event.chainSelector(); ok && len(f.ChainSelectors) > 0 && !slices.Contains(f.ChainSelectors, sel) {
+		return false
+	}
+
+	switch {
+	case event.AddressRef != nil:
+		return matchesAll(*event.AddressRef, f.AddressRefFilters)
+	case event.ChainMetadata != nil:
+		return matchesAll(*event.ChainMetadata, f.ChainMetadataFilters)
+	case event.ContractMetadata != nil:
+		return matchesAll(*event.ContractMetadata, f.ContractMetadataFilters)
+	default:
+		return true
+	}
+}
+
+// matchesAll reports whether the record survives every filter.
+func matchesAll[K Comparable[K], R UniqueRecord[K, R]](record R, filters []FilterFunc[K, R]) bool {
+	records := []R{record}
+	for _, filter := range filters {
+		records = filter(records)
+		if len(records) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Watcher is implemented by stores which can stream changes to their records.
+type Watcher interface {
+	// Watch streams the changes which match the filter on the returned channel until the context
+	// is cancelled or the stream fails, at which point the channel is closed. A stream that fails
+	// delivers a final event with Err set before the channel is closed; the consumer may then
+	// call Watch again with the cursor of the last event it processed to resume the stream.
+	//
+	// Changes made inside a transaction are delivered once the transaction commits.
+	Watch(ctx context.Context, filter WatchFilter) (<-chan ChangeEvent, error)
+}
@@ -0,0 +1,106 @@
+package datastore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	var (
+		refEvent = ChangeEvent{
+			Kind:      ChangeKindAddressRef,
+			Operation: ChangeOperationUpsert,
+			AddressRef: &AddressRef{
+				Address:       "0x123456",
+				ChainSelector: 1,
+				Type:          "Router",
+				Version:       semver.MustParse("1.0.0"),
+			},
+		}
+		chainEvent = ChangeEvent{
+			Kind:          ChangeKindChainMetadata,
+			Operation:     ChangeOperationUpsert,
+			ChainMetadata: &ChainMetadata{ChainSelector: 2},
+		}
+		envEvent = ChangeEvent{
+			Kind:        ChangeKindEnvMetadata,
+			Operation:   ChangeOperationUpsert,
+			EnvMetadata: &EnvMetadata{Metadata: "meta"},
+		}
+		errEvent = ChangeEvent{Err: errors.New("stream failed")}
+	)
+
+	tests := []struct {
+		name   string
+		filter WatchFilter
+		event  ChangeEvent
+		want   bool
+	}{
+		{
+			name:   "zero filter matches everything",
+			filter: WatchFilter{},
+			event:  refEvent,
+			want:   true,
+		},
+		{
+			name:   "kind filter excludes other kinds",
+			filter: WatchFilter{Kinds: []ChangeKind{ChangeKindChainMetadata}},
+			event:  refEvent,
+			want:   false,
+		},
+		{
+			name:   "kind filter includes listed kinds",
+			filter: WatchFilter{Kinds: []ChangeKind{ChangeKindChainMetadata}},
+			event:  chainEvent,
+			want:   true,
+		},
+		{
+			name:   "chain selector filter excludes other chains",
+			filter: WatchFilter{ChainSelectors: []uint64{1}},
+			event:  chainEvent,
+			want:   false,
+		},
+		{
+			name:   "chain selector filter does not apply to env metadata",
+			filter: WatchFilter{ChainSelectors: []uint64{1}},
+			event:  envEvent,
+			want:   true,
+		},
+		{
+			name: "address ref filters are applied",
+			filter: WatchFilter{AddressRefFilters: []FilterFunc[AddressRefKey, AddressRef]{
+				AddressRefByType("OnRamp"),
+			}},
+			event: refEvent,
+			want:  false,
+		},
+		{
+			name: "address ref filters pass matching records",
+			filter: WatchFilter{AddressRefFilters: []FilterFunc[AddressRefKey, AddressRef]{
+				AddressRefByType("Router"),
+				AddressRefByChainSelector(1),
+			}},
+			event: refEvent,
+			want:  true,
+		},
+		{
+			name:   "error events always match",
+			filter: WatchFilter{Kinds: []ChangeKind{ChangeKindEnvMetadata}},
+			event:  errEvent,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.filter.Matches(tt.event))
+		})
+	}
+}
@@ -38,7 +38,8 @@ type ChangeLogic[C any] func(e Environment, config C) (ChangesetOutput, error)
 //
 // If the configuration is unexpected type or format, the changeset should return ErrInvalidConfig. If there are
 // surprising aspects in the environment (a contract expected to be present cannot be located, etc.), then
-// ErrInvalidEnvironment should be returned.
+// ErrInvalidEnvironment should be returned. To report several problems at once, collect them in a
+// PreconditionReport and return its Result.
 type PreconditionVerifier[C any] func(e Environment, config C) error
 
 // ChangeSetV2 is a type which encapsulates the logic to perform a set of changes to be made to an environment, in the
@@ -121,6 +122,9 @@ type ChangesetOutput struct {
 	// Deprecated: This field is no longer being used.
 	// Retained for backwards compatibility. This field is no longer used by the framework and may not be populated or propagated.
 	Reports []operations.Report[any, any]
+	// HookRecords are populated by the changesets registry with the records of the pre-hooks and
+	// post-hooks run for the changeset.
+	HookRecords []HookRecord
 }
 
 // ViewState produces a product specific JSON representation of
@@ -129,28 +133,99 @@ type ViewState func(e Environment) (json.Marshaler, error)
 
 type ViewStateV2 func(e Environment, previousView json.Marshaler) (json.Marshaler, error)
 
+// ErrChangesetOutputConflict is returned by MergeChangesetOutput in strict mode when the source
+// DataStore contains a record whose key already exists in the destination with different content.
+var ErrChangesetOutputConflict = errors.New("conflicting changeset output records")
+
+// mergeChangesetOutputOptions configures MergeChangesetOutput.
+type mergeChangesetOutputOptions struct {
+	strict bool
+}
+
+// MergeChangesetOutputOption is a functional option for MergeChangesetOutput.
+type MergeChangesetOutputOption func(*mergeChangesetOutputOptions)
+
+// WithStrictMerge makes MergeChangesetOutput return ErrChangesetOutputConflict when a DataStore
+// record in the source has the same key as a different record in the destination. By default the
+// merge is lenient and the source record overwrites the destination record.
+func WithStrictMerge() MergeChangesetOutputOption {
+	return func(o *mergeChangesetOutputOptions) {
+		o.strict = true
+	}
+}
+
 // MergeChangesetOutput merges the source ChangesetOutput into the destination ChangesetOutput.
 // It is useful to combine multiple ChangesetOutput objects into one to create one consolidated changeset from multiple granular changesets.
 // Ensure to run proposalutils.AggregateProposals at the end of consolidated changeset to ensure the proposals are merged correctly.
-func MergeChangesetOutput(env Environment, dest *ChangesetOutput, src ChangesetOutput) error {
+//
+// DataStores are merged record by record, with conflicts handled according to the options. Reports
+// are de-duplicated by ID and Jobs by node and spec, keeping the destination entry.
+func MergeChangesetOutput(env Environment, dest *ChangesetOutput, src ChangesetOutput, opts ...MergeChangesetOutputOption) error {
 	if dest == nil {
 		return nil
 	}
 
+	options := &mergeChangesetOutputOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	mergeAddressesToEnv := dest.AddressBook != nil && src.AddressBook != nil
+	if err := mergeChangesetOutputs(dest, src, options); err != nil {
+		return err
+	}
+	if mergeAddressesToEnv {
+		if err := env.ExistingAddresses.Merge(src.AddressBook); err != nil {
+			return fmt.Errorf("failed to merge existing addresses to environment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mergeChangesetOutputs merges the source ChangesetOutput into the destination ChangesetOutput
+// without updating an environment.
+func mergeChangesetOutputs(dest *ChangesetOutput, src ChangesetOutput, options *mergeChangesetOutputOptions) error {
+	// The address book and datastore of the first output are copied into new containers, so that
+	// merging later outputs does not modify the first output.
 	if dest.AddressBook == nil {
-		dest.AddressBook = src.AddressBook
+		if src.AddressBook != nil {
+			dest.AddressBook = NewMemoryAddressBook()
+			if err := dest.AddressBook.Merge(src.AddressBook); err != nil {
+				return fmt.Errorf("failed to merge address book: %w", err)
+			}
+		}
 	} else if src.AddressBook != nil {
 		if err := dest.AddressBook.Merge(src.AddressBook); err != nil {
 			return fmt.Errorf("failed to merge address book: %w", err)
 		}
-		if err := env.ExistingAddresses.Merge(src.AddressBook); err != nil {
-			return fmt.Errorf("failed to merge existing addresses to environment: %w", err)
+	}
+	if dest.DataStore == nil {
+		if src.DataStore != nil {
+			dest.DataStore = datastore.NewMemoryDataStore()
+			if err := dest.DataStore.Merge(src.DataStore.Seal()); err != nil {
+				return fmt.Errorf("failed to merge datastore: %w", err)
+			}
+		}
+	} else if src.DataStore != nil {
+		if options.strict {
+			if err := checkDataStoreConflicts(dest.DataStore, src.DataStore); err != nil {
+				return err
+			}
+		}
+		if err := dest.DataStore.Merge(src.DataStore.Seal()); err != nil {
+			return fmt.Errorf("failed to merge datastore: %w", err)
 		}
 	}
 	if dest.Jobs == nil {
 		dest.Jobs = src.Jobs
 	} else if src.Jobs != nil {
-		dest.Jobs = append(dest.Jobs, src.Jobs...)
+		dest.Jobs = mergeJobs(dest.Jobs, src.Jobs)
+	}
+	if dest.Reports == nil {
+		dest.Reports = src.Reports
+	} else if src.Reports != nil {
+		dest.Reports = mergeReports(dest.Reports, src.Reports)
 	}
 	if dest.MCMSTimelockProposals == nil {
 		dest.MCMSTimelockProposals = src.MCMSTimelockProposals
@@ -168,6 +243,120 @@ func MergeChangesetOutput(env Environment, dest *ChangesetOutput, src ChangesetO
 	} else if src.MCMSProposals != nil {
 		dest.MCMSProposals = append(dest.MCMSProposals, src.MCMSProposals...)
 	}
+	if dest.HookRecords == nil {
+		dest.HookRecords = src.HookRecords
+	} else if src.HookRecords != nil {
+		dest.HookRecords = append(dest.HookRecords, src.HookRecords...)
+	}
+
+	return nil
+}
+
+// mergeJobs appends the source jobs which are not already proposed for the same node with the
+// same spec.
+func mergeJobs(dest, src []ProposedJob) []ProposedJob {
+	type jobKey struct{ node, spec string }
+
+	seen := make(map[jobKey]struct{}, len(dest))
+	for _, job := range dest {
+		seen[jobKey{job.Node, job.Spec}] = struct{}{}
+	}
+
+	for _, job := range src {
+		key := jobKey{job.Node, job.Spec}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		dest = append(dest, job)
+	}
+
+	return dest
+}
+
+// mergeReports appends the source reports whose IDs are not already present.
+func mergeReports(dest, src []operations.Report[any, any]) []operations.Report[any, any] {
+	seen := make(map[string]struct{}, len(dest))
+	for _, report := range dest {
+		seen[report.ID] = struct{}{}
+	}
+
+	for _, report := range src {
+		if _, ok := seen[report.ID]; ok {
+			continue
+		}
+		seen[report.ID] = struct{}{}
+		dest = append(dest, report)
+	}
+
+	return dest
+}
+
+// checkDataStoreConflicts returns ErrChangesetOutputConflict if any record in src has the same key
+// as a different record in dest. Records are compared by their JSON encoding, since metadata may be
+// held as different Go types with the same content.
+func checkDataStoreConflicts(dest datastore.MutableDataStore, src datastore.MutableDataStore) error {
+	var conflicts []error
+
+	srcRefs, err := src.Addresses().Fetch()
+	if err != nil {
+		return fmt.Errorf("failed to fetch source address refs: %w", err)
+	}
+	for _, ref := range srcRefs {
+		existing, getErr := dest.Addresses().Get(ref.Key())
+		if getErr != nil {
+			continue
+		}
+		if !sameJSON(existing, ref) {
+			conflicts = append(conflicts, fmt.Errorf("address ref %s", ref.Key()))
+		}
+	}
+
+	srcChainMetadata, err := src.ChainMetadata().Fetch()
+	if err != nil {
+		return fmt.Errorf("failed to fetch source chain metadata: %w", err)
+	}
+	for _, record := range srcChainMetadata {
+		existing, getErr := dest.ChainMetadata().Get(record.Key())
+		if getErr != nil {
+			continue
+		}
+		if !sameJSON(existing, record) {
+			conflicts = append(conflicts, fmt.Errorf("chain metadata %s", record.Key()))
+		}
+	}
+
+	srcContractMetadata, err := src.ContractMetadata().Fetch()
+	if err != nil {
+		return fmt.Errorf("failed to fetch source contract metadata: %w", err)
+	}
+	for _, record := range srcContractMetadata {
+		existing, getErr := dest.ContractMetadata().Get(record.Key())
+		if getErr != nil {
+			continue
+		}
+		if !sameJSON(existing, record) {
+			conflicts = append(conflicts, fmt.Errorf("contract metadata %s", record.Key()))
+		}
+	}
+
+	srcEnvMetadata, srcErr := src.EnvMetadata().Get()
+	destEnvMetadata, destErr := dest.EnvMetadata().Get()
+	if srcErr == nil && destErr == nil && !sameJSON(destEnvMetadata, srcEnvMetadata) {
+		conflicts = append(conflicts, errors.New("env metadata"))
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: %w", ErrChangesetOutputConflict, errors.Join(conflicts...))
+	}
 
 	return nil
 }
+
+// sameJSON reports whether a and b have the same JSON encoding.
+func sameJSON(a, b any) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,23 @@
+package deployment
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// MergeChangesetOutputToCatalog applies the DataStore of a ChangesetOutput to the catalog in a
+// single transaction, so the address refs, chain metadata, contract metadata and env metadata are
+// either all written or none are. It is a no-op if the output has no DataStore.
+//
+// The transaction options are passed to the catalog, e.g. datastore.WithRetryOnConflict to
+// re-apply the output when it conflicts with a concurrent change.
+func MergeChangesetOutputToCatalog(
+	ctx context.Context, out ChangesetOutput, catalog datastore.CatalogStore, opts ...datastore.TransactionOption,
+) error {
+	if out.DataStore == nil {
+		return nil
+	}
+
+	return datastore.MergeDataStoreToCatalog(ctx, out.DataStore.Seal(), catalog, opts...)
+}
@@ -0,0 +1,63 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	catalogmemory "github.com/smartcontractkit/chainlink-deployments-framework/datastore/catalog/memory"
+)
+
+func TestMergeChangesetOutputToCatalog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies all stores", func(t *testing.T) {
+		t.Parallel()
+
+		catalog, err := catalogmemory.NewMemoryCatalogDataStore()
+		require.NoError(t, err)
+
+		ds := datastore.NewMemoryDataStore()
+		require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+			Address: "0xrouter", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+		}))
+		require.NoError(t, ds.ChainMetadata().Add(datastore.ChainMetadata{ChainSelector: 1, Metadata: "chain"}))
+		require.NoError(t, ds.ContractMetadata().Add(datastore.ContractMetadata{
+			ChainSelector: 1, Address: "0xrouter", Metadata: "contract",
+		}))
+		require.NoError(t, ds.EnvMetadata().Set(datastore.EnvMetadata{Metadata: "env"}))
+
+		err = MergeChangesetOutputToCatalog(t.Context(), ChangesetOutput{DataStore: ds}, catalog,
+			datastore.WithRetryOnConflict(3),
+		)
+		require.NoError(t, err)
+
+		ref, err := catalog.Addresses().Get(t.Context(), datastore.NewAddressRefKey(1, "Router", semver.MustParse("1.0.0"), ""))
+		require.NoError(t, err)
+		assert.Equal(t, "0xrouter", ref.Address)
+
+		_, err = catalog.ChainMetadata().Get(t.Context(), datastore.NewChainMetadataKey(1))
+		require.NoError(t, err)
+		_, err = catalog.ContractMetadata().Get(t.Context(), datastore.NewContractMetadataKey(1, "0xrouter"))
+		require.NoError(t, err)
+		env, err := catalog.EnvMetadata().Get(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "env", env.Metadata)
+	})
+
+	t.Run("no datastore", func(t *testing.T) {
+		t.Parallel()
+
+		catalog, err := catalogmemory.NewMemoryCatalogDataStore()
+		require.NoError(t, err)
+
+		require.NoError(t, MergeChangesetOutputToCatalog(t.Context(), ChangesetOutput{}, catalog))
+
+		refs, err := catalog.Addresses().Fetch(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, refs)
+	})
+}
@@ -3,6 +3,10 @@ package deployment
 import (
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
@@ -10,6 +14,7 @@ import (
 	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
 	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 	focr "github.com/smartcontractkit/chainlink-deployments-framework/offchain/ocr"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
 )
 
 type MyChangeSet struct{}
@@ -95,6 +100,144 @@ func TestChangeSetLegacyFunction(t *testing.T) {
 	require.Equal(t, NewMemoryAddressBook(), out.AddressBook)
 }
 
+func TestMergeChangesetOutput(t *testing.T) {
+	t.Parallel()
+
+	newDataStore := func(t *testing.T, address string, chainMetadata any) datastore.MutableDataStore {
+		t.Helper()
+
+		ds := datastore.NewMemoryDataStore()
+		require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+			Address: address, ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+		}))
+		require.NoError(t, ds.ChainMetadata().Add(datastore.ChainMetadata{ChainSelector: 1, Metadata: chainMetadata}))
+
+		return ds
+	}
+
+	t.Run("merges datastore, reports, jobs and hook records", func(t *testing.T) {
+		t.Parallel()
+
+		dest := ChangesetOutput{
+			Jobs:        []ProposedJob{{JobID: "1", Node: "node-1", Spec: "spec-a"}},
+			Reports:     []operations.Report[any, any]{{ID: "report-1"}},
+			HookRecords: []HookRecord{{Name: "check-a", Status: HookStatusPassed}},
+		}
+		src := ChangesetOutput{
+			DataStore: newDataStore(t, "0xrouter", "chain"),
+			Jobs: []ProposedJob{
+				{JobID: "2", Node: "node-1", Spec: "spec-a"},
+				{JobID: "3", Node: "node-2", Spec: "spec-a"},
+			},
+			Reports:     []operations.Report[any, any]{{ID: "report-1"}, {ID: "report-2"}},
+			HookRecords: []HookRecord{{Name: "check-b", Status: HookStatusWarned}},
+		}
+
+		require.NoError(t, MergeChangesetOutput(NewNoopEnvironment(t), &dest, src))
+		require.NotSame(t, src.DataStore, dest.DataStore)
+		assert.Equal(t, []ProposedJob{
+			{JobID: "1", Node: "node-1", Spec: "spec-a"},
+			{JobID: "3", Node: "node-2", Spec: "spec-a"},
+		}, dest.Jobs)
+		assert.Equal(t, []operations.Report[any, any]{{ID: "report-1"}, {ID: "report-2"}}, dest.Reports)
+		assert.Equal(t, []HookRecord{
+			{Name: "check-a", Status: HookStatusPassed},
+			{Name: "check-b", Status: HookStatusWarned},
+		}, dest.HookRecords)
+
+		// A second output adds a new address ref to the existing datastore
+		other := datastore.NewMemoryDataStore()
+		require.NoError(t, other.Addresses().Add(datastore.AddressRef{
+			Address: "0xramp", ChainSelector: 2, Type: "OnRamp", Version: semver.MustParse("1.0.0"),
+		}))
+		require.NoError(t, MergeChangesetOutput(NewNoopEnvironment(t), &dest, ChangesetOutput{DataStore: other}))
+
+		refs, err := dest.DataStore.Addresses().Fetch()
+		require.NoError(t, err)
+		assert.Len(t, refs, 2)
+
+		// The first output is not modified by merging the second
+		srcRefs, err := src.DataStore.Addresses().Fetch()
+		require.NoError(t, err)
+		assert.Len(t, srcRefs, 1)
+	})
+
+	t.Run("copies the address book of the first output", func(t *testing.T) {
+		t.Parallel()
+
+		first := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+			chainsel.TEST_90000001.Selector: {common.HexToAddress("0x1").String(): NewTypeAndVersion("Router", Version1_0_0)},
+		})
+		second := NewMemoryAddressBookFromMap(map[uint64]map[string]TypeAndVersion{
+			chainsel.TEST_90000001.Selector: {common.HexToAddress("0x2").String(): NewTypeAndVersion("OnRamp", Version1_0_0)},
+		})
+
+		var dest ChangesetOutput
+		require.NoError(t, MergeChangesetOutput(NewNoopEnvironment(t), &dest, ChangesetOutput{AddressBook: first}))
+		require.NoError(t, MergeChangesetOutput(NewNoopEnvironment(t), &dest, ChangesetOutput{AddressBook: second}))
+
+		got, err := dest.AddressBook.Addresses()
+		require.NoError(t, err)
+		assert.Len(t, got[chainsel.TEST_90000001.Selector], 2)
+
+		firstAddrs, err := first.Addresses()
+		require.NoError(t, err)
+		assert.Len(t, firstAddrs[chainsel.TEST_90000001.Selector], 1)
+	})
+
+	tests := []struct {
+		name        string
+		srcAddress  string
+		srcMetadata any
+		opts        []MergeChangesetOutputOption
+		wantErr     string
+		wantAddress string
+	}{
+		{
+			name:        "lenient overwrites conflicting records",
+			srcAddress:  "0xnew",
+			srcMetadata: "new",
+			wantAddress: "0xnew",
+		},
+		{
+			name:        "strict allows identical records",
+			srcAddress:  "0xrouter",
+			srcMetadata: "chain",
+			opts:        []MergeChangesetOutputOption{WithStrictMerge()},
+			wantAddress: "0xrouter",
+		},
+		{
+			name:        "strict rejects conflicting records",
+			srcAddress:  "0xnew",
+			srcMetadata: "new",
+			opts:        []MergeChangesetOutputOption{WithStrictMerge()},
+			wantErr:     "conflicting changeset output records: address ref 1_Router_1.0.0_\nchain metadata 1",
+			wantAddress: "0xrouter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dest := ChangesetOutput{DataStore: newDataStore(t, "0xrouter", "chain")}
+			src := ChangesetOutput{DataStore: newDataStore(t, tt.srcAddress, tt.srcMetadata)}
+
+			err := MergeChangesetOutput(NewNoopEnvironment(t), &dest, src, tt.opts...)
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, ErrChangesetOutputConflict)
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			ref, err := dest.DataStore.Addresses().Get(datastore.NewAddressRefKey(1, "Router", semver.MustParse("1.0.0"), ""))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddress, ref.Address)
+		})
+	}
+}
+
 func NewNoopEnvironment(t *testing.T) Environment {
 	t.Helper()
 
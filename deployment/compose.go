@@ -0,0 +1,184 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// ComposeStep is a single step of a changeset built with Compose. It wraps a ChangeSetV2 together
+// with the way its config is derived from the config of the composed changeset.
+type ComposeStep[C any] struct {
+	name   string
+	verify func(e Environment, config C) error
+	apply  func(e Environment, config C) (ChangesetOutput, error)
+}
+
+// Name returns the name of the step, which is used to identify the step in errors.
+func (s ComposeStep[C]) Name() string {
+	return s.name
+}
+
+// NewComposeStep creates a step which applies the changeset with the config returned by configFn.
+// configFn receives the config of the composed changeset and should have no side effects.
+func NewComposeStep[C, S any](name string, cs ChangeSetV2[S], configFn func(config C) S) ComposeStep[C] {
+	return ComposeStep[C]{
+		name: name,
+		verify: func(e Environment, config C) error {
+			return cs.VerifyPreconditions(e, configFn(config))
+		},
+		apply: func(e Environment, config C) (ChangesetOutput, error) {
+			return cs.Apply(e, configFn(config))
+		},
+	}
+}
+
+// NewStaticComposeStep creates a step which applies the changeset with a fixed config, ignoring
+// the config of the composed changeset.
+func NewStaticComposeStep[C, S any](name string, cs ChangeSetV2[S], config S) ComposeStep[C] {
+	return NewComposeStep(name, cs, func(C) S { return config })
+}
+
+// composedChangeSet is a ChangeSetV2 which applies its steps in order.
+type composedChangeSet[C any] struct {
+	steps []ComposeStep[C]
+}
+
+// Compose creates a ChangeSetV2 which applies the steps in order, so a composite changeset does
+// not have to call Apply and MergeChangesetOutput by hand.
+//
+// After each step, the addresses and metadata in the step's DataStore (and AddressBook) are
+// overlaid onto the environment given to the next step, so later steps can look up what earlier
+// steps deployed. The preconditions of each step are verified against this overlaid environment
+// just before the step is applied, so they may depend on the output of earlier steps. The
+// caller's environment is not modified.
+//
+// The outputs of all steps are merged into a single output, which holds the AddressBook and
+// DataStore records, jobs and proposals of every step. If a step fails, the merged output of
+// the steps which were applied before it is returned together with the error.
+//
+// Usage:
+//
+//	cs := deployment.Compose(
+//	    deployment.NewComposeStep("deploy-ramps", deployRamps, func(c LanesConfig) DeployRampsConfig {
+//	        return c.Ramps
+//	    }),
+//	    deployment.NewComposeStep("configure-lanes", configureLanes, func(c LanesConfig) ConfigureLanesConfig {
+//	        return c.Lanes
+//	    }),
+//	)
+func Compose[C any](steps ...ComposeStep[C]) ChangeSetV2[C] {
+	return composedChangeSet[C]{steps: steps}
+}
+
+// VerifyPreconditions verifies the preconditions of the first step against the environment. The
+// preconditions of the later steps may depend on the output of the steps before them, so they are
+// verified by Apply just before each step is applied.
+func (c composedChangeSet[C]) VerifyPreconditions(e Environment, config C) error {
+	if len(c.steps) == 0 {
+		return fmt.Errorf("%w: composed changeset has no steps", ErrInvalidConfig)
+	}
+
+	step := c.steps[0]
+	if err := step.verify(e, config); err != nil {
+		return fmt.Errorf("step 0 (%s): %w", step.name, err)
+	}
+
+	return nil
+}
+
+// Apply applies the steps in order, verifying the preconditions of each step against the overlaid
+// environment just before it is applied.
+func (c composedChangeSet[C]) Apply(e Environment, config C) (ChangesetOutput, error) {
+	if len(c.steps) == 0 {
+		return ChangesetOutput{}, fmt.Errorf("%w: composed changeset has no steps", ErrInvalidConfig)
+	}
+
+	stepEnv, overlay, err := newComposeEnvironment(e)
+	if err != nil {
+		return ChangesetOutput{}, err
+	}
+
+	outputs := make([]ChangesetOutput, 0, len(c.steps))
+	var applyErr error
+
+	for i, step := range c.steps {
+		if err = step.verify(stepEnv, config); err != nil {
+			applyErr = fmt.Errorf("step %d (%s): failed to verify preconditions: %w", i, step.name, err)
+			break
+		}
+
+		out, err := step.apply(stepEnv, config)
+		if err != nil {
+			applyErr = fmt.Errorf("step %d (%s) failed: %w", i, step.name, err)
+			break
+		}
+		outputs = append(outputs, out)
+
+		if err = overlay.merge(out); err != nil {
+			applyErr = fmt.Errorf("step %d (%s): failed to update environment: %w", i, step.name, err)
+			break
+		}
+	}
+
+	// The outputs are merged into new containers rather than with MergeChangesetOutput, which would
+	// also merge the address books into the step environment, where the overlay already holds them.
+	var merged ChangesetOutput
+	for i, out := range outputs {
+		if err := mergeChangesetOutputs(&merged, out, &mergeChangesetOutputOptions{}); err != nil {
+			return merged, errors.Join(applyErr, fmt.Errorf("failed to merge output of step %d (%s): %w", i, c.steps[i].name, err))
+		}
+	}
+
+	return merged, applyErr
+}
+
+// composeOverlay holds the copies of the environment's address book and datastore which the outputs
+// of the applied steps are merged into.
+type composeOverlay struct {
+	addressBook *AddressBookMap
+	dataStore   *datastore.MemoryDataStore
+}
+
+// newComposeEnvironment returns a shallow copy of the environment whose address book and datastore
+// are copies which can be updated without modifying the caller's environment.
+func newComposeEnvironment(e Environment) (Environment, *composeOverlay, error) {
+	overlay := &composeOverlay{
+		addressBook: NewMemoryAddressBook(),
+		dataStore:   datastore.NewMemoryDataStore(),
+	}
+
+	if e.ExistingAddresses != nil {
+		if err := overlay.addressBook.Merge(e.ExistingAddresses); err != nil {
+			return Environment{}, nil, fmt.Errorf("failed to copy address book: %w", err)
+		}
+	}
+	if e.DataStore != nil {
+		if err := overlay.dataStore.Merge(e.DataStore); err != nil {
+			return Environment{}, nil, fmt.Errorf("failed to copy datastore: %w", err)
+		}
+	}
+
+	e.ExistingAddresses = overlay.addressBook
+	e.DataStore = overlay.dataStore.Seal()
+
+	return e, overlay, nil
+}
+
+// merge merges the address book and datastore of a step's output into the copies. The environment
+// returned by newComposeEnvironment sees the merged records, since it shares the copies.
+func (o *composeOverlay) merge(out ChangesetOutput) error {
+	if out.AddressBook != nil {
+		if err := o.addressBook.Merge(out.AddressBook); err != nil {
+			return fmt.Errorf("failed to merge address book: %w", err)
+		}
+	}
+	if out.DataStore != nil {
+		if err := o.dataStore.Merge(out.DataStore.Seal()); err != nil {
+			return fmt.Errorf("failed to merge datastore: %w", err)
+		}
+	}
+
+	return nil
+}
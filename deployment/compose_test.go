@@ -0,0 +1,261 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/smartcontractkit/mcms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+type composeTestConfig struct {
+	Deploy string
+	Lane   string
+}
+
+// newDeployStep returns a changeset which deploys a Router at the given address.
+func newDeployStep(calls *[]string) ChangeSetV2[string] {
+	return CreateChangeSet(
+		func(e Environment, address string) (ChangesetOutput, error) {
+			*calls = append(*calls, "apply deploy")
+
+			ds := datastore.NewMemoryDataStore()
+			if err := ds.Addresses().Add(datastore.AddressRef{
+				Address: address, ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+			}); err != nil {
+				return ChangesetOutput{}, err
+			}
+
+			return ChangesetOutput{
+				DataStore:             ds,
+				MCMSTimelockProposals: []mcms.TimelockProposal{{}},
+			}, nil
+		},
+		func(e Environment, address string) error {
+			*calls = append(*calls, "verify deploy")
+			if address == "" {
+				return errors.New("address is required")
+			}
+
+			return nil
+		},
+	)
+}
+
+// routerKey is the key of the Router deployed by newDeployStep.
+var routerKey = datastore.NewAddressRefKey(1, "Router", semver.MustParse("1.0.0"), "")
+
+// newLaneStep returns a changeset which requires the Router to be in the environment datastore.
+func newLaneStep(calls *[]string, applyErr error) ChangeSetV2[string] {
+	return CreateChangeSet(
+		func(e Environment, lane string) (ChangesetOutput, error) {
+			*calls = append(*calls, "apply lane")
+			if applyErr != nil {
+				return ChangesetOutput{}, applyErr
+			}
+
+			ref, err := e.DataStore.Addresses().Get(routerKey)
+			if err != nil {
+				return ChangesetOutput{}, err
+			}
+
+			ds := datastore.NewMemoryDataStore()
+			if err := ds.ContractMetadata().Add(datastore.ContractMetadata{
+				ChainSelector: 1, Address: ref.Address, Metadata: lane,
+			}); err != nil {
+				return ChangesetOutput{}, err
+			}
+
+			return ChangesetOutput{
+				DataStore:             ds,
+				MCMSTimelockProposals: []mcms.TimelockProposal{{}},
+			}, nil
+		},
+		func(e Environment, lane string) error {
+			*calls = append(*calls, "verify lane")
+			if _, err := e.DataStore.Addresses().Get(routerKey); err != nil {
+				return fmt.Errorf("router is not deployed: %w", err)
+			}
+
+			return nil
+		},
+	)
+}
+
+func newComposed(calls *[]string, laneErr error) ChangeSetV2[composeTestConfig] {
+	return Compose(
+		NewComposeStep("deploy", newDeployStep(calls), func(c composeTestConfig) string { return c.Deploy }),
+		NewComposeStep("lane", newLaneStep(calls, laneErr), func(c composeTestConfig) string { return c.Lane }),
+	)
+}
+
+func TestCompose_Apply(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	cs := newComposed(&calls, nil)
+
+	env := Environment{DataStore: datastore.NewMemoryDataStore().Seal()}
+	out, err := cs.Apply(env, composeTestConfig{Deploy: "0xrouter", Lane: "lane-a"})
+	require.NoError(t, err)
+
+	// The preconditions of each step are verified just before it is applied, so the lane step
+	// sees the Router deployed by the deploy step
+	assert.Equal(t, []string{"verify deploy", "apply deploy", "verify lane", "apply lane"}, calls)
+
+	require.NotNil(t, out.DataStore)
+	refs, err := out.DataStore.Addresses().Fetch()
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, "0xrouter", refs[0].Address)
+
+	md, err := out.DataStore.ContractMetadata().Get(datastore.NewContractMetadataKey(1, "0xrouter"))
+	require.NoError(t, err)
+	assert.Equal(t, "lane-a", md.Metadata)
+
+	assert.Len(t, out.MCMSTimelockProposals, 2)
+
+	// The caller's environment is not modified
+	envRefs, err := env.DataStore.Addresses().Fetch()
+	require.NoError(t, err)
+	assert.Empty(t, envRefs)
+}
+
+func TestCompose_Apply_AddressBooks(t *testing.T) {
+	t.Parallel()
+
+	// newSaveStep returns a changeset which returns an AddressBook with a Router at the given address.
+	newSaveStep := func(address string) ChangeSetV2[composeTestConfig] {
+		return CreateChangeSet(
+			func(e Environment, _ composeTestConfig) (ChangesetOutput, error) {
+				ab := NewMemoryAddressBook()
+				if err := ab.Save(chainsel.TEST_90000001.Selector, address, NewTypeAndVersion("Router", Version1_0_0)); err != nil {
+					return ChangesetOutput{}, err
+				}
+
+				return ChangesetOutput{AddressBook: ab}, nil
+			},
+			func(e Environment, _ composeTestConfig) error { return nil },
+		)
+	}
+
+	first := common.HexToAddress("0x1").String()
+	second := common.HexToAddress("0x2").String()
+	cs := Compose(
+		NewStaticComposeStep[composeTestConfig]("first", newSaveStep(first), composeTestConfig{}),
+		NewStaticComposeStep[composeTestConfig]("second", newSaveStep(second), composeTestConfig{}),
+	)
+
+	env := Environment{
+		ExistingAddresses: NewMemoryAddressBook(),
+		DataStore:         datastore.NewMemoryDataStore().Seal(),
+	}
+	out, err := cs.Apply(env, composeTestConfig{})
+	require.NoError(t, err)
+
+	require.NotNil(t, out.AddressBook)
+	addrs, err := out.AddressBook.AddressesForChain(chainsel.TEST_90000001.Selector)
+	require.NoError(t, err)
+	assert.Len(t, addrs, 2)
+	assert.Contains(t, addrs, first)
+	assert.Contains(t, addrs, second)
+
+	// The caller's address book is not modified
+	envAddrs, err := env.ExistingAddresses.Addresses()
+	require.NoError(t, err)
+	assert.Empty(t, envAddrs)
+}
+
+func TestCompose_Apply_PreconditionFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	cs := newComposed(&calls, nil)
+
+	_, err := cs.Apply(Environment{}, composeTestConfig{Lane: "lane-a"})
+	require.ErrorContains(t, err, "step 0 (deploy): failed to verify preconditions: address is required")
+
+	assert.Equal(t, []string{"verify deploy"}, calls)
+}
+
+func TestCompose_Apply_LaterPreconditionFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	cs := Compose(
+		NewStaticComposeStep[composeTestConfig]("deploy", newDeployStep(&calls), "0xrouter"),
+		NewStaticComposeStep[composeTestConfig]("lane", newLaneStep(&calls, nil), "lane-a"),
+		NewStaticComposeStep[composeTestConfig]("deploy-again", newDeployStep(&calls), ""),
+	)
+
+	out, err := cs.Apply(Environment{}, composeTestConfig{})
+	require.ErrorContains(t, err, "step 2 (deploy-again): failed to verify preconditions: address is required")
+
+	assert.Equal(t, []string{"verify deploy", "apply deploy", "verify lane", "apply lane", "verify deploy"}, calls)
+
+	// The output of the steps applied before the failure is returned
+	require.NotNil(t, out.DataStore)
+	refs, err := out.DataStore.Addresses().Fetch()
+	require.NoError(t, err)
+	assert.Len(t, refs, 1)
+	assert.Len(t, out.MCMSTimelockProposals, 2)
+}
+
+func TestCompose_Apply_StepFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	stepErr := errors.New("rpc unavailable")
+	cs := newComposed(&calls, stepErr)
+
+	out, err := cs.Apply(Environment{}, composeTestConfig{Deploy: "0xrouter", Lane: "lane-a"})
+	require.ErrorIs(t, err, stepErr)
+	require.ErrorContains(t, err, "step 1 (lane) failed")
+
+	// The output of the steps applied before the failure is returned
+	require.NotNil(t, out.DataStore)
+	refs, err := out.DataStore.Addresses().Fetch()
+	require.NoError(t, err)
+	assert.Len(t, refs, 1)
+	assert.Len(t, out.MCMSTimelockProposals, 1)
+}
+
+func TestCompose_VerifyPreconditions(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	cs := Compose(
+		NewStaticComposeStep[composeTestConfig]("first", newDeployStep(&calls), ""),
+		NewStaticComposeStep[composeTestConfig]("second", newDeployStep(&calls), ""),
+	)
+
+	// Only the first step is verified, since the later steps may depend on its output
+	err := cs.VerifyPreconditions(Environment{}, composeTestConfig{})
+	require.EqualError(t, err, "step 0 (first): address is required")
+	assert.Equal(t, []string{"verify deploy"}, calls)
+
+	laneCS := newComposed(&calls, nil)
+	err = laneCS.VerifyPreconditions(Environment{}, composeTestConfig{Deploy: "0xrouter"})
+	require.NoError(t, err)
+
+	_, err = Compose[composeTestConfig]().Apply(Environment{}, composeTestConfig{})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	err = Compose[composeTestConfig]().VerifyPreconditions(Environment{}, composeTestConfig{})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestComposeStep_Name(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	step := NewStaticComposeStep[composeTestConfig]("deploy", newDeployStep(&calls), "0xrouter")
+	assert.Equal(t, "deploy", step.Name())
+}
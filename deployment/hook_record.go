@@ -0,0 +1,61 @@
+package deployment
+
+import (
+	"fmt"
+	"time"
+)
+
+// HookPhase is the phase of a changeset in which a hook runs.
+type HookPhase string
+
+const (
+	// HookPhasePre is the phase of the hooks which run before the changeset is applied.
+	HookPhasePre HookPhase = "pre"
+	// HookPhasePost is the phase of the hooks which run after the changeset is applied.
+	HookPhasePost HookPhase = "post"
+	// HookPhasePostProposal is the phase of the hooks which run after a proposal of the changeset
+	// is executed.
+	HookPhasePostProposal HookPhase = "post-proposal"
+)
+
+// HookStatus is the outcome of a hook execution.
+type HookStatus string
+
+const (
+	// HookStatusPassed is the status of a hook which succeeded.
+	HookStatusPassed HookStatus = "passed"
+	// HookStatusFailed is the status of a hook which failed with the Abort failure policy.
+	HookStatusFailed HookStatus = "failed"
+	// HookStatusWarned is the status of a hook which failed with the Warn failure policy, so the
+	// pipeline continued.
+	HookStatusWarned HookStatus = "warned"
+	// HookStatusSkipped is the status of a hook which did not run because a hook it depends on
+	// failed.
+	HookStatusSkipped HookStatus = "skipped"
+)
+
+// HookRecord records the execution of a changeset hook. The records of the hooks run by a
+// changeset are returned in its ChangesetOutput and saved with its artifacts.
+type HookRecord struct {
+	Name  string    `json:"name"`
+	Phase HookPhase `json:"phase"`
+	// Kind describes where the hook is registered, e.g. "global pre-hook".
+	Kind          string        `json:"kind"`
+	FailurePolicy string        `json:"failurePolicy"`
+	Status        HookStatus    `json:"status"`
+	StartedAt     time.Time     `json:"startedAt"`
+	Duration      time.Duration `json:"duration"`
+	// Error is the error message of the hook, empty if it passed.
+	Error string `json:"error,omitempty"`
+}
+
+// String returns the record as "<kind> <name>: <status> (<duration>)", followed by the error
+// if there is one.
+func (r HookRecord) String() string {
+	s := fmt.Sprintf("%s %q: %s (%s)", r.Kind, r.Name, r.Status, r.Duration.Round(time.Millisecond))
+	if r.Error != "" {
+		s += ": " + r.Error
+	}
+
+	return s
+}
@@ -0,0 +1,24 @@
+package deployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHookRecord_String(t *testing.T) {
+	t.Parallel()
+
+	r := HookRecord{
+		Name:     "verify-router",
+		Kind:     "changeset post-hook",
+		Status:   HookStatusPassed,
+		Duration: 1234567 * time.Microsecond,
+	}
+	assert.Equal(t, `changeset post-hook "verify-router": passed (1.235s)`, r.String())
+
+	r.Status = HookStatusWarned
+	r.Error = "router not found"
+	assert.Equal(t, `changeset post-hook "verify-router": warned (1.235s): router not found`, r.String())
+}
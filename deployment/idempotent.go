@@ -0,0 +1,59 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// Idempotent is optionally implemented by a ChangeSetV2 to describe the address refs which
+// applying it with a config would add to the datastore.
+//
+// Execution environments (such as the changesets registry) compare them with the datastore of the
+// environment before applying the changeset. If every ref already exists, the changeset has
+// already been applied with this config, and applying it again would redeploy its contracts, so
+// it is skipped unless forced.
+type Idempotent[C any] interface {
+	// ExpectedAddressRefs returns the keys of the address refs which applying the changeset with
+	// the config would create. It should have no side effects. An empty result means the changeset
+	// cannot tell, and is never considered applied.
+	ExpectedAddressRefs(e Environment, config C) ([]datastore.AddressRefKey, error)
+}
+
+// ExpectedAddressRefsFunc describes the address refs which applying a changeset with a config
+// would create. This is the standalone version of Idempotent.ExpectedAddressRefs for use with
+// WithExpectedAddressRefs.
+type ExpectedAddressRefsFunc[C any] func(e Environment, config C) ([]datastore.AddressRefKey, error)
+
+type idempotentChangeSet[C any] struct {
+	ChangeSetV2[C]
+
+	expected ExpectedAddressRefsFunc[C]
+}
+
+func (ics idempotentChangeSet[C]) ExpectedAddressRefs(e Environment, config C) ([]datastore.AddressRefKey, error) {
+	return ics.expected(e, config)
+}
+
+// WithExpectedAddressRefs returns a changeset which applies cs, and implements Idempotent with
+// the refs returned by expected.
+func WithExpectedAddressRefs[C any](cs ChangeSetV2[C], expected ExpectedAddressRefsFunc[C]) ChangeSetV2[C] {
+	return idempotentChangeSet[C]{ChangeSetV2: cs, expected: expected}
+}
+
+// MissingAddressRefs returns the keys of the refs which do not exist in the datastore, in the
+// order they are given.
+func MissingAddressRefs(ds datastore.DataStore, keys []datastore.AddressRefKey) ([]datastore.AddressRefKey, error) {
+	var missing []datastore.AddressRefKey
+	for _, key := range keys {
+		if _, err := ds.Addresses().Get(key); err != nil {
+			if !errors.Is(err, datastore.ErrAddressRefNotFound) {
+				return nil, fmt.Errorf("failed to get address ref %s: %w", key, err)
+			}
+			missing = append(missing, key)
+		}
+	}
+
+	return missing, nil
+}
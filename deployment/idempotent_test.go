@@ -0,0 +1,68 @@
+package deployment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+func TestWithExpectedAddressRefs(t *testing.T) {
+	t.Parallel()
+
+	key := datastore.NewAddressRefKey(1, "Router", semver.MustParse("1.0.0"), "")
+
+	applied := false
+	cs := WithExpectedAddressRefs(
+		CreateChangeSet(
+			func(Environment, string) (ChangesetOutput, error) {
+				applied = true
+				return ChangesetOutput{}, nil
+			},
+			func(Environment, string) error { return errors.New("invalid") },
+		),
+		func(_ Environment, config string) ([]datastore.AddressRefKey, error) {
+			assert.Equal(t, "config", config)
+			return []datastore.AddressRefKey{key}, nil
+		},
+	)
+
+	idempotent, ok := cs.(Idempotent[string])
+	require.True(t, ok)
+
+	refs, err := idempotent.ExpectedAddressRefs(Environment{}, "config")
+	require.NoError(t, err)
+	assert.Equal(t, []datastore.AddressRefKey{key}, refs)
+
+	// The changeset itself is unchanged
+	_, err = cs.Apply(Environment{}, "config")
+	require.NoError(t, err)
+	assert.True(t, applied)
+	require.EqualError(t, cs.VerifyPreconditions(Environment{}, "config"), "invalid")
+}
+
+func TestMissingAddressRefs(t *testing.T) {
+	t.Parallel()
+
+	version := semver.MustParse("1.0.0")
+	ds := datastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+		Address: "0x1", ChainSelector: 1, Type: "Router", Version: version,
+	}))
+
+	existing := datastore.NewAddressRefKey(1, "Router", version, "")
+	otherChain := datastore.NewAddressRefKey(2, "Router", version, "")
+	otherQualifier := datastore.NewAddressRefKey(1, "Router", version, "v2")
+
+	missing, err := MissingAddressRefs(ds.Seal(), []datastore.AddressRefKey{otherChain, existing, otherQualifier})
+	require.NoError(t, err)
+	assert.Equal(t, []datastore.AddressRefKey{otherChain, otherQualifier}, missing)
+
+	missing, err = MissingAddressRefs(ds.Seal(), []datastore.AddressRefKey{existing})
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
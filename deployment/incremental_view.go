@@ -0,0 +1,421 @@
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/aptos"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// defaultChainViewConcurrency is the default number of chain views generated in parallel.
+const defaultChainViewConcurrency = 8
+
+// ChainViewFunc generates the view of a single chain. previous is the view of the chain in the
+// previous view state, or nil if there is none.
+type ChainViewFunc func(e Environment, selector uint64, previous json.RawMessage) (json.Marshaler, error)
+
+// ChainViewDependenciesFunc returns the datastore records which the view of a chain depends on. The
+// view of the chain is regenerated when the records change.
+type ChainViewDependenciesFunc func(ds datastore.DataStore, selector uint64) (any, error)
+
+// ChainVersionFunc returns a version of the on-chain state of a chain, such as its latest block.
+// The view of the chain is also regenerated when the version changes.
+type ChainVersionFunc func(ctx context.Context, c chain.BlockChain) (string, error)
+
+// ChainViewCache holds what the view of a chain was generated from, which is compared against the
+// environment to decide if the view has to be regenerated.
+type ChainViewCache struct {
+	// DependenciesHash is the SHA-256 hash of the datastore records the view depends on.
+	DependenciesHash string `json:"dependenciesHash"`
+	// Version is the version of the on-chain state. It is empty if the version of the chain is
+	// unknown, or if on-chain versions are disabled with WithChainVersion(nil).
+	Version string `json:"version,omitempty"`
+	// ViewVersion is the version of the view function, set with WithViewVersion.
+	ViewVersion string `json:"viewVersion,omitempty"`
+}
+
+// IncrementalView is the view state produced by NewIncrementalViewState. It holds the view of each
+// chain and the cache entries used to decide which chain views to regenerate.
+type IncrementalView struct {
+	Chains map[uint64]json.RawMessage `json:"chains"`
+	Cache  map[uint64]ChainViewCache  `json:"chainViewCache"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v *IncrementalView) MarshalJSON() ([]byte, error) {
+	type alias IncrementalView
+
+	return json.Marshal((*alias)(v))
+}
+
+type incrementalViewConfig struct {
+	dependencies ChainViewDependenciesFunc
+	version      ChainVersionFunc
+	viewVersion  string
+	concurrency  int
+}
+
+// IncrementalViewOption configures NewIncrementalViewState.
+type IncrementalViewOption func(*incrementalViewConfig)
+
+// WithChainViewDependencies sets the function which returns the datastore records a chain view
+// depends on. By default, a chain view depends on the address refs, contract metadata and chain
+// metadata of the chain.
+func WithChainViewDependencies(fn ChainViewDependenciesFunc) IncrementalViewOption {
+	return func(c *incrementalViewConfig) {
+		c.dependencies = fn
+	}
+}
+
+// WithChainVersion sets the function which returns the version of the on-chain state of a chain,
+// so that the view of a chain is also regenerated when its version changes. Default:
+// LatestBlockVersion.
+//
+// LatestBlockVersion regenerates a chain view on every new block, which for active chains is
+// every run. A domain can instead provide a coarser version, such as the last block in which its
+// contracts emitted an event, so that chain views are not regenerated for blocks which did not
+// change them. A nil function disables on-chain versions, so that chain views are only
+// regenerated when their datastore records change.
+func WithChainVersion(fn ChainVersionFunc) IncrementalViewOption {
+	return func(c *incrementalViewConfig) {
+		c.version = fn
+	}
+}
+
+// WithViewVersion sets the version of the view function, so that every chain view is regenerated
+// when the version changes. A domain should bump it whenever the output of its view function
+// changes. Default: the VCS revision of the binary, if it was stamped at build time.
+func WithViewVersion(version string) IncrementalViewOption {
+	return func(c *incrementalViewConfig) {
+		c.viewVersion = version
+	}
+}
+
+// WithChainViewConcurrency sets the maximum number of chain views generated in parallel.
+// Default: 8.
+func WithChainViewConcurrency(n int) IncrementalViewOption {
+	return func(c *incrementalViewConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// NewIncrementalViewState creates a ViewStateV2 which generates the view of each chain of the
+// environment with viewChain, regenerating only the chains whose datastore records or latest block
+// changed since the previous view state. Chains are regenerated in parallel, and the views of unchanged chains
+// are copied from the previous view state. The result is an *IncrementalView.
+//
+// The view of a chain is regenerated if the chain is not in the previous view state, if the hash of
+// its datastore records differs, if the version of the view function differs, or if its on-chain
+// version differs or cannot be determined. Without a datastore and with on-chain versions
+// disabled, every chain is regenerated. Chains which are no longer in the environment are
+// dropped.
+//
+// Usage:
+//
+//	viewState := deployment.NewIncrementalViewState(
+//	    func(e deployment.Environment, selector uint64, _ json.RawMessage) (json.Marshaler, error) {
+//	        return viewChain(e, selector)
+//	    },
+//	)
+func NewIncrementalViewState(viewChain ChainViewFunc, opts ...IncrementalViewOption) ViewStateV2 {
+	cfg := &incrementalViewConfig{
+		dependencies: defaultChainViewDependencies,
+		version:      LatestBlockVersion,
+		viewVersion:  buildRevision(),
+		concurrency:  defaultChainViewConcurrency,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(e Environment, previousView json.Marshaler) (json.Marshaler, error) {
+		previous, err := decodeIncrementalView(previousView)
+		if err != nil {
+			// The previous view state may have been generated by a different view function, in
+			// which case every chain is generated
+			if e.Logger != nil {
+				e.Logger.Warnw("Ignoring previous view state", "error", err)
+			}
+			previous = &IncrementalView{}
+		}
+
+		ctx := context.Background()
+		if e.GetContext != nil {
+			ctx = e.GetContext()
+		}
+
+		selectors := e.BlockChains.ListChainSelectors()
+		caches := make(map[uint64]ChainViewCache, len(selectors))
+		var stale []uint64
+
+		for _, selector := range selectors {
+			c, err := chainViewCache(ctx, e, cfg, selector)
+			if err != nil {
+				return nil, err
+			}
+			caches[selector] = c
+
+			prevCache, ok := previous.Cache[selector]
+			_, hasView := previous.Chains[selector]
+			if !ok || !hasView || !cfg.cacheable(c) || prevCache != c {
+				stale = append(stale, selector)
+			}
+		}
+
+		if e.Logger != nil {
+			e.Logger.Infow("Generating chain views", "stale", stale, "cached", len(selectors)-len(stale))
+		}
+
+		views, err := generateChainViews(e, cfg.concurrency, viewChain, stale, previous.Chains)
+		if err != nil {
+			return nil, err
+		}
+
+		out := &IncrementalView{
+			Chains: make(map[uint64]json.RawMessage, len(selectors)),
+			Cache:  caches,
+		}
+		for _, selector := range selectors {
+			if view, ok := views[selector]; ok {
+				out.Chains[selector] = view
+				continue
+			}
+			out.Chains[selector] = previous.Chains[selector]
+		}
+
+		return out, nil
+	}
+}
+
+// cacheable reports whether the cache entry identifies the state the view was generated from, so
+// that the view can be reused when the entry is unchanged.
+func (cfg *incrementalViewConfig) cacheable(c ChainViewCache) bool {
+	if cfg.version != nil {
+		return c.Version != ""
+	}
+
+	return c.DependenciesHash != ""
+}
+
+// decodeIncrementalView decodes the previous view state. A missing or empty previous view state
+// decodes to an empty view, so that every chain is generated.
+func decodeIncrementalView(previousView json.Marshaler) (*IncrementalView, error) {
+	view := &IncrementalView{}
+	if previousView == nil {
+		return view, nil
+	}
+
+	b, err := previousView.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal previous view: %w", err)
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return view, nil
+	}
+	if err = json.Unmarshal(b, view); err != nil {
+		return nil, fmt.Errorf("failed to decode previous view: %w", err)
+	}
+
+	return view, nil
+}
+
+// chainViewCache returns the cache entry of the chain in the current environment.
+func chainViewCache(ctx context.Context, e Environment, cfg *incrementalViewConfig, selector uint64) (ChainViewCache, error) {
+	c := ChainViewCache{ViewVersion: cfg.viewVersion}
+
+	if e.DataStore != nil {
+		deps, err := cfg.dependencies(e.DataStore, selector)
+		if err != nil {
+			return c, fmt.Errorf("failed to get view dependencies of chain %d: %w", selector, err)
+		}
+		b, err := json.Marshal(deps)
+		if err != nil {
+			return c, fmt.Errorf("failed to marshal view dependencies of chain %d: %w", selector, err)
+		}
+		sum := sha256.Sum256(b)
+		c.DependenciesHash = hex.EncodeToString(sum[:])
+	}
+
+	if cfg.version == nil {
+		return c, nil
+	}
+
+	bc, err := e.BlockChains.GetBySelector(selector)
+	if err != nil {
+		return c, err
+	}
+
+	// An unknown version means the view is always regenerated
+	version, err := cfg.version(ctx, bc)
+	if err != nil && !errors.Is(err, ErrChainVersionUnsupported) {
+		return c, fmt.Errorf("failed to get version of chain %d: %w", selector, err)
+	}
+	c.Version = version
+
+	return c, nil
+}
+
+// buildRevision returns the VCS revision the binary was built from, with a "-dirty" suffix if the
+// working tree had local changes, or an empty string if the binary was not stamped with one.
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+	if revision != "" && modified == "true" {
+		revision += "-dirty"
+	}
+
+	return revision
+}
+
+// generateChainViews generates the views of the chains in parallel, with at most concurrency views
+// being generated at once.
+func generateChainViews(
+	e Environment, concurrency int, viewChain ChainViewFunc, selectors []uint64, previous map[uint64]json.RawMessage,
+) (map[uint64]json.RawMessage, error) {
+	type result struct {
+		view json.RawMessage
+		err  error
+	}
+
+	// Use indexed assignment to collect results (no mutex needed)
+	results := make([]result, len(selectors))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, selector := range selectors {
+		wg.Add(1)
+
+		go func(index int, selector uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			view, err := viewChain(e, selector, previous[selector])
+			if err != nil {
+				results[index].err = fmt.Errorf("failed to generate view of chain %d: %w", selector, err)
+				return
+			}
+
+			b, err := view.MarshalJSON()
+			if err != nil {
+				results[index].err = fmt.Errorf("failed to marshal view of chain %d: %w", selector, err)
+				return
+			}
+			results[index].view = b
+		}(i, selector)
+	}
+	wg.Wait()
+
+	views := make(map[uint64]json.RawMessage, len(selectors))
+	var errs []error
+	for i, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		views[selectors[i]] = r.view
+	}
+
+	return views, errors.Join(errs...)
+}
+
+// chainViewDependencies are the records a chain view depends on by default.
+type chainViewDependencies struct {
+	Addresses        []datastore.AddressRef       `json:"addresses"`
+	ContractMetadata []datastore.ContractMetadata `json:"contractMetadata"`
+	ChainMetadata    []datastore.ChainMetadata    `json:"chainMetadata"`
+}
+
+// defaultChainViewDependencies returns the address refs, contract metadata and chain metadata of
+// the chain, sorted so that the hash does not depend on the order of the datastore records.
+func defaultChainViewDependencies(ds datastore.DataStore, selector uint64) (any, error) {
+	deps := chainViewDependencies{
+		Addresses:        ds.Addresses().Filter(datastore.AddressRefByChainSelector(selector)),
+		ContractMetadata: ds.ContractMetadata().Filter(datastore.ContractMetadataByChainSelector(selector)),
+		ChainMetadata:    ds.ChainMetadata().Filter(datastore.ChainMetadataByChainSelector(selector)),
+	}
+
+	slices.SortFunc(deps.Addresses, func(a, b datastore.AddressRef) int {
+		return strings.Compare(a.Key().String(), b.Key().String())
+	})
+	slices.SortFunc(deps.ContractMetadata, func(a, b datastore.ContractMetadata) int {
+		return strings.Compare(a.Key().String(), b.Key().String())
+	})
+	slices.SortFunc(deps.ChainMetadata, func(a, b datastore.ChainMetadata) int {
+		return strings.Compare(a.Key().String(), b.Key().String())
+	})
+
+	return deps, nil
+}
+
+// ErrChainVersionUnsupported is returned by a ChainVersionFunc when it cannot determine the version
+// of a chain. The view of the chain is then always regenerated.
+var ErrChainVersionUnsupported = errors.New("chain version is not supported for this chain family")
+
+// LatestBlockVersion is a ChainVersionFunc which returns the latest block number of EVM
+// chains, the latest slot of Solana chains and the latest block height of Aptos chains.
+func LatestBlockVersion(ctx context.Context, c chain.BlockChain) (string, error) {
+	switch c := c.(type) {
+	case evm.Chain:
+		if c.Client == nil {
+			return "", ErrChainVersionUnsupported
+		}
+		header, err := c.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return "", err
+		}
+
+		return header.Number.String(), nil
+	case solana.Chain:
+		if c.Client == nil {
+			return "", ErrChainVersionUnsupported
+		}
+		slot, err := c.Client.GetSlot(ctx, solana.SolDefaultCommitment)
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatUint(slot, 10), nil
+	case aptos.Chain:
+		if c.Client == nil {
+			return "", ErrChainVersionUnsupported
+		}
+		info, err := c.Client.Info()
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatUint(info.BlockHeight(), 10), nil
+	default:
+		return "", ErrChainVersionUnsupported
+	}
+}
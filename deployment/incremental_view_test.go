@@ -0,0 +1,313 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	evmprov "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm/provider"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+var (
+	viewSelectorA = chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector
+	// viewSelectorB sorts before viewSelectorA
+	viewSelectorB = chainsel.ETHEREUM_TESTNET_SEPOLIA_OPTIMISM_1.Selector
+)
+
+// viewRecorder is a ChainViewFunc which records the chains it generated views for.
+type viewRecorder struct {
+	mu       sync.Mutex
+	calls    []uint64
+	previous map[uint64]json.RawMessage
+	run      int
+}
+
+func (r *viewRecorder) view(_ Environment, selector uint64, previous json.RawMessage) (json.Marshaler, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, selector)
+	r.previous[selector] = previous
+	raw := json.RawMessage(fmt.Sprintf(`{"run":%d}`, r.run))
+
+	return &raw, nil
+}
+
+// nextRun resets the recorded calls, and returns them sorted.
+func (r *viewRecorder) nextRun() []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := slices.Sorted(slices.Values(r.calls))
+	r.calls = nil
+	r.run++
+
+	return calls
+}
+
+func newViewEnv(t *testing.T, selectors ...uint64) (Environment, *datastore.MemoryDataStore) {
+	t.Helper()
+
+	chains := make([]chain.BlockChain, 0, len(selectors))
+	for _, selector := range selectors {
+		chains = append(chains, evm.Chain{Selector: selector})
+	}
+	ds := datastore.NewMemoryDataStore()
+
+	return Environment{
+		GetContext:  t.Context,
+		BlockChains: chain.NewBlockChainsFromSlice(chains),
+		DataStore:   ds.Seal(),
+	}, ds
+}
+
+func TestNewIncrementalViewState(t *testing.T) {
+	t.Parallel()
+
+	versions := map[uint64]string{viewSelectorA: "100", viewSelectorB: "200"}
+	var versionsMu sync.Mutex
+	versionFn := func(_ context.Context, c chain.BlockChain) (string, error) {
+		versionsMu.Lock()
+		defer versionsMu.Unlock()
+
+		return versions[c.ChainSelector()], nil
+	}
+
+	recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+	viewState := NewIncrementalViewState(recorder.view, WithChainVersion(versionFn), WithChainViewConcurrency(1))
+
+	env, ds := newViewEnv(t, viewSelectorA, viewSelectorB)
+
+	// First run generates every chain
+	out, err := viewState(env, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorB, viewSelectorA}, recorder.nextRun())
+
+	view, ok := out.(*IncrementalView)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"run":0}`, string(view.Chains[viewSelectorA]))
+	assert.Equal(t, "100", view.Cache[viewSelectorA].Version)
+	assert.NotEmpty(t, view.Cache[viewSelectorA].DependenciesHash)
+
+	// Nothing changed, so every view is copied from the previous view state
+	out, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.nextRun())
+	assert.JSONEq(t, `{"run":0}`, string(out.(*IncrementalView).Chains[viewSelectorB]))
+
+	// A new datastore record on chain A regenerates chain A only
+	require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+		Address: "0x1", ChainSelector: viewSelectorA, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+	env.DataStore = ds.Seal()
+
+	out, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorA}, recorder.nextRun())
+	assert.JSONEq(t, `{"run":0}`, string(recorder.previous[viewSelectorA]))
+	assert.JSONEq(t, `{"run":2}`, string(out.(*IncrementalView).Chains[viewSelectorA]))
+	assert.JSONEq(t, `{"run":0}`, string(out.(*IncrementalView).Chains[viewSelectorB]))
+
+	// A new block on chain B regenerates chain B only
+	versionsMu.Lock()
+	versions[viewSelectorB] = "201"
+	versionsMu.Unlock()
+
+	out, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorB}, recorder.nextRun())
+
+	// Chains which are no longer in the environment are dropped
+	envA, _ := newViewEnv(t, viewSelectorA)
+	envA.DataStore = env.DataStore
+
+	out, err = viewState(envA, out)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.nextRun())
+	assert.Len(t, out.(*IncrementalView).Chains, 1)
+	assert.Len(t, out.(*IncrementalView).Cache, 1)
+
+	// The saved view state round trips through JSON
+	b, err := out.MarshalJSON()
+	require.NoError(t, err)
+	raw := json.RawMessage(b)
+
+	_, err = viewState(envA, &raw)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.nextRun())
+}
+
+func TestNewIncrementalViewState_WithoutChainVersion(t *testing.T) {
+	t.Parallel()
+
+	recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+	viewState := NewIncrementalViewState(recorder.view, WithChainVersion(nil))
+
+	env, ds := newViewEnv(t, viewSelectorA, viewSelectorB)
+
+	out, err := viewState(env, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorB, viewSelectorA}, recorder.nextRun())
+	assert.Empty(t, out.(*IncrementalView).Cache[viewSelectorA].Version)
+
+	// Without a chain version, views are reused until their datastore records change
+	out, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.nextRun())
+
+	require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+		Address: "0x1", ChainSelector: viewSelectorB, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+	env.DataStore = ds.Seal()
+
+	_, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorB}, recorder.nextRun())
+
+	// Without a datastore there is nothing to compare, so every chain is regenerated
+	env.DataStore = nil
+
+	out, err = viewState(env, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorB, viewSelectorA}, recorder.nextRun())
+
+	_, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorB, viewSelectorA}, recorder.nextRun())
+}
+
+func TestNewIncrementalViewState_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	// The latest block is used by default, which is unknown for chains without a client
+	recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+	viewState := NewIncrementalViewState(recorder.view)
+
+	suiSelector := chainsel.SUI_LOCALNET.Selector
+	env, _ := newViewEnv(t, viewSelectorA)
+	env.BlockChains = chain.NewBlockChainsFromSlice([]chain.BlockChain{
+		evm.Chain{Selector: viewSelectorA},
+		sui.Chain{ChainMetadata: sui.ChainMetadata{Selector: suiSelector}},
+	})
+
+	out, err := viewState(env, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorA, suiSelector}, recorder.nextRun())
+
+	// Chains without a version are always regenerated
+	_, err = viewState(env, out)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorA, suiSelector}, recorder.nextRun())
+}
+
+func TestNewIncrementalViewState_ViewVersion(t *testing.T) {
+	t.Parallel()
+
+	recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+	staticVersion := WithChainVersion(func(context.Context, chain.BlockChain) (string, error) { return "1", nil })
+	env, _ := newViewEnv(t, viewSelectorA)
+
+	out, err := NewIncrementalViewState(recorder.view, staticVersion, WithViewVersion("v1"))(env, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorA}, recorder.nextRun())
+	assert.Equal(t, "v1", out.(*IncrementalView).Cache[viewSelectorA].ViewVersion)
+
+	out, err = NewIncrementalViewState(recorder.view, staticVersion, WithViewVersion("v1"))(env, out)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.nextRun())
+
+	// A new version of the view function regenerates every chain
+	_, err = NewIncrementalViewState(recorder.view, staticVersion, WithViewVersion("v2"))(env, out)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{viewSelectorA}, recorder.nextRun())
+}
+
+func TestNewIncrementalViewState_Errors(t *testing.T) {
+	t.Parallel()
+
+	staticVersion := WithChainVersion(func(context.Context, chain.BlockChain) (string, error) { return "1", nil })
+
+	t.Run("view fails", func(t *testing.T) {
+		t.Parallel()
+
+		viewState := NewIncrementalViewState(func(_ Environment, selector uint64, _ json.RawMessage) (json.Marshaler, error) {
+			return nil, errors.New("rpc unavailable")
+		}, staticVersion)
+
+		env, _ := newViewEnv(t, viewSelectorA, viewSelectorB)
+		_, err := viewState(env, nil)
+		require.ErrorContains(t, err, fmt.Sprintf("failed to generate view of chain %d: rpc unavailable", viewSelectorA))
+		require.ErrorContains(t, err, fmt.Sprintf("failed to generate view of chain %d: rpc unavailable", viewSelectorB))
+	})
+
+	t.Run("version fails", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+		viewState := NewIncrementalViewState(recorder.view,
+			WithChainVersion(func(context.Context, chain.BlockChain) (string, error) { return "", errors.New("timeout") }),
+		)
+
+		env, _ := newViewEnv(t, viewSelectorA)
+		_, err := viewState(env, nil)
+		require.ErrorContains(t, err, "failed to get version of chain")
+	})
+
+	t.Run("dependencies fail", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+		viewState := NewIncrementalViewState(recorder.view, staticVersion,
+			WithChainViewDependencies(func(datastore.DataStore, uint64) (any, error) { return nil, errors.New("boom") }),
+		)
+
+		env, _ := newViewEnv(t, viewSelectorA)
+		_, err := viewState(env, nil)
+		require.ErrorContains(t, err, "failed to get view dependencies of chain")
+	})
+
+	t.Run("previous view of another shape is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &viewRecorder{previous: map[uint64]json.RawMessage{}}
+		viewState := NewIncrementalViewState(recorder.view, staticVersion)
+
+		env, _ := newViewEnv(t, viewSelectorA)
+		raw := json.RawMessage(`{"chains": ["legacy"]}`)
+
+		_, err := viewState(env, &raw)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{viewSelectorA}, recorder.nextRun())
+	})
+}
+
+func TestLatestBlockVersion(t *testing.T) {
+	t.Parallel()
+
+	bc, err := evmprov.NewSimChainProvider(t, chainsel.GETH_TESTNET.Selector, evmprov.SimChainProviderConfig{}).
+		Initialize(t.Context())
+	require.NoError(t, err)
+
+	version, err := LatestBlockVersion(t.Context(), bc)
+	require.NoError(t, err)
+	assert.Equal(t, "1", version)
+
+	_, err = LatestBlockVersion(t.Context(), evm.Chain{})
+	require.ErrorIs(t, err, ErrChainVersionUnsupported)
+
+	_, err = LatestBlockVersion(t.Context(), sui.Chain{})
+	require.ErrorIs(t, err, ErrChainVersionUnsupported)
+}
@@ -0,0 +1,433 @@
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sol "github.com/gagliardetto/solana-go"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmschainwrappers "github.com/smartcontractkit/mcms/chainwrappers"
+	mcmssdk "github.com/smartcontractkit/mcms/sdk"
+	mcmsaptos "github.com/smartcontractkit/mcms/sdk/aptos"
+	mcmssolana "github.com/smartcontractkit/mcms/sdk/solana"
+	mcmssui "github.com/smartcontractkit/mcms/sdk/sui"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldfmcmsadapters "github.com/smartcontractkit/chainlink-deployments-framework/chain/mcms/adapters"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// Contract types used by the built-in MCMS readers to find the MCMS contracts in the datastore. The
+// MCMS and timelock refs are matched on these types, the chain selector and the qualifier of the
+// MCMSTimelockProposalInput.
+const (
+	// RBACTimelockContractType is the timelock contract on EVM and TON, and the timelock instance
+	// seed on Solana.
+	RBACTimelockContractType datastore.ContractType = "RBACTimelock"
+	// ProposerMCMSContractType is the proposer MCMS contract on EVM and TON, and the proposer MCMS
+	// instance seed on Solana.
+	ProposerMCMSContractType datastore.ContractType = "ProposerManyChainMultiSig"
+	// CancellerMCMSContractType is the canceller MCMS contract on EVM and TON, and the canceller
+	// MCMS instance seed on Solana.
+	CancellerMCMSContractType datastore.ContractType = "CancellerManyChainMultiSig"
+	// BypasserMCMSContractType is the bypasser MCMS contract on EVM and TON, and the bypasser MCMS
+	// instance seed on Solana.
+	BypasserMCMSContractType datastore.ContractType = "BypasserManyChainMultiSig"
+
+	// MCMSProgramContractType is the MCMS program on Solana.
+	MCMSProgramContractType datastore.ContractType = "ManyChainMultiSigProgram"
+	// RBACTimelockProgramContractType is the timelock program on Solana.
+	RBACTimelockProgramContractType datastore.ContractType = "RBACTimelockProgram"
+	// ProposerAccessControllerContractType is the proposer access controller account on Solana.
+	ProposerAccessControllerContractType datastore.ContractType = "ProposerAccessControllerAccount"
+	// CancellerAccessControllerContractType is the canceller access controller account on Solana.
+	CancellerAccessControllerContractType datastore.ContractType = "CancellerAccessControllerAccount"
+	// BypasserAccessControllerContractType is the bypasser access controller account on Solana.
+	BypasserAccessControllerContractType datastore.ContractType = "BypasserAccessControllerAccount"
+
+	// AptosMCMSContractType is the MCMS package on Aptos, which also contains the timelock.
+	AptosMCMSContractType datastore.ContractType = "AptosManyChainMultisig"
+
+	// SuiMCMSPackageContractType is the MCMS package on Sui.
+	SuiMCMSPackageContractType datastore.ContractType = "SuiManyChainMultiSig"
+	// SuiMCMSObjectContractType is the MCMS state object on Sui.
+	SuiMCMSObjectContractType datastore.ContractType = "SuiManyChainMultiSigObject"
+	// SuiMCMSAccountObjectContractType is the MCMS account object on Sui.
+	SuiMCMSAccountObjectContractType datastore.ContractType = "SuiMCMSAccountObject"
+	// SuiMCMSRegistryObjectContractType is the MCMS registry object on Sui.
+	SuiMCMSRegistryObjectContractType datastore.ContractType = "SuiMCMSRegistryObject"
+	// SuiTimelockObjectContractType is the timelock object on Sui.
+	SuiTimelockObjectContractType datastore.ContractType = "SuiRBACTimelockObject"
+	// SuiMCMSDeployerStateObjectContractType is the MCMS deployer state object on Sui.
+	SuiMCMSDeployerStateObjectContractType datastore.ContractType = "SuiMCMSDeployerStateObject"
+)
+
+// mcmsInspectorBuilder builds the MCMS inspector used to read the on-chain op count.
+type mcmsInspectorBuilder func(
+	e Environment, chainSelector uint64, action mcmstypes.TimelockAction, metadata mcmstypes.ChainMetadata,
+) (mcmssdk.Inspector, error)
+
+// buildMCMSInspector builds an MCMS inspector from the environment's chains.
+func buildMCMSInspector(
+	e Environment, chainSelector uint64, action mcmstypes.TimelockAction, metadata mcmstypes.ChainMetadata,
+) (mcmssdk.Inspector, error) {
+	chainAccessor := cldfmcmsadapters.Wrap(e.BlockChains)
+
+	return mcmschainwrappers.BuildInspector(&chainAccessor, mcmstypes.ChainSelector(chainSelector), action, metadata)
+}
+
+// DefaultMCMSReaders returns the built-in MCMS readers keyed by chain family. They are used by the
+// registry returned by GetMCMSReaderRegistry for any family without a registered reader.
+func DefaultMCMSReaders() map[string]MCMSReader {
+	return map[string]MCMSReader{
+		chain_selectors.FamilyEVM:    NewEVMMCMSReader(),
+		chain_selectors.FamilySolana: NewSolanaMCMSReader(),
+		chain_selectors.FamilyAptos:  NewAptosMCMSReader(),
+		chain_selectors.FamilySui:    NewSuiMCMSReader(),
+		chain_selectors.FamilyTon:    NewTONMCMSReader(),
+	}
+}
+
+// NewEVMMCMSReader returns an MCMSReader for EVM chains. The timelock is found by
+// RBACTimelockContractType and the MCMS contract by the type for the role of the timelock action,
+// e.g. ProposerMCMSContractType for schedule.
+func NewEVMMCMSReader() MCMSReader {
+	return &roleMCMSReader{buildInspector: buildMCMSInspector}
+}
+
+// NewTONMCMSReader returns an MCMSReader for TON chains, which use the same contract types as EVM.
+func NewTONMCMSReader() MCMSReader {
+	return &roleMCMSReader{buildInspector: buildMCMSInspector}
+}
+
+// NewSolanaMCMSReader returns an MCMSReader for Solana chains. The MCMS and timelock addresses are
+// composed from the program refs and the instance seed refs, and the access controller accounts
+// are read from the datastore.
+func NewSolanaMCMSReader() MCMSReader {
+	return &solanaMCMSReader{buildInspector: buildMCMSInspector}
+}
+
+// NewAptosMCMSReader returns an MCMSReader for Aptos chains, where the MCMS package found by
+// AptosMCMSContractType also serves as the timelock.
+func NewAptosMCMSReader() MCMSReader {
+	return &aptosMCMSReader{buildInspector: buildMCMSInspector}
+}
+
+// NewSuiMCMSReader returns an MCMSReader for Sui chains, which reads the MCMS package and objects
+// from the datastore.
+func NewSuiMCMSReader() MCMSReader {
+	return &suiMCMSReader{buildInspector: buildMCMSInspector}
+}
+
+// findMCMSRef returns the single address ref of the contract type on the chain with the input's
+// qualifier.
+func findMCMSRef(
+	e Environment, chainSelector uint64, contractType datastore.ContractType, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	if e.DataStore == nil {
+		return datastore.AddressRef{}, errors.New("environment datastore is not set")
+	}
+
+	ref, err := datastore.FindUniqueRef(e.DataStore.Addresses(), datastore.AddressRef{
+		ChainSelector: chainSelector,
+		Type:          contractType,
+		Qualifier:     input.Qualifier,
+	})
+	if err != nil {
+		return datastore.AddressRef{}, fmt.Errorf("failed to find %s: %w", contractType, err)
+	}
+
+	return ref, nil
+}
+
+// mcmsContractTypeForAction returns the contract type of the MCMS which holds the role required by
+// the timelock action.
+func mcmsContractTypeForAction(action mcmstypes.TimelockAction) (datastore.ContractType, error) {
+	switch action {
+	case mcmstypes.TimelockActionSchedule:
+		return ProposerMCMSContractType, nil
+	case mcmstypes.TimelockActionCancel:
+		return CancellerMCMSContractType, nil
+	case mcmstypes.TimelockActionBypass:
+		return BypasserMCMSContractType, nil
+	default:
+		return "", fmt.Errorf("unknown timelock action %q", action)
+	}
+}
+
+// readOpCount reads the op count of the MCMS in the chain metadata and sets it as the starting op
+// count.
+func readOpCount(
+	build mcmsInspectorBuilder, e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+	metadata mcmstypes.ChainMetadata,
+) (mcmstypes.ChainMetadata, error) {
+	inspector, err := build(e, chainSelector, input.TimelockAction, metadata)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, fmt.Errorf("failed to build MCMS inspector: %w", err)
+	}
+
+	opCount, err := inspector.GetOpCount(e.GetContext(), metadata.MCMAddress)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, fmt.Errorf("failed to get op count for MCMS %s: %w", metadata.MCMAddress, err)
+	}
+	metadata.StartingOpCount = opCount
+
+	return metadata, nil
+}
+
+// roleMCMSReader reads chains which deploy one MCMS contract per timelock role, such as EVM and TON.
+type roleMCMSReader struct {
+	buildInspector mcmsInspectorBuilder
+}
+
+// GetTimelockRef implements MCMSReader.
+func (r *roleMCMSReader) GetTimelockRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	return findMCMSRef(e, chainSelector, RBACTimelockContractType, input)
+}
+
+// GetMCMSRef implements MCMSReader.
+func (r *roleMCMSReader) GetMCMSRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	contractType, err := mcmsContractTypeForAction(input.TimelockAction)
+	if err != nil {
+		return datastore.AddressRef{}, err
+	}
+
+	return findMCMSRef(e, chainSelector, contractType, input)
+}
+
+// GetChainMetadata implements MCMSReader.
+func (r *roleMCMSReader) GetChainMetadata(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (mcmstypes.ChainMetadata, error) {
+	mcmsRef, err := r.GetMCMSRef(e, chainSelector, input)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, err
+	}
+
+	return readOpCount(r.buildInspector, e, chainSelector, input, mcmstypes.ChainMetadata{MCMAddress: mcmsRef.Address})
+}
+
+// solanaMCMSReader reads Solana chains, where each MCMS and timelock instance is a PDA seed of a
+// shared program.
+type solanaMCMSReader struct {
+	buildInspector mcmsInspectorBuilder
+}
+
+// instanceRef returns the seed ref of the instance type with its address replaced by the
+// "<program>.<seed>" contract address used by the MCMS SDK.
+func (r *solanaMCMSReader) instanceRef(
+	e Environment, chainSelector uint64, programType, instanceType datastore.ContractType, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	programRef, err := findMCMSRef(e, chainSelector, programType, input)
+	if err != nil {
+		return datastore.AddressRef{}, err
+	}
+	programID, err := sol.PublicKeyFromBase58(programRef.Address)
+	if err != nil {
+		return datastore.AddressRef{}, fmt.Errorf("invalid %s address %q: %w", programType, programRef.Address, err)
+	}
+
+	seedRef, err := findMCMSRef(e, chainSelector, instanceType, input)
+	if err != nil {
+		return datastore.AddressRef{}, err
+	}
+	seed, err := solanaPDASeed(seedRef.Address)
+	if err != nil {
+		return datastore.AddressRef{}, fmt.Errorf("invalid %s seed: %w", instanceType, err)
+	}
+
+	seedRef.Address = mcmssolana.ContractAddress(programID, seed)
+
+	return seedRef, nil
+}
+
+// GetTimelockRef implements MCMSReader.
+func (r *solanaMCMSReader) GetTimelockRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	return r.instanceRef(e, chainSelector, RBACTimelockProgramContractType, RBACTimelockContractType, input)
+}
+
+// GetMCMSRef implements MCMSReader.
+func (r *solanaMCMSReader) GetMCMSRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	contractType, err := mcmsContractTypeForAction(input.TimelockAction)
+	if err != nil {
+		return datastore.AddressRef{}, err
+	}
+
+	return r.instanceRef(e, chainSelector, MCMSProgramContractType, contractType, input)
+}
+
+// GetChainMetadata implements MCMSReader.
+func (r *solanaMCMSReader) GetChainMetadata(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (mcmstypes.ChainMetadata, error) {
+	mcmsRef, err := r.GetMCMSRef(e, chainSelector, input)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, err
+	}
+	programID, seed, err := mcmssolana.ParseContractAddress(mcmsRef.Address)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, fmt.Errorf("invalid MCMS address %q: %w", mcmsRef.Address, err)
+	}
+
+	accessControllers := make([]sol.PublicKey, 0, 3)
+	for _, contractType := range []datastore.ContractType{
+		ProposerAccessControllerContractType,
+		CancellerAccessControllerContractType,
+		BypasserAccessControllerContractType,
+	} {
+		ref, findErr := findMCMSRef(e, chainSelector, contractType, input)
+		if findErr != nil {
+			return mcmstypes.ChainMetadata{}, findErr
+		}
+		account, parseErr := sol.PublicKeyFromBase58(ref.Address)
+		if parseErr != nil {
+			return mcmstypes.ChainMetadata{}, fmt.Errorf("invalid %s address %q: %w", contractType, ref.Address, parseErr)
+		}
+		accessControllers = append(accessControllers, account)
+	}
+
+	metadata, err := mcmssolana.NewChainMetadata(0, programID, seed,
+		accessControllers[0], accessControllers[1], accessControllers[2],
+	)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, fmt.Errorf("failed to build chain metadata: %w", err)
+	}
+
+	return readOpCount(r.buildInspector, e, chainSelector, input, metadata)
+}
+
+// solanaPDASeed converts a seed stored as an address ref address into a PDA seed.
+func solanaPDASeed(s string) (mcmssolana.PDASeed, error) {
+	var seed mcmssolana.PDASeed
+	if len(s) == 0 || len(s) > len(seed) {
+		return seed, fmt.Errorf("seed %q must be between 1 and %d bytes", s, len(seed))
+	}
+	copy(seed[:], s)
+
+	return seed, nil
+}
+
+// aptosMCMSReader reads Aptos chains, where a single MCMS package holds all roles and the timelock.
+type aptosMCMSReader struct {
+	buildInspector mcmsInspectorBuilder
+}
+
+// GetTimelockRef implements MCMSReader.
+func (r *aptosMCMSReader) GetTimelockRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	return findMCMSRef(e, chainSelector, AptosMCMSContractType, input)
+}
+
+// GetMCMSRef implements MCMSReader.
+func (r *aptosMCMSReader) GetMCMSRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	return findMCMSRef(e, chainSelector, AptosMCMSContractType, input)
+}
+
+// GetChainMetadata implements MCMSReader.
+func (r *aptosMCMSReader) GetChainMetadata(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (mcmstypes.ChainMetadata, error) {
+	mcmsRef, err := r.GetMCMSRef(e, chainSelector, input)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, err
+	}
+
+	role, err := mcmsaptos.AptosRoleFromAction(input.TimelockAction)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, err
+	}
+	additionalFields, err := json.Marshal(mcmsaptos.AdditionalFieldsMetadata{Role: role})
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, fmt.Errorf("failed to marshal additional fields: %w", err)
+	}
+
+	return readOpCount(r.buildInspector, e, chainSelector, input, mcmstypes.ChainMetadata{
+		MCMAddress:       mcmsRef.Address,
+		AdditionalFields: additionalFields,
+	})
+}
+
+// suiMCMSReader reads Sui chains, where the MCMS state is split over a package and several objects.
+type suiMCMSReader struct {
+	buildInspector mcmsInspectorBuilder
+}
+
+// GetTimelockRef implements MCMSReader.
+func (r *suiMCMSReader) GetTimelockRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	return findMCMSRef(e, chainSelector, SuiTimelockObjectContractType, input)
+}
+
+// GetMCMSRef implements MCMSReader.
+func (r *suiMCMSReader) GetMCMSRef(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (datastore.AddressRef, error) {
+	return findMCMSRef(e, chainSelector, SuiMCMSObjectContractType, input)
+}
+
+// GetChainMetadata implements MCMSReader.
+func (r *suiMCMSReader) GetChainMetadata(
+	e Environment, chainSelector uint64, input MCMSTimelockProposalInput,
+) (mcmstypes.ChainMetadata, error) {
+	role, err := suiRoleFromAction(input.TimelockAction)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, err
+	}
+
+	addresses := make(map[datastore.ContractType]string)
+	for _, contractType := range []datastore.ContractType{
+		SuiMCMSPackageContractType,
+		SuiMCMSObjectContractType,
+		SuiMCMSAccountObjectContractType,
+		SuiMCMSRegistryObjectContractType,
+		SuiTimelockObjectContractType,
+		SuiMCMSDeployerStateObjectContractType,
+	} {
+		ref, findErr := findMCMSRef(e, chainSelector, contractType, input)
+		if findErr != nil {
+			return mcmstypes.ChainMetadata{}, findErr
+		}
+		addresses[contractType] = ref.Address
+	}
+
+	metadata, err := mcmssui.NewChainMetadata(0, role,
+		addresses[SuiMCMSPackageContractType],
+		addresses[SuiMCMSObjectContractType],
+		addresses[SuiMCMSAccountObjectContractType],
+		addresses[SuiMCMSRegistryObjectContractType],
+		addresses[SuiTimelockObjectContractType],
+		addresses[SuiMCMSDeployerStateObjectContractType],
+	)
+	if err != nil {
+		return mcmstypes.ChainMetadata{}, fmt.Errorf("failed to build chain metadata: %w", err)
+	}
+
+	return readOpCount(r.buildInspector, e, chainSelector, input, metadata)
+}
+
+// suiRoleFromAction returns the Sui timelock role required by the timelock action.
+func suiRoleFromAction(action mcmstypes.TimelockAction) (mcmssui.TimelockRole, error) {
+	switch action {
+	case mcmstypes.TimelockActionSchedule:
+		return mcmssui.TimelockRoleProposer, nil
+	case mcmstypes.TimelockActionCancel:
+		return mcmssui.TimelockRoleCanceller, nil
+	case mcmstypes.TimelockActionBypass:
+		return mcmssui.TimelockRoleBypasser, nil
+	default:
+		return 0, fmt.Errorf("unknown timelock action %q", action)
+	}
+}
@@ -0,0 +1,256 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	sol "github.com/gagliardetto/solana-go"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmssdk "github.com/smartcontractkit/mcms/sdk"
+	mcmssolana "github.com/smartcontractkit/mcms/sdk/solana"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// fakeOpCountInspector is an MCMS inspector which only supports GetOpCount.
+type fakeOpCountInspector struct {
+	mcmssdk.Inspector
+
+	opCounts map[string]uint64
+}
+
+func (i *fakeOpCountInspector) GetOpCount(_ context.Context, mcmAddr string) (uint64, error) {
+	count, ok := i.opCounts[mcmAddr]
+	if !ok {
+		return 0, errors.New("unknown MCMS")
+	}
+
+	return count, nil
+}
+
+// newFakeInspectorBuilder returns an inspector builder which records the metadata it was given.
+func newFakeInspectorBuilder(opCounts map[string]uint64, gotMetadata *mcmstypes.ChainMetadata) mcmsInspectorBuilder {
+	return func(_ Environment, _ uint64, _ mcmstypes.TimelockAction, metadata mcmstypes.ChainMetadata) (mcmssdk.Inspector, error) {
+		*gotMetadata = metadata
+		return &fakeOpCountInspector{opCounts: opCounts}, nil
+	}
+}
+
+func newMCMSReaderTestEnv(t *testing.T, refs ...datastore.AddressRef) Environment {
+	t.Helper()
+
+	ds := datastore.NewMemoryDataStore()
+	for _, ref := range refs {
+		if ref.Version == nil {
+			ref.Version = semver.MustParse("1.0.0")
+		}
+		require.NoError(t, ds.Addresses().Add(ref))
+	}
+
+	return Environment{DataStore: ds.Seal(), GetContext: t.Context}
+}
+
+func TestRoleMCMSReader(t *testing.T) {
+	t.Parallel()
+
+	selector := uint64(ethMainnetSelector)
+	env := newMCMSReaderTestEnv(t,
+		datastore.AddressRef{ChainSelector: selector, Type: RBACTimelockContractType, Address: "0xtimelock", Qualifier: "ccip"},
+		datastore.AddressRef{ChainSelector: selector, Type: ProposerMCMSContractType, Address: "0xproposer", Qualifier: "ccip"},
+		datastore.AddressRef{ChainSelector: selector, Type: BypasserMCMSContractType, Address: "0xbypasser", Qualifier: "ccip"},
+		datastore.AddressRef{ChainSelector: selector, Type: ProposerMCMSContractType, Address: "0xother", Qualifier: "keystone"},
+	)
+
+	tests := []struct {
+		name         string
+		input        MCMSTimelockProposalInput
+		wantMCMS     string
+		wantOpCount  uint64
+		wantErr      string
+		wantTimelock string
+	}{
+		{
+			name:         "schedule uses proposer",
+			input:        MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionSchedule, Qualifier: "ccip"},
+			wantMCMS:     "0xproposer",
+			wantOpCount:  7,
+			wantTimelock: "0xtimelock",
+		},
+		{
+			name:         "bypass uses bypasser",
+			input:        MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionBypass, Qualifier: "ccip"},
+			wantMCMS:     "0xbypasser",
+			wantOpCount:  3,
+			wantTimelock: "0xtimelock",
+		},
+		{
+			name:    "missing canceller",
+			input:   MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionCancel, Qualifier: "ccip"},
+			wantErr: "failed to find CancellerManyChainMultiSig: no address ref matched query",
+		},
+		{
+			name:    "ambiguous without qualifier",
+			input:   MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionSchedule},
+			wantErr: "multiple address refs matched query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotMetadata mcmstypes.ChainMetadata
+			reader := &roleMCMSReader{buildInspector: newFakeInspectorBuilder(
+				map[string]uint64{"0xproposer": 7, "0xbypasser": 3}, &gotMetadata,
+			)}
+
+			metadata, err := reader.GetChainMetadata(env, selector, tt.input)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, mcmstypes.ChainMetadata{MCMAddress: tt.wantMCMS, StartingOpCount: tt.wantOpCount}, metadata)
+
+			mcmsRef, err := reader.GetMCMSRef(env, selector, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMCMS, mcmsRef.Address)
+
+			timelockRef, err := reader.GetTimelockRef(env, selector, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTimelock, timelockRef.Address)
+		})
+	}
+}
+
+func TestSolanaMCMSReader(t *testing.T) {
+	t.Parallel()
+
+	selector := chain_selectors.SOLANA_DEVNET.Selector
+	mcmsProgram := sol.NewWallet().PublicKey()
+	timelockProgram := sol.NewWallet().PublicKey()
+	proposerAC := sol.NewWallet().PublicKey()
+	cancellerAC := sol.NewWallet().PublicKey()
+	bypasserAC := sol.NewWallet().PublicKey()
+
+	env := newMCMSReaderTestEnv(t,
+		datastore.AddressRef{ChainSelector: selector, Type: MCMSProgramContractType, Address: mcmsProgram.String()},
+		datastore.AddressRef{ChainSelector: selector, Type: RBACTimelockProgramContractType, Address: timelockProgram.String()},
+		datastore.AddressRef{ChainSelector: selector, Type: ProposerMCMSContractType, Address: "proposer-seed"},
+		datastore.AddressRef{ChainSelector: selector, Type: RBACTimelockContractType, Address: "timelock-seed"},
+		datastore.AddressRef{ChainSelector: selector, Type: ProposerAccessControllerContractType, Address: proposerAC.String()},
+		datastore.AddressRef{ChainSelector: selector, Type: CancellerAccessControllerContractType, Address: cancellerAC.String()},
+		datastore.AddressRef{ChainSelector: selector, Type: BypasserAccessControllerContractType, Address: bypasserAC.String()},
+	)
+	input := MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionSchedule}
+
+	proposerSeed, err := solanaPDASeed("proposer-seed")
+	require.NoError(t, err)
+	timelockSeed, err := solanaPDASeed("timelock-seed")
+	require.NoError(t, err)
+	wantMCMS := mcmssolana.ContractAddress(mcmsProgram, proposerSeed)
+
+	var gotMetadata mcmstypes.ChainMetadata
+	reader := &solanaMCMSReader{buildInspector: newFakeInspectorBuilder(map[string]uint64{wantMCMS: 11}, &gotMetadata)}
+
+	timelockRef, err := reader.GetTimelockRef(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, mcmssolana.ContractAddress(timelockProgram, timelockSeed), timelockRef.Address)
+
+	mcmsRef, err := reader.GetMCMSRef(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, wantMCMS, mcmsRef.Address)
+
+	metadata, err := reader.GetChainMetadata(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, wantMCMS, metadata.MCMAddress)
+	assert.Equal(t, uint64(11), metadata.StartingOpCount)
+	require.NoError(t, mcmssolana.ValidateChainMetadata(metadata))
+
+	var fields mcmssolana.AdditionalFieldsMetadata
+	require.NoError(t, json.Unmarshal(metadata.AdditionalFields, &fields))
+	assert.Equal(t, proposerAC, fields.ProposerRoleAccessController)
+	assert.Equal(t, cancellerAC, fields.CancellerRoleAccessController)
+	assert.Equal(t, bypasserAC, fields.BypasserRoleAccessController)
+
+	_, err = solanaPDASeed("a-seed-which-is-longer-than-32-bytes")
+	require.ErrorContains(t, err, "must be between 1 and 32 bytes")
+}
+
+func TestAptosMCMSReader(t *testing.T) {
+	t.Parallel()
+
+	selector := chain_selectors.APTOS_TESTNET.Selector
+	env := newMCMSReaderTestEnv(t,
+		datastore.AddressRef{ChainSelector: selector, Type: AptosMCMSContractType, Address: "0xmcms"},
+	)
+	input := MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionCancel}
+
+	var gotMetadata mcmstypes.ChainMetadata
+	reader := &aptosMCMSReader{buildInspector: newFakeInspectorBuilder(map[string]uint64{"0xmcms": 5}, &gotMetadata)}
+
+	timelockRef, err := reader.GetTimelockRef(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, "0xmcms", timelockRef.Address)
+
+	metadata, err := reader.GetChainMetadata(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, "0xmcms", metadata.MCMAddress)
+	assert.Equal(t, uint64(5), metadata.StartingOpCount)
+	assert.JSONEq(t, `{"role":1}`, string(metadata.AdditionalFields))
+
+	// The inspector is built with the role, so it reads the op count of the right MCMS
+	assert.Equal(t, metadata.AdditionalFields, gotMetadata.AdditionalFields)
+}
+
+func TestSuiMCMSReader(t *testing.T) {
+	t.Parallel()
+
+	selector := chain_selectors.SUI_TESTNET.Selector
+	env := newMCMSReaderTestEnv(t,
+		datastore.AddressRef{ChainSelector: selector, Type: SuiMCMSPackageContractType, Address: "0xpackage"},
+		datastore.AddressRef{ChainSelector: selector, Type: SuiMCMSObjectContractType, Address: "0xmcms"},
+		datastore.AddressRef{ChainSelector: selector, Type: SuiMCMSAccountObjectContractType, Address: "0xaccount"},
+		datastore.AddressRef{ChainSelector: selector, Type: SuiMCMSRegistryObjectContractType, Address: "0xregistry"},
+		datastore.AddressRef{ChainSelector: selector, Type: SuiTimelockObjectContractType, Address: "0xtimelock"},
+		datastore.AddressRef{ChainSelector: selector, Type: SuiMCMSDeployerStateObjectContractType, Address: "0xdeployer"},
+	)
+	input := MCMSTimelockProposalInput{TimelockAction: mcmstypes.TimelockActionSchedule}
+
+	var gotMetadata mcmstypes.ChainMetadata
+	reader := &suiMCMSReader{buildInspector: newFakeInspectorBuilder(map[string]uint64{"0xmcms": 2}, &gotMetadata)}
+
+	timelockRef, err := reader.GetTimelockRef(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, "0xtimelock", timelockRef.Address)
+
+	metadata, err := reader.GetChainMetadata(env, selector, input)
+	require.NoError(t, err)
+	assert.Equal(t, "0xmcms", metadata.MCMAddress)
+	assert.Equal(t, uint64(2), metadata.StartingOpCount)
+	assert.JSONEq(t, `{
+		"role": 2,
+		"mcms_package_id": "0xpackage",
+		"account_obj": "0xaccount",
+		"registry_obj": "0xregistry",
+		"timelock_obj": "0xtimelock",
+		"deployer_state_obj": "0xdeployer"
+	}`, string(metadata.AdditionalFields))
+}
+
+func TestMCMSReaders_NoDataStore(t *testing.T) {
+	t.Parallel()
+
+	for family, reader := range DefaultMCMSReaders() {
+		_, err := reader.GetTimelockRef(Environment{}, 1, MCMSTimelockProposalInput{
+			TimelockAction: mcmstypes.TimelockActionSchedule,
+		})
+		require.ErrorContains(t, err, "environment datastore is not set", family)
+	}
+}
@@ -86,6 +86,8 @@ type MCMSReader interface {
 type MCMSReaderRegistry struct {
 	mu sync.RWMutex
 	m  map[string]MCMSReader
+	// defaults are returned by Get for chain families without a registered reader.
+	defaults map[string]MCMSReader
 }
 
 func newMCMSReaderRegistry() *MCMSReaderRegistry {
@@ -102,9 +104,13 @@ var (
 // GetMCMSReaderRegistry returns the global singleton MCMS reader registry.
 // The first call creates the registry; subsequent calls return the same pointer.
 // This is the recommended way to get the registry, as it ensures a single instance is created and shared.
+//
+// The registry falls back to the DefaultMCMSReaders for chain families without a registered
+// reader. Registering a reader for a family replaces its default.
 func GetMCMSReaderRegistry() *MCMSReaderRegistry {
 	once.Do(func() {
 		singletonRegistry = newMCMSReaderRegistry()
+		singletonRegistry.defaults = DefaultMCMSReaders()
 	})
 
 	return singletonRegistry
@@ -134,12 +140,16 @@ func (r *MCMSReaderRegistry) Register(chainFamily string, reader MCMSReader) err
 	return nil
 }
 
-// Get retrieves an MCMSReader for a specific chain family.
+// Get retrieves an MCMSReader for a specific chain family, falling back to the registry's default
+// reader for the family if none is registered.
 func (r *MCMSReaderRegistry) Get(chainFamily string) (MCMSReader, bool) {
 	chainFamily = strings.TrimSpace(chainFamily)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	reader, ok := r.m[chainFamily]
+	if reader, ok := r.m[chainFamily]; ok {
+		return reader, true
+	}
+	reader, ok := r.defaults[chainFamily]
 
 	return reader, ok
 }
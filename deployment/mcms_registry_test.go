@@ -209,6 +209,30 @@ func TestGetMCMSReaderRegistry_ReturnsSingleton(t *testing.T) {
 	require.Same(t, first, second)
 }
 
+func TestMCMSReaderRegistry_Defaults(t *testing.T) {
+	t.Parallel()
+
+	registry := newMCMSReaderRegistry()
+	registry.defaults = DefaultMCMSReaders()
+
+	for _, family := range []string{"evm", "solana", "aptos", "sui", "ton"} {
+		_, ok := registry.Get(family)
+		require.True(t, ok, family)
+	}
+
+	// Registering a reader replaces the default for that family only
+	reader := &mockReader{}
+	require.NoError(t, registry.Register("evm", reader))
+
+	got, ok := registry.Get("evm")
+	require.True(t, ok)
+	require.Same(t, reader, got)
+
+	got, ok = registry.Get("solana")
+	require.True(t, ok)
+	require.NotSame(t, reader, got)
+}
+
 type mockReader struct{}
 
 func (m *mockReader) GetChainMetadata(_ Environment, _ uint64, _ MCMSTimelockProposalInput) (mcms_types.ChainMetadata, error) {
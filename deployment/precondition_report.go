@@ -0,0 +1,293 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PreconditionSeverity is the severity of a precondition finding.
+type PreconditionSeverity string
+
+const (
+	// SeverityError is the severity of a finding which prevents the changeset from being applied.
+	SeverityError PreconditionSeverity = "error"
+	// SeverityWarning is the severity of a finding which is reported but does not prevent the
+	// changeset from being applied.
+	SeverityWarning PreconditionSeverity = "warning"
+)
+
+// PreconditionScope is what a precondition finding is about.
+type PreconditionScope string
+
+const (
+	// ScopeConfig is the scope of findings about the changeset config. Errors in this scope wrap
+	// ErrInvalidConfig.
+	ScopeConfig PreconditionScope = "config"
+	// ScopeEnvironment is the scope of findings about the environment, such as a contract which is
+	// expected to be deployed but cannot be found. Errors in this scope wrap ErrInvalidEnvironment.
+	ScopeEnvironment PreconditionScope = "environment"
+)
+
+// PreconditionFinding is a single problem found while verifying the preconditions of a changeset.
+type PreconditionFinding struct {
+	Severity PreconditionSeverity `json:"severity"`
+	Scope    PreconditionScope    `json:"scope"`
+	// Path is the path of the field the finding is about, such as "chains[3].router". Empty if the
+	// finding is not about a specific field.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the finding as "<path>: <message>", or the message alone if there is no path.
+func (f PreconditionFinding) String() string {
+	if f.Path == "" {
+		return f.Message
+	}
+
+	return f.Path + ": " + f.Message
+}
+
+// PreconditionReport collects the findings of a VerifyPreconditions implementation, so that all
+// problems with a config are reported at once instead of one per run.
+//
+// Usage:
+//
+//	func (cs MyChangeset) VerifyPreconditions(e deployment.Environment, cfg MyConfig) error {
+//	    report := deployment.NewPreconditionReport()
+//	    for i, c := range cfg.Chains {
+//	        if c.Router == "" {
+//	            report.ConfigError(deployment.FieldPath("chains", i, "router"), "router address is required")
+//	        }
+//	        if !e.BlockChains.Exists(c.Selector) {
+//	            report.EnvironmentError(deployment.FieldPath("chains", i, "selector"), "chain %d is not in the environment", c.Selector)
+//	        }
+//	    }
+//
+//	    return report.Result(e)
+//	}
+type PreconditionReport struct {
+	findings []PreconditionFinding
+}
+
+// NewPreconditionReport creates an empty precondition report.
+func NewPreconditionReport() *PreconditionReport {
+	return &PreconditionReport{}
+}
+
+// Add adds a finding to the report.
+func (r *PreconditionReport) Add(f PreconditionFinding) {
+	r.findings = append(r.findings, f)
+}
+
+// ConfigError adds an error about the config field at path.
+func (r *PreconditionReport) ConfigError(path, format string, args ...any) {
+	r.add(SeverityError, ScopeConfig, path, format, args...)
+}
+
+// ConfigWarning adds a warning about the config field at path.
+func (r *PreconditionReport) ConfigWarning(path, format string, args ...any) {
+	r.add(SeverityWarning, ScopeConfig, path, format, args...)
+}
+
+// EnvironmentError adds an error about the environment, found while checking the config field at
+// path.
+func (r *PreconditionReport) EnvironmentError(path, format string, args ...any) {
+	r.add(SeverityError, ScopeEnvironment, path, format, args...)
+}
+
+// EnvironmentWarning adds a warning about the environment, found while checking the config field
+// at path.
+func (r *PreconditionReport) EnvironmentWarning(path, format string, args ...any) {
+	r.add(SeverityWarning, ScopeEnvironment, path, format, args...)
+}
+
+func (r *PreconditionReport) add(severity PreconditionSeverity, scope PreconditionScope, path, format string, args ...any) {
+	r.Add(PreconditionFinding{
+		Severity: severity,
+		Scope:    scope,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Findings returns the findings in the order they were added.
+func (r *PreconditionReport) Findings() []PreconditionFinding {
+	return r.findings
+}
+
+// HasErrors returns true if the report has at least one finding with error severity.
+func (r *PreconditionReport) HasErrors() bool {
+	for _, f := range r.findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns a *PreconditionError holding all findings if the report has at least one error, or
+// nil if it only has warnings or is empty. The warnings of a report without errors are dropped;
+// use Result to report them.
+func (r *PreconditionReport) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+
+	return &PreconditionError{Findings: r.findings}
+}
+
+// Result returns the same error as Err. If the report only has warnings, they are handed to the
+// PreconditionWarnings of the environment's context, so the caller of VerifyPreconditions can
+// show them, or logged if the context has none.
+func (r *PreconditionReport) Result(e Environment) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if len(r.findings) == 0 {
+		return nil
+	}
+
+	if e.GetContext != nil {
+		if warnings, ok := e.GetContext().Value(preconditionWarningsKey{}).(*PreconditionWarnings); ok {
+			warnings.add(r.findings...)
+			return nil
+		}
+	}
+	if e.Logger != nil {
+		for _, f := range r.findings {
+			e.Logger.Warnw("Precondition warning", "scope", f.Scope, "path", f.Path, "message", f.Message)
+		}
+	}
+
+	return nil
+}
+
+// PreconditionWarnings collects the warnings of the precondition reports which passed, so that
+// they can be shown to the operator along with the result of a changeset. Reports hand their
+// warnings to the collector of the environment's context, see ContextWithPreconditionWarnings.
+type PreconditionWarnings struct {
+	mu       sync.Mutex
+	findings []PreconditionFinding
+}
+
+// Findings returns the collected warnings in the order they were reported.
+func (w *PreconditionWarnings) Findings() []PreconditionFinding {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.findings)
+}
+
+func (w *PreconditionWarnings) add(findings ...PreconditionFinding) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.findings = append(w.findings, findings...)
+}
+
+type preconditionWarningsKey struct{}
+
+// ContextWithPreconditionWarnings returns a copy of ctx to which PreconditionReport.Result hands
+// the warnings of reports without errors.
+func ContextWithPreconditionWarnings(ctx context.Context, warnings *PreconditionWarnings) context.Context {
+	return context.WithValue(ctx, preconditionWarningsKey{}, warnings)
+}
+
+// PreconditionError is returned by PreconditionReport.Err. It holds every finding of the report,
+// including warnings, and wraps ErrInvalidConfig and/or ErrInvalidEnvironment depending on the
+// scopes of its errors.
+//
+// Use errors.As to retrieve it from the error returned by VerifyPreconditions.
+type PreconditionError struct {
+	Findings []PreconditionFinding
+}
+
+// Errors returns the findings with error severity.
+func (e *PreconditionError) Errors() []PreconditionFinding {
+	errs := make([]PreconditionFinding, 0, len(e.Findings))
+	for _, f := range e.Findings {
+		if f.Severity == SeverityError {
+			errs = append(errs, f)
+		}
+	}
+
+	return errs
+}
+
+// Error lists the findings with error severity.
+func (e *PreconditionError) Error() string {
+	errs := e.Errors()
+	msgs := make([]string, 0, len(errs))
+	for _, f := range errs {
+		msgs = append(msgs, f.String())
+	}
+
+	sentinel := ErrInvalidConfig
+	if !e.hasScope(ScopeConfig) {
+		sentinel = ErrInvalidEnvironment
+	}
+
+	return fmt.Sprintf("%s: %d precondition failure(s): %s", sentinel, len(errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns ErrInvalidConfig if any error is about the config, and ErrInvalidEnvironment if
+// any error is about the environment.
+func (e *PreconditionError) Unwrap() []error {
+	var errs []error
+	if e.hasScope(ScopeConfig) {
+		errs = append(errs, ErrInvalidConfig)
+	}
+	if e.hasScope(ScopeEnvironment) {
+		errs = append(errs, ErrInvalidEnvironment)
+	}
+
+	return errs
+}
+
+// hasScope returns true if any finding with error severity has the scope.
+func (e *PreconditionError) hasScope(scope PreconditionScope) bool {
+	for _, f := range e.Findings {
+		if f.Severity == SeverityError && f.Scope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldPath builds the path of a config field from its parts. String parts are field names,
+// joined with dots, and int parts are indices.
+//
+// Example:
+//
+//	FieldPath("chains", 3, "router") // "chains[3].router"
+func FieldPath(parts ...any) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		switch p := part.(type) {
+		case int:
+			sb.WriteString("[" + strconv.Itoa(p) + "]")
+		default:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(fmt.Sprint(p))
+		}
+	}
+
+	return sb.String()
+}
+
+// AsPreconditionError returns the *PreconditionError in err's tree, if any.
+func AsPreconditionError(err error) (*PreconditionError, bool) {
+	var perr *PreconditionError
+	ok := errors.As(err, &perr)
+
+	return perr, ok
+}
@@ -0,0 +1,162 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func TestPreconditionReport_Err(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewPreconditionReport()
+		require.NoError(t, report.Err())
+		assert.False(t, report.HasErrors())
+	})
+
+	t.Run("warnings only", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewPreconditionReport()
+		report.ConfigWarning("fee", "fee is unusually high")
+		report.EnvironmentWarning("", "chain is congested")
+
+		require.NoError(t, report.Err())
+		assert.Len(t, report.Findings(), 2)
+	})
+
+	t.Run("config errors", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewPreconditionReport()
+		report.ConfigError(FieldPath("chains", 0, "router"), "router address is required")
+		report.ConfigWarning("fee", "fee is unusually high")
+		report.ConfigError(FieldPath("chains", 3, "router"), "invalid address %q", "0xzz")
+
+		err := report.Err()
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.NotErrorIs(t, err, ErrInvalidEnvironment)
+		assert.EqualError(t, err, `invalid changeset config: 2 precondition failure(s): `+
+			`chains[0].router: router address is required; chains[3].router: invalid address "0xzz"`)
+
+		perr, ok := AsPreconditionError(fmt.Errorf("wrapped: %w", err))
+		require.True(t, ok)
+		assert.Len(t, perr.Findings, 3)
+		assert.Len(t, perr.Errors(), 2)
+	})
+
+	t.Run("environment errors", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewPreconditionReport()
+		report.EnvironmentError(FieldPath("chains", 1, "selector"), "chain 1 is not in the environment")
+
+		err := report.Err()
+		require.ErrorIs(t, err, ErrInvalidEnvironment)
+		require.NotErrorIs(t, err, ErrInvalidConfig)
+		assert.EqualError(t, err, "invalid environment: 1 precondition failure(s): chains[1].selector: chain 1 is not in the environment")
+	})
+
+	t.Run("config and environment errors", func(t *testing.T) {
+		t.Parallel()
+
+		report := NewPreconditionReport()
+		report.ConfigError("owner", "owner is required")
+		report.Add(PreconditionFinding{Severity: SeverityError, Scope: ScopeEnvironment, Message: "timelock not found"})
+
+		err := report.Err()
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, ErrInvalidEnvironment)
+		assert.Contains(t, err.Error(), "owner: owner is required; timelock not found")
+	})
+}
+
+func TestPreconditionReport_Result(t *testing.T) {
+	t.Parallel()
+
+	newWarningReport := func() *PreconditionReport {
+		report := NewPreconditionReport()
+		report.ConfigWarning("fee", "fee is unusually high")
+
+		return report
+	}
+
+	t.Run("warnings are collected from the context", func(t *testing.T) {
+		t.Parallel()
+
+		warnings := &PreconditionWarnings{}
+		ctx := ContextWithPreconditionWarnings(t.Context(), warnings)
+		e := Environment{GetContext: func() context.Context { return ctx }}
+
+		require.NoError(t, newWarningReport().Result(e))
+		require.NoError(t, NewPreconditionReport().Result(e))
+		assert.Equal(t, []PreconditionFinding{
+			{Severity: SeverityWarning, Scope: ScopeConfig, Path: "fee", Message: "fee is unusually high"},
+		}, warnings.Findings())
+	})
+
+	t.Run("warnings are logged without a collector", func(t *testing.T) {
+		t.Parallel()
+
+		lggr, observed := logger.TestObserved(t, zapcore.WarnLevel)
+		e := Environment{GetContext: t.Context, Logger: lggr}
+
+		require.NoError(t, newWarningReport().Result(e))
+		require.Equal(t, 1, observed.FilterMessage("Precondition warning").Len())
+	})
+
+	t.Run("errors are returned", func(t *testing.T) {
+		t.Parallel()
+
+		warnings := &PreconditionWarnings{}
+		ctx := ContextWithPreconditionWarnings(t.Context(), warnings)
+		e := Environment{GetContext: func() context.Context { return ctx }}
+
+		report := newWarningReport()
+		report.ConfigError("owner", "owner is required")
+
+		err := report.Result(e)
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Empty(t, warnings.Findings())
+	})
+}
+
+func TestAsPreconditionError_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, ok := AsPreconditionError(errors.New("boom"))
+	assert.False(t, ok)
+}
+
+func TestFieldPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		parts []any
+		want  string
+	}{
+		{parts: nil, want: ""},
+		{parts: []any{"owner"}, want: "owner"},
+		{parts: []any{"chains", 3, "router"}, want: "chains[3].router"},
+		{parts: []any{"lanes", 0, 1, "dest"}, want: "lanes[0][1].dest"},
+		{parts: []any{0, "name"}, want: "[0].name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, FieldPath(tt.parts...))
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"reflect"
 	"slices"
-	"strings"
 
 	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
@@ -85,8 +84,8 @@ func parseTypedInput(inputStr string) (TypedJSON, error) {
 		return TypedJSON{}, errors.New("input is empty")
 	}
 
-	var inputObject TypedJSON
-	if err := json.Unmarshal([]byte(inputStr), &inputObject); err != nil {
+	inputObject, err := decodeTypedInput(inputStr)
+	if err != nil {
 		return TypedJSON{}, fmt.Errorf("JSON must be in JSON format with 'payload' fields: %w", err)
 	}
 	if len(inputObject.Payload) == 0 {
@@ -96,19 +95,6 @@ func parseTypedInput(inputStr string) (TypedJSON, error) {
 	return inputObject, nil
 }
 
-func decodePayload[C any](payload json.RawMessage) (C, error) {
-	var config C
-
-	payloadDecoder := json.NewDecoder(strings.NewReader(string(payload)))
-	payloadDecoder.UseNumber()
-	payloadDecoder.DisallowUnknownFields()
-	if err := payloadDecoder.Decode(&config); err != nil {
-		return config, fmt.Errorf("failed to unmarshal payload: %w", err)
-	}
-
-	return config, nil
-}
-
 // WithJSON returns a fully configured changeset, which pairs a [fdeployment.ChangeSet] with its configuration based
 // a JSON input. It also allows extensions, such as a PostProcessing function.
 // InputStr must be a JSON object with a "payload" field that contains the actual input data for a Durable Pipeline.
@@ -209,14 +195,30 @@ func loadInputChainOverrides(inputStr string) ([]uint64, error) {
 	}
 
 	// looks at the input JSON for "ChainOverrides" field
-	var inputObject TypedJSON
-	if err := json.Unmarshal([]byte(inputStr), &inputObject); err != nil {
+	inputObject, err := decodeTypedInput(inputStr)
+	if err != nil {
 		return nil, err
 	}
 
 	return inputObject.ChainOverrides, nil
 }
 
+// decodeTypedInput strictly decodes the input envelope, so that a misspelt field such as
+// "chainOverride" is rejected rather than ignored. The payload itself is decoded by decodePayload.
+// Errors include the JSON path of the offending value when it is known.
+func decodeTypedInput(inputStr string) (TypedJSON, error) {
+	var inputObject TypedJSON
+	if err := decodeStrict([]byte(inputStr), &inputObject); err != nil {
+		if path := locatePayloadError(json.RawMessage(inputStr), reflect.TypeFor[TypedJSON]()); path != "" {
+			return TypedJSON{}, fmt.Errorf("%w at %s", err, path)
+		}
+
+		return TypedJSON{}, err
+	}
+
+	return inputObject, nil
+}
+
 // WithConfigFrom takes a provider function which returns a config or an error, and stores the error (if any) in
 // the configured changeset. This is then used to abort execution with a cleaner message than a panic.
 // This allows for more robust error handling to happen for complex configs, factored into a function, without
@@ -348,6 +348,53 @@ func TestInputChainOverrides_WithJSON_ChainOverridesField(t *testing.T) {
 	assert.Equal(t, config.ChainOverrides, configs.InputChainOverrides)
 }
 
+func TestInputChainOverrides_StrictEnvelope(t *testing.T) {
+	t.Parallel()
+
+	cs := deployment.CreateChangeSet(
+		func(e deployment.Environment, c TestConfig) (deployment.ChangesetOutput, error) {
+			return deployment.ChangesetOutput{}, nil
+		},
+		func(e deployment.Environment, c TestConfig) error { return nil },
+	)
+
+	tests := []struct {
+		name      string
+		inputJSON string
+		errorMsg  string
+	}{
+		{
+			name:      "misspelt chain overrides",
+			inputJSON: `{"payload":{},"chainOverride":[1]}`,
+			errorMsg:  `json: unknown field "chainOverride" at $.chainOverride`,
+		},
+		{
+			name:      "invalid chain override",
+			inputJSON: `{"payload":{},"chainOverrides":[1,"2"]}`,
+			errorMsg:  "at $.chainOverrides[1]",
+		},
+		{
+			name:      "trailing data",
+			inputJSON: `{"payload":{}} {}`,
+			errorMsg:  "unexpected data after the JSON value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configured := Configure(cs).WithJSON(TestConfig{}, tt.inputJSON)
+
+			_, err := configured.Configurations()
+			require.ErrorContains(t, err, tt.errorMsg)
+
+			_, err = configured.Apply(deployment.Environment{Logger: logger.Test(t)})
+			require.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
 func TestWithConfigResolver_Success(t *testing.T) {
 	type TestConfigType struct {
 		Value string
@@ -523,7 +570,7 @@ func TestWithJSON_MissingPayload(t *testing.T) {
 		func(e deployment.Environment, config TestConfig) error { return nil },
 	)
 	env := deployment.Environment{Logger: logger.Test(t)}
-	configured := Configure(cs).WithJSON(TestConfig{}, `{"notPayload":"value"}`)
+	configured := Configure(cs).WithJSON(TestConfig{}, `{"chainOverrides":[1]}`)
 
 	_, err := configured.Apply(env)
 	require.Error(t, err)
@@ -560,7 +607,7 @@ func TestWithEnvInput_MissingPayload(t *testing.T) {
 		Value string
 	}
 
-	t.Setenv("DURABLE_PIPELINE_INPUT", `{"notPayload":"value"}`)
+	t.Setenv("DURABLE_PIPELINE_INPUT", `{"chainOverrides":[1]}`)
 
 	cs := deployment.CreateChangeSet(
 		func(e deployment.Environment, config TestConfig) (deployment.ChangesetOutput, error) {
@@ -583,7 +630,7 @@ func TestWithConfigResolver_MissingPayload(t *testing.T) {
 	}
 	manager.Register(resolver, resolvers.ResolverInfo{Description: "Test"})
 
-	t.Setenv("DURABLE_PIPELINE_INPUT", `{"notPayload":"value"}`)
+	t.Setenv("DURABLE_PIPELINE_INPUT", `{"chainOverrides":[1]}`)
 
 	cs := deployment.CreateChangeSet(
 		func(e deployment.Environment, config string) (deployment.ChangesetOutput, error) {
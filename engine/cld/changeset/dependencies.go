@@ -0,0 +1,204 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+var (
+	// ErrPrerequisiteNotApplied is returned when a prerequisite of a changeset has no artifacts in
+	// the environment.
+	ErrPrerequisiteNotApplied = errors.New("prerequisite changeset has not been applied")
+
+	// ErrUnknownPrerequisite is returned when a changeset declares a prerequisite which is not
+	// registered.
+	ErrUnknownPrerequisite = errors.New("prerequisite changeset is not registered")
+
+	// ErrDependencyCycle is returned when the prerequisites of the registered changesets form a
+	// cycle.
+	ErrDependencyCycle = errors.New("changeset dependency cycle")
+
+	// ErrDataStorePreconditionFailed is returned when a datastore precondition of a changeset is
+	// not met.
+	ErrDataStorePreconditionFailed = errors.New("datastore precondition failed")
+)
+
+// DataStorePrecondition is a named check which the environment datastore must pass before the
+// changeset is applied. For example, a precondition can require that a contract has already been
+// deployed on a chain.
+type DataStorePrecondition struct {
+	Name  string
+	Check func(ds fdatastore.DataStore) error
+}
+
+// ArtifactsChecker reports whether a changeset has artifacts in the environment, which means it
+// has been applied. It is implemented by domain.ArtifactsDir.
+type ArtifactsChecker interface {
+	ChangesetArtifactsExist(csKey string) (bool, error)
+}
+
+// WithPrerequisites declares the keys of the changesets which must have been applied in the
+// environment before this changeset. Calling it more than once appends to the prerequisites.
+func WithPrerequisites(keys ...string) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.Prerequisites = append(o.Prerequisites, keys...)
+	}
+}
+
+// WithDataStorePrecondition declares a check which the environment datastore must pass before the
+// changeset is applied. Calling it more than once appends to the preconditions.
+func WithDataStorePrecondition(name string, check func(ds fdatastore.DataStore) error) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.DataStorePreconditions = append(o.DataStorePreconditions, DataStorePrecondition{
+			Name:  name,
+			Check: check,
+		})
+	}
+}
+
+// WithArtifactsChecker enables checking that the prerequisites of the changeset have artifacts in
+// the environment before it is applied. Without this option prerequisites are not checked on
+// Apply.
+func WithArtifactsChecker(checker ArtifactsChecker) ApplyOption {
+	return func(cfg *applyConfig) {
+		cfg.artifacts = checker
+	}
+}
+
+// CheckPrerequisites checks that every prerequisite of the changeset has artifacts in the
+// environment and that the datastore passes every datastore precondition of the changeset.
+//
+// The artifacts checker is optional. If it is nil, only the datastore preconditions are checked.
+func (r *ChangesetsRegistry) CheckPrerequisites(
+	key string, artifacts ArtifactsChecker, ds fdatastore.DataStore,
+) error {
+	r.mu.Lock()
+	entry, err := r.getApplyEntryLocked(key)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return checkPrerequisites(key, entry.options, artifacts, ds)
+}
+
+func checkPrerequisites(
+	key string, options ChangesetConfig, artifacts ArtifactsChecker, ds fdatastore.DataStore,
+) error {
+	if artifacts != nil {
+		for _, prereq := range options.Prerequisites {
+			exists, err := artifacts.ChangesetArtifactsExist(prereq)
+			if err != nil {
+				return fmt.Errorf("failed to check artifacts of prerequisite %q of changeset %q: %w", prereq, key, err)
+			}
+			if !exists {
+				return fmt.Errorf("%w: %q is required by %q", ErrPrerequisiteNotApplied, prereq, key)
+			}
+		}
+	}
+
+	if len(options.DataStorePreconditions) > 0 && ds == nil {
+		return fmt.Errorf("%w: changeset %q has datastore preconditions but the environment has no datastore",
+			ErrDataStorePreconditionFailed, key,
+		)
+	}
+
+	for _, pc := range options.DataStorePreconditions {
+		if err := pc.Check(ds); err != nil {
+			return fmt.Errorf("%w: %q of changeset %q: %w", ErrDataStorePreconditionFailed, pc.Name, key, err)
+		}
+	}
+
+	return nil
+}
+
+// DependencyGraph returns the declared prerequisites of every registered changeset, keyed by the
+// changeset key. Changesets without prerequisites map to an empty slice.
+func (r *ChangesetsRegistry) DependencyGraph() map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	graph := make(map[string][]string, len(r.entries))
+	for key, entry := range r.entries {
+		graph[key] = slices.Clone(entry.options.Prerequisites)
+		if graph[key] == nil {
+			graph[key] = []string{}
+		}
+	}
+
+	return graph
+}
+
+// RunPlan returns the changeset keys in the order they must be applied so that every changeset
+// runs after its prerequisites. If keys are given, the plan contains only those changesets and
+// their transitive prerequisites; otherwise it contains every registered changeset.
+//
+// Changesets that do not depend on each other keep the order in which they were added to the
+// registry. An error is returned if a key or prerequisite is not registered, or if the
+// prerequisites form a cycle.
+func (r *ChangesetsRegistry) RunPlan(keys ...string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roots := keys
+	if len(roots) == 0 {
+		roots = r.keyHistory
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		state = make(map[string]int, len(r.entries))
+		plan  = make([]string, 0, len(r.entries))
+		path  []string
+	)
+
+	var visit func(key string) error
+	visit = func(key string) error {
+		switch state[key] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(slices.Clone(path[slices.Index(path, key):]), key)
+			return fmt.Errorf("%w: %v", ErrDependencyCycle, cycle)
+		}
+
+		entry, ok := r.entries[key]
+		if !ok {
+			return fmt.Errorf("changeset '%s' not found", key)
+		}
+
+		state[key] = visiting
+		path = append(path, key)
+
+		for _, prereq := range entry.options.Prerequisites {
+			if _, ok := r.entries[prereq]; !ok {
+				return fmt.Errorf("%w: %q is required by %q", ErrUnknownPrerequisite, prereq, key)
+			}
+			if err := visit(prereq); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[key] = visited
+		plan = append(plan, key)
+
+		return nil
+	}
+
+	for _, key := range roots {
+		if err := visit(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
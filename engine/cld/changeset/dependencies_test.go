@@ -0,0 +1,259 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// fakeArtifactsChecker reports the changesets in applied as having artifacts.
+type fakeArtifactsChecker struct {
+	applied map[string]bool
+	err     error
+}
+
+func (f fakeArtifactsChecker) ChangesetArtifactsExist(csKey string) (bool, error) {
+	return f.applied[csKey], f.err
+}
+
+func Test_ChangesetOptions_Dependencies(t *testing.T) {
+	t.Parallel()
+
+	check := func(fdatastore.DataStore) error { return nil }
+
+	r := NewChangesetsRegistry()
+	r.Add("0002_configure_lanes", noopChangeset{},
+		WithPrerequisites("0001_deploy_ramps"),
+		WithPrerequisites("0001_deploy_tokens"),
+		WithDataStorePrecondition("ramps deployed", check),
+	)
+
+	opts, err := r.GetChangesetOptions("0002_configure_lanes")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0001_deploy_ramps", "0001_deploy_tokens"}, opts.Prerequisites)
+	require.Len(t, opts.DataStorePreconditions, 1)
+	assert.Equal(t, "ramps deployed", opts.DataStorePreconditions[0].Name)
+}
+
+func Test_Changesets_CheckPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	checkErr := errors.New("no ramps")
+
+	tests := []struct {
+		name      string
+		opts      []ChangesetOption
+		artifacts ArtifactsChecker
+		ds        fdatastore.DataStore
+		wantErr   error
+		wantMsg   string
+	}{
+		{
+			name:      "no dependencies",
+			artifacts: fakeArtifactsChecker{},
+		},
+		{
+			name:      "prerequisite applied",
+			opts:      []ChangesetOption{WithPrerequisites("0001_deploy")},
+			artifacts: fakeArtifactsChecker{applied: map[string]bool{"0001_deploy": true}},
+		},
+		{
+			name:      "prerequisite not applied",
+			opts:      []ChangesetOption{WithPrerequisites("0001_deploy")},
+			artifacts: fakeArtifactsChecker{},
+			wantErr:   ErrPrerequisiteNotApplied,
+			wantMsg:   `prerequisite changeset has not been applied: "0001_deploy" is required by "0002_configure"`,
+		},
+		{
+			name:      "artifacts check fails",
+			opts:      []ChangesetOption{WithPrerequisites("0001_deploy")},
+			artifacts: fakeArtifactsChecker{err: errors.New("permission denied")},
+			wantMsg:   `failed to check artifacts of prerequisite "0001_deploy" of changeset "0002_configure": permission denied`,
+		},
+		{
+			name: "prerequisites skipped without artifacts checker",
+			opts: []ChangesetOption{WithPrerequisites("0001_deploy")},
+		},
+		{
+			name: "precondition passes",
+			opts: []ChangesetOption{WithDataStorePrecondition("ramps", func(fdatastore.DataStore) error { return nil })},
+			ds:   fdatastore.NewMemoryDataStore().Seal(),
+		},
+		{
+			name:    "precondition fails",
+			opts:    []ChangesetOption{WithDataStorePrecondition("ramps", func(fdatastore.DataStore) error { return checkErr })},
+			ds:      fdatastore.NewMemoryDataStore().Seal(),
+			wantErr: checkErr,
+			wantMsg: `datastore precondition failed: "ramps" of changeset "0002_configure": no ramps`,
+		},
+		{
+			name:    "precondition without datastore",
+			opts:    []ChangesetOption{WithDataStorePrecondition("ramps", func(fdatastore.DataStore) error { return nil })},
+			wantErr: ErrDataStorePreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewChangesetsRegistry()
+			r.Add("0002_configure", noopChangeset{}, tt.opts...)
+
+			err := r.CheckPrerequisites("0002_configure", tt.artifacts, tt.ds)
+			if tt.wantErr == nil && tt.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantMsg != "" {
+				require.EqualError(t, err, tt.wantMsg)
+			}
+		})
+	}
+
+	t.Run("unknown changeset", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewChangesetsRegistry().CheckPrerequisites("missing", nil, nil)
+		require.EqualError(t, err, "changeset 'missing' not found")
+	})
+}
+
+func Test_Apply_PrerequisiteNotApplied_BlocksChangeset(t *testing.T) {
+	t.Parallel()
+
+	cs := &recordingChangeset{}
+	hookCalled := false
+
+	r := NewChangesetsRegistry()
+	r.AddGlobalPreHooks(PreHook{
+		HookDefinition: HookDefinition{Name: "pre", FailurePolicy: Abort},
+		Func: func(_ context.Context, _ PreHookParams) error {
+			hookCalled = true
+			return nil
+		},
+	})
+	r.Add("0002_configure", cs, WithPrerequisites("0001_deploy"))
+
+	env := hookTestEnv(t)
+
+	_, err := r.Apply("0002_configure", env, WithArtifactsChecker(fakeArtifactsChecker{}))
+	require.ErrorIs(t, err, ErrPrerequisiteNotApplied)
+	assert.False(t, cs.applyCalled)
+	assert.False(t, hookCalled)
+
+	_, err = r.Apply("0002_configure", env,
+		WithArtifactsChecker(fakeArtifactsChecker{applied: map[string]bool{"0001_deploy": true}}),
+	)
+	require.NoError(t, err)
+	assert.True(t, cs.applyCalled)
+	assert.True(t, hookCalled)
+}
+
+func Test_Changesets_DependencyGraph(t *testing.T) {
+	t.Parallel()
+
+	r := NewChangesetsRegistry()
+	r.Add("0001_deploy", noopChangeset{})
+	r.Add("0002_configure", noopChangeset{}, WithPrerequisites("0001_deploy"))
+
+	assert.Equal(t, map[string][]string{
+		"0001_deploy":    {},
+		"0002_configure": {"0001_deploy"},
+	}, r.DependencyGraph())
+}
+
+func Test_Changesets_RunPlan(t *testing.T) {
+	t.Parallel()
+
+	newRegistry := func() *ChangesetsRegistry {
+		r := NewChangesetsRegistry()
+		r.Add("configure-lanes", noopChangeset{}, WithPrerequisites("deploy-ramps", "deploy-tokens"))
+		r.Add("deploy-tokens", noopChangeset{})
+		r.Add("deploy-ramps", noopChangeset{}, WithPrerequisites("deploy-tokens"))
+		r.Add("unrelated", noopChangeset{})
+
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		registry func() *ChangesetsRegistry
+		keys     []string
+		want     []string
+		wantErr  error
+		wantMsg  string
+	}{
+		{
+			name:     "all changesets",
+			registry: newRegistry,
+			want:     []string{"deploy-tokens", "deploy-ramps", "configure-lanes", "unrelated"},
+		},
+		{
+			name:     "selected changeset and its prerequisites",
+			registry: newRegistry,
+			keys:     []string{"deploy-ramps"},
+			want:     []string{"deploy-tokens", "deploy-ramps"},
+		},
+		{
+			name:     "unknown changeset",
+			registry: newRegistry,
+			keys:     []string{"missing"},
+			wantMsg:  "changeset 'missing' not found",
+		},
+		{
+			name: "unknown prerequisite",
+			registry: func() *ChangesetsRegistry {
+				r := NewChangesetsRegistry()
+				r.Add("configure-lanes", noopChangeset{}, WithPrerequisites("deploy-ramps"))
+
+				return r
+			},
+			wantErr: ErrUnknownPrerequisite,
+			wantMsg: `prerequisite changeset is not registered: "deploy-ramps" is required by "configure-lanes"`,
+		},
+		{
+			name: "cycle",
+			registry: func() *ChangesetsRegistry {
+				r := NewChangesetsRegistry()
+				r.Add("a", noopChangeset{}, WithPrerequisites("b"))
+				r.Add("b", noopChangeset{}, WithPrerequisites("c"))
+				r.Add("c", noopChangeset{}, WithPrerequisites("b"))
+
+				return r
+			},
+			wantErr: ErrDependencyCycle,
+			wantMsg: "changeset dependency cycle: [b c b]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.registry().RunPlan(tt.keys...)
+			if tt.wantErr == nil && tt.wantMsg == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+
+				return
+			}
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantMsg != "" {
+				require.EqualError(t, err, tt.wantMsg)
+			}
+		})
+	}
+}
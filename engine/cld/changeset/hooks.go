@@ -8,8 +8,10 @@ import (
 	"github.com/smartcontractkit/mcms"
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
 	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain"
 	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
 )
 
@@ -45,7 +47,10 @@ type HookEnv struct {
 	Logger      logger.Logger
 	BlockChains chain.BlockChains
 	DataStore   datastore.DataStore
-	// TODO: read-only Offchain and CRE clients
+	// Offchain is a read-only job-distributor client, nil if the environment has none.
+	Offchain offchain.ReadOnlyClient
+	// CRE is a read-only view of the CRE runner, nil if the environment has none.
+	CRE cre.ReadOnlyView
 }
 
 // ProposalHookEnv is the restricted environment surface exposed to proposal hooks.
@@ -56,7 +61,10 @@ type ProposalHookEnv struct {
 	BlockChains chain.BlockChains
 	DataStore   datastore.DataStore
 	ForkContext ForkContext
-	// TODO: read-only Offchain and CRE clients
+	// Offchain is a read-only job-distributor client, nil if the environment has none.
+	Offchain offchain.ReadOnlyClient
+	// CRE is a read-only view of the CRE runner, nil if the environment has none.
+	CRE cre.ReadOnlyView
 }
 
 // PreHookParams is passed to pre-hooks.
@@ -86,6 +94,9 @@ type PostProposalHookParams struct {
 	Config       any
 	Reports      []MCMSTimelockExecuteReport
 	Err          string
+	// HookRecords are the records of the hooks run when the changeset was applied, if they were
+	// passed to RunProposalHooks with WithHookRecords.
+	HookRecords []fdeployment.HookRecord
 
 	// Deprecated: use `Config` instead. Will be removed in a future version.
 	Input any
@@ -108,6 +119,14 @@ type HookDefinition struct {
 	Name          string
 	FailurePolicy FailurePolicy
 	Timeout       time.Duration // zero means DefaultHookTimeout (30s)
+
+	// Parallel is the name of the parallel group of the hook. Consecutive hooks of the same group
+	// run concurrently, once all hooks before the group have completed. Hooks without a group run
+	// alone, in order.
+	Parallel string
+	// After lists the names of the hooks which must complete before this hook starts. They must be
+	// earlier hooks or hooks of the same parallel group. The hook is skipped if any of them fails.
+	After []string
 }
 
 // PreHook pairs a HookDefinition with a PreHookFunc.
@@ -139,6 +158,21 @@ func ExecuteHook(
 	def HookDefinition,
 	fn func(ctx context.Context) error,
 ) error {
+	_, err := executeHook(env, def, fn)
+	if err != nil && def.FailurePolicy == Warn {
+		return nil
+	}
+
+	return err
+}
+
+// executeHook runs a hook function like ExecuteHook, but returns the duration of the hook and its
+// error regardless of the FailurePolicy.
+func executeHook(
+	env fdeployment.Environment,
+	def HookDefinition,
+	fn func(ctx context.Context) error,
+) (time.Duration, error) {
 	timeout := def.Timeout
 	if timeout == 0 {
 		timeout = DefaultHookTimeout
@@ -165,9 +199,5 @@ func ExecuteHook(
 		)
 	}
 
-	if err != nil && def.FailurePolicy == Warn {
-		return nil
-	}
-
-	return err
+	return duration, err
 }
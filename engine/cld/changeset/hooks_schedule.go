@@ -0,0 +1,253 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// hookTask is a hook scheduled by runHooks.
+type hookTask struct {
+	HookDefinition
+
+	// kind describes the hook in errors and records, e.g. "global pre-hook".
+	kind  string
+	phase fdeployment.HookPhase
+	run   func(ctx context.Context) error
+}
+
+func (h PreHook) task(kind string, params PreHookParams) hookTask {
+	return hookTask{
+		HookDefinition: h.HookDefinition,
+		kind:           kind,
+		phase:          fdeployment.HookPhasePre,
+		run:            func(ctx context.Context) error { return h.Func(ctx, params) },
+	}
+}
+
+func (h PostHook) task(kind string, params PostHookParams) hookTask {
+	return hookTask{
+		HookDefinition: h.HookDefinition,
+		kind:           kind,
+		phase:          fdeployment.HookPhasePost,
+		run:            func(ctx context.Context) error { return h.Func(ctx, params) },
+	}
+}
+
+func (h PostProposalHook) task(kind string, params PostProposalHookParams) hookTask {
+	return hookTask{
+		HookDefinition: h.HookDefinition,
+		kind:           kind,
+		phase:          fdeployment.HookPhasePostProposal,
+		run:            func(ctx context.Context) error { return h.Func(ctx, params) },
+	}
+}
+
+// hookStages groups the tasks into stages which run in order: consecutive tasks of the same
+// parallel group form one stage, and every other task forms its own stage.
+func hookStages(tasks []hookTask) [][]hookTask {
+	var stages [][]hookTask
+	for _, t := range tasks {
+		last := len(stages) - 1
+		if t.Parallel != "" && last >= 0 && stages[last][0].Parallel == t.Parallel {
+			stages[last] = append(stages[last], t)
+			continue
+		}
+		stages = append(stages, []hookTask{t})
+	}
+
+	return stages
+}
+
+// validateHookStages checks that every After dependency names an earlier hook or a hook of the
+// same stage, and that the dependencies within a stage have no cycle.
+func validateHookStages(stages [][]hookTask) error {
+	earlier := make(map[string]bool)
+	for _, stage := range stages {
+		inStage := make(map[string][]int)
+		for i, t := range stage {
+			inStage[t.Name] = append(inStage[t.Name], i)
+		}
+
+		// Kahn's algorithm over the dependencies within the stage
+		pending := make([]int, len(stage))
+		dependents := make([][]int, len(stage))
+		for i, t := range stage {
+			for _, dep := range t.After {
+				if deps, ok := inStage[dep]; ok {
+					for _, d := range deps {
+						if d == i {
+							return fmt.Errorf("%s %q depends on itself", t.kind, t.Name)
+						}
+						pending[i]++
+						dependents[d] = append(dependents[d], i)
+					}
+
+					continue
+				}
+				if !earlier[dep] {
+					return fmt.Errorf("%s %q depends on %q, which is not an earlier hook or a hook of its parallel group",
+						t.kind, t.Name, dep,
+					)
+				}
+			}
+		}
+
+		var ready []int
+		for i := range stage {
+			if pending[i] == 0 {
+				ready = append(ready, i)
+			}
+		}
+		sorted := 0
+		for len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			sorted++
+			for _, d := range dependents[i] {
+				pending[d]--
+				if pending[d] == 0 {
+					ready = append(ready, d)
+				}
+			}
+		}
+		if sorted != len(stage) {
+			return fmt.Errorf("hooks of parallel group %q have a dependency cycle", stage[0].Parallel)
+		}
+
+		for _, t := range stage {
+			earlier[t.Name] = true
+		}
+	}
+
+	return nil
+}
+
+// runHooks runs the tasks stage by stage, running the tasks of a stage concurrently while
+// respecting their After dependencies. It returns a record of every task, in task order, and the
+// errors of all failed tasks joined.
+//
+// A task fails if ExecuteHook returns an error, so tasks with the Warn failure policy never fail.
+// A failed task does not stop the stages after it, so that every hook failure is reported at
+// once. Only the tasks which depend on a failed task, in its stage or a later one, are skipped and
+// reported as failed.
+func runHooks(e fdeployment.Environment, tasks []hookTask) ([]fdeployment.HookRecord, error) {
+	stages := hookStages(tasks)
+	if err := validateHookStages(stages); err != nil {
+		return nil, err
+	}
+
+	var (
+		records = make([]fdeployment.HookRecord, 0, len(tasks))
+		errs    []error
+		failed  = make(map[string]bool)
+	)
+	for _, stage := range stages {
+		stageRecords, stageErrs := runHookStage(e, stage, failed)
+		records = append(records, stageRecords...)
+		errs = append(errs, stageErrs...)
+	}
+
+	return records, errors.Join(errs...)
+}
+
+// runHookStage runs the tasks of a stage concurrently, starting each task once its dependencies
+// within the stage have completed. It records the names of failed tasks in failed, which also
+// holds the failed tasks of earlier stages, and returns the records of the tasks and the errors
+// of the failed tasks, in task order.
+func runHookStage(
+	e fdeployment.Environment, stage []hookTask, failed map[string]bool,
+) ([]fdeployment.HookRecord, []error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		records = make([]fdeployment.HookRecord, len(stage))
+		errs    = make([]error, len(stage))
+		done    = make(map[string][]chan struct{}, len(stage))
+	)
+	doneChs := make([]chan struct{}, len(stage))
+	for i, t := range stage {
+		doneChs[i] = make(chan struct{})
+		done[t.Name] = append(done[t.Name], doneChs[i])
+	}
+
+	for i, t := range stage {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(doneChs[i])
+
+			for _, dep := range t.After {
+				for _, ch := range done[dep] {
+					<-ch
+				}
+			}
+
+			mu.Lock()
+			var failedDep string
+			for _, dep := range t.After {
+				if failed[dep] {
+					failedDep = dep
+					break
+				}
+			}
+			mu.Unlock()
+
+			var err error
+			record := t.record()
+			if failedDep != "" {
+				err = fmt.Errorf("%s %q skipped: dependency %q failed", t.kind, t.Name, failedDep)
+				record.Status = fdeployment.HookStatusSkipped
+				record.Error = fmt.Sprintf("dependency %q failed", failedDep)
+				e.Logger.Warnw("hook skipped", "hook", t.Name, "dependency", failedDep)
+			} else {
+				record.StartedAt = time.Now()
+				duration, hookErr := executeHook(e, t.HookDefinition, t.run)
+				record.Duration = duration
+				switch {
+				case hookErr == nil:
+					record.Status = fdeployment.HookStatusPassed
+				case t.FailurePolicy == Warn:
+					record.Status = fdeployment.HookStatusWarned
+					record.Error = hookErr.Error()
+				default:
+					record.Status = fdeployment.HookStatusFailed
+					record.Error = hookErr.Error()
+					err = fmt.Errorf("%s %q failed: %w", t.kind, t.Name, hookErr)
+				}
+			}
+
+			mu.Lock()
+			records[i] = record
+			if err != nil {
+				failed[t.Name] = true
+				errs[i] = err
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	var stageErrs []error
+	for _, err := range errs {
+		if err != nil {
+			stageErrs = append(stageErrs, err)
+		}
+	}
+
+	return records, stageErrs
+}
+
+// record returns the record of the task, without its outcome.
+func (t hookTask) record() fdeployment.HookRecord {
+	return fdeployment.HookRecord{
+		Name:          t.Name,
+		Phase:         t.phase,
+		Kind:          t.kind,
+		FailurePolicy: t.FailurePolicy.String(),
+	}
+}
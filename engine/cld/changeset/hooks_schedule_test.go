@@ -0,0 +1,285 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// recordingTask returns a task which records its name in order when it runs and returns err.
+func recordingTask(def HookDefinition, mu *sync.Mutex, order *[]string, err error) hookTask {
+	return hookTask{
+		HookDefinition: def,
+		kind:           "test hook",
+		run: func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			*order = append(*order, def.Name)
+
+			return err
+		},
+	}
+}
+
+func TestHookStages(t *testing.T) {
+	t.Parallel()
+
+	tasks := []hookTask{
+		{HookDefinition: HookDefinition{Name: "a"}},
+		{HookDefinition: HookDefinition{Name: "b", Parallel: "notify"}},
+		{HookDefinition: HookDefinition{Name: "c", Parallel: "notify"}},
+		{HookDefinition: HookDefinition{Name: "d"}},
+		{HookDefinition: HookDefinition{Name: "e", Parallel: "notify"}},
+		{HookDefinition: HookDefinition{Name: "f", Parallel: "checks"}},
+	}
+
+	var names [][]string
+	for _, stage := range hookStages(tasks) {
+		var stageNames []string
+		for _, task := range stage {
+			stageNames = append(stageNames, task.Name)
+		}
+		names = append(names, stageNames)
+	}
+
+	assert.Equal(t, [][]string{{"a"}, {"b", "c"}, {"d"}, {"e"}, {"f"}}, names)
+}
+
+func TestValidateHookStages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		defs    []HookDefinition
+		wantErr string
+	}{
+		{
+			name: "earlier hook and hook of the same group",
+			defs: []HookDefinition{
+				{Name: "a"},
+				{Name: "b", Parallel: "g", After: []string{"a"}},
+				{Name: "c", Parallel: "g", After: []string{"b"}},
+			},
+		},
+		{
+			name: "unknown hook",
+			defs: []HookDefinition{
+				{Name: "a", After: []string{"missing"}},
+			},
+			wantErr: `test hook "a" depends on "missing", which is not an earlier hook or a hook of its parallel group`,
+		},
+		{
+			name: "later hook",
+			defs: []HookDefinition{
+				{Name: "a", After: []string{"b"}},
+				{Name: "b"},
+			},
+			wantErr: `test hook "a" depends on "b", which is not an earlier hook or a hook of its parallel group`,
+		},
+		{
+			name: "itself",
+			defs: []HookDefinition{
+				{Name: "a", After: []string{"a"}},
+			},
+			wantErr: `test hook "a" depends on itself`,
+		},
+		{
+			name: "cycle",
+			defs: []HookDefinition{
+				{Name: "a", Parallel: "g", After: []string{"b"}},
+				{Name: "b", Parallel: "g", After: []string{"a"}},
+			},
+			wantErr: `hooks of parallel group "g" have a dependency cycle`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tasks := make([]hookTask, 0, len(tt.defs))
+			for _, def := range tt.defs {
+				tasks = append(tasks, hookTask{HookDefinition: def, kind: "test hook"})
+			}
+
+			err := validateHookStages(hookStages(tasks))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRunHooks_ParallelGroupRunsConcurrently(t *testing.T) {
+	t.Parallel()
+
+	// Each hook waits for the other to start, which only completes if they run concurrently
+	started := map[string]chan struct{}{"a": make(chan struct{}), "b": make(chan struct{})}
+	waitFor := func(self, other string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			close(started[self])
+			select {
+			case <-started[other]:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	tasks := []hookTask{
+		{HookDefinition: HookDefinition{Name: "a", Parallel: "g", Timeout: 5 * time.Second}, kind: "test hook", run: waitFor("a", "b")},
+		{HookDefinition: HookDefinition{Name: "b", Parallel: "g", Timeout: 5 * time.Second}, kind: "test hook", run: waitFor("b", "a")},
+	}
+
+	_, err := runHooks(hookTestEnv(t), tasks)
+	require.NoError(t, err)
+}
+
+func TestRunHooks_AfterOrdersHooksOfGroup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	tasks := []hookTask{
+		recordingTask(HookDefinition{Name: "last", Parallel: "g", After: []string{"middle"}}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "middle", Parallel: "g", After: []string{"first"}}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "first", Parallel: "g"}, &mu, &order, nil),
+	}
+
+	_, err := runHooks(hookTestEnv(t), tasks)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "middle", "last"}, order)
+}
+
+func TestRunHooks_AggregatesErrors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	tasks := []hookTask{
+		recordingTask(HookDefinition{Name: "a", Parallel: "g", FailurePolicy: Abort}, &mu, &order, errA),
+		recordingTask(HookDefinition{Name: "b", Parallel: "g", FailurePolicy: Abort}, &mu, &order, errB),
+		recordingTask(HookDefinition{Name: "warn", Parallel: "g", FailurePolicy: Warn}, &mu, &order, errors.New("ignored")),
+		recordingTask(HookDefinition{Name: "after-a", Parallel: "g", After: []string{"a"}}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "after-warn", Parallel: "g", After: []string{"warn"}}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "next-stage"}, &mu, &order, nil),
+	}
+
+	records, err := runHooks(hookTestEnv(t), tasks)
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errB)
+	require.EqualError(t, err, `test hook "a" failed: a failed
+test hook "b" failed: b failed
+test hook "after-a" skipped: dependency "a" failed`)
+
+	// Warn failures do not skip their dependents, and later stages still run after a failure
+	assert.ElementsMatch(t, []string{"a", "b", "warn", "after-warn", "next-stage"}, order)
+
+	// Every hook is recorded in task order, including those which did not run
+	require.Len(t, records, len(tasks))
+	statuses := make(map[string]fdeployment.HookStatus)
+	for i, r := range records {
+		assert.Equal(t, tasks[i].Name, r.Name)
+		assert.Equal(t, "test hook", r.Kind)
+		statuses[r.Name] = r.Status
+	}
+	assert.Equal(t, map[string]fdeployment.HookStatus{
+		"a":          fdeployment.HookStatusFailed,
+		"b":          fdeployment.HookStatusFailed,
+		"warn":       fdeployment.HookStatusWarned,
+		"after-a":    fdeployment.HookStatusSkipped,
+		"after-warn": fdeployment.HookStatusPassed,
+		"next-stage": fdeployment.HookStatusPassed,
+	}, statuses)
+	assert.Equal(t, "a failed", records[0].Error)
+	assert.Equal(t, "Abort", records[0].FailurePolicy)
+	assert.Equal(t, "ignored", records[2].Error)
+	assert.Equal(t, "Warn", records[2].FailurePolicy)
+	assert.False(t, records[2].StartedAt.IsZero())
+	assert.Equal(t, `dependency "a" failed`, records[3].Error)
+	assert.True(t, records[3].StartedAt.IsZero())
+	assert.Empty(t, records[5].Error)
+}
+
+func TestRunHooks_RunsStagesAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	errA := errors.New("a failed")
+	errC := errors.New("c failed")
+	tasks := []hookTask{
+		recordingTask(HookDefinition{Name: "a"}, &mu, &order, errA),
+		recordingTask(HookDefinition{Name: "b"}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "after-a", After: []string{"a"}}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "c", FailurePolicy: Abort}, &mu, &order, errC),
+	}
+
+	_, err := runHooks(hookTestEnv(t), tasks)
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errC)
+	require.ErrorContains(t, err, `test hook "after-a" skipped: dependency "a" failed`)
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+}
+
+func TestRunHooks_InvalidDependencyRunsNoHook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	tasks := []hookTask{
+		recordingTask(HookDefinition{Name: "a"}, &mu, &order, nil),
+		recordingTask(HookDefinition{Name: "b", After: []string{"missing"}}, &mu, &order, nil),
+	}
+
+	records, err := runHooks(hookTestEnv(t), tasks)
+	require.ErrorContains(t, err, `depends on "missing"`)
+	assert.Empty(t, records)
+	assert.Empty(t, order)
+}
+
+func TestApply_ParallelPreHooksAggregateErrors(t *testing.T) {
+	t.Parallel()
+
+	cs := &recordingChangeset{}
+	failing := func(name string) PreHook {
+		return PreHook{
+			HookDefinition: HookDefinition{Name: name, FailurePolicy: Abort, Parallel: "checks"},
+			Func: func(context.Context, PreHookParams) error {
+				return errors.New(name + " blocked")
+			},
+		}
+	}
+
+	r := NewChangesetsRegistry()
+	r.AddGlobalPreHooks(failing("global-check"))
+	r.entries["test-cs"] = registryEntry{
+		changeset: cs,
+		preHooks:  []PreHook{failing("changeset-check")},
+	}
+
+	_, err := r.Apply("test-cs", hookTestEnv(t))
+	require.ErrorContains(t, err, `global pre-hook "global-check" failed: global-check blocked`)
+	require.ErrorContains(t, err, `changeset pre-hook "changeset-check" failed: changeset-check blocked`)
+	assert.False(t, cs.applyCalled)
+}
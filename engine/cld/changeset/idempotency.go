@@ -0,0 +1,115 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// ErrAlreadyApplied is returned by ChangesetsRegistry.Apply when the changeset implements
+// deployment.Idempotent and every address ref it would create already exists in the datastore.
+var ErrAlreadyApplied = errors.New("changeset already applied")
+
+// IdempotencyMode is what ChangesetsRegistry.Apply does when every address ref an idempotent
+// changeset would create already exists in the datastore.
+type IdempotencyMode int
+
+const (
+	// IdempotencySkip skips the changeset, and Apply returns ErrAlreadyApplied. This is the
+	// default.
+	IdempotencySkip IdempotencyMode = iota
+	// IdempotencyWarn logs a warning and applies the changeset anyway.
+	IdempotencyWarn
+)
+
+// WithIdempotencyMode sets what Apply does when every address ref the changeset would create
+// already exists in the datastore. It only has an effect on changesets which implement
+// deployment.Idempotent.
+func WithIdempotencyMode(mode IdempotencyMode) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.IdempotencyMode = mode
+	}
+}
+
+// WithForce applies the changeset even if every address ref it would create already exists in the
+// datastore.
+func WithForce() ApplyOption {
+	return func(cfg *applyConfig) {
+		cfg.force = true
+	}
+}
+
+// idempotencyChecker is implemented by changeset types which can describe the address refs their
+// changeset would create, if it implements deployment.Idempotent.
+type idempotencyChecker interface {
+	expectedAddressRefs(env fdeployment.Environment, input any) ([]fdatastore.AddressRefKey, bool, error)
+}
+
+func (ccs ChangeSetImpl[C]) expectedAddressRefs(
+	env fdeployment.Environment, input any,
+) ([]fdatastore.AddressRefKey, bool, error) {
+	idempotent, ok := ccs.changeset.operation.(fdeployment.Idempotent[C])
+	if !ok {
+		return nil, false, nil
+	}
+
+	config, ok := input.(C)
+	if !ok {
+		return nil, false, fmt.Errorf("invalid input type: expected %T but got %T", *new(C), input)
+	}
+
+	refs, err := idempotent.ExpectedAddressRefs(env, config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return refs, true, nil
+}
+
+func (ccs PostProcessingChangeSetImpl[C]) expectedAddressRefs(
+	env fdeployment.Environment, input any,
+) ([]fdatastore.AddressRefKey, bool, error) {
+	return ccs.changeset.expectedAddressRefs(env, input)
+}
+
+// checkIdempotency returns ErrAlreadyApplied if the changeset is idempotent and every address ref
+// it would create with the input already exists in the datastore of the environment, unless the
+// changeset is configured to only warn about it.
+func checkIdempotency(key string, e fdeployment.Environment, input any, entry registryEntry) error {
+	checker, ok := entry.changeset.(idempotencyChecker)
+	if !ok || e.DataStore == nil {
+		return nil
+	}
+
+	refs, ok, err := checker.expectedAddressRefs(e, input)
+	if err != nil {
+		return fmt.Errorf("failed to get the address refs changeset %q would create: %w", key, err)
+	}
+	if !ok || len(refs) == 0 {
+		return nil
+	}
+
+	missing, err := fdeployment.MissingAddressRefs(e.DataStore, refs)
+	if err != nil {
+		return fmt.Errorf("failed to check the address refs changeset %q would create: %w", key, err)
+	}
+	if len(missing) > 0 {
+		return nil
+	}
+
+	if entry.options.IdempotencyMode == IdempotencyWarn {
+		if e.Logger != nil {
+			e.Logger.Warnw("All address refs the changeset would create already exist, applying it again",
+				"changeset", key, "addressRefs", len(refs),
+			)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: all %d address refs changeset %q would create already exist in the datastore, force the apply to run it again",
+		ErrAlreadyApplied, len(refs), key,
+	)
+}
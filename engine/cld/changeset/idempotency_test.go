@@ -0,0 +1,203 @@
+package changeset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+// newIdempotentChangeset returns an idempotent changeset which would create a Router on each
+// chain of its config, and a pointer to the number of times it has been applied.
+func newIdempotentChangeset(expectedErr error) (fdeployment.ChangeSetV2[[]uint64], *int) {
+	applied := 0
+	cs := fdeployment.WithExpectedAddressRefs(
+		fdeployment.CreateChangeSet(
+			func(fdeployment.Environment, []uint64) (fdeployment.ChangesetOutput, error) {
+				applied++
+				return fdeployment.ChangesetOutput{}, nil
+			},
+			func(fdeployment.Environment, []uint64) error { return nil },
+		),
+		func(_ fdeployment.Environment, selectors []uint64) ([]fdatastore.AddressRefKey, error) {
+			if expectedErr != nil {
+				return nil, expectedErr
+			}
+
+			refs := make([]fdatastore.AddressRefKey, 0, len(selectors))
+			for _, selector := range selectors {
+				refs = append(refs, fdatastore.NewAddressRefKey(selector, "Router", semver.MustParse("1.0.0"), ""))
+			}
+
+			return refs, nil
+		},
+	)
+
+	return cs, &applied
+}
+
+// idempotencyTestDataStore returns a datastore with a Router deployed on chain 1.
+func idempotencyTestDataStore(t *testing.T) fdatastore.DataStore {
+	t.Helper()
+
+	ds := fdatastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(fdatastore.AddressRef{
+		Address: "0x1", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+
+	return ds.Seal()
+}
+
+func Test_Apply_Idempotency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      []uint64
+		expectedErr error
+		mode        IdempotencyMode
+		applyOpts   []ApplyOption
+		noDataStore bool
+		postProcess bool
+		wantApplied bool
+		wantErr     string
+	}{
+		{
+			name:    "all refs exist skips the changeset",
+			config:  []uint64{1},
+			wantErr: `changeset already applied: all 1 address refs changeset "0001_cs" would create already exist in the datastore`,
+		},
+		{
+			name:        "post-processed changesets are checked",
+			config:      []uint64{1},
+			postProcess: true,
+			wantErr:     "changeset already applied",
+		},
+		{
+			name:        "some refs are missing",
+			config:      []uint64{1, 2},
+			wantApplied: true,
+		},
+		{
+			name:        "no expected refs",
+			config:      []uint64{},
+			wantApplied: true,
+		},
+		{
+			name:        "forced",
+			config:      []uint64{1},
+			applyOpts:   []ApplyOption{WithForce()},
+			wantApplied: true,
+		},
+		{
+			name:        "warn mode",
+			config:      []uint64{1},
+			mode:        IdempotencyWarn,
+			wantApplied: true,
+		},
+		{
+			name:        "no datastore",
+			config:      []uint64{1},
+			noDataStore: true,
+			wantApplied: true,
+		},
+		{
+			name:        "expected refs fail",
+			config:      []uint64{1},
+			expectedErr: errors.New("boom"),
+			wantErr:     `failed to get the address refs changeset "0001_cs" would create: boom`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cs, applied := newIdempotentChangeset(tt.expectedErr)
+
+			var configured ChangeSet = Configure(cs).With(tt.config)
+			if tt.postProcess {
+				configured = Configure(cs).With(tt.config).ThenWith(
+					func(_ fdeployment.Environment, out fdeployment.ChangesetOutput) (fdeployment.ChangesetOutput, error) {
+						return out, nil
+					},
+				)
+			}
+
+			r := NewChangesetsRegistry()
+			r.Add("0001_cs", configured, WithIdempotencyMode(tt.mode))
+
+			env := hookTestEnv(t)
+			if !tt.noDataStore {
+				env.DataStore = idempotencyTestDataStore(t)
+			}
+
+			_, err := r.Apply("0001_cs", env, tt.applyOpts...)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantApplied, *applied == 1)
+		})
+	}
+}
+
+func Test_Apply_Idempotency_ErrAlreadyApplied(t *testing.T) {
+	t.Parallel()
+
+	cs, _ := newIdempotentChangeset(nil)
+
+	r := NewChangesetsRegistry()
+	r.Add("0001_cs", Configure(cs).With([]uint64{1}))
+
+	env := hookTestEnv(t)
+	env.DataStore = idempotencyTestDataStore(t)
+
+	_, err := r.Apply("0001_cs", env)
+	require.ErrorIs(t, err, ErrAlreadyApplied)
+}
+
+func Test_Apply_Idempotency_WarnLogs(t *testing.T) {
+	t.Parallel()
+
+	cs, _ := newIdempotentChangeset(nil)
+
+	r := NewChangesetsRegistry()
+	r.Add("0001_cs", Configure(cs).With([]uint64{1}), WithIdempotencyMode(IdempotencyWarn))
+
+	lggr, logs := logger.TestObserved(t, zapcore.WarnLevel)
+	env := hookTestEnv(t)
+	env.Logger = lggr
+	env.DataStore = idempotencyTestDataStore(t)
+
+	_, err := r.Apply("0001_cs", env)
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("All address refs the changeset would create already exist, applying it again").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "0001_cs", entries[0].ContextMap()["changeset"])
+}
+
+func Test_Apply_Idempotency_NotIdempotent(t *testing.T) {
+	t.Parallel()
+
+	cs := &recordingChangeset{}
+
+	r := NewChangesetsRegistry()
+	r.entries["0001_cs"] = registryEntry{changeset: cs}
+
+	env := hookTestEnv(t)
+	env.DataStore = idempotencyTestDataStore(t)
+
+	_, err := r.Apply("0001_cs", env)
+	require.NoError(t, err)
+	assert.True(t, cs.applyCalled)
+}
@@ -1,7 +1,6 @@
 package changeset
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -11,8 +10,10 @@ import (
 	"github.com/smartcontractkit/mcms"
 	mcmstypes "github.com/smartcontractkit/mcms/types"
 
+	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	cldfenv "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/environment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain"
 )
 
 // ----- mcms timelock execution report types -----
@@ -76,25 +77,57 @@ func (*EVMForkContext) ChainFamily() string {
 	return chainsel.FamilyEVM
 }
 
+type proposalHooksConfig struct {
+	hookRecords []fdeployment.HookRecord
+}
+
+// ProposalHooksOption configures ChangesetsRegistry.RunProposalHooks behavior.
+type ProposalHooksOption func(*proposalHooksConfig)
+
+// WithHookRecords passes the records of the hooks run when the changeset was applied, as saved in
+// its artifacts, to the post-proposal-hooks in PostProposalHookParams.HookRecords.
+func WithHookRecords(records []fdeployment.HookRecord) ProposalHooksOption {
+	return func(cfg *proposalHooksConfig) {
+		cfg.hookRecords = records
+	}
+}
+
 // RunProposalHooks executes all post-proposal hooks for the given proposal and reports. It returns
 // an error if any of the hooks fail.
 // Execution order is:
 //  1. Per-changeset post-proposal-hooks
-//  2. Global post-proposal-hooks
+//  2. Namespace post-proposal-hooks of changesets mounted from another registry
+//  3. Global post-proposal-hooks
 func (r *ChangesetsRegistry) RunProposalHooks(
 	key string, e fdeployment.Environment, proposal *mcms.TimelockProposal, input, config any,
-	reports []MCMSTimelockExecuteReport, execError string, forkCtx ForkContext,
+	reports []MCMSTimelockExecuteReport, execError string, forkCtx ForkContext, opts ...ProposalHooksOption,
 ) error {
+	_, err := r.RunProposalHooksWithRecords(key, e, proposal, input, config, reports, execError, forkCtx, opts...)
+
+	return err
+}
+
+// RunProposalHooksWithRecords executes the post-proposal hooks like RunProposalHooks, and also
+// returns the records of the hooks, which are returned with the error when a hook fails.
+func (r *ChangesetsRegistry) RunProposalHooksWithRecords(
+	key string, e fdeployment.Environment, proposal *mcms.TimelockProposal, input, config any,
+	reports []MCMSTimelockExecuteReport, execError string, forkCtx ForkContext, opts ...ProposalHooksOption,
+) ([]fdeployment.HookRecord, error) {
+	var cfg proposalHooksConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	applySnapshot, err := r.getApplySnapshot(key)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	blockChains := e.BlockChains
 	if forkCtx == nil {
 		blockChains, err = blockChains.ReadOnly()
 		if err != nil {
-			return fmt.Errorf("failed to get read-only blockchains for proposal hooks: %w", err)
+			return nil, fmt.Errorf("failed to get read-only blockchains for proposal hooks: %w", err)
 		}
 	}
 
@@ -105,7 +138,8 @@ func (r *ChangesetsRegistry) RunProposalHooks(
 			BlockChains: blockChains,
 			DataStore:   e.DataStore,
 			ForkContext: forkCtx,
-			// TODO: CRE client
+			Offchain:    offchain.NewReadOnlyClient(e.Offchain),
+			CRE:         cre.NewReadOnlyView(e.CRERunner),
 		},
 		ChangesetKey: key,
 		Proposal:     proposal,
@@ -113,24 +147,22 @@ func (r *ChangesetsRegistry) RunProposalHooks(
 		Config:       config,
 		Reports:      reports,
 		Err:          execError,
+		HookRecords:  cfg.hookRecords,
 	}
 
-	for _, h := range applySnapshot.registryEntry.postProposalHooks {
-		err := ExecuteHook(e, h.HookDefinition, func(ctx context.Context) error {
-			return h.Func(ctx, params)
-		})
-		if err != nil {
-			return fmt.Errorf("changeset post-proposal-hook %q failed: %w", h.Name, err)
-		}
+	entry := applySnapshot.registryEntry
+	tasks := make([]hookTask, 0,
+		len(entry.postProposalHooks)+len(entry.namespacePostProposalHooks)+len(applySnapshot.globalPostProposalHooks),
+	)
+	for _, h := range entry.postProposalHooks {
+		tasks = append(tasks, h.task("changeset post-proposal-hook", params))
+	}
+	for _, h := range entry.namespacePostProposalHooks {
+		tasks = append(tasks, h.task("namespace post-proposal-hook", params))
 	}
-
 	for _, h := range applySnapshot.globalPostProposalHooks {
-		if err := ExecuteHook(e, h.HookDefinition, func(ctx context.Context) error {
-			return h.Func(ctx, params)
-		}); err != nil {
-			return fmt.Errorf("global post-proposal-hook %q failed: %w", h.Name, err)
-		}
+		tasks = append(tasks, h.task("global post-proposal-hook", params))
 	}
 
-	return nil
+	return runHooks(e, tasks)
 }
@@ -9,7 +9,10 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/smartcontractkit/mcms"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 )
 
 func Test_RunProposalHooks(t *testing.T) {
@@ -186,9 +189,14 @@ func Test_RunProposalHooks_HookReceivesCorrectParams(t *testing.T) {
 		return r, receivedParams
 	}
 
+	hookRecords := []fdeployment.HookRecord{
+		{Name: "verify", Phase: fdeployment.HookPhasePost, Status: fdeployment.HookStatusWarned, Error: "not found"},
+	}
+
 	tests := []struct {
 		name           string
 		execError      string
+		opts           []ProposalHooksOption
 		expectedParams PostProposalHookParams
 	}{
 		{
@@ -216,6 +224,19 @@ func Test_RunProposalHooks_HookReceivesCorrectParams(t *testing.T) {
 				Err:          "timelock execution failed: out of gas",
 			},
 		},
+		{
+			name: "with hook records",
+			opts: []ProposalHooksOption{WithHookRecords(hookRecords)},
+			expectedParams: PostProposalHookParams{
+				Env:          ProposalHookEnv{Name: "test-env"},
+				ChangesetKey: "test-cs",
+				Proposal:     proposal,
+				Input:        input,
+				Config:       config,
+				Reports:      reports,
+				HookRecords:  hookRecords,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -223,7 +244,7 @@ func Test_RunProposalHooks_HookReceivesCorrectParams(t *testing.T) {
 			t.Parallel()
 
 			r, receivedParams := createRegistry()
-			err := r.RunProposalHooks("test-cs", hookTestEnv(t), proposal, input, config, reports, tt.execError, nil)
+			err := r.RunProposalHooks("test-cs", hookTestEnv(t), proposal, input, config, reports, tt.execError, nil, tt.opts...)
 			require.NoError(t, err)
 
 			require.Empty(t, cmp.Diff(tt.expectedParams, *receivedParams,
@@ -232,3 +253,36 @@ func Test_RunProposalHooks_HookReceivesCorrectParams(t *testing.T) {
 		})
 	}
 }
+
+func Test_RunProposalHooksWithRecords(t *testing.T) {
+	t.Parallel()
+
+	r := NewChangesetsRegistry()
+	r.entries["test-cs"] = registryEntry{
+		changeset: noopChangeset{},
+		postProposalHooks: []PostProposalHook{
+			{
+				HookDefinition: HookDefinition{Name: "notify", FailurePolicy: Warn},
+				Func:           func(context.Context, PostProposalHookParams) error { return nil },
+			},
+			{
+				HookDefinition: HookDefinition{Name: "verify", FailurePolicy: Abort},
+				Func: func(context.Context, PostProposalHookParams) error {
+					return errors.New("owner not transferred")
+				},
+			},
+		},
+	}
+
+	records, err := r.RunProposalHooksWithRecords(
+		"test-cs", hookTestEnv(t), &mcms.TimelockProposal{}, nil, nil, nil, "", nil,
+	)
+	require.ErrorContains(t, err, "owner not transferred")
+	require.Len(t, records, 2)
+	assert.Equal(t, "notify", records[0].Name)
+	assert.Equal(t, fdeployment.HookPhasePostProposal, records[0].Phase)
+	assert.Equal(t, fdeployment.HookStatusPassed, records[0].Status)
+	assert.Equal(t, "verify", records[1].Name)
+	assert.Equal(t, fdeployment.HookStatusFailed, records[1].Status)
+	assert.Equal(t, "owner not transferred", records[1].Error)
+}
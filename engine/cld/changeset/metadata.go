@@ -0,0 +1,148 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// ErrEnvironmentNotAllowed is returned by Apply when the changeset is not allowed in the
+// environment. See WithAllowedEnvironments.
+var ErrEnvironmentNotAllowed = errors.New("changeset not allowed in environment")
+
+// ChangesetMetadata describes a registered changeset for the people and tools working with the
+// registry. Apply only uses it to reject the changeset in environments it is not allowed in, and
+// to warn about deprecated changesets.
+type ChangesetMetadata struct {
+	// Key is the key the changeset is registered with. It is only set by
+	// ChangesetsRegistry.Metadata and ChangesetsRegistry.ListMetadata.
+	Key string
+	// Owner is the team or person responsible for the changeset.
+	Owner string
+	// Description is a short description of what the changeset does.
+	Description string
+	// Tags are free-form labels used to group and filter changesets.
+	Tags []string
+	// AllowedEnvironments are the names of the environments the changeset can be applied in. It is
+	// allowed in every environment if empty.
+	AllowedEnvironments []string
+	// Deprecated marks a changeset which should no longer be applied.
+	Deprecated bool
+	// ReplacedBy is the key of the changeset which replaces a deprecated changeset, if any.
+	ReplacedBy string
+}
+
+// AllowedIn returns whether the changeset can be applied in the environment.
+func (m ChangesetMetadata) AllowedIn(envName string) bool {
+	return len(m.AllowedEnvironments) == 0 || slices.Contains(m.AllowedEnvironments, envName)
+}
+
+// HasTags returns whether the changeset has all the tags.
+func (m ChangesetMetadata) HasTags(tags ...string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(m.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithOwner sets the team or person responsible for the changeset.
+func WithOwner(owner string) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.Metadata.Owner = owner
+	}
+}
+
+// WithDescription sets a short description of what the changeset does.
+func WithDescription(description string) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.Metadata.Description = description
+	}
+}
+
+// WithTags adds labels used to group and filter changesets. Calling it more than once appends to
+// the tags.
+func WithTags(tags ...string) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.Metadata.Tags = append(o.Metadata.Tags, tags...)
+	}
+}
+
+// WithAllowedEnvironments restricts the environments the changeset can be applied in; Apply
+// returns ErrEnvironmentNotAllowed in any other environment. Calling it more than once appends to
+// the environments.
+func WithAllowedEnvironments(envNames ...string) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.Metadata.AllowedEnvironments = append(o.Metadata.AllowedEnvironments, envNames...)
+	}
+}
+
+// WithDeprecation marks the changeset as deprecated, optionally replaced by the changeset with
+// the replacedBy key. Deprecated changesets can still be applied, with a warning.
+func WithDeprecation(replacedBy string) ChangesetOption {
+	return func(o *ChangesetConfig) {
+		o.Metadata.Deprecated = true
+		o.Metadata.ReplacedBy = replacedBy
+	}
+}
+
+// Metadata returns the metadata of the changeset.
+func (r *ChangesetsRegistry) Metadata(key string) (ChangesetMetadata, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[key]
+	if !ok {
+		return ChangesetMetadata{}, fmt.Errorf("changeset '%s' not found", key)
+	}
+
+	return entry.metadata(key), nil
+}
+
+// ListMetadata returns the metadata of all registered changesets, in the order they were added.
+func (r *ChangesetsRegistry) ListMetadata() []ChangesetMetadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metadata := make([]ChangesetMetadata, 0, len(r.keyHistory))
+	for _, key := range r.keyHistory {
+		metadata = append(metadata, r.entries[key].metadata(key))
+	}
+
+	return metadata
+}
+
+// metadata returns a copy of the metadata of the entry with its key.
+func (e registryEntry) metadata(key string) ChangesetMetadata {
+	m := e.options.Metadata
+	m.Key = key
+	m.Tags = slices.Clone(m.Tags)
+	m.AllowedEnvironments = slices.Clone(m.AllowedEnvironments)
+
+	return m
+}
+
+// checkMetadata returns ErrEnvironmentNotAllowed if the changeset is not allowed in the
+// environment, and warns if the changeset is deprecated.
+func checkMetadata(key string, e fdeployment.Environment, m ChangesetMetadata) error {
+	if !m.AllowedIn(e.Name) {
+		return fmt.Errorf("%w: changeset %q can only be applied in %s, not %q",
+			ErrEnvironmentNotAllowed, key, strings.Join(m.AllowedEnvironments, ", "), e.Name,
+		)
+	}
+
+	if m.Deprecated && e.Logger != nil {
+		if m.ReplacedBy != "" {
+			e.Logger.Warnw("Changeset is deprecated", "changeset", key, "replacedBy", m.ReplacedBy)
+		} else {
+			e.Logger.Warnw("Changeset is deprecated", "changeset", key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func Test_ChangesetMetadata_AllowedIn(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ChangesetMetadata{}.AllowedIn("mainnet"))
+
+	m := ChangesetMetadata{AllowedEnvironments: []string{"testnet", "staging"}}
+	assert.True(t, m.AllowedIn("testnet"))
+	assert.True(t, m.AllowedIn("staging"))
+	assert.False(t, m.AllowedIn("mainnet"))
+}
+
+func Test_ChangesetMetadata_HasTags(t *testing.T) {
+	t.Parallel()
+
+	m := ChangesetMetadata{Tags: []string{"ccip", "router"}}
+	assert.True(t, m.HasTags())
+	assert.True(t, m.HasTags("ccip"))
+	assert.True(t, m.HasTags("ccip", "router"))
+	assert.False(t, m.HasTags("ccip", "token"))
+	assert.False(t, ChangesetMetadata{}.HasTags("ccip"))
+}
+
+func Test_ChangesetsRegistry_Metadata(t *testing.T) {
+	t.Parallel()
+
+	r := NewChangesetsRegistry()
+	r.Add("0001_cs", &recordingChangeset{},
+		WithOwner("ccip-team"),
+		WithDescription("Deploys the router"),
+		WithTags("ccip"),
+		WithTags("router"),
+		WithAllowedEnvironments("testnet"),
+		WithAllowedEnvironments("staging"),
+		WithDeprecation("0002_cs"),
+	)
+	r.Add("0002_cs", &recordingChangeset{})
+
+	got, err := r.Metadata("0001_cs")
+	require.NoError(t, err)
+	assert.Equal(t, ChangesetMetadata{
+		Key:                 "0001_cs",
+		Owner:               "ccip-team",
+		Description:         "Deploys the router",
+		Tags:                []string{"ccip", "router"},
+		AllowedEnvironments: []string{"testnet", "staging"},
+		Deprecated:          true,
+		ReplacedBy:          "0002_cs",
+	}, got)
+
+	// The returned metadata is a copy
+	got.Tags[0] = "changed"
+	got, err = r.Metadata("0001_cs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ccip", "router"}, got.Tags)
+
+	_, err = r.Metadata("0003_cs")
+	require.EqualError(t, err, "changeset '0003_cs' not found")
+
+	list := r.ListMetadata()
+	require.Len(t, list, 2)
+	assert.Equal(t, "0001_cs", list[0].Key)
+	assert.Equal(t, ChangesetMetadata{Key: "0002_cs"}, list[1])
+}
+
+func Test_Apply_AllowedEnvironments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		allowed     []string
+		wantApplied bool
+		wantErr     string
+	}{
+		{
+			name:        "no restriction",
+			wantApplied: true,
+		},
+		{
+			name:        "allowed",
+			allowed:     []string{"staging", "test-env"},
+			wantApplied: true,
+		},
+		{
+			name:    "not allowed",
+			allowed: []string{"staging", "mainnet"},
+			wantErr: `changeset not allowed in environment: changeset "0001_cs" can only be applied in staging, mainnet, not "test-env"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cs := &recordingChangeset{}
+			r := NewChangesetsRegistry()
+			r.Add("0001_cs", cs, WithAllowedEnvironments(tt.allowed...))
+
+			_, err := r.Apply("0001_cs", hookTestEnv(t))
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, ErrEnvironmentNotAllowed)
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantApplied, cs.applyCalled)
+		})
+	}
+}
+
+func Test_Apply_DeprecatedWarns(t *testing.T) {
+	t.Parallel()
+
+	cs := &recordingChangeset{}
+	r := NewChangesetsRegistry()
+	r.Add("0001_cs", cs, WithDeprecation("0002_cs"))
+
+	lggr, logs := logger.TestObserved(t, zapcore.WarnLevel)
+	env := hookTestEnv(t)
+	env.Logger = lggr
+
+	_, err := r.Apply("0001_cs", env)
+	require.NoError(t, err)
+	assert.True(t, cs.applyCalled)
+
+	entries := logs.FilterMessage("Changeset is deprecated").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "0001_cs", entries[0].ContextMap()["changeset"])
+	assert.Equal(t, "0002_cs", entries[0].ContextMap()["replacedBy"])
+}
@@ -0,0 +1,146 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NamespaceSeparator separates the namespace from the key in the qualified key of a changeset
+// mounted from another registry, such as "ccip/deploy-router".
+const NamespaceSeparator = "/"
+
+// QualifiedKey returns the key of the changeset mounted under the namespace.
+func QualifiedKey(namespace, key string) string {
+	return namespace + NamespaceSeparator + key
+}
+
+// SplitKey returns the namespace and the key of the changeset from its qualified key. The
+// namespace is empty if the key is not qualified. Only the first separator is considered, so the
+// key of a changeset mounted from a registry with namespaces of its own stays qualified.
+func SplitKey(qualifiedKey string) (namespace, key string) {
+	namespace, key, ok := strings.Cut(qualifiedKey, NamespaceSeparator)
+	if !ok {
+		return "", qualifiedKey
+	}
+
+	return namespace, key
+}
+
+// Mount adds the changesets of the sub registry to the registry under the namespace, with keys
+// qualified by the namespace. Prerequisites and replacement keys naming changesets of the sub
+// registry are qualified too.
+//
+// The global hooks of the sub registry are scoped to the namespace: they only run for the
+// changesets of the namespace, after the global pre-hooks and before the global post-hooks of the
+// registry. Changesets and hooks added to the sub registry after it is mounted are not picked up.
+func (r *ChangesetsRegistry) Mount(namespace string, sub *ChangesetsRegistry) error {
+	if namespace == "" {
+		return errors.New("namespace is required")
+	}
+	if strings.Contains(namespace, NamespaceSeparator) {
+		return fmt.Errorf("namespace %q must not contain %q", namespace, NamespaceSeparator)
+	}
+	if sub == r {
+		return errors.New("cannot mount a registry into itself")
+	}
+
+	sub.mu.Lock()
+	keys := slices.Clone(sub.keyHistory)
+	entries := make(map[string]registryEntry, len(keys))
+	for _, key := range keys {
+		entries[key] = sub.entries[key]
+	}
+	preHooks := slices.Clone(sub.globalPreHooks)
+	postHooks := slices.Clone(sub.globalPostHooks)
+	postProposalHooks := slices.Clone(sub.globalPostProposalHooks)
+	sub.mu.Unlock()
+
+	qualify := func(key string) string {
+		if _, ok := entries[key]; ok {
+			return QualifiedKey(namespace, key)
+		}
+
+		return key
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := r.entries[QualifiedKey(namespace, key)]; ok {
+			return fmt.Errorf("changeset '%s' already registered", QualifiedKey(namespace, key))
+		}
+	}
+
+	for _, key := range keys {
+		entry := entries[key]
+
+		options := entry.options
+		options.Prerequisites = slices.Clone(entry.options.Prerequisites)
+		for i, prerequisite := range options.Prerequisites {
+			options.Prerequisites[i] = qualify(prerequisite)
+		}
+		if options.Metadata.ReplacedBy != "" {
+			options.Metadata.ReplacedBy = qualify(options.Metadata.ReplacedBy)
+		}
+		entry.options = options
+
+		// The hooks of the outer namespace run around those of namespaces mounted in the sub registry
+		entry.namespacePreHooks = slices.Concat(preHooks, entry.namespacePreHooks)
+		entry.namespacePostHooks = slices.Concat(entry.namespacePostHooks, postHooks)
+		entry.namespacePostProposalHooks = slices.Concat(entry.namespacePostProposalHooks, postProposalHooks)
+
+		r.entries[QualifiedKey(namespace, key)] = entry
+		r.keyHistory = append(r.keyHistory, QualifiedKey(namespace, key))
+	}
+
+	return nil
+}
+
+var _ RegistryProvider = (*NamespacedRegistryProvider)(nil)
+
+// NamespacedRegistryProvider is a RegistryProvider which mounts the registries of other
+// providers under namespaces, so that the changesets of several teams can be registered in one
+// domain without their keys colliding.
+type NamespacedRegistryProvider struct {
+	*BaseRegistryProvider
+
+	namespaces []string
+	providers  map[string]RegistryProvider
+}
+
+// NewNamespacedRegistryProvider creates a new NamespacedRegistryProvider without namespaces.
+func NewNamespacedRegistryProvider() *NamespacedRegistryProvider {
+	return &NamespacedRegistryProvider{
+		BaseRegistryProvider: NewBaseRegistryProvider(),
+		providers:            make(map[string]RegistryProvider),
+	}
+}
+
+// Mount registers the provider under the namespace. Its registry is mounted by Init, in the order
+// the providers were registered.
+func (p *NamespacedRegistryProvider) Mount(namespace string, provider RegistryProvider) *NamespacedRegistryProvider {
+	if _, ok := p.providers[namespace]; !ok {
+		p.namespaces = append(p.namespaces, namespace)
+	}
+	p.providers[namespace] = provider
+
+	return p
+}
+
+// Init initializes the provider of every namespace and mounts its registry under the namespace.
+func (p *NamespacedRegistryProvider) Init() error {
+	for _, namespace := range p.namespaces {
+		provider := p.providers[namespace]
+		if err := provider.Init(); err != nil {
+			return fmt.Errorf("failed to initialize registry of namespace %s: %w", namespace, err)
+		}
+		if err := p.Registry().Mount(namespace, provider.Registry()); err != nil {
+			return fmt.Errorf("failed to mount registry of namespace %s: %w", namespace, err)
+		}
+	}
+
+	return nil
+}
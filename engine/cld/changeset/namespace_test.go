@@ -0,0 +1,188 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SplitKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give          string
+		wantNamespace string
+		wantKey       string
+	}{
+		{give: "0001_cs", wantKey: "0001_cs"},
+		{give: "ccip/0001_cs", wantNamespace: "ccip", wantKey: "0001_cs"},
+		{give: "ccip/lanes/0001_cs", wantNamespace: "ccip", wantKey: "lanes/0001_cs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+
+			namespace, key := SplitKey(tt.give)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+
+	assert.Equal(t, "ccip/0001_cs", QualifiedKey("ccip", "0001_cs"))
+}
+
+func Test_ChangesetsRegistry_Mount(t *testing.T) {
+	t.Parallel()
+
+	ccip := NewChangesetsRegistry()
+	ccip.Add("deploy-router", &recordingChangeset{})
+	ccip.Add("configure-router", &recordingChangeset{},
+		WithPrerequisites("deploy-router", "shared/deploy-mcms"),
+		WithDeprecation("configure-router-v2"),
+	)
+
+	feeds := NewChangesetsRegistry()
+	feeds.Add("deploy-router", &recordingChangeset{})
+
+	r := NewChangesetsRegistry()
+	r.Add("0001_root", &recordingChangeset{})
+	require.NoError(t, r.Mount("ccip", ccip))
+	require.NoError(t, r.Mount("data-feeds", feeds))
+
+	assert.Equal(t, []string{
+		"0001_root", "ccip/deploy-router", "ccip/configure-router", "data-feeds/deploy-router",
+	}, r.ListKeys())
+
+	// Keys of the mounted registry are qualified, other keys are kept
+	options, err := r.GetChangesetOptions("ccip/configure-router")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ccip/deploy-router", "shared/deploy-mcms"}, options.Prerequisites)
+	assert.Equal(t, "configure-router-v2", options.Metadata.ReplacedBy)
+
+	// The mounted registry is not modified
+	options, err = ccip.GetChangesetOptions("configure-router")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy-router", "shared/deploy-mcms"}, options.Prerequisites)
+
+	_, err = r.Apply("data-feeds/deploy-router", hookTestEnv(t))
+	require.NoError(t, err)
+}
+
+func Test_ChangesetsRegistry_Mount_Errors(t *testing.T) {
+	t.Parallel()
+
+	sub := NewChangesetsRegistry()
+	sub.Add("deploy-router", &recordingChangeset{})
+
+	r := NewChangesetsRegistry()
+	require.EqualError(t, r.Mount("", sub), "namespace is required")
+	require.EqualError(t, r.Mount("ccip/lanes", sub), `namespace "ccip/lanes" must not contain "/"`)
+	require.EqualError(t, r.Mount("ccip", r), "cannot mount a registry into itself")
+
+	require.NoError(t, r.Mount("ccip", sub))
+	require.EqualError(t, r.Mount("ccip", sub), "changeset 'ccip/deploy-router' already registered")
+	assert.Equal(t, []string{"ccip/deploy-router"}, r.ListKeys())
+}
+
+func Test_ChangesetsRegistry_Mount_NamespaceHooks(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	preHook := func(name string) PreHook {
+		return PreHook{
+			HookDefinition: HookDefinition{Name: name},
+			Func: func(context.Context, PreHookParams) error {
+				order = append(order, name)
+				return nil
+			},
+		}
+	}
+	postHook := func(name string) PostHook {
+		return PostHook{
+			HookDefinition: HookDefinition{Name: name},
+			Func: func(context.Context, PostHookParams) error {
+				order = append(order, name)
+				return nil
+			},
+		}
+	}
+
+	lanes := NewChangesetsRegistry()
+	lanes.Add("add-lane", &recordingChangeset{})
+	lanes.AddGlobalPreHooks(preHook("lanes-pre"))
+	lanes.AddGlobalPostHooks(postHook("lanes-post"))
+
+	ccip := NewChangesetsRegistry()
+	ccip.Add("deploy-router", &recordingChangeset{})
+	ccip.AddGlobalPreHooks(preHook("ccip-pre"))
+	ccip.AddGlobalPostHooks(postHook("ccip-post"))
+	require.NoError(t, ccip.Mount("lanes", lanes))
+
+	feeds := NewChangesetsRegistry()
+	feeds.Add("deploy-router", &recordingChangeset{})
+
+	r := NewChangesetsRegistry()
+	r.AddGlobalPreHooks(preHook("global-pre"))
+	r.AddGlobalPostHooks(postHook("global-post"))
+	require.NoError(t, r.Mount("ccip", ccip))
+	require.NoError(t, r.Mount("data-feeds", feeds))
+
+	_, err := r.Apply("ccip/lanes/add-lane", hookTestEnv(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"global-pre", "ccip-pre", "lanes-pre", "lanes-post", "ccip-post", "global-post",
+	}, order)
+
+	order = nil
+	_, err = r.Apply("ccip/deploy-router", hookTestEnv(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"global-pre", "ccip-pre", "ccip-post", "global-post"}, order)
+
+	// The hooks of a namespace do not run for the changesets of other namespaces
+	order = nil
+	_, err = r.Apply("data-feeds/deploy-router", hookTestEnv(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"global-pre", "global-post"}, order)
+}
+
+// namespaceTestProvider is a RegistryProvider adding the changesets of its keys.
+type namespaceTestProvider struct {
+	*BaseRegistryProvider
+
+	keys    []string
+	initErr error
+}
+
+func (p *namespaceTestProvider) Init() error {
+	for _, key := range p.keys {
+		p.Registry().Add(key, &recordingChangeset{})
+	}
+
+	return p.initErr
+}
+
+func Test_NamespacedRegistryProvider(t *testing.T) {
+	t.Parallel()
+
+	p := NewNamespacedRegistryProvider().
+		Mount("ccip", &namespaceTestProvider{BaseRegistryProvider: NewBaseRegistryProvider(), keys: []string{"deploy-router"}}).
+		Mount("data-feeds", &namespaceTestProvider{BaseRegistryProvider: NewBaseRegistryProvider(), keys: []string{"deploy-router", "deploy-feed"}})
+
+	require.NoError(t, p.Init())
+	assert.Equal(t, []string{
+		"ccip/deploy-router", "data-feeds/deploy-router", "data-feeds/deploy-feed",
+	}, p.Registry().ListKeys())
+}
+
+func Test_NamespacedRegistryProvider_InitError(t *testing.T) {
+	t.Parallel()
+
+	p := NewNamespacedRegistryProvider().
+		Mount("ccip", &namespaceTestProvider{BaseRegistryProvider: NewBaseRegistryProvider(), initErr: errors.New("boom")})
+
+	require.EqualError(t, p.Init(), "failed to initialize registry of namespace ccip: boom")
+}
@@ -0,0 +1,256 @@
+package changeset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PayloadError is returned when the payload of a changeset input cannot be decoded into the
+// config type of the changeset.
+type PayloadError struct {
+	// Path is the JSON path of the value which could not be decoded, such as
+	// "$.chains[0].router". Empty if the location of the error is unknown.
+	Path string
+	Err  error
+}
+
+// Error returns the decoding error, followed by the path of the value if it is known.
+func (e *PayloadError) Error() string {
+	if e.Path == "" {
+		return "failed to unmarshal payload: " + e.Err.Error()
+	}
+
+	return fmt.Sprintf("failed to unmarshal payload: %s at %s", e.Err, e.Path)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *PayloadError) Unwrap() error {
+	return e.Err
+}
+
+// decodePayload strictly decodes the payload into a value of type C. Unknown fields and trailing
+// data are rejected, and numbers are decoded as json.Number where the type allows it.
+//
+// On failure a *PayloadError is returned, holding the JSON path of the offending value.
+func decodePayload[C any](payload json.RawMessage) (C, error) {
+	var config C
+
+	if err := decodeStrict(payload, &config); err != nil {
+		return config, &PayloadError{
+			Path: locatePayloadError(payload, reflect.TypeFor[C]()),
+			Err:  err,
+		}
+	}
+
+	return config, nil
+}
+
+// decodeStrict decodes data into v, rejecting unknown fields and trailing data.
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after the JSON value")
+	}
+
+	return nil
+}
+
+// locatePayloadError walks the payload alongside the type it is decoded into, and returns the JSON
+// path of the first value which cannot be decoded, or an empty string if none is found. Object
+// keys are visited in sorted order so that the result is deterministic.
+func locatePayloadError(payload json.RawMessage, t reflect.Type) string {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		// The payload is not valid JSON, so there is no value to point at
+		return ""
+	}
+
+	path, ok := locateValueError(value, t, "$")
+	if !ok {
+		return ""
+	}
+
+	return path
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// locateValueError returns the path of the first value within value which cannot be decoded into
+// type t, and true if one is found.
+func locateValueError(value any, t reflect.Type, path string) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Types which decode themselves are opaque, so the error is attributed to the whole value
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return path, !decodesInto(value, t)
+	}
+
+	switch t.Kind() { //nolint:exhaustive // Remaining kinds are decoded as scalars
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return path, true
+		}
+
+		fields := jsonFields(t)
+		for _, key := range slices.Sorted(maps.Keys(object)) {
+			fieldType, ok := lookupJSONField(fields, key)
+			if !ok {
+				return joinJSONPath(path, key), true
+			}
+			if p, found := locateValueError(object[key], fieldType, joinJSONPath(path, key)); found {
+				return p, true
+			}
+		}
+
+		return "", false
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are decoded from base64 strings
+			return path, !decodesInto(value, t)
+		}
+
+		items, ok := value.([]any)
+		if !ok {
+			return path, true
+		}
+		for i, item := range items {
+			if p, found := locateValueError(item, t.Elem(), path+"["+strconv.Itoa(i)+"]"); found {
+				return p, true
+			}
+		}
+
+		return "", false
+
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return path, true
+		}
+		for _, key := range slices.Sorted(maps.Keys(object)) {
+			if p, found := locateValueError(object[key], t.Elem(), joinJSONPath(path, key)); found {
+				return p, true
+			}
+		}
+
+		return "", false
+
+	case reflect.Interface:
+		return "", false
+
+	default:
+		return path, !decodesInto(value, t)
+	}
+}
+
+// decodesInto returns true if the JSON encoding of value strictly decodes into type t.
+func decodesInto(value any, t reflect.Type) bool {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	return decodeStrict(b, reflect.New(t).Interface()) == nil
+}
+
+// jsonFields returns the types of the fields of struct type t by their JSON names, including the
+// fields promoted from embedded structs. Fields of shallower structs take precedence.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	var collect func(t reflect.Type, depth int, depths map[string]int, visited map[reflect.Type]bool)
+	collect = func(t reflect.Type, depth int, depths map[string]int, visited map[reflect.Type]bool) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+
+			if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+				collect(fieldType, depth+1, depths, visited)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if d, ok := depths[name]; ok && d <= depth {
+				continue
+			}
+			depths[name] = depth
+			fields[name] = field.Type
+		}
+	}
+	collect(t, 0, make(map[string]int), make(map[reflect.Type]bool))
+
+	return fields
+}
+
+// lookupJSONField finds a field by its JSON name, falling back to a case-insensitive match like
+// encoding/json does.
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+var jsonPathIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// joinJSONPath appends an object key to a JSON path, quoting it if it is not an identifier.
+func joinJSONPath(path, key string) string {
+	if jsonPathIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+
+	return path + "[" + strconv.Quote(key) + "]"
+}
@@ -0,0 +1,169 @@
+package changeset
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payloadTestBase struct {
+	Owner string `json:"owner"`
+}
+
+type payloadTestChain struct {
+	Selector uint64 `json:"selector"`
+	Router   string `json:"router"`
+}
+
+type payloadTestConfig struct {
+	payloadTestBase
+
+	Chains  []payloadTestChain        `json:"chains"`
+	Version *semver.Version           `json:"version"`
+	Labels  map[string]map[string]int `json:"labels"`
+	Data    []byte                    `json:"data"`
+	Extra   any                       `json:"extra"`
+	Ignored string                    `json:"-"`
+}
+
+func Test_decodePayload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		payload     string
+		wantPath    string
+		wantErr     string
+		wantOwner   string
+		wantChains  int
+		wantVersion string
+	}{
+		{
+			name:        "valid payload",
+			payload:     `{"owner":"0x1","chains":[{"selector":1,"router":"0x2"}],"version":"1.2.0","extra":{"any":"thing"}}`,
+			wantOwner:   "0x1",
+			wantChains:  1,
+			wantVersion: "1.2.0",
+		},
+		{
+			name:       "keys are matched case insensitively",
+			payload:    `{"OWNER":"0x1","Chains":[{"Selector":1}]}`,
+			wantOwner:  "0x1",
+			wantChains: 1,
+		},
+		{
+			name:     "unknown top level field",
+			payload:  `{"owner":"0x1","ignored":"x"}`,
+			wantPath: "$.ignored",
+			wantErr:  `json: unknown field "ignored"`,
+		},
+		{
+			name:     "unknown field in an array item",
+			payload:  `{"chains":[{"selector":1},{"selector":2,"routr":"0x2"}]}`,
+			wantPath: "$.chains[1].routr",
+			wantErr:  `json: unknown field "routr"`,
+		},
+		{
+			name:     "wrong type in an array item",
+			payload:  `{"chains":[{"selector":"one"}]}`,
+			wantPath: "$.chains[0].selector",
+			wantErr:  "cannot unmarshal string",
+		},
+		{
+			name:     "wrong type in a map value",
+			payload:  `{"labels":{"team a":{"size":"big"}}}`,
+			wantPath: `$.labels["team a"].size`,
+			wantErr:  "cannot unmarshal string",
+		},
+		{
+			name:     "invalid value of a self decoding type",
+			payload:  `{"version":"not a version"}`,
+			wantPath: "$.version",
+			wantErr:  "invalid semantic version",
+		},
+		{
+			name:     "invalid base64 bytes",
+			payload:  `{"data":"%%%"}`,
+			wantPath: "$.data",
+			wantErr:  "illegal base64 data",
+		},
+		{
+			name:     "object instead of an array",
+			payload:  `{"chains":{"selector":1}}`,
+			wantPath: "$.chains",
+			wantErr:  "cannot unmarshal object",
+		},
+		{
+			name:    "trailing data",
+			payload: `{"owner":"0x1"} {"owner":"0x2"}`,
+			wantErr: "unexpected data after the JSON value",
+		},
+		{
+			name:    "invalid JSON",
+			payload: `{"owner":`,
+			wantErr: "unexpected EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodePayload[payloadTestConfig](json.RawMessage(tt.payload))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.ErrorContains(t, err, "failed to unmarshal payload: ")
+
+				var perr *PayloadError
+				require.ErrorAs(t, err, &perr)
+				assert.Equal(t, tt.wantPath, perr.Path)
+				if tt.wantPath != "" {
+					assert.Contains(t, err.Error(), " at "+tt.wantPath)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOwner, got.Owner)
+			assert.Len(t, got.Chains, tt.wantChains)
+			if tt.wantVersion != "" {
+				assert.Equal(t, tt.wantVersion, got.Version.String())
+			}
+		})
+	}
+}
+
+func Test_decodePayload_UsesNumbers(t *testing.T) {
+	t.Parallel()
+
+	got, err := decodePayload[map[string]any](json.RawMessage(`{"big":123456789012345678901234567890}`))
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("123456789012345678901234567890"), got["big"])
+}
+
+func Test_jsonFields(t *testing.T) {
+	t.Parallel()
+
+	type embedded struct {
+		Name  string `json:"name"`
+		Shade string `json:"shadowed"`
+	}
+	type outer struct {
+		*embedded
+
+		Shadowed int `json:"shadowed"`
+		Untagged bool
+		private  string //nolint:unused // Unexported fields are not decoded
+	}
+
+	fields := jsonFields(reflect.TypeFor[outer]())
+	assert.Len(t, fields, 3)
+	assert.Equal(t, "string", fields["name"].String())
+	assert.Equal(t, "int", fields["shadowed"].String())
+	assert.Equal(t, "bool", fields["Untagged"].String())
+}
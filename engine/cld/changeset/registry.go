@@ -1,13 +1,14 @@
 package changeset
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"slices"
 	"sync"
 
+	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain"
 	foperations "github.com/smartcontractkit/chainlink-deployments-framework/operations"
 )
 
@@ -61,6 +62,12 @@ type registryEntry struct {
 	preHooks          []PreHook
 	postHooks         []PostHook
 	postProposalHooks []PostProposalHook
+
+	// namespacePreHooks, namespacePostHooks and namespacePostProposalHooks are the global hooks of
+	// the registries the changeset was mounted from. See ChangesetsRegistry.Mount.
+	namespacePreHooks          []PreHook
+	namespacePostHooks         []PostHook
+	namespacePostProposalHooks []PostProposalHook
 }
 
 // hookCarrier is implemented by changeset types that carry hooks through the
@@ -103,8 +110,10 @@ type ChangesetsRegistry struct {
 }
 
 type applyConfig struct {
-	inputStr string
-	runHooks bool
+	inputStr  string
+	runHooks  bool
+	artifacts ArtifactsChecker
+	force     bool
 }
 
 // ApplyOption configures ChangesetsRegistry.Apply behavior.
@@ -168,14 +177,26 @@ func (r *ChangesetsRegistry) AddGlobalPostProposalHooks(hooks ...PostProposalHoo
 // Apply applies a changeset, running any registered hooks around it.
 //
 // Execution order:
+//  0. Allowed environment check, prerequisite and datastore precondition checks, and the
+//     idempotency check of changesets implementing deployment.Idempotent (skipped with WithForce)
 //  1. Global pre-hooks (in order added)
-//  2. Per-changeset pre-hooks (in order specified)
-//  3. entry.changeset.Apply(env)
-//  4. Per-changeset post-hooks
-//  5. Global post-hooks
+//  2. Namespace pre-hooks of changesets mounted from another registry (see Mount)
+//  3. Per-changeset pre-hooks (in order specified)
+//  4. entry.changeset.Apply(env)
+//  5. Per-changeset post-hooks
+//  6. Namespace post-hooks
+//  7. Global post-hooks
+//
+// Consecutive hooks of the same HookDefinition.Parallel group run concurrently,
+// respecting their HookDefinition.After dependencies. The errors of all failed
+// hooks are joined into the returned error.
 //
 // If Apply failed, that error is always returned. Post-hook failures after
 // a failed Apply are logged but never mask the Apply error.
+//
+// The records of the pre-hooks and post-hooks are returned in the HookRecords of
+// the output, which is empty except for them when a hook fails. Post-hooks see
+// the records of the pre-hooks in their output.
 func (r *ChangesetsRegistry) Apply(
 	key string, e fdeployment.Environment, opts ...ApplyOption,
 ) (fdeployment.ChangesetOutput, error) {
@@ -189,34 +210,54 @@ func (r *ChangesetsRegistry) Apply(
 		return fdeployment.ChangesetOutput{}, err
 	}
 
+	if err = checkMetadata(key, e, applySnapshot.registryEntry.options.Metadata); err != nil {
+		return fdeployment.ChangesetOutput{}, err
+	}
+
+	err = checkPrerequisites(key, applySnapshot.registryEntry.options, cfg.artifacts, e.DataStore)
+	if err != nil {
+		return fdeployment.ChangesetOutput{}, err
+	}
+
 	resolvedInput, err := applySnapshot.registryEntry.changeset.resolvedInput(cfg.inputStr)
 	if err != nil {
 		return fdeployment.ChangesetOutput{}, fmt.Errorf("failed to get changeset configuration: %w", err)
 	}
 
+	if !cfg.force {
+		if err = checkIdempotency(key, e, resolvedInput, applySnapshot.registryEntry); err != nil {
+			return fdeployment.ChangesetOutput{}, err
+		}
+	}
+
+	var hookRecords []fdeployment.HookRecord
 	if cfg.runHooks {
-		err = runPreHooks(e, key, resolvedInput, applySnapshot)
+		hookRecords, err = runPreHooks(e, key, resolvedInput, applySnapshot)
 		if err != nil {
-			return fdeployment.ChangesetOutput{}, err
+			return fdeployment.ChangesetOutput{HookRecords: hookRecords}, err
 		}
 	}
 
 	output, applyErr := applySnapshot.registryEntry.changeset.applyWithInput(e, resolvedInput)
+	output.HookRecords = append(output.HookRecords, hookRecords...)
 
 	if cfg.runHooks {
-		err = runPostHooks(e, key, resolvedInput, output, applyErr, applySnapshot)
-		if err != nil {
-			return fdeployment.ChangesetOutput{}, err
+		postRecords, postErr := runPostHooks(e, key, resolvedInput, output, applyErr, applySnapshot)
+		output.HookRecords = append(output.HookRecords, postRecords...)
+		if postErr != nil {
+			return fdeployment.ChangesetOutput{HookRecords: output.HookRecords}, postErr
 		}
 	}
 
 	return output, applyErr
 }
 
-func runPreHooks(e fdeployment.Environment, key string, resolvedInput any, applySnapshot applySnapshot) error {
+func runPreHooks(
+	e fdeployment.Environment, key string, resolvedInput any, applySnapshot applySnapshot,
+) ([]fdeployment.HookRecord, error) {
 	readOnlyChains, err := e.BlockChains.ReadOnly()
 	if err != nil {
-		return fmt.Errorf("failed to get read-only blockchains for pre-hooks: %w", err)
+		return nil, fmt.Errorf("failed to get read-only blockchains for pre-hooks: %w", err)
 	}
 
 	preParams := PreHookParams{
@@ -225,34 +266,35 @@ func runPreHooks(e fdeployment.Environment, key string, resolvedInput any, apply
 			Logger:      e.Logger,
 			BlockChains: readOnlyChains,
 			DataStore:   e.DataStore,
+			Offchain:    offchain.NewReadOnlyClient(e.Offchain),
+			CRE:         cre.NewReadOnlyView(e.CRERunner),
 		},
 		ChangesetKey: key,
 		Config:       resolvedInput,
 	}
 
+	entry := applySnapshot.registryEntry
+	tasks := make([]hookTask, 0, len(applySnapshot.globalPreHooks)+len(entry.namespacePreHooks)+len(entry.preHooks))
 	for _, h := range applySnapshot.globalPreHooks {
-		err := ExecuteHook(e, h.HookDefinition, func(ctx context.Context) error { return h.Func(ctx, preParams) })
-		if err != nil {
-			return fmt.Errorf("global pre-hook %q failed: %w", h.Name, err)
-		}
+		tasks = append(tasks, h.task("global pre-hook", preParams))
 	}
-	for _, h := range applySnapshot.registryEntry.preHooks {
-		err := ExecuteHook(e, h.HookDefinition, func(ctx context.Context) error { return h.Func(ctx, preParams) })
-		if err != nil {
-			return fmt.Errorf("changeset pre-hook %q failed: %w", h.Name, err)
-		}
+	for _, h := range entry.namespacePreHooks {
+		tasks = append(tasks, h.task("namespace pre-hook", preParams))
+	}
+	for _, h := range entry.preHooks {
+		tasks = append(tasks, h.task("changeset pre-hook", preParams))
 	}
 
-	return nil
+	return runHooks(e, tasks)
 }
 
 func runPostHooks(
 	e fdeployment.Environment, key string, resolvedInput any, output fdeployment.ChangesetOutput,
 	applyErr error, applySnapshot applySnapshot,
-) error {
+) ([]fdeployment.HookRecord, error) {
 	readOnlyChains, err := e.BlockChains.ReadOnly()
 	if err != nil {
-		return fmt.Errorf("failed to get read-only blockchains for post-hooks: %w", err)
+		return nil, fmt.Errorf("failed to get read-only blockchains for post-hooks: %w", err)
 	}
 
 	postParams := PostHookParams{
@@ -261,6 +303,8 @@ func runPostHooks(
 			Logger:      e.Logger,
 			BlockChains: readOnlyChains,
 			DataStore:   e.DataStore,
+			Offchain:    offchain.NewReadOnlyClient(e.Offchain),
+			CRE:         cre.NewReadOnlyView(e.CRERunner),
 		},
 		ChangesetKey: key,
 		Config:       resolvedInput,
@@ -268,28 +312,27 @@ func runPostHooks(
 		Err:          applyErr,
 	}
 
-	for _, h := range applySnapshot.registryEntry.postHooks {
-		err := ExecuteHook(e, h.HookDefinition, func(ctx context.Context) error { return h.Func(ctx, postParams) })
-		if err != nil {
-			if applyErr != nil {
-				e.Logger.Warnw("post-hook failed after changeset error", "hook", h.Name, "hookErr", err, "changesetErr", applyErr)
-			} else {
-				return fmt.Errorf("changeset post-hook %q failed: %w", h.Name, err)
-			}
-		}
+	entry := applySnapshot.registryEntry
+	tasks := make([]hookTask, 0, len(entry.postHooks)+len(entry.namespacePostHooks)+len(applySnapshot.globalPostHooks))
+	for _, h := range entry.postHooks {
+		tasks = append(tasks, h.task("changeset post-hook", postParams))
+	}
+	for _, h := range entry.namespacePostHooks {
+		tasks = append(tasks, h.task("namespace post-hook", postParams))
 	}
 	for _, h := range applySnapshot.globalPostHooks {
-		err := ExecuteHook(e, h.HookDefinition, func(ctx context.Context) error { return h.Func(ctx, postParams) })
-		if err != nil {
-			if applyErr != nil {
-				e.Logger.Warnw("global post-hook failed after changeset error", "hook", h.Name, "hookErr", err, "changesetErr", applyErr)
-			} else {
-				return fmt.Errorf("global post-hook %q failed: %w", h.Name, err)
-			}
-		}
+		tasks = append(tasks, h.task("global post-hook", postParams))
 	}
 
-	return nil
+	// After a failed Apply, the failures of the post-hooks never mask the Apply error.
+	records, err := runHooks(e, tasks)
+	if err != nil && applyErr != nil {
+		e.Logger.Warnw("post-hooks failed after changeset error", "hookErr", err, "changesetErr", applyErr)
+
+		return records, nil
+	}
+
+	return records, err
 }
 
 type applySnapshot struct {
@@ -367,6 +410,17 @@ type ChangesetConfig struct {
 	ChainsToLoad      []uint64 // nil = load all chains, empty = load no chains, populated = load specific chains
 	WithoutJD         bool
 	OperationRegistry *foperations.OperationRegistry
+
+	// Prerequisites are the keys of the changesets which must be applied before this changeset.
+	Prerequisites []string
+	// DataStorePreconditions are checks which the environment datastore must pass before this
+	// changeset is applied.
+	DataStorePreconditions []DataStorePrecondition
+	// IdempotencyMode is what Apply does when the changeset implements deployment.Idempotent and
+	// every address ref it would create already exists.
+	IdempotencyMode IdempotencyMode
+	// Metadata describes the changeset. Its AllowedEnvironments restrict where Apply runs it.
+	Metadata ChangesetMetadata
 }
 
 // OnlyLoadChainsFor will configure the environment to load only the specified chains.
@@ -403,6 +457,12 @@ func WithOperationRegistry(registry *foperations.OperationRegistry) ChangesetOpt
 // - OnlyLoadChainsFor: will configure the environment to load only the specified chains.
 // - WithoutJD: will configure the environment to not load Job Distributor.
 // - WithOperationRegistry: will configure the changeset to use the specified operation registry.
+// - WithPrerequisites: will declare the changesets which must be applied before this changeset.
+// - WithDataStorePrecondition: will declare a check the datastore must pass before this changeset is applied.
+// - WithIdempotencyMode: will set whether an already applied idempotent changeset is skipped or only warned about.
+// - WithOwner, WithDescription, WithTags: will describe the changeset in listings.
+// - WithAllowedEnvironments: will restrict the environments the changeset can be applied in.
+// - WithDeprecation: will mark the changeset as deprecated, optionally naming its replacement.
 func (r *ChangesetsRegistry) Add(key string, cs ChangeSet, opts ...ChangesetOption) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
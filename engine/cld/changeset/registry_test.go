@@ -8,7 +8,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain/jd/memory"
 	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
 )
 
@@ -749,6 +752,70 @@ func Test_Apply_HookEnvConstruction(t *testing.T) {
 	assert.NotNil(t, receivedEnv.Logger)
 }
 
+func Test_Apply_HookEnv_ReadOnlyOffchainAndCRE(t *testing.T) {
+	t.Parallel()
+
+	var preEnv, postEnv HookEnv
+
+	r := NewChangesetsRegistry()
+	r.entries["test-cs"] = registryEntry{
+		changeset: &recordingChangeset{},
+		preHooks: []PreHook{{
+			HookDefinition: HookDefinition{Name: "pre-env-checker", FailurePolicy: Abort},
+			Func: func(_ context.Context, params PreHookParams) error {
+				preEnv = params.Env
+				return nil
+			},
+		}},
+		postHooks: []PostHook{{
+			HookDefinition: HookDefinition{Name: "post-env-checker", FailurePolicy: Abort},
+			Func: func(_ context.Context, params PostHookParams) error {
+				postEnv = params.Env
+				return nil
+			},
+		}},
+	}
+
+	env := hookTestEnv(t)
+	env.Offchain = memory.NewMemoryJobDistributor()
+	env.CRERunner = cre.NewRunner()
+
+	_, err := r.Apply("test-cs", env)
+	require.NoError(t, err)
+
+	for _, hookEnv := range []HookEnv{preEnv, postEnv} {
+		require.NotNil(t, hookEnv.Offchain)
+		require.NotNil(t, hookEnv.CRE)
+
+		_, isClient := hookEnv.Offchain.(offchain.Client)
+		assert.False(t, isClient, "hooks must not be able to write to job-distributor")
+	}
+}
+
+func Test_Apply_HookEnv_NoOffchainOrCRE(t *testing.T) {
+	t.Parallel()
+
+	var receivedEnv HookEnv
+
+	r := NewChangesetsRegistry()
+	r.entries["test-cs"] = registryEntry{
+		changeset: &recordingChangeset{},
+		preHooks: []PreHook{{
+			HookDefinition: HookDefinition{Name: "env-checker", FailurePolicy: Abort},
+			Func: func(_ context.Context, params PreHookParams) error {
+				receivedEnv = params.Env
+				return nil
+			},
+		}},
+	}
+
+	_, err := r.Apply("test-cs", hookTestEnv(t))
+	require.NoError(t, err)
+
+	assert.Nil(t, receivedEnv.Offchain)
+	assert.Nil(t, receivedEnv.CRE)
+}
+
 func Test_WithPreHooks_Additive(t *testing.T) {
 	t.Parallel()
 
@@ -1163,12 +1230,92 @@ func Test_Apply_HappyPath_WithHooks(t *testing.T) {
 
 	got, err := r.Apply("test-cs", hookTestEnv(t))
 	require.NoError(t, err)
-	assert.Equal(t, fdeployment.ChangesetOutput{}, got)
+	require.Len(t, got.HookRecords, 2)
+	assert.Equal(t, fdeployment.ChangesetOutput{HookRecords: got.HookRecords}, got)
 	assert.True(t, cs.applyCalled, "changeset should have been called")
 	assert.True(t, preHookRan, "pre-hook should have run")
 	assert.True(t, postHookRan, "post-hook should have run")
 }
 
+func Test_Apply_HookRecords(t *testing.T) {
+	t.Parallel()
+
+	var seenByPostHook []fdeployment.HookRecord
+
+	r := NewChangesetsRegistry()
+	r.AddGlobalPreHooks(PreHook{
+		HookDefinition: HookDefinition{Name: "check-balance"},
+		Func:           func(context.Context, PreHookParams) error { return nil },
+	})
+	r.AddGlobalPostHooks(PostHook{
+		HookDefinition: HookDefinition{Name: "verify", FailurePolicy: Warn},
+		Func: func(_ context.Context, params PostHookParams) error {
+			seenByPostHook = params.Output.HookRecords
+			return errors.New("router not found")
+		},
+	})
+	r.Add("test-cs", &recordingChangeset{})
+
+	got, err := r.Apply("test-cs", hookTestEnv(t))
+	require.NoError(t, err)
+
+	require.Len(t, got.HookRecords, 2)
+	pre, post := got.HookRecords[0], got.HookRecords[1]
+
+	assert.Equal(t, "check-balance", pre.Name)
+	assert.Equal(t, fdeployment.HookPhasePre, pre.Phase)
+	assert.Equal(t, "global pre-hook", pre.Kind)
+	assert.Equal(t, "Abort", pre.FailurePolicy)
+	assert.Equal(t, fdeployment.HookStatusPassed, pre.Status)
+	assert.Empty(t, pre.Error)
+
+	assert.Equal(t, "verify", post.Name)
+	assert.Equal(t, fdeployment.HookPhasePost, post.Phase)
+	assert.Equal(t, "global post-hook", post.Kind)
+	assert.Equal(t, "Warn", post.FailurePolicy)
+	assert.Equal(t, fdeployment.HookStatusWarned, post.Status)
+	assert.Equal(t, "router not found", post.Error)
+
+	// Post-hooks see the records of the pre-hooks
+	assert.Equal(t, []fdeployment.HookRecord{pre}, seenByPostHook)
+}
+
+func Test_Apply_HookRecords_PreHookAbort(t *testing.T) {
+	t.Parallel()
+
+	cs := &recordingChangeset{}
+	r := NewChangesetsRegistry()
+	r.AddGlobalPreHooks(
+		PreHook{
+			HookDefinition: HookDefinition{Name: "blocker"},
+			Func:           func(context.Context, PreHookParams) error { return errors.New("blocked") },
+		},
+		PreHook{
+			HookDefinition: HookDefinition{Name: "also-blocker"},
+			Func:           func(context.Context, PreHookParams) error { return errors.New("also blocked") },
+		},
+		PreHook{
+			HookDefinition: HookDefinition{Name: "passes"},
+			Func:           func(context.Context, PreHookParams) error { return nil },
+		},
+	)
+	r.Add("test-cs", cs)
+
+	got, err := r.Apply("test-cs", hookTestEnv(t))
+	require.ErrorContains(t, err, "blocked")
+	require.ErrorContains(t, err, "also blocked")
+	assert.False(t, cs.applyCalled)
+
+	// The output holds only the records, so the failed hooks can still be reported. Every pre-hook
+	// runs, so that all failures are reported at once.
+	assert.Equal(t, fdeployment.ChangesetOutput{HookRecords: got.HookRecords}, got)
+	require.Len(t, got.HookRecords, 3)
+	assert.Equal(t, fdeployment.HookStatusFailed, got.HookRecords[0].Status)
+	assert.Equal(t, "blocked", got.HookRecords[0].Error)
+	assert.Equal(t, fdeployment.HookStatusFailed, got.HookRecords[1].Status)
+	assert.Equal(t, fdeployment.HookStatusPassed, got.HookRecords[2].Status)
+}
+
 func Test_WithPostProposalHooks(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,108 @@
+package changeset
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+// PayloadType returns the type of the payload expected in the input of the changeset. This is the
+// input type of the config resolver if the changeset has one, or the config type otherwise.
+func (c Configurations) PayloadType() reflect.Type {
+	if c.ConfigResolver != nil {
+		if rt := reflect.TypeOf(c.ConfigResolver); rt.Kind() == reflect.Func && rt.NumIn() == 1 {
+			return rt.In(0)
+		}
+	}
+
+	return c.InputType
+}
+
+// GetInputSchema returns the JSON Schema of the payload expected in the input of a changeset.
+//
+// See PayloadSchema for how the schema is derived from the payload type.
+func (r *ChangesetsRegistry) GetInputSchema(key string) (*jsonschema.Schema, error) {
+	cfg, err := r.GetConfigurations(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.PayloadType() == nil {
+		return nil, fmt.Errorf("changeset '%s' has no input type", key)
+	}
+
+	schema, err := PayloadSchema(cfg.PayloadType())
+	if err != nil {
+		return nil, fmt.Errorf("changeset '%s': %w", key, err)
+	}
+
+	return schema, nil
+}
+
+var (
+	bigIntType   = reflect.TypeFor[big.Int]()
+	bigFloatType = reflect.TypeFor[big.Float]()
+
+	// customSchemaType is implemented by types which provide their own schema to jsonschema.
+	customSchemaType = reflect.TypeFor[interface{ JSONSchema() *jsonschema.Schema }]()
+)
+
+// PayloadSchema returns the JSON Schema of a payload decoded into a value of type t, following
+// the rules of the strict payload decoding:
+//
+//   - Fields are named by their json tags, and unknown fields are not allowed.
+//   - Fields are optional, unless tagged with `jsonschema:"required"`.
+//   - Types which decode themselves from text, such as addresses and versions, are strings.
+//     *big.Int values are integers.
+//
+// Named types are kept in "$defs", keyed by their package path and name so that the definitions of
+// several schemas can be merged without conflicts.
+func PayloadSchema(t reflect.Type) (schema *jsonschema.Schema, err error) {
+	// The reflector panics on types which cannot be represented in JSON, such as channels
+	defer func() {
+		if r := recover(); r != nil {
+			schema, err = nil, fmt.Errorf("generate JSON schema for %s: %v", t, r)
+		}
+	}()
+
+	reflector := &jsonschema.Reflector{
+		Anonymous:                  true,
+		RequiredFromJSONSchemaTags: true,
+		Namer:                      schemaDefinitionName,
+		Mapper:                     mapPayloadSchema,
+	}
+
+	return reflector.ReflectFromType(t), nil
+}
+
+// schemaDefinitionName names a type by its package path and name, with the slashes replaced so
+// that the name can be used in a JSON pointer without escaping.
+func schemaDefinitionName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return ""
+	}
+
+	return strings.ReplaceAll(t.PkgPath()+"."+t.Name(), "/", ".")
+}
+
+// mapPayloadSchema returns the schema of types which do not decode from their fields.
+func mapPayloadSchema(t reflect.Type) *jsonschema.Schema {
+	switch {
+	case t == bigIntType:
+		return &jsonschema.Schema{Type: "integer"}
+	case t == bigFloatType:
+		return &jsonschema.Schema{Type: "number"}
+	case t.Implements(customSchemaType) || reflect.PointerTo(t).Implements(customSchemaType):
+		return nil
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return &jsonschema.Schema{Type: "string"}
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		// Any value may be accepted by a custom decoder
+		return &jsonschema.Schema{}
+	default:
+		return nil
+	}
+}
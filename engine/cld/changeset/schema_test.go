@@ -0,0 +1,114 @@
+package changeset
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+type schemaTestRoute struct {
+	Router common.Address   `json:"router"`
+	Next   *schemaTestRoute `json:"next,omitempty"`
+}
+
+type schemaTestConfig struct {
+	Selector uint64            `json:"selector" jsonschema:"required"`
+	Amount   *big.Int          `json:"amount"`
+	Routes   []schemaTestRoute `json:"routes"`
+	Raw      json.RawMessage   `json:"raw"`
+	Skipped  string            `json:"-"`
+}
+
+func TestPayloadSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := PayloadSchema(reflect.TypeFor[schemaTestConfig]())
+	require.NoError(t, err)
+
+	b, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	const (
+		configDef = "github.com.smartcontractkit.chainlink-deployments-framework.engine.cld.changeset.schemaTestConfig"
+		routeDef  = "github.com.smartcontractkit.chainlink-deployments-framework.engine.cld.changeset.schemaTestRoute"
+	)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/`+configDef+`",
+		"$defs": {
+			"`+configDef+`": {
+				"type": "object",
+				"properties": {
+					"selector": {"type": "integer"},
+					"amount": {"type": "integer"},
+					"routes": {"type": "array", "items": {"$ref": "#/$defs/`+routeDef+`"}},
+					"raw": true
+				},
+				"additionalProperties": false,
+				"required": ["selector"]
+			},
+			"`+routeDef+`": {
+				"type": "object",
+				"properties": {
+					"router": {"type": "string"},
+					"next": {"$ref": "#/$defs/`+routeDef+`"}
+				},
+				"additionalProperties": false
+			}
+		}
+	}`, string(b))
+}
+
+func TestPayloadSchema_UnsupportedType(t *testing.T) {
+	t.Parallel()
+
+	_, err := PayloadSchema(reflect.TypeFor[struct {
+		Updates chan int `json:"updates"`
+	}]())
+	require.ErrorContains(t, err, "unsupported type chan int")
+}
+
+func TestChangesetsRegistry_GetInputSchema(t *testing.T) {
+	t.Parallel()
+
+	type resolverInput struct {
+		Name string `json:"name"`
+	}
+	resolver := func(in resolverInput) (schemaTestConfig, error) { return schemaTestConfig{}, nil }
+
+	cs := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, schemaTestConfig) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(fdeployment.Environment, schemaTestConfig) error { return nil },
+	)
+
+	registry := NewChangesetsRegistry()
+	registry.Add("0001_env_input", Configure(cs).WithEnvInput())
+	registry.Add("0002_resolver", Configure(cs).WithConfigResolver(fresolvers.ConfigResolver(resolver)))
+	registry.Add("0003_untyped", noopChangeset{})
+
+	schema, err := registry.GetInputSchema("0001_env_input")
+	require.NoError(t, err)
+	assert.Contains(t, schema.Ref, "schemaTestConfig")
+
+	// The payload of changesets with a config resolver is the input of the resolver
+	schema, err = registry.GetInputSchema("0002_resolver")
+	require.NoError(t, err)
+	assert.Contains(t, schema.Ref, "resolverInput")
+
+	_, err = registry.GetInputSchema("0003_untyped")
+	require.ErrorContains(t, err, "changeset '0003_untyped' has no input type")
+
+	_, err = registry.GetInputSchema("0004_unknown")
+	require.ErrorContains(t, err, "changeset '0004_unknown' not found")
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+var (
+	auditShort = "Audit log operations"
+
+	auditLong = text.LongDesc(`
+		Commands for inspecting the audit log of changeset executions.
+
+		The audit log is an append-only, hash-chained JSON Lines file written by the audit log
+		hooks under the environment directory.
+	`)
+)
+
+// Config holds the configuration for audit commands.
+type Config struct {
+	// Logger is the logger to use for command output. Required.
+	Logger logger.Logger
+
+	// Domain is the domain context for the commands. Required.
+	Domain domain.Domain
+}
+
+// Validate checks that all required configuration fields are set.
+func (c Config) Validate() error {
+	var missing []string
+
+	if c.Logger == nil {
+		missing = append(missing, "Logger")
+	}
+	if c.Domain.RootPath() == "" {
+		missing = append(missing, "Domain")
+	}
+
+	if len(missing) > 0 {
+		return errors.New("audit.Config: missing required fields: " + strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// NewCommand creates a new audit command with all subcommands.
+func NewCommand(cfg Config) (*cobra.Command, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: auditShort,
+		Long:  auditLong,
+	}
+
+	cmd.AddCommand(newVerifyCmd(cfg))
+
+	return cmd, nil
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/auditlog"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func executeCommand(t *testing.T, dom domain.Domain, args ...string) (*bytes.Buffer, error) {
+	t.Helper()
+
+	cmd, err := NewCommand(Config{Logger: logger.Nop(), Domain: dom})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+
+	return out, cmd.Execute()
+}
+
+func TestNewCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewCommand(Config{Logger: logger.Nop(), Domain: domain.NewDomain(t.TempDir(), "testdomain")})
+	require.NoError(t, err)
+
+	assert.Equal(t, "audit", cmd.Use)
+	assert.Equal(t, auditShort, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+
+	subs := cmd.Commands()
+	require.Len(t, subs, 1)
+	assert.Equal(t, "verify", subs[0].Use)
+	require.NotNil(t, subs[0].Flags().Lookup("environment"))
+	require.NotNil(t, subs[0].Flags().Lookup("head"))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCommand(Config{})
+	require.EqualError(t, err, "audit.Config: missing required fields: Logger, Domain")
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	newLog := func(t *testing.T) (domain.Domain, []auditlog.Entry) {
+		t.Helper()
+
+		dom := domain.NewDomain(t.TempDir(), "testdomain")
+		path := auditlog.FilePath(dom.EnvDir("staging"))
+
+		var entries []auditlog.Entry
+		for _, key := range []string{"0001_deploy", "0002_configure"} {
+			entry, err := auditlog.Append(path, auditlog.Entry{
+				Event: auditlog.EventPreApply, Environment: "staging", ChangesetKey: key, Operator: "alice",
+			})
+			require.NoError(t, err)
+			entries = append(entries, entry)
+		}
+
+		return dom, entries
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		t.Parallel()
+
+		dom, entries := newLog(t)
+		out, err := executeCommand(t, dom, "verify", "-e", "staging", "--head", entries[0].Hash)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "✅ Audit log of testdomain staging is intact: 2 entries, head "+entries[1].Hash)
+	})
+
+	t.Run("edited entry", func(t *testing.T) {
+		t.Parallel()
+
+		dom, _ := newLog(t)
+		path := auditlog.FilePath(dom.EnvDir("staging"))
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, bytes.Replace(b, []byte("0001_deploy"), []byte("0001_other"), 1), 0o600))
+
+		_, err = executeCommand(t, dom, "verify", "-e", "staging")
+		require.ErrorIs(t, err, auditlog.ErrChainBroken)
+		require.ErrorContains(t, err, "line 1: seq 1: hash")
+	})
+
+	t.Run("truncated log", func(t *testing.T) {
+		t.Parallel()
+
+		dom, entries := newLog(t)
+		path := auditlog.FilePath(dom.EnvDir("staging"))
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b[:bytes.IndexByte(b, '\n')+1], 0o600))
+
+		_, err = executeCommand(t, dom, "verify", "-e", "staging", "--head", entries[1].Hash)
+		require.ErrorIs(t, err, auditlog.ErrChainBroken)
+		require.ErrorContains(t, err, "no entry with hash "+entries[1].Hash)
+	})
+
+	t.Run("no log", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := executeCommand(t, domain.NewDomain(t.TempDir(), "testdomain"), "verify", "-e", "staging")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/auditlog"
+)
+
+var (
+	verifyShort = "Verify the hash chain of the audit log"
+
+	verifyLong = text.LongDesc(`
+		Verifies that the entries of the audit log of an environment form an intact hash chain,
+		reporting the first entry which was edited, removed or reordered.
+
+		Entries removed from the end of the log cannot be detected from the log alone. Keep the
+		head hash printed by this command elsewhere, and pass it with --head later to check that
+		the log still contains it.
+	`)
+
+	verifyExample = text.Examples(`
+		# Verify the audit log of the staging environment
+		ccip audit verify --environment staging
+
+		# Verify that the audit log still contains a previously recorded head
+		ccip audit verify --environment staging --head sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	`)
+)
+
+// newVerifyCmd creates the "verify" subcommand for verifying the audit log.
+func newVerifyCmd(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "verify",
+		Short:   verifyShort,
+		Long:    verifyLong,
+		Example: verifyExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			envKey := flags.MustString(cmd.Flags().GetString("environment"))
+			heads, _ := cmd.Flags().GetStringSlice("head")
+
+			path := auditlog.FilePath(cfg.Domain.EnvDir(envKey))
+			result, err := auditlog.Verify(path)
+			if err != nil {
+				return fmt.Errorf("audit log verification failed for %s %s: %w", cfg.Domain, envKey, err)
+			}
+			for _, head := range heads {
+				if !result.Contains(head) {
+					return fmt.Errorf("audit log verification failed for %s %s: %w: no entry with hash %s",
+						cfg.Domain, envKey, auditlog.ErrChainBroken, head,
+					)
+				}
+			}
+
+			cmd.Printf("✅ Audit log of %s %s is intact: %d entries, head %s\n",
+				cfg.Domain, envKey, len(result.Entries), result.Head(),
+			)
+
+			return nil
+		},
+	}
+
+	// Shared flags
+	flags.Environment(cmd)
+
+	// Local flags specific to this command
+	cmd.Flags().StringSlice("head", nil, "Require the audit log to contain the entries with these hashes, such as previously recorded heads")
+
+	return cmd
+}
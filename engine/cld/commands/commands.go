@@ -34,6 +34,7 @@ import (
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	cs "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/addressbook"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/audit"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/contract"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/datastore"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/mcms"
@@ -62,16 +63,25 @@ func New(lggr logger.Logger) *Commands {
 // StateConfig holds configuration for state commands.
 type StateConfig struct {
 	// ViewState is the function that generates state from an environment.
-	// This is domain-specific and must be provided by the user.
+	// This is domain-specific and must be provided by the user, unless ChainView is set.
 	ViewState state.ViewStateFunc
+
+	// ChainView generates the view of a single chain, for state which is generated
+	// incrementally per chain. Optional, used instead of ViewState.
+	ChainView fdeployment.ChainViewFunc
+
+	// ChainViewOptions configures the incremental state generated with ChainView. Optional.
+	ChainViewOptions []fdeployment.IncrementalViewOption
 }
 
 // State creates the state command group for managing environment state.
 func (c *Commands) State(dom domain.Domain, cfg StateConfig) (*cobra.Command, error) {
 	return state.NewCommand(state.Config{
-		Logger:    c.lggr,
-		Domain:    dom,
-		ViewState: cfg.ViewState,
+		Logger:           c.lggr,
+		Domain:           dom,
+		ViewState:        cfg.ViewState,
+		ChainView:        cfg.ChainView,
+		ChainViewOptions: cfg.ChainViewOptions,
 	})
 }
 
@@ -91,6 +101,14 @@ func (c *Commands) AddressBook(dom domain.Domain) (*cobra.Command, error) {
 	})
 }
 
+// Audit creates the audit command group for verifying the audit log.
+func (c *Commands) Audit(dom domain.Domain) (*cobra.Command, error) {
+	return audit.NewCommand(audit.Config{
+		Logger: c.lggr,
+		Domain: dom,
+	})
+}
+
 // MCMSConfig holds configuration for MCMS commands.
 type MCMSConfig struct {
 	// ProposalContextProvider creates proposal context for analysis.
@@ -150,3 +150,21 @@ func TestCommands_MCMS_MissingProposalContextProvider(t *testing.T) {
 	assert.Nil(t, cmd)
 	require.ErrorContains(t, err, "missing required fields: ProposalContextProvider")
 }
+
+func TestCommands_Audit(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.Nop()
+	cmds := New(lggr)
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+
+	cmd, err := cmds.Audit(dom)
+
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+	assert.Equal(t, "audit", cmd.Use)
+
+	subs := cmd.Commands()
+	require.Len(t, subs, 1)
+	assert.Equal(t, "verify", subs[0].Use)
+}
@@ -18,7 +18,9 @@ var (
 		Commands for managing datastore artifacts.
 
 		The datastore contains contract addresses and metadata for deployed contracts.
-		These commands allow merging changeset artifacts and syncing to the catalog service.
+		These commands allow merging changeset artifacts, verifying the local datastore files and
+		writing their manifest, syncing to the catalog service, promoting records between
+		environments and exporting or importing address refs.
 	`)
 )
 
@@ -75,7 +77,12 @@ func NewCommand(cfg Config) (*cobra.Command, error) {
 	}
 
 	cmd.AddCommand(newMergeCmd(cfg))
+	cmd.AddCommand(newVerifyCmd(cfg))
+	cmd.AddCommand(newWriteManifestCmd(cfg))
 	cmd.AddCommand(newSyncToCatalogCmd(cfg))
+	cmd.AddCommand(newPromoteCmd(cfg))
+	cmd.AddCommand(newExportCmd(cfg))
+	cmd.AddCommand(newImportCmd(cfg))
 
 	return cmd, nil
 }
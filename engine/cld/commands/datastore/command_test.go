@@ -22,7 +22,7 @@ import (
 // Methods are not called in tests since we mock the CatalogMerger and CatalogSyncer functions.
 type mockCatalogStore struct{}
 
-func (m *mockCatalogStore) WithTransaction(_ context.Context, _ fdatastore.TransactionLogic) error {
+func (m *mockCatalogStore) WithTransaction(_ context.Context, _ fdatastore.TransactionLogic, _ ...fdatastore.TransactionOption) error {
 	return nil
 }
 
@@ -42,6 +42,10 @@ func (m *mockCatalogStore) EnvMetadata() fdatastore.MutableUnaryStoreV2[fdatasto
 	return nil
 }
 
+func (m *mockCatalogStore) Watch(_ context.Context, _ fdatastore.WatchFilter) (<-chan fdatastore.ChangeEvent, error) {
+	return nil, nil
+}
+
 // newTestCommand creates a new command with a test domain rooted in a temp directory.
 // This helper reduces boilerplate and ensures portability across platforms.
 func newTestCommand(t *testing.T, deps Deps) (*cobra.Command, error) {
@@ -74,13 +78,13 @@ func TestNewCommand_Structure(t *testing.T) {
 
 	// Verify subcommands
 	subs := cmd.Commands()
-	require.Len(t, subs, 2)
+	require.Len(t, subs, 7)
 
 	uses := make([]string, len(subs))
 	for i, sc := range subs {
 		uses[i] = sc.Use
 	}
-	assert.ElementsMatch(t, []string{"merge", "sync-to-catalog"}, uses)
+	assert.ElementsMatch(t, []string{"merge", "verify", "write-manifest", "sync-to-catalog", "promote", "export", "import"}, uses)
 }
 
 // TestNewCommand_MergeFlags verifies the merge subcommand has correct local flags.
@@ -180,7 +184,7 @@ func TestMerge_FileMode_Success(t *testing.T) {
 				DatastoreType: cfgdomain.DatastoreTypeFile,
 			}, nil
 		},
-		FileMerger: func(_ domain.EnvDir, name, timestamp string) error {
+		FileMerger: func(_ domain.EnvDir, name, timestamp string, _ ...domain.MergeDataStoreOption) error {
 			fileMergerCalled = true
 			mergedName = name
 			mergedTimestamp = timestamp
@@ -217,7 +221,7 @@ func TestMerge_FileMode_WithTimestamp(t *testing.T) {
 				DatastoreType: cfgdomain.DatastoreTypeFile,
 			}, nil
 		},
-		FileMerger: func(_ domain.EnvDir, _, timestamp string) error {
+		FileMerger: func(_ domain.EnvDir, _, timestamp string, _ ...domain.MergeDataStoreOption) error {
 			mergedTimestamp = timestamp
 
 			return nil
@@ -298,7 +302,7 @@ func TestMerge_AllMode_Success(t *testing.T) {
 
 			return nil
 		},
-		FileMerger: func(_ domain.EnvDir, _, _ string) error {
+		FileMerger: func(_ domain.EnvDir, _, _ string, _ ...domain.MergeDataStoreOption) error {
 			fileMergerCalled = true
 
 			return nil
@@ -386,7 +390,7 @@ func TestMerge_FileMergerError(t *testing.T) {
 				DatastoreType: cfgdomain.DatastoreTypeFile,
 			}, nil
 		},
-		FileMerger: func(_ domain.EnvDir, _, _ string) error {
+		FileMerger: func(_ domain.EnvDir, _, _ string, _ ...domain.MergeDataStoreOption) error {
 			return expectedError
 		},
 	})
@@ -18,7 +18,7 @@ type ConfigLoaderFunc func(dom domain.Domain, envKey string, lggr logger.Logger)
 type CatalogLoaderFunc func(ctx context.Context, envKey string, cfg *config.Config, dom domain.Domain) (fdatastore.CatalogStore, error)
 
 // FileMergerFunc merges changeset datastore to local files.
-type FileMergerFunc func(envDir domain.EnvDir, name, timestamp string) error
+type FileMergerFunc func(envDir domain.EnvDir, name, timestamp string, opts ...domain.MergeDataStoreOption) error
 
 // CatalogMergerFunc merges changeset datastore to catalog.
 type CatalogMergerFunc func(ctx context.Context, envDir domain.EnvDir, name, timestamp string, catalog fdatastore.CatalogStore) error
@@ -26,6 +26,10 @@ type CatalogMergerFunc func(ctx context.Context, envDir domain.EnvDir, name, tim
 // CatalogSyncerFunc syncs the entire local datastore to catalog.
 type CatalogSyncerFunc func(ctx context.Context, envDir domain.EnvDir, catalog fdatastore.CatalogStore) error
 
+// catalogConflictAttempts is the number of times the default catalog merger and syncer apply the
+// datastore when the catalog reports a conflict with a concurrent change.
+const catalogConflictAttempts = 5
+
 // defaultConfigLoader is the production implementation that loads config.
 func defaultConfigLoader(dom domain.Domain, envKey string, lggr logger.Logger) (*config.Config, error) {
 	return config.Load(dom, envKey, lggr)
@@ -37,18 +41,22 @@ func defaultCatalogLoader(ctx context.Context, envKey string, cfg *config.Config
 }
 
 // defaultFileMerger is the production implementation that merges to files.
-func defaultFileMerger(envDir domain.EnvDir, name, timestamp string) error {
-	return envDir.MergeChangesetDataStore(name, timestamp)
+func defaultFileMerger(envDir domain.EnvDir, name, timestamp string, opts ...domain.MergeDataStoreOption) error {
+	return envDir.MergeChangesetDataStore(name, timestamp, opts...)
 }
 
-// defaultCatalogMerger is the production implementation that merges to catalog.
+// defaultCatalogMerger is the production implementation that merges to catalog, retrying on
+// conflict.
 func defaultCatalogMerger(ctx context.Context, envDir domain.EnvDir, name, timestamp string, catalog fdatastore.CatalogStore) error {
-	return envDir.MergeChangesetDataStoreCatalog(ctx, name, timestamp, catalog)
+	return envDir.MergeChangesetDataStoreCatalog(ctx, name, timestamp, catalog,
+		fdatastore.WithRetryOnConflict(catalogConflictAttempts),
+	)
 }
 
-// defaultCatalogSyncer is the production implementation that syncs to catalog.
+// defaultCatalogSyncer is the production implementation that syncs to catalog, retrying on
+// conflict.
 func defaultCatalogSyncer(ctx context.Context, envDir domain.EnvDir, catalog fdatastore.CatalogStore) error {
-	return envDir.SyncDataStoreToCatalog(ctx, catalog)
+	return envDir.SyncDataStoreToCatalog(ctx, catalog, fdatastore.WithRetryOnConflict(catalogConflictAttempts))
 }
 
 // Deps holds the injectable dependencies for datastore commands.
@@ -0,0 +1,272 @@
+package datastore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+)
+
+var (
+	exportShort = "Export datastore records as CSV, YAML or Markdown"
+
+	exportLong = text.LongDesc(`
+		Exports the records of an environment's datastore as a CSV file, a YAML list or a
+		Markdown table, for sharing contract addresses with teams that work from spreadsheets.
+
+		The --kind flag selects the records to export: address_ref (the default), chain_metadata,
+		contract_metadata or env_metadata. Metadata is written as JSON in CSV and Markdown output.
+
+		Chain names are resolved from chain selectors with chain-selectors. Labels are joined into
+		a single cell with the label separator in CSV and Markdown output. CSV cells which a
+		spreadsheet would evaluate as a formula are prefixed with a single quote.
+
+		Available address ref columns: chain_selector, chain_name, type, version, qualifier,
+		address, labels. The columns, label separator, types and qualifiers flags only apply to
+		address refs.
+	`)
+
+	exportExample = text.Examples(`
+		# Export all address refs of the mainnet environment as CSV to stdout
+		ccip datastore export --environment mainnet
+
+		# Export the Router addresses as a Markdown table
+		ccip datastore export --environment mainnet --format markdown \
+		  --columns chain_name,address --types Router
+
+		# Export to a YAML file
+		ccip datastore export --environment mainnet --format yaml --output addresses.yaml
+
+		# Export the contract metadata of a chain
+		ccip datastore export --environment mainnet --kind contract_metadata \
+		  --chain-selectors 5009297550715157269
+	`)
+)
+
+type exportFlags struct {
+	environment    string
+	kind           string
+	format         string
+	output         string
+	columns        []string
+	labelSeparator string
+	chainSelectors []string
+	types          []string
+	qualifiers     []string
+}
+
+// newExportCmd creates the "export" subcommand for exporting address refs.
+func newExportCmd(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   exportShort,
+		Long:    exportLong,
+		Example: exportExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			columns, _ := cmd.Flags().GetStringSlice("columns")
+			chainSelectors, _ := cmd.Flags().GetStringSlice("chain-selectors")
+			types, _ := cmd.Flags().GetStringSlice("types")
+			qualifiers, _ := cmd.Flags().GetStringSlice("qualifiers")
+
+			f := exportFlags{
+				environment:    flags.MustString(cmd.Flags().GetString("environment")),
+				kind:           flags.MustString(cmd.Flags().GetString("kind")),
+				format:         flags.MustString(cmd.Flags().GetString("format")),
+				output:         flags.MustString(cmd.Flags().GetString("output")),
+				labelSeparator: flags.MustString(cmd.Flags().GetString("label-separator")),
+				columns:        columns,
+				chainSelectors: chainSelectors,
+				types:          types,
+				qualifiers:     qualifiers,
+			}
+
+			return runExport(cmd, cfg, f)
+		},
+	}
+
+	// Shared flags
+	flags.Environment(cmd)
+
+	// Local flags specific to this command
+	cmd.Flags().StringP("kind", "k", string(fdatastore.ChangeKindAddressRef),
+		"Records to export: address_ref, chain_metadata, contract_metadata or env_metadata")
+	cmd.Flags().StringP("format", "f", string(fdatastore.ExportFormatCSV), "Output format: csv, yaml or markdown")
+	cmd.Flags().StringP("output", "o", "", "File to write the export to (default: stdout)")
+	cmd.Flags().StringSlice("columns", nil, "Columns to export, in order (default: all)")
+	cmd.Flags().String("label-separator", fdatastore.DefaultLabelSeparator, "Separator used to join labels into a single cell")
+	cmd.Flags().StringSlice("chain-selectors", nil, "Only export records on these chain selectors")
+	cmd.Flags().StringSlice("types", nil, "Only export address refs of these contract types")
+	cmd.Flags().StringSlice("qualifiers", nil, "Only export address refs with these qualifiers")
+
+	return cmd
+}
+
+// runExport executes the export command logic.
+func runExport(cmd *cobra.Command, cfg Config, f exportFlags) error {
+	format, err := fdatastore.ParseExportFormat(f.format)
+	if err != nil {
+		return err
+	}
+
+	export, err := f.exporter(format)
+	if err != nil {
+		return err
+	}
+
+	ds, err := loadEnvDataStore(cmd.Context(), cfg, cfg.Domain, f.environment)
+	if err != nil {
+		return fmt.Errorf("failed to load datastore for %s %s: %w", cfg.Domain, f.environment, err)
+	}
+
+	var w io.Writer = cmd.OutOrStdout()
+	if f.output != "" {
+		file, createErr := os.Create(f.output)
+		if createErr != nil {
+			return fmt.Errorf("failed to create output file: %w", createErr)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	kind := strings.ReplaceAll(f.kind, "_", " ")
+	if fdatastore.ChangeKind(f.kind) == fdatastore.ChangeKindAddressRef {
+		kind = "address refs"
+	}
+	if err = export(w, ds); err != nil {
+		return fmt.Errorf("failed to export %s: %w", kind, err)
+	}
+
+	if f.output != "" {
+		cmd.Printf("✅ Exported %s to %s\n", kind, f.output)
+	}
+
+	return nil
+}
+
+// exporter validates the flags for the selected record kind and returns the function which writes
+// the export.
+func (f exportFlags) exporter(format fdatastore.ExportFormat) (func(io.Writer, fdatastore.DataStore) error, error) {
+	kind := fdatastore.ChangeKind(f.kind)
+
+	if kind != fdatastore.ChangeKindAddressRef {
+		for flag, values := range map[string][]string{
+			"columns":    f.columns,
+			"types":      f.types,
+			"qualifiers": f.qualifiers,
+		} {
+			if len(values) > 0 {
+				return nil, fmt.Errorf("--%s only applies to address refs", flag)
+			}
+		}
+	}
+
+	selectors, err := parseChainSelectors(f.chainSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case fdatastore.ChangeKindAddressRef:
+		opts := fdatastore.ExportOptions{LabelSeparator: f.labelSeparator}
+		if len(f.columns) > 0 {
+			if opts.Columns, err = fdatastore.ParseExportColumns(f.columns); err != nil {
+				return nil, err
+			}
+		}
+		if opts.Filters, err = addressRefFilters(f.chainSelectors, f.types, f.qualifiers); err != nil {
+			return nil, err
+		}
+
+		return func(w io.Writer, ds fdatastore.DataStore) error {
+			return fdatastore.ExportAddressRefs(w, ds, format, opts)
+		}, nil
+	case fdatastore.ChangeKindChainMetadata:
+		var filters []fdatastore.FilterFunc[fdatastore.ChainMetadataKey, fdatastore.ChainMetadata]
+		if selectors != nil {
+			filters = append(filters, func(records []fdatastore.ChainMetadata) []fdatastore.ChainMetadata {
+				return filterRecords(records, func(r fdatastore.ChainMetadata) bool { return selectors[r.ChainSelector] })
+			})
+		}
+
+		return func(w io.Writer, ds fdatastore.DataStore) error {
+			return fdatastore.ExportChainMetadata(w, ds, format, filters...)
+		}, nil
+	case fdatastore.ChangeKindContractMetadata:
+		var filters []fdatastore.FilterFunc[fdatastore.ContractMetadataKey, fdatastore.ContractMetadata]
+		if selectors != nil {
+			filters = append(filters, func(records []fdatastore.ContractMetadata) []fdatastore.ContractMetadata {
+				return filterRecords(records, func(r fdatastore.ContractMetadata) bool { return selectors[r.ChainSelector] })
+			})
+		}
+
+		return func(w io.Writer, ds fdatastore.DataStore) error {
+			return fdatastore.ExportContractMetadata(w, ds, format, filters...)
+		}, nil
+	case fdatastore.ChangeKindEnvMetadata:
+		if selectors != nil {
+			return nil, errors.New("--chain-selectors does not apply to env metadata")
+		}
+
+		return func(w io.Writer, ds fdatastore.DataStore) error {
+			return fdatastore.ExportEnvMetadata(w, ds, format)
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid record kind %q", f.kind)
+	}
+}
+
+// parseChainSelectors parses the chain selector flag into a set, returning nil when it is empty.
+func parseChainSelectors(chainSelectors []string) (map[uint64]bool, error) {
+	if len(chainSelectors) == 0 {
+		return nil, nil //nolint:nilnil // an empty flag selects every chain
+	}
+
+	selectors := make(map[uint64]bool, len(chainSelectors))
+	for _, s := range chainSelectors {
+		sel, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain selector %q: %w", s, err)
+		}
+		selectors[sel] = true
+	}
+
+	return selectors, nil
+}
+
+// addressRefFilters builds address ref filters from the chain selector, type and qualifier flags.
+func addressRefFilters(chainSelectors, types, qualifiers []string) ([]fdatastore.FilterFunc[fdatastore.AddressRefKey, fdatastore.AddressRef], error) {
+	var filters []fdatastore.FilterFunc[fdatastore.AddressRefKey, fdatastore.AddressRef]
+
+	selectors, err := parseChainSelectors(chainSelectors)
+	if err != nil {
+		return nil, err
+	}
+	if selectors != nil {
+		filters = append(filters, func(records []fdatastore.AddressRef) []fdatastore.AddressRef {
+			return filterRecords(records, func(r fdatastore.AddressRef) bool { return selectors[r.ChainSelector] })
+		})
+	}
+
+	if len(types) > 0 {
+		filters = append(filters, func(records []fdatastore.AddressRef) []fdatastore.AddressRef {
+			return filterRecords(records, func(r fdatastore.AddressRef) bool { return slices.Contains(types, r.Type.String()) })
+		})
+	}
+
+	if len(qualifiers) > 0 {
+		filters = append(filters, func(records []fdatastore.AddressRef) []fdatastore.AddressRef {
+			return filterRecords(records, func(r fdatastore.AddressRef) bool { return slices.Contains(qualifiers, r.Qualifier) })
+		})
+	}
+
+	return filters, nil
+}
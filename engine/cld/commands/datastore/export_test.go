@@ -0,0 +1,169 @@
+package datastore
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config"
+	cfgdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+// fileConfigLoader loads a config which uses the file datastore.
+func fileConfigLoader(_ domain.Domain, _ string, _ logger.Logger) (*config.Config, error) {
+	return &config.Config{DatastoreType: cfgdomain.DatastoreTypeFile}, nil
+}
+
+// newFileModeTestCommand executes the datastore command with the given args for a domain whose
+// environments use file datastores, and returns its output.
+func newFileModeTestCommand(t *testing.T, dom domain.Domain, args ...string) (*bytes.Buffer, error) {
+	t.Helper()
+
+	cmd, err := NewCommand(Config{
+		Logger: logger.Nop(),
+		Domain: dom,
+		Deps:   Deps{ConfigLoader: fileConfigLoader},
+	})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+
+	return out, cmd.Execute()
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+
+	ds := fdatastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(fdatastore.AddressRef{
+		Address: "0xrouter", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+	require.NoError(t, ds.Addresses().Add(fdatastore.AddressRef{
+		Address: "0xramp", ChainSelector: 2, Type: "OnRamp", Version: semver.MustParse("1.0.0"),
+	}))
+	require.NoError(t, ds.ContractMetadata().Add(fdatastore.ContractMetadata{
+		Address: "0xrouter", ChainSelector: 1, Metadata: map[string]any{"owner": "timelock"},
+	}))
+	require.NoError(t, ds.ContractMetadata().Add(fdatastore.ContractMetadata{
+		Address: "0xramp", ChainSelector: 2, Metadata: map[string]any{"owner": "deployer"},
+	}))
+	writeEnvDataStore(t, dom.EnvDir("mainnet"), ds)
+
+	t.Run("contract metadata", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := newFileModeTestCommand(t, dom,
+			"export", "-e", "mainnet", "--kind", "contract_metadata", "--chain-selectors", "2",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "chain_selector,chain_name,address,metadata\n2,,0xramp,\"{\"\"owner\"\":\"\"deployer\"\"}\"\n", out.String())
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := newFileModeTestCommand(t, dom,
+			"export", "-e", "mainnet", "--columns", "chain_selector,address", "--types", "Router",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "chain_selector,address\n1,0xrouter\n", out.String())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "addresses.md")
+		out, err := newFileModeTestCommand(t, dom,
+			"export", "-e", "mainnet", "--format", "markdown", "--columns", "type", "--output", path,
+		)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "✅ Exported address refs to "+path)
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "| type |\n| --- |\n| Router |\n| OnRamp |\n", string(b))
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newFileModeTestCommand(t, dom, "export", "-e", "mainnet", "--format", "xlsx")
+		require.ErrorContains(t, err, "invalid export format")
+
+		_, err = newFileModeTestCommand(t, dom, "export", "-e", "mainnet", "--columns", "owner")
+		require.ErrorContains(t, err, "invalid export column")
+
+		_, err = newFileModeTestCommand(t, dom, "export", "-e", "mainnet", "--chain-selectors", "abc")
+		require.ErrorContains(t, err, "invalid chain selector")
+
+		_, err = newFileModeTestCommand(t, dom, "export", "-e", "mainnet", "--kind", "jobs")
+		require.ErrorContains(t, err, `invalid record kind "jobs"`)
+
+		_, err = newFileModeTestCommand(t, dom, "export", "-e", "mainnet", "--kind", "chain_metadata", "--types", "Router")
+		require.ErrorContains(t, err, "--types only applies to address refs")
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+
+	t.Run("writes artifact", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "addresses.csv")
+		require.NoError(t, os.WriteFile(path, []byte(
+			"chain_selector,type,version,address,labels\n"+
+				"1,Router,1.0.0,0xrouter,a;b\n",
+		), 0o600))
+
+		out, err := newFileModeTestCommand(t, dom,
+			"import", "-e", "mainnet", "--file", path, "--name", "bootstrap", "--label-separator", ";",
+		)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "address refs: 1, chain metadata: 0, contract metadata: 0, env metadata: 0")
+		assert.Contains(t, out.String(), "datastore merge --environment mainnet --name bootstrap")
+
+		imported, err := dom.EnvDir("mainnet").ArtifactsDir().LoadDataStoreByChangesetKey("bootstrap")
+		require.NoError(t, err)
+		ref, err := imported.Addresses().Get(fdatastore.NewAddressRefKey(1, "Router", semver.MustParse("1.0.0"), ""))
+		require.NoError(t, err)
+		assert.Equal(t, "0xrouter", ref.Address)
+		assert.Equal(t, []string{"a", "b"}, ref.Labels.List())
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "addresses.csv")
+		require.NoError(t, os.WriteFile(path, []byte(
+			"chain_selector,type,version,address\n"+
+				"1,Router,latest,0xrouter\n",
+		), 0o600))
+
+		_, err := newFileModeTestCommand(t, dom, "import", "-e", "testnet", "--file", path)
+		require.ErrorContains(t, err, `line 2: invalid version "latest"`)
+		assert.NoDirExists(t, dom.EnvDir("testnet").ArtifactsDir().ChangesetDirPath("import"))
+	})
+
+	t.Run("missing file flag", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newFileModeTestCommand(t, dom, "import", "-e", "mainnet")
+		require.ErrorContains(t, err, `required flag(s) "file" not set`)
+	})
+}
@@ -0,0 +1,107 @@
+package datastore
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+)
+
+var (
+	importShort = "Import address refs from a CSV file"
+
+	importLong = text.LongDesc(`
+		Builds address refs from a CSV file and writes them to the environment as a changeset-style
+		datastore artifact. This is intended for bootstrapping environments whose contracts were
+		deployed outside of CLDF.
+
+		The first row of the CSV is a header using the export column names. The address, type and
+		version columns are required, as is one of chain_selector or chain_name. Every row is
+		validated, and nothing is written if any row is invalid.
+
+		The artifact is not merged automatically. Review it, then apply it with the merge command
+		using the same name.
+	`)
+
+	importExample = text.Examples(`
+		# Import address refs into the mainnet environment
+		ccip datastore import --environment mainnet --file addresses.csv
+
+		# Import address refs whose labels are separated by semicolons
+		ccip datastore import --environment mainnet --file addresses.csv --label-separator ";"
+	`)
+)
+
+type importFlags struct {
+	environment    string
+	file           string
+	name           string
+	labelSeparator string
+}
+
+// newImportCmd creates the "import" subcommand for importing address refs from a CSV file.
+func newImportCmd(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   importShort,
+		Long:    importLong,
+		Example: importExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			f := importFlags{
+				environment:    flags.MustString(cmd.Flags().GetString("environment")),
+				file:           flags.MustString(cmd.Flags().GetString("file")),
+				name:           flags.MustString(cmd.Flags().GetString("name")),
+				labelSeparator: flags.MustString(cmd.Flags().GetString("label-separator")),
+			}
+
+			return runImport(cmd, cfg, f)
+		},
+	}
+
+	// Shared flags
+	flags.Environment(cmd)
+
+	// Local flags specific to this command
+	cmd.Flags().String("file", "", "CSV file to import (required)")
+	cmd.Flags().StringP("name", "n", "import", "Name of the import artifact")
+	cmd.Flags().String("label-separator", fdatastore.DefaultLabelSeparator, "Separator between labels in the labels column")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// runImport executes the import command logic.
+func runImport(cmd *cobra.Command, cfg Config, f importFlags) error {
+	file, err := os.Open(f.file)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	ds, err := fdatastore.ImportAddressRefsCSV(file, fdatastore.ImportOptions{LabelSeparator: f.labelSeparator})
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", f.file, err)
+	}
+
+	artDir := cfg.Domain.EnvDir(f.environment).ArtifactsDir()
+	if err = artDir.SaveChangesetOutput(f.name, fdeployment.ChangesetOutput{DataStore: ds}); err != nil {
+		return fmt.Errorf("failed to save import artifact: %w", err)
+	}
+
+	counts, err := countRecords(ds.Seal())
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("📦 Imported %s into %s %s\n", f.file, cfg.Domain, f.environment)
+	cmd.Printf("   %s\n", counts)
+	cmd.Printf("✅ Wrote import artifact to %s\n", artDir.ChangesetDirPath(f.name))
+	cmd.Printf("   Review it, then run: datastore merge --environment %s --name %s\n", f.environment, f.name)
+
+	return nil
+}
@@ -1,13 +1,19 @@
 package datastore
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	evmprovider "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm/provider"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
 	cfgdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
 )
 
 var (
@@ -19,6 +25,12 @@ var (
 		- file: merges to local JSON files
 		- catalog: merges to the remote catalog service
 		- all: merges to both local files and catalog
+
+		When merging to local files, a manifest of the content hashes of the datastore files is
+		written alongside them, and verified when the environment is loaded. The manifest can be
+		signed with a local ed25519 key or an AWS KMS key. The local files are verified against the
+		existing manifest before merging, so files edited by hand are not signed into the new
+		manifest unless --skip-datastore-verification is set.
 	`)
 
 	mergeExample = text.Examples(`
@@ -27,6 +39,10 @@ var (
 
 		# Merge with a specific durable pipeline timestamp
 		ccip datastore merge --environment staging --name 0001_deploy_cap --timestamp 1234567890
+
+		# Merge and sign the datastore manifest with a KMS key
+		ccip datastore merge --environment staging --name 0001_deploy_cap \
+		  --manifest-kms-key-id alias/datastore-manifest --manifest-kms-key-region us-west-2
 	`)
 )
 
@@ -34,6 +50,33 @@ type mergeFlags struct {
 	environment string
 	name        string
 	timestamp   string
+	signer      manifestSignerFlags
+	skipVerify  bool
+}
+
+// manifestSignerFlags selects the key which signs the datastore manifest.
+type manifestSignerFlags struct {
+	keyFile      string
+	kmsKeyID     string
+	kmsKeyRegion string
+}
+
+// addManifestSignerFlags registers the flags which select the key to sign the datastore manifest.
+func addManifestSignerFlags(cmd *cobra.Command) {
+	cmd.Flags().String("manifest-key-file", "", "File containing a hex encoded ed25519 private key to sign the datastore manifest")
+	cmd.Flags().String("manifest-kms-key-id", "", "AWS KMS key ID to sign the datastore manifest")
+	cmd.Flags().String("manifest-kms-key-region", "", "AWS KMS key region, required with --manifest-kms-key-id")
+	cmd.MarkFlagsMutuallyExclusive("manifest-key-file", "manifest-kms-key-id")
+	cmd.MarkFlagsRequiredTogether("manifest-kms-key-id", "manifest-kms-key-region")
+}
+
+// getManifestSignerFlags reads the flags registered by addManifestSignerFlags.
+func getManifestSignerFlags(cmd *cobra.Command) manifestSignerFlags {
+	return manifestSignerFlags{
+		keyFile:      flags.MustString(cmd.Flags().GetString("manifest-key-file")),
+		kmsKeyID:     flags.MustString(cmd.Flags().GetString("manifest-kms-key-id")),
+		kmsKeyRegion: flags.MustString(cmd.Flags().GetString("manifest-kms-key-region")),
+	}
 }
 
 // newMergeCmd creates the "merge" subcommand for merging datastore artifacts.
@@ -48,6 +91,8 @@ func newMergeCmd(cfg Config) *cobra.Command {
 				environment: flags.MustString(cmd.Flags().GetString("environment")),
 				name:        flags.MustString(cmd.Flags().GetString("name")),
 				timestamp:   flags.MustString(cmd.Flags().GetString("timestamp")),
+				signer:      getManifestSignerFlags(cmd),
+				skipVerify:  flags.MustBool(cmd.Flags().GetBool("skip-datastore-verification")),
 			}
 
 			return runMerge(cmd, cfg, f)
@@ -60,6 +105,8 @@ func newMergeCmd(cfg Config) *cobra.Command {
 	// Local flags specific to this command
 	cmd.Flags().StringP("name", "n", "", "Changeset name (required)")
 	cmd.Flags().StringP("timestamp", "t", "", "Pipeline timestamp (optional)")
+	addManifestSignerFlags(cmd)
+	cmd.Flags().Bool("skip-datastore-verification", false, "Merge even if the local datastore files do not match the datastore manifest")
 	_ = cmd.MarkFlagRequired("name")
 
 	return cmd
@@ -73,11 +120,25 @@ func runMerge(cmd *cobra.Command, cfg Config, f mergeFlags) error {
 
 	// --- Load
 
+	var mergeOpts []domain.MergeDataStoreOption
+	signer, err := loadManifestSigner(f.signer)
+	if err != nil {
+		return err
+	}
+	if signer != nil {
+		mergeOpts = append(mergeOpts, domain.WithManifestSigner(signer))
+	}
+
 	// Load config to check datastore type
 	envCfg, err := deps.ConfigLoader(cfg.Domain, f.environment, cfg.Logger)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if f.skipVerify {
+		mergeOpts = append(mergeOpts, domain.WithoutManifestVerification())
+	} else {
+		mergeOpts = append(mergeOpts, domain.WithTrustedManifestSigners(envCfg.TrustedManifestSigners...))
+	}
 
 	// --- Execute
 
@@ -103,7 +164,7 @@ func runMerge(cmd *cobra.Command, cfg Config, f mergeFlags) error {
 	case cfgdomain.DatastoreTypeFile:
 		cmd.Printf("📁 Using file-based datastore mode\n")
 
-		if err := deps.FileMerger(envDir, f.name, f.timestamp); err != nil {
+		if err := deps.FileMerger(envDir, f.name, f.timestamp, mergeOpts...); err != nil {
 			return fmt.Errorf("error during datastore merge to file for %s %s %s: %w",
 				cfg.Domain, f.environment, f.name, err,
 			)
@@ -128,7 +189,7 @@ func runMerge(cmd *cobra.Command, cfg Config, f mergeFlags) error {
 			)
 		}
 
-		if err := deps.FileMerger(envDir, f.name, f.timestamp); err != nil {
+		if err := deps.FileMerger(envDir, f.name, f.timestamp, mergeOpts...); err != nil {
 			return fmt.Errorf("error during datastore merge to file for %s %s %s: %w",
 				cfg.Domain, f.environment, f.name, err,
 			)
@@ -144,3 +205,36 @@ func runMerge(cmd *cobra.Command, cfg Config, f mergeFlags) error {
 
 	return nil
 }
+
+// loadManifestSigner returns the signer for the datastore manifest selected by the flags, or nil
+// if the manifest is not to be signed.
+func loadManifestSigner(f manifestSignerFlags) (domain.ManifestSigner, error) {
+	switch {
+	case f.keyFile != "":
+		b, err := os.ReadFile(f.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest key file: %w", err)
+		}
+
+		key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest key: %w", err)
+		}
+		// Accept either the 32 byte seed or the 64 byte private key
+		if len(key) == ed25519.SeedSize {
+			key = ed25519.NewKeyFromSeed(key)
+		}
+
+		return domain.NewEd25519ManifestSigner(key)
+	case f.kmsKeyID != "":
+		// The AWS profile is taken from the environment
+		signer, err := evmprovider.NewKMSSigner(f.kmsKeyID, f.kmsKeyRegion, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create KMS signer: %w", err)
+		}
+
+		return domain.NewEVMManifestSigner(signer), nil
+	default:
+		return nil, nil //nolint:nilnil // no signer configured
+	}
+}
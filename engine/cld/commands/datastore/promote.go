@@ -0,0 +1,305 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+	cfgdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+)
+
+var (
+	promoteShort = "Promote datastore records to another environment"
+
+	promoteLong = text.LongDesc(`
+		Selects records from the datastore of a source environment, remaps their chain selectors
+		and writes them to the target environment as a changeset-style datastore artifact.
+
+		The artifact is not merged automatically. Review it in the target environment's artifacts
+		directory, then apply it with the merge command using the same name. The merge destination
+		(local files or catalog) follows the target environment's datastore configuration.
+
+		Address policy:
+		- drop: address refs are not promoted, and contract metadata is re-keyed to the address of
+		  the contract with the same type, version and qualifier in the target environment
+		- keep: records are promoted with their addresses unchanged
+
+		The --types and --qualifiers filters select contract metadata through the address ref of
+		its contract in the source environment. Env metadata is only promoted when it is listed
+		in --kinds, as merging it replaces the env metadata of the target environment.
+	`)
+
+	promoteExample = text.Examples(`
+		# Promote contract and chain metadata from testnet to mainnet
+		ccip datastore promote --source-environment testnet --environment mainnet \
+		  --chain-mapping 16015286601757825753:5009297550715157269
+
+		# Copy the Router address refs of one chain from the ccip domain into the keystone domain
+		keystone datastore promote --source-domain ccip --source-environment mainnet --environment mainnet \
+		  --address-policy keep --kinds address_ref --types Router --chain-selectors 5009297550715157269
+	`)
+)
+
+type promoteFlags struct {
+	environment       string
+	sourceEnvironment string
+	sourceDomain      string
+	name              string
+	addressPolicy     string
+	chainMappings     []string
+	kinds             []string
+	chainSelectors    []string
+	types             []string
+	qualifiers        []string
+}
+
+// newPromoteCmd creates the "promote" subcommand for promoting datastore records between environments.
+func newPromoteCmd(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "promote",
+		Short:   promoteShort,
+		Long:    promoteLong,
+		Example: promoteExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			chainMappings, _ := cmd.Flags().GetStringSlice("chain-mapping")
+			kinds, _ := cmd.Flags().GetStringSlice("kinds")
+			chainSelectors, _ := cmd.Flags().GetStringSlice("chain-selectors")
+			types, _ := cmd.Flags().GetStringSlice("types")
+			qualifiers, _ := cmd.Flags().GetStringSlice("qualifiers")
+
+			f := promoteFlags{
+				environment:       flags.MustString(cmd.Flags().GetString("environment")),
+				sourceEnvironment: flags.MustString(cmd.Flags().GetString("source-environment")),
+				sourceDomain:      flags.MustString(cmd.Flags().GetString("source-domain")),
+				name:              flags.MustString(cmd.Flags().GetString("name")),
+				addressPolicy:     flags.MustString(cmd.Flags().GetString("address-policy")),
+				chainMappings:     chainMappings,
+				kinds:             kinds,
+				chainSelectors:    chainSelectors,
+				types:             types,
+				qualifiers:        qualifiers,
+			}
+
+			return runPromote(cmd, cfg, f)
+		},
+	}
+
+	// Shared flags
+	flags.Environment(cmd)
+
+	// Local flags specific to this command
+	cmd.Flags().String("source-environment", "", "Environment to promote records from (required)")
+	cmd.Flags().String("source-domain", "", "Domain to promote records from (default: this domain)")
+	cmd.Flags().StringP("name", "n", "", "Name of the promotion artifact (default: promote_<source-environment>)")
+	cmd.Flags().String("address-policy", fdatastore.AddressPolicyDrop.String(), "Address policy: drop or keep")
+	cmd.Flags().StringSlice("chain-mapping", nil, "Chain selector mapping as <source>:<target> (repeatable)")
+	cmd.Flags().StringSlice("kinds", nil, "Record kinds to promote: address_ref, chain_metadata, contract_metadata, env_metadata (default: all but env_metadata)")
+	cmd.Flags().StringSlice("chain-selectors", nil, "Only promote records on these source chain selectors")
+	cmd.Flags().StringSlice("types", nil, "Only promote address refs and contract metadata of these contract types")
+	cmd.Flags().StringSlice("qualifiers", nil, "Only promote address refs and contract metadata with these qualifiers")
+	_ = cmd.MarkFlagRequired("source-environment")
+
+	return cmd
+}
+
+// runPromote executes the promote command logic.
+func runPromote(cmd *cobra.Command, cfg Config, f promoteFlags) error {
+	ctx := cmd.Context()
+
+	opts, err := f.promoteOptions()
+	if err != nil {
+		return err
+	}
+
+	sourceDomain := cfg.Domain
+	if f.sourceDomain != "" {
+		sourceDomain = domain.NewDomain(cfg.Domain.RootPath(), f.sourceDomain)
+	}
+
+	name := f.name
+	if name == "" {
+		name = "promote_" + f.sourceEnvironment
+	}
+
+	// --- Load
+
+	source, err := loadEnvDataStore(ctx, cfg, sourceDomain, f.sourceEnvironment)
+	if err != nil {
+		return fmt.Errorf("failed to load source datastore for %s %s: %w", sourceDomain, f.sourceEnvironment, err)
+	}
+
+	if opts.AddressPolicy == fdatastore.AddressPolicyDrop {
+		opts.Target, err = loadEnvDataStore(ctx, cfg, cfg.Domain, f.environment)
+		if err != nil {
+			return fmt.Errorf("failed to load target datastore for %s %s: %w", cfg.Domain, f.environment, err)
+		}
+	}
+
+	// --- Execute
+
+	result, err := fdatastore.Promote(source, opts)
+	if err != nil {
+		return fmt.Errorf("failed to promote datastore: %w", err)
+	}
+
+	artDir := cfg.Domain.EnvDir(f.environment).ArtifactsDir()
+	if err = artDir.SaveChangesetOutput(name, fdeployment.ChangesetOutput{DataStore: result.DataStore}); err != nil {
+		return fmt.Errorf("failed to save promotion artifact: %w", err)
+	}
+
+	// --- Report
+
+	counts, err := countRecords(result.DataStore.Seal())
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("📦 Promoted %s %s -> %s %s (address policy: %s)\n",
+		sourceDomain, f.sourceEnvironment, cfg.Domain, f.environment, opts.AddressPolicy,
+	)
+	cmd.Printf("   %s\n", counts)
+	for _, s := range result.Skipped {
+		cmd.Printf("⚠️  Skipped %s %s: %s\n", s.Kind, s.Key, s.Reason)
+	}
+	cmd.Printf("✅ Wrote promotion artifact to %s\n", artDir.ChangesetDirPath(name))
+	cmd.Printf("   Review it, then run: datastore merge --environment %s --name %s\n", f.environment, name)
+
+	return nil
+}
+
+// promoteOptions converts the command flags into promotion options.
+func (f promoteFlags) promoteOptions() (fdatastore.PromoteOptions, error) {
+	policy, err := fdatastore.ParseAddressPolicy(f.addressPolicy)
+	if err != nil {
+		return fdatastore.PromoteOptions{}, err
+	}
+
+	opts := fdatastore.PromoteOptions{AddressPolicy: policy}
+
+	if len(f.chainMappings) > 0 {
+		opts.ChainSelectorMapping = make(map[uint64]uint64, len(f.chainMappings))
+		for _, m := range f.chainMappings {
+			src, dst, ok := strings.Cut(m, ":")
+			if !ok {
+				return fdatastore.PromoteOptions{}, fmt.Errorf("invalid chain mapping %q: expected <source>:<target>", m)
+			}
+			srcSel, srcErr := strconv.ParseUint(strings.TrimSpace(src), 10, 64)
+			dstSel, dstErr := strconv.ParseUint(strings.TrimSpace(dst), 10, 64)
+			if err = errors.Join(srcErr, dstErr); err != nil {
+				return fdatastore.PromoteOptions{}, fmt.Errorf("invalid chain mapping %q: %w", m, err)
+			}
+			opts.ChainSelectorMapping[srcSel] = dstSel
+		}
+	}
+
+	for _, k := range f.kinds {
+		kind := fdatastore.ChangeKind(k)
+		switch kind {
+		case fdatastore.ChangeKindAddressRef, fdatastore.ChangeKindChainMetadata,
+			fdatastore.ChangeKindContractMetadata, fdatastore.ChangeKindEnvMetadata:
+			opts.Kinds = append(opts.Kinds, kind)
+		default:
+			return fdatastore.PromoteOptions{}, fmt.Errorf("invalid record kind %q", k)
+		}
+	}
+
+	if len(f.chainSelectors) > 0 {
+		selectors := make(map[uint64]bool, len(f.chainSelectors))
+		for _, s := range f.chainSelectors {
+			sel, parseErr := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+			if parseErr != nil {
+				return fdatastore.PromoteOptions{}, fmt.Errorf("invalid chain selector %q: %w", s, parseErr)
+			}
+			selectors[sel] = true
+		}
+
+		opts.AddressRefFilters = append(opts.AddressRefFilters, func(records []fdatastore.AddressRef) []fdatastore.AddressRef {
+			return filterRecords(records, func(r fdatastore.AddressRef) bool { return selectors[r.ChainSelector] })
+		})
+		opts.ChainMetadataFilters = append(opts.ChainMetadataFilters, func(records []fdatastore.ChainMetadata) []fdatastore.ChainMetadata {
+			return filterRecords(records, func(r fdatastore.ChainMetadata) bool { return selectors[r.ChainSelector] })
+		})
+		opts.ContractMetadataFilters = append(opts.ContractMetadataFilters, func(records []fdatastore.ContractMetadata) []fdatastore.ContractMetadata {
+			return filterRecords(records, func(r fdatastore.ContractMetadata) bool { return selectors[r.ChainSelector] })
+		})
+	}
+
+	refFilters, err := addressRefFilters(nil, f.types, f.qualifiers)
+	if err != nil {
+		return fdatastore.PromoteOptions{}, err
+	}
+	opts.AddressRefFilters = append(opts.AddressRefFilters, refFilters...)
+
+	return opts, nil
+}
+
+// filterRecords returns the records for which keep returns true.
+func filterRecords[R any](records []R, keep func(R) bool) []R {
+	filtered := make([]R, 0, len(records))
+	for _, r := range records {
+		if keep(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// loadEnvDataStore loads the datastore of an environment from the catalog or local files,
+// depending on the environment's datastore configuration.
+func loadEnvDataStore(ctx context.Context, cfg Config, dom domain.Domain, envKey string) (fdatastore.DataStore, error) {
+	deps := cfg.deps()
+
+	envCfg, err := deps.ConfigLoader(dom, envKey, cfg.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	switch envCfg.DatastoreType {
+	case cfgdomain.DatastoreTypeCatalog, cfgdomain.DatastoreTypeAll:
+		catalog, catalogErr := deps.CatalogLoader(ctx, envKey, envCfg, dom)
+		if catalogErr != nil {
+			return nil, fmt.Errorf("failed to load catalog: %w", catalogErr)
+		}
+
+		return fdatastore.LoadDataStoreFromCatalog(ctx, catalog)
+	case cfgdomain.DatastoreTypeFile:
+		return dom.EnvDir(envKey).DataStore()
+	default:
+		return nil, fmt.Errorf("invalid datastore type: %s", envCfg.DatastoreType)
+	}
+}
+
+// countRecords summarises the number of records of each kind in the datastore.
+func countRecords(ds fdatastore.DataStore) (string, error) {
+	refs, err := ds.Addresses().Fetch()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch address refs: %w", err)
+	}
+	chainMeta, err := ds.ChainMetadata().Fetch()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch chain metadata: %w", err)
+	}
+	contractMeta, err := ds.ContractMetadata().Fetch()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch contract metadata: %w", err)
+	}
+
+	envMeta := 0
+	if _, err = ds.EnvMetadata().Get(); err == nil {
+		envMeta = 1
+	}
+
+	return fmt.Sprintf("address refs: %d, chain metadata: %d, contract metadata: %d, env metadata: %d",
+		len(refs), len(chainMeta), len(contractMeta), envMeta,
+	), nil
+}
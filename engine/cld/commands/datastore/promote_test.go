@@ -0,0 +1,143 @@
+package datastore
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config"
+	cfgdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+// writeEnvDataStore writes the datastore files for an environment directory.
+func writeEnvDataStore(t *testing.T, envDir domain.EnvDir, ds *fdatastore.MemoryDataStore) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(envDir.DataStoreDirPath(), 0o755))
+
+	write := func(path string, v any) {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+	}
+
+	write(envDir.AddressRefsFilePath(), ds.AddressRefStore.Records)
+	write(envDir.ChainMetadataFilePath(), ds.ChainMetadataStore.Records)
+	write(envDir.ContractMetadataFilePath(), ds.ContractMetadataStore.Records)
+	write(envDir.EnvMetadataFilePath(), ds.EnvMetadataStore.Record)
+}
+
+func TestPromote_FileMode(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+
+	source := fdatastore.NewMemoryDataStore()
+	require.NoError(t, source.Addresses().Add(fdatastore.AddressRef{
+		Address: "0xtestrouter", ChainSelector: 100, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+	require.NoError(t, source.ContractMetadata().Add(fdatastore.ContractMetadata{
+		Address: "0xtestrouter", ChainSelector: 100, Metadata: map[string]any{"owner": "timelock"},
+	}))
+	writeEnvDataStore(t, dom.EnvDir("testnet"), source)
+
+	target := fdatastore.NewMemoryDataStore()
+	require.NoError(t, target.Addresses().Add(fdatastore.AddressRef{
+		Address: "0xmainrouter", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+	writeEnvDataStore(t, dom.EnvDir("mainnet"), target)
+
+	cmd, err := NewCommand(Config{
+		Logger: logger.Nop(),
+		Domain: dom,
+		Deps: Deps{
+			ConfigLoader: func(_ domain.Domain, _ string, _ logger.Logger) (*config.Config, error) {
+				return &config.Config{DatastoreType: cfgdomain.DatastoreTypeFile}, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{
+		"promote", "-e", "mainnet", "--source-environment", "testnet", "--chain-mapping", "100:1",
+	})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "address refs: 0, chain metadata: 0, contract metadata: 1, env metadata: 0")
+	assert.Contains(t, out.String(), "✅ Wrote promotion artifact")
+
+	artDir := dom.EnvDir("mainnet").ArtifactsDir()
+	promoted, err := artDir.LoadDataStoreByChangesetKey("promote_testnet")
+	require.NoError(t, err)
+
+	cm, err := promoted.ContractMetadata().Get(fdatastore.NewContractMetadataKey(1, "0xmainrouter"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"owner": "timelock"}, cm.Metadata)
+
+	// The artifact is not merged into the target datastore
+	mainnet, err := dom.EnvDir("mainnet").DataStore()
+	require.NoError(t, err)
+	cms, err := mainnet.ContractMetadata().Fetch()
+	require.NoError(t, err)
+	assert.Empty(t, cms)
+	assert.DirExists(t, filepath.Join(artDir.ChangesetDirPath("promote_testnet")))
+}
+
+func TestPromote_InvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "missing source environment",
+			args:    []string{"promote", "-e", "mainnet"},
+			wantErr: `required flag(s) "source-environment" not set`,
+		},
+		{
+			name:    "invalid address policy",
+			args:    []string{"promote", "-e", "mainnet", "--source-environment", "testnet", "--address-policy", "maybe"},
+			wantErr: "invalid address policy",
+		},
+		{
+			name:    "invalid chain mapping",
+			args:    []string{"promote", "-e", "mainnet", "--source-environment", "testnet", "--chain-mapping", "100"},
+			wantErr: "invalid chain mapping",
+		},
+		{
+			name:    "invalid kind",
+			args:    []string{"promote", "-e", "mainnet", "--source-environment", "testnet", "--kinds", "jobs"},
+			wantErr: `invalid record kind "jobs"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, err := newTestCommand(t, Deps{})
+			require.NoError(t, err)
+
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tt.args)
+
+			require.ErrorContains(t, cmd.Execute(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package datastore
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+)
+
+var (
+	verifyShort = "Verify the datastore files against their manifest"
+
+	verifyLong = text.LongDesc(`
+		Verifies that the local datastore files of an environment match the content hashes in the
+		datastore manifest written by the merge command. The manifest signature is verified against
+		the trusted signers given by --trusted-signers, or else by the datastore_manifest section of
+		the environment in the domain config. Without trusted signers the signature is not verified.
+
+		Run this in CI to detect datastore files which were edited by hand instead of merged from
+		a changeset artifact.
+	`)
+
+	verifyExample = text.Examples(`
+		# Verify the datastore of the staging environment
+		ccip datastore verify --environment staging
+
+		# Verify that the manifest is signed by a trusted key
+		ccip datastore verify --environment staging --trusted-signers 0x1234567890abcdef1234567890abcdef12345678
+	`)
+)
+
+// newVerifyCmd creates the "verify" subcommand for verifying the datastore manifest.
+func newVerifyCmd(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "verify",
+		Short:   verifyShort,
+		Long:    verifyLong,
+		Example: verifyExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			envKey := flags.MustString(cmd.Flags().GetString("environment"))
+			trustedSigners, _ := cmd.Flags().GetStringSlice("trusted-signers")
+
+			if !cmd.Flags().Changed("trusted-signers") {
+				envCfg, err := cfg.deps().ConfigLoader(cfg.Domain, envKey, cfg.Logger)
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				trustedSigners = envCfg.TrustedManifestSigners
+			}
+
+			envDir := cfg.Domain.EnvDir(envKey)
+			if err := envDir.VerifyDataStoreManifest(trustedSigners...); err != nil {
+				return fmt.Errorf("datastore verification failed for %s %s: %w", cfg.Domain, envKey, err)
+			}
+
+			cmd.Printf("✅ Datastore files match the manifest for %s %s\n", cfg.Domain, envKey)
+			if len(trustedSigners) == 0 {
+				cmd.Printf("⚠️  The manifest signature was not verified as no trusted signers are configured\n")
+			}
+
+			return nil
+		},
+	}
+
+	// Shared flags
+	flags.Environment(cmd)
+
+	// Local flags specific to this command
+	cmd.Flags().StringSlice("trusted-signers", nil, "Require the manifest to be signed by one of these ed25519 public keys or EVM addresses (default from domain config)")
+
+	return cmd
+}
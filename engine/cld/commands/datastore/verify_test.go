@@ -0,0 +1,199 @@
+package datastore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	seed := bytes.Repeat([]byte{1}, ed25519.SeedSize)
+	pub := hex.EncodeToString(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+
+	newEnv := func(t *testing.T) (domain.Domain, domain.EnvDir) {
+		t.Helper()
+
+		dom := domain.NewDomain(t.TempDir(), "testdomain")
+		envDir := dom.EnvDir("staging")
+
+		ds := fdatastore.NewMemoryDataStore()
+		require.NoError(t, ds.Addresses().Add(fdatastore.AddressRef{
+			Address: "0xrouter", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+		}))
+		writeEnvDataStore(t, envDir, ds)
+
+		signer, err := domain.NewEd25519ManifestSigner(ed25519.NewKeyFromSeed(seed))
+		require.NoError(t, err)
+		require.NoError(t, envDir.WriteDataStoreManifest(signer))
+
+		return dom, envDir
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		dom, _ := newEnv(t)
+		out, err := newFileModeTestCommand(t, dom, "verify", "-e", "staging", "--trusted-signers", pub)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "✅ Datastore files match the manifest for testdomain staging")
+	})
+
+	t.Run("edited file", func(t *testing.T) {
+		t.Parallel()
+
+		dom, envDir := newEnv(t)
+		require.NoError(t, os.WriteFile(envDir.AddressRefsFilePath(), []byte(`[]`), 0o600))
+
+		_, err := newFileModeTestCommand(t, dom, "verify", "-e", "staging")
+		require.ErrorIs(t, err, domain.ErrDataStoreManifestMismatch)
+		require.ErrorContains(t, err, domain.AddressRefsFileName)
+	})
+
+	t.Run("untrusted signer", func(t *testing.T) {
+		t.Parallel()
+
+		dom, _ := newEnv(t)
+		_, err := newFileModeTestCommand(t, dom, "verify", "-e", "staging", "--trusted-signers", "0xabc")
+		require.ErrorIs(t, err, domain.ErrDataStoreManifestSignature)
+	})
+
+	t.Run("no trusted signers", func(t *testing.T) {
+		t.Parallel()
+
+		dom, _ := newEnv(t)
+		out, err := newFileModeTestCommand(t, dom, "verify", "-e", "staging")
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "signature was not verified")
+	})
+
+	t.Run("trusted signers from domain config", func(t *testing.T) {
+		t.Parallel()
+
+		dom, _ := newEnv(t)
+		cmd, err := NewCommand(Config{
+			Logger: logger.Nop(),
+			Domain: dom,
+			Deps: Deps{ConfigLoader: func(_ domain.Domain, _ string, _ logger.Logger) (*config.Config, error) {
+				return &config.Config{TrustedManifestSigners: []string{"0x0000000000000000000000000000000000000001"}}, nil
+			}},
+		})
+		require.NoError(t, err)
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"verify", "-e", "staging"})
+
+		require.ErrorIs(t, cmd.Execute(), domain.ErrDataStoreManifestSignature)
+	})
+}
+
+func TestWriteManifest(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+	envDir := dom.EnvDir("staging")
+	writeEnvDataStore(t, envDir, fdatastore.NewMemoryDataStore())
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(bytes.Repeat([]byte{1}, ed25519.SeedSize))), 0o600))
+
+	out, err := newFileModeTestCommand(t, dom, "write-manifest", "-e", "staging", "--manifest-key-file", keyFile)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "✅ Wrote datastore manifest for testdomain staging")
+
+	pub := hex.EncodeToString(ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)).Public().(ed25519.PublicKey))
+	require.NoError(t, envDir.VerifyDataStoreManifest(pub))
+}
+
+func TestMerge_ManifestSigning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		keyFile    string
+		wantOpts   int
+		wantErr    string
+		extraFlags []string
+	}{
+		{
+			name:     "unsigned",
+			wantOpts: 1,
+		},
+		{
+			name:       "skip datastore verification",
+			extraFlags: []string{"--skip-datastore-verification"},
+			wantOpts:   1,
+		},
+		{
+			name:     "ed25519 seed",
+			keyFile:  hex.EncodeToString(bytes.Repeat([]byte{1}, ed25519.SeedSize)),
+			wantOpts: 2,
+		},
+		{
+			name:     "ed25519 private key with prefix",
+			keyFile:  "0x" + hex.EncodeToString(ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))),
+			wantOpts: 2,
+		},
+		{
+			name:    "invalid key",
+			keyFile: "abcd",
+			wantErr: "invalid ed25519 private key length",
+		},
+		{
+			name:       "kms key without region",
+			extraFlags: []string{"--manifest-kms-key-id", "alias/key"},
+			wantErr:    "if any flags in the group [manifest-kms-key-id manifest-kms-key-region] are set they must all be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotOpts int
+			cmd, err := newTestCommand(t, Deps{
+				ConfigLoader: fileConfigLoader,
+				FileMerger: func(_ domain.EnvDir, _, _ string, opts ...domain.MergeDataStoreOption) error {
+					gotOpts = len(opts)
+
+					return nil
+				},
+			})
+			require.NoError(t, err)
+
+			args := []string{"merge", "-e", "staging", "-n", "0001_deploy"}
+			if tt.keyFile != "" {
+				path := filepath.Join(t.TempDir(), "key")
+				require.NoError(t, os.WriteFile(path, []byte(tt.keyFile+"\n"), 0o600))
+				args = append(args, "--manifest-key-file", path)
+			}
+			args = append(args, tt.extraFlags...)
+
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(args)
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOpts, gotOpts)
+		})
+	}
+}
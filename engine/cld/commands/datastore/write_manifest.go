@@ -0,0 +1,67 @@
+package datastore
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+)
+
+var (
+	writeManifestShort = "Write the datastore manifest for the current datastore files"
+
+	writeManifestLong = text.LongDesc(`
+		Hashes the current local datastore files of an environment and writes the datastore
+		manifest, optionally signing it with a local ed25519 key or an AWS KMS key.
+
+		Environments whose datastore predates the manifest cannot be loaded until a manifest is
+		written. Review the datastore files before running this command, since the manifest marks
+		their current content as trusted.
+	`)
+
+	writeManifestExample = text.Examples(`
+		# Write an unsigned manifest for the staging environment
+		ccip datastore write-manifest --environment staging
+
+		# Write a manifest signed with a KMS key
+		ccip datastore write-manifest --environment staging \
+		  --manifest-kms-key-id alias/datastore-manifest --manifest-kms-key-region us-west-2
+	`)
+)
+
+// newWriteManifestCmd creates the "write-manifest" subcommand for writing the datastore manifest.
+func newWriteManifestCmd(cfg Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "write-manifest",
+		Short:   writeManifestShort,
+		Long:    writeManifestLong,
+		Example: writeManifestExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			envKey := flags.MustString(cmd.Flags().GetString("environment"))
+
+			signer, err := loadManifestSigner(getManifestSignerFlags(cmd))
+			if err != nil {
+				return err
+			}
+
+			envDir := cfg.Domain.EnvDir(envKey)
+			if err = envDir.WriteDataStoreManifest(signer); err != nil {
+				return fmt.Errorf("failed to write datastore manifest for %s %s: %w", cfg.Domain, envKey, err)
+			}
+
+			cmd.Printf("✅ Wrote datastore manifest for %s %s\n", cfg.Domain, envKey)
+
+			return nil
+		},
+	}
+
+	// Shared flags
+	flags.Environment(cmd)
+
+	// Local flags specific to this command
+	addManifestSignerFlags(cmd)
+
+	return cmd
+}
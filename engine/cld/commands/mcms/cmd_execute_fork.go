@@ -300,7 +300,7 @@ func runPostProposalHooks(
 	forkContext := &cldfchangeset.EVMForkContext{ChainConfig: chainConfig, Client: forkClient}
 	forkCfg.env.Name = forkCfg.envStr // ensure hooks load the correct env config for the fork
 
-	err := runHooksInternal(mcmsCfg, forkCfg.env, forkCfg.timelockProposal, reports, execError, forkContext)
+	err := runHooksInternal(mcmsCfg, forkCfg.env, forkCfg.timelockProposal, reports, execError, forkContext, nil)
 	if err != nil {
 		return fmt.Errorf("failed to run post-proposal hooks: %w", err)
 	}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/samber/lo"
@@ -17,6 +19,7 @@ import (
 	cldfchangeset "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
 )
 
 var (
@@ -24,6 +27,12 @@ var (
 
 	runHooksLong = text.LongDesc(`
 		Run post proposal execution hooks
+
+		The records of the hooks run when each changeset of the proposal was applied are loaded
+		from its artifacts and passed to the hooks. The artifacts of a durable pipeline run are
+		found with --timestamp, which defaults to the timestamp prefix of the proposal file name.
+		The records of the post proposal execution hooks are appended to the hook records log
+		of each changeset.
 	`)
 
 	runHooksExample = text.Examples(`
@@ -43,6 +52,7 @@ type runHooksFlags struct {
 	chainSelector uint64
 	reports       []cldfchangeset.MCMSTimelockExecuteReport
 	error         string
+	timestamp     string
 }
 
 type proposalMetadata struct {
@@ -80,6 +90,7 @@ func newRunProposalHooksCmd(cfg Config) *cobra.Command {
 				chainSelector: flags.MustUint64(cmd.Flags().GetUint64("selector")),
 				reports:       reports,
 				error:         flags.MustString(cmd.Flags().GetString("error")),
+				timestamp:     flags.MustString(cmd.Flags().GetString("timestamp")),
 			}
 
 			return runHooks(cmd.Context(), cfg, f)
@@ -92,6 +103,7 @@ func newRunProposalHooksCmd(cfg Config) *cobra.Command {
 	flags.ChainSelector(cmd, true)
 	cmd.Flags().String("report", "", "File with timelock execution report (required).")
 	cmd.Flags().String("error", "", "The error message, in case the timelock execution failed.")
+	cmd.Flags().StringP("timestamp", "t", "", "Durable Pipeline timestamp of the run which created the proposal (optional)")
 	_ = cmd.MarkFlagRequired("report")
 
 	return cmd
@@ -117,7 +129,29 @@ func runHooks(ctx context.Context, cfg Config, hFlags runHooksFlags) error {
 		return errors.New("expected proposal to be a TimelockProposal")
 	}
 
-	return runHooksInternal(cfg, proposalCfg.Env, proposalCfg.TimelockProposal, hFlags.reports, hFlags.error, nil)
+	artdir := cfg.Domain.EnvDir(hFlags.environment).ArtifactsDir()
+	timestamp := hFlags.timestamp
+	if timestamp == "" {
+		timestamp = durablePipelineTimestamp(hFlags.proposalPath)
+	}
+	if timestamp != "" {
+		if err = artdir.SetDurablePipelines(timestamp); err != nil {
+			return fmt.Errorf("failed to set durable pipeline timestamp: %w", err)
+		}
+	}
+
+	return runHooksInternal(cfg, proposalCfg.Env, proposalCfg.TimelockProposal, hFlags.reports, hFlags.error, nil, artdir)
+}
+
+// durablePipelineTimestamp returns the timestamp prefixing the name of a proposal file created by
+// a durable pipeline run, or an empty string if the file name has no such prefix.
+func durablePipelineTimestamp(proposalPath string) string {
+	prefix, _, ok := strings.Cut(filepath.Base(proposalPath), "-")
+	if !ok || len(prefix) != 19 || strings.Trim(prefix, "0123456789") != "" {
+		return ""
+	}
+
+	return prefix
 }
 
 func runHooksInternal(
@@ -127,6 +161,7 @@ func runHooksInternal(
 	reports []cldfchangeset.MCMSTimelockExecuteReport,
 	execError string,
 	forkCtx cldfchangeset.ForkContext,
+	artdir *domain.ArtifactsDir,
 ) error {
 	if cfg.LoadChangesets == nil {
 		return errors.New("LoadChangesets function is required for proposal hook execution")
@@ -148,11 +183,38 @@ func runHooksInternal(
 			return changeset.ID != "" && changeset.ID == r.Input.Changeset.ID
 		})
 
-		herr := changesetRegistry.RunProposalHooks(changeset.Name, env, timelockProposal,
-			changeset.Input, changeset.Config, changesetReports, execError, forkCtx)
+		var opts []cldfchangeset.ProposalHooksOption
+		if artdir != nil {
+			records, lerr := artdir.LoadHookRecordsByChangesetKey(changeset.Name)
+			switch {
+			case lerr == nil:
+				opts = append(opts, cldfchangeset.WithHookRecords(records))
+			case !errors.Is(lerr, domain.ErrArtifactNotFound):
+				cfg.Logger.Warnf("Failed to load hook records of changeset %s: %v", changeset.Name, lerr)
+			}
+		}
+
+		records, herr := changesetRegistry.RunProposalHooksWithRecords(changeset.Name, env, timelockProposal,
+			changeset.Input, changeset.Config, changesetReports, execError, forkCtx, opts...)
+
+		// The records are saved even if a hook failed, so that the failure is kept with the
+		// artifacts of the changeset
+		var serr error
+		if artdir != nil && len(records) > 0 {
+			if serr = artdir.AppendHookRecords(changeset.Name, records); serr != nil {
+				serr = fmt.Errorf("failed to save proposal hook records of changeset %q: %w", changeset.Name, serr)
+			}
+		}
 		if herr != nil {
+			if serr != nil {
+				cfg.Logger.Error(serr.Error())
+			}
+
 			return fmt.Errorf("proposal hook for changeset %q failed: %w", changeset.Name, herr)
 		}
+		if serr != nil {
+			return serr
+		}
 	}
 
 	return nil
@@ -131,6 +131,7 @@ func Test_newRunProposalHooksCmd(t *testing.T) { //nolint:paralleltest
 				generateStableUUIDs(t)
 
 				testCtx.testDir = t.TempDir()
+				testCtx.cfg.Domain = cldfdomain.NewDomain(testCtx.testDir, "test")
 
 				envName := "testnet"
 				err := os.Mkdir(filepath.Join(testCtx.testDir, envName), 0o700)
@@ -168,6 +169,73 @@ func Test_newRunProposalHooksCmd(t *testing.T) { //nolint:paralleltest
 				require.Equal(t, 1, testCtx.logs.FilterMessage("test-global-post-proposal-hook; config: map[config-key:config-value]").Len())
 				require.Equal(t, 1, testCtx.logs.FilterMessage("test-global-post-proposal-hook; config: map[]").Len())
 				require.Equal(t, 0, testCtx.logs.FilterMessage("test-global-post-proposal-hook; forkctx family: EVM").Len())
+
+				// The records of the post-proposal hooks are saved in the hook records log
+				records, err := testCtx.cfg.Domain.EnvDir("testnet").ArtifactsDir().LoadHookRecordsLog("001_test_changeset")
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+				require.Equal(t, cldf.HookPhasePostProposal, records[0].Phase)
+				require.Equal(t, cldf.HookStatusPassed, records[0].Status)
+			},
+		},
+		{
+			name: "success: passes the hook records of the durable pipeline run to the hooks",
+			cfg: Config{
+				LoadChangesets: loadChangesets,
+				Deps: Deps{
+					EnvironmentLoader: func(
+						ctx context.Context, domain cldfdomain.Domain, envKey string, lggr logger.Logger, opts ...cldfenv.LoadEnvironmentOption,
+					) (cldf.Environment, error) {
+						return cldf.Environment{
+							Name:       envKey,
+							Logger:     lggr,
+							GetContext: func() context.Context { return ctx },
+						}, nil
+					},
+				},
+			},
+			setup: func(t *testing.T, testCtx *testCase) {
+				t.Helper()
+
+				testCtx.testDir = t.TempDir()
+				testCtx.cfg.Domain = cldfdomain.NewDomain(testCtx.testDir, "test")
+
+				const (
+					envName   = "testnet"
+					timestamp = "1700000000000000001"
+				)
+				artdir := testCtx.cfg.Domain.EnvDir(envName).ArtifactsDir()
+				require.NoError(t, artdir.SetDurablePipelines(timestamp))
+				require.NoError(t, artdir.SaveChangesetOutput("001_test_changeset", cldf.ChangesetOutput{
+					HookRecords: []cldf.HookRecord{
+						{Name: "check", Phase: cldf.HookPhasePre, Status: cldf.HookStatusPassed},
+						{Name: "verify", Phase: cldf.HookPhasePost, Status: cldf.HookStatusWarned, Error: "not found"},
+					},
+				}))
+
+				proposalPath := filepath.Join(testCtx.testDir, timestamp+"-test-testnet-001_test_changeset_mcms_timelock_proposal_0.json")
+				err := os.WriteFile(proposalPath, testProposalWithChangesetsJSON, 0o600)
+				require.NoError(t, err)
+
+				reportPath := filepath.Join(testCtx.testDir, "report.json")
+				err = os.WriteFile(reportPath, testReportJSON, 0o600)
+				require.NoError(t, err)
+
+				testCtx.args = []string{
+					"--environment", envName,
+					"--proposal", proposalPath,
+					"--report", reportPath,
+					"--selector", strconv.FormatUint(chainsel.GETH_TESTNET.Selector, 10),
+				}
+
+				lggr, logs := logger.TestObserved(t, zapcore.DebugLevel)
+				testCtx.cfg.Logger = lggr
+				testCtx.logs = logs
+			},
+			assert: func(t *testing.T, testCtx *testCase, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, 1, testCtx.logs.FilterMessage("test-changeset-post-proposal-hook; # hook records: 2").Len())
 			},
 		},
 	}
@@ -184,6 +252,28 @@ func Test_newRunProposalHooksCmd(t *testing.T) { //nolint:paralleltest
 	}
 }
 
+func Test_durablePipelineTimestamp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: "proposals/1700000000000000001-ccip-testnet-0001_cs_mcms_timelock_proposal_0.json", want: "1700000000000000001"},
+		{give: "proposals/ccip-testnet-0001_cs_mcms_timelock_proposal_0.json"},
+		{give: "proposals/170000000000-ccip-testnet-0001_cs_mcms_timelock_proposal_0.json"},
+		{give: "proposal.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, durablePipelineTimestamp(tt.give))
+		})
+	}
+}
+
 // ----- helpers -----
 
 var testProposalWithoutChangesetsJSON = []byte(`{
@@ -355,6 +445,7 @@ func loadChangesets(envName string) (*changeset.ChangesetsRegistry, error) {
 					params.Env.Logger.Info("test-changeset-post-proposal-hook executed")
 					params.Env.Logger.Infof("test-changeset-post-proposal-hook; # reports: %d", len(params.Reports))
 					params.Env.Logger.Infof("test-changeset-post-proposal-hook; config: %v", params.Config)
+					params.Env.Logger.Infof("test-changeset-post-proposal-hook; # hook records: %d", len(params.HookRecords))
 					if params.Env.ForkContext != nil {
 						params.Env.Logger.Infof("test-changeset-post-proposal-hook; forkctx family: %v",
 							params.Env.ForkContext.ChainFamily())
@@ -0,0 +1,402 @@
+package pipeline
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	evmprovider "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm/provider"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/approval"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/pipeline/input"
+)
+
+var (
+	approvalsShort = "Sign and verify changeset approvals"
+
+	approvalsLong = text.LongDesc(`
+		Sign and verify approvals of changeset configs.
+
+		Changesets gated by the approval.Gate pre-hook only run once a quorum of approvers
+		signed the digest of the changeset key, environment and resolved config. The
+		signatures are stored in the approvals/<changeset>.json file of the environment
+		directory, which is committed alongside the durable pipeline input.
+	`)
+
+	approvalsSignShort = "Sign the approval of a changeset config"
+
+	approvalsSignLong = text.LongDesc(`
+		Sign the approval of a changeset config.
+
+		Resolves the config of the changeset from the input file, computes its approval
+		digest and adds a signature of it to the approvals file of the environment,
+		replacing any previous signature by the same key. Signatures of a previous digest
+		are discarded, since they no longer approve the config.
+
+		The digest can be signed with a local ed25519 key, a local EVM key or an AWS KMS key.
+	`)
+
+	approvalsSignExample = text.Examples(`
+		# Approve a changeset with a local ed25519 key
+		chainlink-deployments durable-pipeline approvals sign \
+		  --environment mainnet \
+		  --input-file inputs.yaml \
+		  --changeset 0001_deploy_router \
+		  --key-file ~/.keys/approver.key
+
+		# Approve a changeset with a KMS key
+		chainlink-deployments durable-pipeline approvals sign \
+		  --environment mainnet \
+		  --input-file inputs.yaml \
+		  --changeset 0001_deploy_router \
+		  --kms-key-id alias/approver --kms-key-region us-west-2
+	`)
+
+	approvalsVerifyShort = "Verify the approvals of a changeset config"
+
+	approvalsVerifyLong = text.LongDesc(`
+		Verify the approvals of a changeset config.
+
+		Resolves the config of the changeset from the input file and verifies the
+		signatures of the approvals file of the environment against its approval digest.
+
+		If approvers are given, their signatures are matched with the approvers and the
+		command fails unless the quorum is met, like the approval.Gate pre-hook would.
+		Otherwise it fails if any signature is invalid.
+	`)
+
+	approvalsVerifyExample = text.Examples(`
+		# Show the approvals of a changeset
+		chainlink-deployments durable-pipeline approvals verify \
+		  --environment mainnet \
+		  --input-file inputs.yaml \
+		  --changeset 0001_deploy_router
+
+		# Check that two of three approvers approved a changeset
+		chainlink-deployments durable-pipeline approvals verify \
+		  --environment mainnet \
+		  --input-file inputs.yaml \
+		  --changeset 0001_deploy_router \
+		  --approver alice=0x1234... --approver bob=d75a98... --approver carol=0x5678... \
+		  --quorum 2
+	`)
+)
+
+func newApprovalsCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approvals",
+		Short: approvalsShort,
+		Long:  approvalsLong,
+	}
+
+	cmd.AddCommand(
+		newApprovalsSignCmd(cfg),
+		newApprovalsVerifyCmd(cfg),
+	)
+
+	return cmd
+}
+
+type approvalsSignFlags struct {
+	environment  string
+	inputFile    string
+	changeset    string
+	keyFile      string
+	evmKeyFile   string
+	kmsKeyID     string
+	kmsKeyRegion string
+}
+
+func newApprovalsSignCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sign",
+		Short:   approvalsSignShort,
+		Long:    approvalsSignLong,
+		Example: approvalsSignExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			f := approvalsSignFlags{
+				environment:  flags.MustString(cmd.Flags().GetString("environment")),
+				inputFile:    flags.MustString(cmd.Flags().GetString("input-file")),
+				changeset:    flags.MustString(cmd.Flags().GetString("changeset")),
+				keyFile:      flags.MustString(cmd.Flags().GetString("key-file")),
+				evmKeyFile:   flags.MustString(cmd.Flags().GetString("evm-key-file")),
+				kmsKeyID:     flags.MustString(cmd.Flags().GetString("kms-key-id")),
+				kmsKeyRegion: flags.MustString(cmd.Flags().GetString("kms-key-region")),
+			}
+
+			return runApprovalsSign(cmd, cfg, f)
+		},
+	}
+
+	flags.Environment(cmd)
+	cmd.Flags().StringP("input-file", "i", "", "Input file name in durable_pipelines/inputs directory (required)")
+	cmd.Flags().StringP("changeset", "c", "", "Changeset name (required)")
+	cmd.Flags().String("key-file", "", "File containing a hex encoded ed25519 private key to sign with")
+	cmd.Flags().String("evm-key-file", "", "File containing a hex encoded EVM private key to sign with")
+	cmd.Flags().String("kms-key-id", "", "AWS KMS key ID to sign with")
+	cmd.Flags().String("kms-key-region", "", "AWS KMS key region, required with --kms-key-id")
+	_ = cmd.MarkFlagRequired("input-file")
+	_ = cmd.MarkFlagRequired("changeset")
+	cmd.MarkFlagsOneRequired("key-file", "evm-key-file", "kms-key-id")
+	cmd.MarkFlagsMutuallyExclusive("key-file", "evm-key-file", "kms-key-id")
+	cmd.MarkFlagsRequiredTogether("kms-key-id", "kms-key-region")
+
+	return cmd
+}
+
+func runApprovalsSign(cmd *cobra.Command, cfg *Config, f approvalsSignFlags) error {
+	signer, err := loadApprovalSigner(f)
+	if err != nil {
+		return err
+	}
+
+	digest, err := approvalDigest(cfg, f.environment, f.inputFile, f.changeset)
+	if err != nil {
+		return err
+	}
+
+	path := approval.FilePath(cfg.Domain.EnvDir(f.environment), f.changeset)
+	file, err := approval.LoadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if file.Digest != digest && len(file.Signatures) > 0 {
+		fmt.Fprintf(out, "Discarding %d signature(s) of previous digest %s\n", len(file.Signatures), file.Digest)
+		file.Signatures = nil
+	}
+	file.Environment = f.environment
+	file.Changeset = f.changeset
+	file.Digest = digest
+
+	sig, err := signer.Sign(digest)
+	if err != nil {
+		return fmt.Errorf("failed to sign approval: %w", err)
+	}
+	file.AddSignature(sig)
+
+	if err = approval.WriteFile(path, file); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Digest: %s\n", digest)
+	fmt.Fprintf(out, "Signed by %s (%s), %d signature(s) in %s\n", sig.Signer, sig.Scheme, len(file.Signatures), path)
+
+	return nil
+}
+
+// loadApprovalSigner returns the approval signer selected by the flags.
+func loadApprovalSigner(f approvalsSignFlags) (approval.Signer, error) {
+	switch {
+	case f.keyFile != "":
+		key, err := readHexKeyFile(f.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		// Accept either the 32 byte seed or the 64 byte private key
+		if len(key) == ed25519.SeedSize {
+			key = ed25519.NewKeyFromSeed(key)
+		}
+
+		return approval.NewEd25519Signer(key)
+	case f.evmKeyFile != "":
+		key, err := readHexKeyFile(f.evmKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		ecdsaKey, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVM private key: %w", err)
+		}
+
+		return approval.NewEVMKeySigner(ecdsaKey), nil
+	default:
+		// The AWS profile is taken from the environment
+		signer, err := evmprovider.NewKMSSigner(f.kmsKeyID, f.kmsKeyRegion, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create KMS signer: %w", err)
+		}
+
+		return approval.NewEVMSigner(signer), nil
+	}
+}
+
+// readHexKeyFile reads a hex encoded key, with or without 0x prefix, from a file.
+func readHexKeyFile(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+
+	return key, nil
+}
+
+type approvalsVerifyFlags struct {
+	environment string
+	inputFile   string
+	changeset   string
+	approvers   []string
+	quorum      int
+}
+
+func newApprovalsVerifyCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "verify",
+		Short:   approvalsVerifyShort,
+		Long:    approvalsVerifyLong,
+		Example: approvalsVerifyExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			approvers, _ := cmd.Flags().GetStringArray("approver")
+
+			f := approvalsVerifyFlags{
+				environment: flags.MustString(cmd.Flags().GetString("environment")),
+				inputFile:   flags.MustString(cmd.Flags().GetString("input-file")),
+				changeset:   flags.MustString(cmd.Flags().GetString("changeset")),
+				approvers:   approvers,
+				quorum:      flags.MustInt(cmd.Flags().GetInt("quorum")),
+			}
+
+			return runApprovalsVerify(cmd, cfg, f)
+		},
+	}
+
+	flags.Environment(cmd)
+	cmd.Flags().StringP("input-file", "i", "", "Input file name in durable_pipelines/inputs directory (required)")
+	cmd.Flags().StringP("changeset", "c", "", "Changeset name (required)")
+	cmd.Flags().StringArray("approver", nil, "Approver as name=signer, where signer is an ed25519 public key or EVM address (repeatable)")
+	cmd.Flags().Int("quorum", 0, "Number of approvers who must approve, defaults to all approvers")
+	_ = cmd.MarkFlagRequired("input-file")
+	_ = cmd.MarkFlagRequired("changeset")
+
+	return cmd
+}
+
+func runApprovalsVerify(cmd *cobra.Command, cfg *Config, f approvalsVerifyFlags) error {
+	policy, err := parseApprovalPolicy(f.approvers, f.quorum)
+	if err != nil {
+		return err
+	}
+
+	digest, err := approvalDigest(cfg, f.environment, f.inputFile, f.changeset)
+	if err != nil {
+		return err
+	}
+
+	path := approval.FilePath(cfg.Domain.EnvDir(f.environment), f.changeset)
+	file, err := approval.LoadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	result, err := approval.Verify(file, digest, policy)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Digest: %s\n\n", digest)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "SIGNER\tSCHEME\tAPPROVER\tSTATUS\n")
+
+	invalid := 0
+	for _, sig := range result.Signatures {
+		approver, status := sig.Approver, "valid"
+		if approver == "" {
+			approver = "-"
+		}
+		if sig.Err != nil {
+			invalid++
+			status = "invalid: " + sig.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", sig.Signature.Signer, sig.Signature.Scheme, approver, status)
+	}
+	if err = w.Flush(); err != nil {
+		return fmt.Errorf("flush tabwriter: %w", err)
+	}
+
+	if len(policy.Approvers) == 0 {
+		if invalid > 0 {
+			return fmt.Errorf("%d of %d signature(s) are invalid", invalid, len(result.Signatures))
+		}
+
+		return nil
+	}
+
+	if len(result.Approved) < policy.Quorum {
+		return fmt.Errorf("%w: %d of %d required approvals, missing approvers: %s",
+			approval.ErrQuorumNotMet, len(result.Approved), policy.Quorum, strings.Join(result.Missing, ", "),
+		)
+	}
+
+	fmt.Fprintf(out, "\nApproved by %s (%d of %d required)\n",
+		strings.Join(result.Approved, ", "), len(result.Approved), policy.Quorum,
+	)
+
+	return nil
+}
+
+// parseApprovalPolicy parses approvers given as name=signer. A zero quorum requires all of them.
+func parseApprovalPolicy(approvers []string, quorum int) (approval.Policy, error) {
+	var policy approval.Policy
+	for _, a := range approvers {
+		name, signer, ok := strings.Cut(a, "=")
+		if !ok || name == "" || signer == "" {
+			return approval.Policy{}, fmt.Errorf("invalid approver %q: expected name=signer", a)
+		}
+		policy.Approvers = append(policy.Approvers, approval.Approver{Name: name, Signer: signer})
+	}
+
+	if len(policy.Approvers) == 0 {
+		if quorum != 0 {
+			return approval.Policy{}, errors.New("--quorum requires --approver")
+		}
+
+		return policy, nil
+	}
+
+	policy.Quorum = quorum
+	if quorum == 0 {
+		policy.Quorum = len(policy.Approvers)
+	}
+	if policy.Quorum < 0 || policy.Quorum > len(policy.Approvers) {
+		return approval.Policy{}, fmt.Errorf("invalid quorum %d for %d approvers", quorum, len(policy.Approvers))
+	}
+
+	return policy, nil
+}
+
+// approvalDigest resolves the config of the changeset from the input file and returns its
+// approval digest.
+func approvalDigest(cfg *Config, envName, inputFile, changesetName string) (string, error) {
+	if err := input.PrepareInputForRunByName(inputFile, changesetName, cfg.Domain, envName); err != nil {
+		return "", fmt.Errorf("failed to parse input file: %w", err)
+	}
+
+	registry, err := cfg.LoadChangesets(envName)
+	if err != nil {
+		return "", err
+	}
+
+	config, err := registry.GetResolvedInput(changesetName, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve config of %s: %w", changesetName, err)
+	}
+
+	return approval.Digest(envName, changesetName, config)
+}
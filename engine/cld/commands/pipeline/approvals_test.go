@@ -0,0 +1,188 @@
+package pipeline
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/approval"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func Test_parseApprovalPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := parseApprovalPolicy([]string{"alice=0xabc", "bob=def"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, approval.Policy{
+		Approvers: []approval.Approver{{Name: "alice", Signer: "0xabc"}, {Name: "bob", Signer: "def"}},
+		Quorum:    2,
+	}, policy)
+
+	policy, err = parseApprovalPolicy([]string{"alice=0xabc", "bob=def"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, policy.Quorum)
+
+	policy, err = parseApprovalPolicy(nil, 0)
+	require.NoError(t, err)
+	assert.Empty(t, policy.Approvers)
+
+	_, err = parseApprovalPolicy([]string{"alice"}, 0)
+	require.EqualError(t, err, `invalid approver "alice": expected name=signer`)
+
+	_, err = parseApprovalPolicy([]string{"alice=0xabc"}, 2)
+	require.EqualError(t, err, "invalid quorum 2 for 1 approvers")
+
+	_, err = parseApprovalPolicy(nil, 1)
+	require.EqualError(t, err, "--quorum requires --approver")
+}
+
+//nolint:paralleltest // changes the working directory
+func TestApprovalsCmd_SignAndVerify(t *testing.T) {
+	const env = "mainnet"
+
+	workspaceRoot := t.TempDir()
+	testDomain := domain.NewDomain(filepath.Join(workspaceRoot, domain.DomainsDirName), "test")
+	inputsDir := filepath.Join(workspaceRoot, "domains", testDomain.String(), env, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	writeInput := func(value int) {
+		yamlContent := `environment: mainnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload:
+        value: ` + strconv.Itoa(value)
+		require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "test-input.yaml"), []byte(yamlContent), 0o600))
+	}
+	writeInput(1)
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	// Approver keys
+	edKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	edPub := hex.EncodeToString(edKey.Public().(ed25519.PublicKey))
+	edKeyFile := filepath.Join(workspaceRoot, "ed25519.key")
+	require.NoError(t, os.WriteFile(edKeyFile, []byte(hex.EncodeToString(edKey.Seed())), 0o600))
+
+	evmKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmAddr := crypto.PubkeyToAddress(evmKey.PublicKey).Hex()
+	evmKeyFile := filepath.Join(workspaceRoot, "evm.key")
+	require.NoError(t, os.WriteFile(evmKeyFile, []byte("0x"+hex.EncodeToString(crypto.FromECDSA(evmKey))), 0o600))
+
+	cs := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, any) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(fdeployment.Environment, any) error { return nil },
+	)
+
+	execute := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+
+		cfg := &Config{
+			Logger: logger.Test(t),
+			Domain: testDomain,
+			LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+				reg := changeset.NewChangesetsRegistry()
+				reg.Add("0001_test_changeset", changeset.Configure(cs).WithEnvInput())
+
+				return reg, nil
+			},
+			ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+		}
+
+		cmd, err := NewCommand(cfg)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs(append([]string{"approvals"}, append(args,
+			"--environment", env,
+			"--input-file", "test-input.yaml",
+			"--changeset", "0001_test_changeset",
+		)...))
+		err = cmd.Execute()
+
+		return buf.String(), err
+	}
+
+	approvers := []string{"--approver", "alice=" + edPub, "--approver", "bob=" + evmAddr}
+
+	t.Run("sign with ed25519 key", func(t *testing.T) {
+		output, err := execute(t, "sign", "--key-file", edKeyFile)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Signed by "+edPub+" (ed25519), 1 signature(s)")
+	})
+
+	t.Run("quorum not met", func(t *testing.T) {
+		output, err := execute(t, append([]string{"verify"}, approvers...)...)
+		require.ErrorIs(t, err, approval.ErrQuorumNotMet)
+		require.ErrorContains(t, err, "1 of 2 required approvals, missing approvers: bob")
+		assert.Regexp(t, edPub+`\s+ed25519\s+alice\s+valid`, output)
+	})
+
+	t.Run("sign with evm key", func(t *testing.T) {
+		output, err := execute(t, "sign", "--evm-key-file", evmKeyFile)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Signed by "+evmAddr+" (evm), 2 signature(s)")
+	})
+
+	t.Run("quorum met", func(t *testing.T) {
+		output, err := execute(t, append([]string{"verify"}, approvers...)...)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Approved by alice, bob (2 of 2 required)")
+	})
+
+	t.Run("gate hook accepts the approvals", func(t *testing.T) {
+		hook := approval.Gate(testDomain, approval.Policy{
+			Approvers: []approval.Approver{{Name: "alice", Signer: edPub}, {Name: "bob", Signer: evmAddr}},
+			Quorum:    2,
+		})
+		err := hook.Func(t.Context(), changeset.PreHookParams{
+			Env:          changeset.HookEnv{Name: env, Logger: logger.Test(t)},
+			ChangesetKey: "0001_test_changeset",
+			Config:       map[string]any{"value": 1},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("config changed", func(t *testing.T) {
+		writeInput(2)
+
+		output, err := execute(t, append([]string{"verify"}, approvers...)...)
+		require.ErrorContains(t, err, "0 of 2 required approvals, missing approvers: alice, bob")
+		assert.Contains(t, output, "invalid: ed25519 signature does not match")
+
+		output, err = execute(t, "verify")
+		require.EqualError(t, err, "2 of 2 signature(s) are invalid")
+		assert.Contains(t, output, "invalid: evm signature was not made by the signer")
+
+		// Signing the new config discards the approvals of the previous one
+		output, err = execute(t, "sign", "--key-file", edKeyFile)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Discarding 2 signature(s) of previous digest")
+		assert.Contains(t, output, "1 signature(s)")
+	})
+
+	t.Run("requires a key", func(t *testing.T) {
+		_, err := execute(t, "sign")
+		require.ErrorContains(t, err, "at least one of the flags in the group [key-file evm-key-file kms-key-id] is required")
+	})
+}
@@ -84,8 +84,11 @@ func NewCommand(cfg *Config) (*cobra.Command, error) {
 
 	cmd.AddCommand(
 		newRunCmd(cfg),
+		newApprovalsCmd(cfg),
+		newEstimateCostCmd(cfg),
 		newInputGenerateCmd(cfg),
 		newListCmd(cfg),
+		newPlanCmd(cfg),
 		newTemplateInputCmd(cfg),
 	)
 
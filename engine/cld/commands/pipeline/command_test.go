@@ -103,7 +103,7 @@ func TestNewCommand(t *testing.T) {
 	require.NotNil(t, cmd)
 	require.Equal(t, "pipeline", cmd.Use)
 	require.Equal(t, []string{"durable-pipeline"}, cmd.Aliases)
-	require.Len(t, cmd.Commands(), 4) // run, input-generate, list, template-input
+	require.Len(t, cmd.Commands(), 7) // run, approvals, estimate-cost, input-generate, list, plan, template-input
 }
 
 func TestNewCommand_InvalidConfig(t *testing.T) {
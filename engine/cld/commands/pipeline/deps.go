@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/smartcontractkit/mcms"
 
@@ -21,6 +22,24 @@ type EnvironmentLoaderFunc func(
 	opts ...environment.LoadEnvironmentOption,
 ) (fdeployment.Environment, error)
 
+// ForkEnvironmentLoaderFunc loads a deployment environment in which the chains are forks of real
+// networks.
+type ForkEnvironmentLoaderFunc func(
+	ctx context.Context,
+	dom domain.Domain,
+	envKey string,
+	blockNumbers map[uint64]*big.Int,
+	opts ...environment.LoadEnvironmentOption,
+) (environment.ForkedEnvironment, error)
+
+// UnforkableChainsLoaderFunc loads the chains of an environment whose family cannot be forked.
+type UnforkableChainsLoaderFunc func(
+	ctx context.Context,
+	dom domain.Domain,
+	envKey string,
+	opts ...environment.LoadEnvironmentOption,
+) (chain.BlockChains, error)
+
 // TimelockDelayCorrectorFunc corrects schedule proposal delays against on-chain minDelay.
 type TimelockDelayCorrectorFunc func(
 	ctx context.Context,
@@ -33,6 +52,10 @@ type TimelockDelayCorrectorFunc func(
 type Deps struct {
 	// EnvironmentLoader loads a deployment environment. Default: environment.Load
 	EnvironmentLoader EnvironmentLoaderFunc
+	// ForkEnvironmentLoader loads a forked deployment environment. Default: environment.LoadFork
+	ForkEnvironmentLoader ForkEnvironmentLoaderFunc
+	// UnforkableChainsLoader loads the chains which cannot be forked. Default: environment.LoadUnforkableChains
+	UnforkableChainsLoader UnforkableChainsLoaderFunc
 	// TimelockDelayCorrector corrects timelock proposal delays. Default: timelockdelay.CorrectTimelockDelays
 	TimelockDelayCorrector TimelockDelayCorrectorFunc
 }
@@ -46,6 +69,12 @@ func (d *Deps) applyDefaults() {
 	if d.EnvironmentLoader == nil {
 		d.EnvironmentLoader = DefaultEnvironmentLoader
 	}
+	if d.ForkEnvironmentLoader == nil {
+		d.ForkEnvironmentLoader = environment.LoadFork
+	}
+	if d.UnforkableChainsLoader == nil {
+		d.UnforkableChainsLoader = environment.LoadUnforkableChains
+	}
 	if d.TimelockDelayCorrector == nil {
 		d.TimelockDelayCorrector = timelockdelay.CorrectTimelockDelays
 	}
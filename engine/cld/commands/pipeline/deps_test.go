@@ -16,9 +16,13 @@ func TestDeps_applyDefaults(t *testing.T) {
 
 	d := &Deps{}
 	require.Nil(t, d.EnvironmentLoader)
+	require.Nil(t, d.ForkEnvironmentLoader)
+	require.Nil(t, d.UnforkableChainsLoader)
 
 	d.applyDefaults()
 	require.NotNil(t, d.EnvironmentLoader)
+	require.NotNil(t, d.ForkEnvironmentLoader)
+	require.NotNil(t, d.UnforkableChainsLoader)
 }
 
 func TestDeps_applyDefaults_PreservesCustomLoader(t *testing.T) {
@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+// printDryRunOutput prints the artifacts and operations reports which a run of the changeset would
+// have written, in place of writing them.
+func printDryRunOutput(
+	w io.Writer, csKey string, out fdeployment.ChangesetOutput, newReports []operations.Report[any, any],
+) error {
+	fmt.Fprintf(w, "\n=== Dry run of %s: nothing was written ===\n", csKey)
+
+	fmt.Fprintf(w, "\nOperations reports: %d new\n", len(newReports))
+	for _, report := range newReports {
+		fmt.Fprintf(w, "  • %s %s (%s)\n", report.Def.ID, report.Def.Version, report.ID)
+	}
+
+	//nolint:staticcheck // JobSpecs are still written as an artifact
+	if len(out.JobSpecs) > 0 {
+		//nolint:staticcheck
+		if err := printDryRunArtifact(w, domain.ArtifactJobSpec, out.JobSpecs); err != nil {
+			return err
+		}
+	}
+
+	if len(out.Jobs) > 0 {
+		if err := printDryRunArtifact(w, domain.ArtifactJobs, out.Jobs); err != nil {
+			return err
+		}
+	}
+
+	//nolint:staticcheck // AddressBook is still written as an artifact
+	if out.AddressBook != nil {
+		//nolint:staticcheck
+		addresses, err := out.AddressBook.Addresses()
+		if err != nil {
+			return fmt.Errorf("failed to get address book addresses: %w", err)
+		}
+		if len(addresses) > 0 {
+			if err = printDryRunArtifact(w, domain.ArtifactAddress, addresses); err != nil {
+				return err
+			}
+		}
+	}
+
+	if out.DataStore != nil {
+		if err := printDryRunArtifact(w, domain.ArtifactDataStore, out.DataStore); err != nil {
+			return err
+		}
+	}
+
+	for i, proposal := range out.MCMSProposals {
+		if err := printDryRunArtifact(w, fmt.Sprintf("%s[%d]", domain.ArtifactMCMSProposal, i), proposal); err != nil {
+			return err
+		}
+	}
+
+	for i, proposal := range out.MCMSTimelockProposals {
+		if err := printDryRunArtifact(w, fmt.Sprintf("%s[%d]", domain.ArtifactsMCMSTimelockProposal, i), proposal); err != nil {
+			return err
+		}
+		if i < len(out.DescribedTimelockProposals) && out.DescribedTimelockProposals[i] != "" {
+			fmt.Fprintf(w, "\n%s[%d]:\n%s\n", domain.ArtifactsMCMSTimelockProposalDecoded, i, out.DescribedTimelockProposals[i])
+		}
+	}
+
+	return nil
+}
+
+// printDryRunArtifact prints an artifact as indented JSON under its name.
+func printDryRunArtifact(w io.Writer, name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+
+	fmt.Fprintf(w, "\n%s:\n%s\n", name, b)
+
+	return nil
+}
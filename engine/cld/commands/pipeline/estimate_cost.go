@@ -0,0 +1,270 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/cost"
+	dprun "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/pipeline/run"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+var (
+	estimateCostShort = "Estimate the native token cost of a durable pipeline changeset"
+
+	estimateCostLong = text.LongDesc(`
+		Estimate the native token cost of a durable pipeline changeset.
+
+		This command applies a changeset against forks of the environment's EVM chains and
+		reports the fees paid by the transactions it sent on each chain, as gas used times
+		effective gas price. Solana and Aptos chains cannot be forked, so their transactions
+		are not sent: the fee of each Solana transaction is read from the network, and Aptos
+		transactions are simulated. Simulations do not change the chain state, so Aptos
+		transactions which depend on earlier ones in the same run may fail to simulate, and
+		are reported as simulation failures. The cost of chains of other families is not
+		estimated. Changeset hooks are not run and nothing is persisted.
+
+		With --execute-proposals, the MCMS proposals returned by the changeset are also
+		executed on the forks, so their cost is included in the estimate.
+
+		With --price-file, the cost is also reported in USD. The price file is a local
+		YAML or JSON file which maps chain names or chain selectors to the USD price of
+		one native token.
+	`)
+
+	estimateCostExample = text.Examples(`
+		# Estimate the cost of changeset 0001_deploy_router in mainnet
+		chainlink-deployments durable-pipeline estimate-cost \
+		  --environment mainnet \
+		  --changeset 0001_deploy_router \
+		  --input-file inputs.yaml
+
+		# Include the cost of executing the proposals, in USD
+		chainlink-deployments durable-pipeline estimate-cost \
+		  --environment mainnet \
+		  --input-file inputs.yaml \
+		  --execute-proposals \
+		  --price-file prices.yaml
+	`)
+)
+
+type estimateCostFlags struct {
+	runFlags
+
+	priceFile        string
+	executeProposals bool
+	formatAsJSON     bool
+}
+
+func newEstimateCostCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "estimate-cost",
+		Short:   estimateCostShort,
+		Long:    estimateCostLong,
+		Example: estimateCostExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			f := estimateCostFlags{
+				runFlags: runFlags{
+					environment:    flags.MustString(cmd.Flags().GetString("environment")),
+					changeset:      flags.MustString(cmd.Flags().GetString("changeset")),
+					inputFile:      flags.MustString(cmd.Flags().GetString("input-file")),
+					changesetIndex: flags.MustInt(cmd.Flags().GetInt("changeset-index")),
+					force:          flags.MustBool(cmd.Flags().GetBool("force")),
+				},
+				priceFile:        flags.MustString(cmd.Flags().GetString("price-file")),
+				executeProposals: flags.MustBool(cmd.Flags().GetBool("execute-proposals")),
+				formatAsJSON:     flags.MustBool(cmd.Flags().GetBool("json")),
+			}
+
+			return runEstimateCost(cmd, cfg, f)
+		},
+	}
+
+	flags.Environment(cmd)
+	cmd.Flags().StringP("changeset", "c", "", "changeset to estimate by name")
+	cmd.Flags().StringP("input-file", "i", "", "YAML input file name. Not the full path, just the name")
+	cmd.Flags().IntP("changeset-index", "x", 0, "Index of changeset to estimate by position in array format input file")
+	cmd.Flags().String("price-file", "", "Path to a YAML or JSON file of USD prices per native token, keyed by chain name or selector")
+	cmd.Flags().Bool("execute-proposals", false, "Also execute the MCMS proposals returned by the changeset on the forks")
+	cmd.Flags().BoolP("json", "j", false, "Emit the cost report as JSON")
+	cmd.Flags().Bool("force", false, "Estimate the changeset even if all the address refs it would create already exist")
+
+	_ = cmd.MarkFlagRequired("input-file")
+	cmd.MarkFlagsMutuallyExclusive("changeset", "changeset-index")
+
+	return cmd
+}
+
+func runEstimateCost(cmd *cobra.Command, cfg *Config, f estimateCostFlags) error {
+	// Load the prices first so an invalid price file fails before any fork is started
+	var prices cost.Prices
+	if f.priceFile != "" {
+		var err error
+		if prices, err = cost.LoadPrices(f.priceFile); err != nil {
+			return err
+		}
+	}
+
+	artdir := cfg.Domain.EnvDir(f.environment).ArtifactsDir()
+
+	csKey, _, err := resolveChangesetForRun(cmd, cfg, f.runFlags)
+	if err != nil {
+		return err
+	}
+
+	if err = artdir.SetDurablePipelines(strconv.FormatInt(time.Now().UnixNano(), 10)); err != nil {
+		return err
+	}
+
+	registry, err := cfg.LoadChangesets(f.environment)
+	if err != nil {
+		return err
+	}
+
+	envOptions, err := dprun.ConfigureEnvironmentOptions(registry, csKey, false, cfg.Logger)
+	if err != nil {
+		return err
+	}
+
+	csOptions, err := registry.GetChangesetOptions(csKey)
+	if err != nil {
+		return err
+	}
+
+	reports, err := artdir.LoadOperationsReports(csKey)
+	if err != nil {
+		return fmt.Errorf("failed to load operations report: %w", err)
+	}
+
+	deps := cfg.deps()
+	fork, err := deps.ForkEnvironmentLoader(cmd.Context(), cfg.Domain, f.environment, nil, envOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to load forked environment: %w", err)
+	}
+
+	unforkable, err := deps.UnforkableChainsLoader(cmd.Context(), cfg.Domain, f.environment, envOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to load chains which cannot be forked: %w", err)
+	}
+	blockChains := maps.Collect(fork.BlockChains.All())
+	maps.Insert(blockChains, unforkable.All())
+
+	// Replay the existing operations reports, as a run would, without persisting the new ones
+	env := fork.Environment
+	env.OperationsBundle = operations.NewBundle(env.GetContext, cfg.Logger,
+		operations.NewMemoryReporter(operations.WithReports(reports)),
+		operations.WithOperationRegistry(csOptions.OperationRegistry),
+	)
+
+	tracker, err := cost.NewTracker(cmd.Context(), chain.NewBlockChains(blockChains))
+	if err != nil {
+		return err
+	}
+	// The Solana and Aptos chains of the tracker record their transactions instead of sending them
+	env.BlockChains = tracker.BlockChains()
+
+	cfg.Logger.Infof("Estimating cost of changeset %s for environment: %s", csKey, f.environment)
+
+	// Hooks may have side effects, such as notifications or audit logs, so they are not run
+	applyOpts := []changeset.ApplyOption{changeset.WithArtifactsChecker(artdir), changeset.WithoutHooks()}
+	if f.force {
+		applyOpts = append(applyOpts, changeset.WithForce())
+	}
+
+	warnings := collectPreconditionWarnings(&env)
+	out, err := registry.Apply(csKey, env, applyOpts...)
+	format := preconditionFormatTable
+	if f.formatAsJSON {
+		format = preconditionFormatJSON
+	}
+	if err != nil {
+		if printErr := printPreconditionFindings(cmd.OutOrStdout(), err, nil, format); printErr != nil {
+			cfg.Logger.Errorf("failed to print precondition findings: %v", printErr)
+		}
+
+		return err
+	}
+	// The warnings are printed to stderr, so that the cost report is the only output on stdout
+	if printErr := printPreconditionFindings(cmd.ErrOrStderr(), nil, warnings, format); printErr != nil {
+		cfg.Logger.Errorf("failed to print precondition findings: %v", printErr)
+	}
+
+	if f.executeProposals {
+		if _, err = fork.ApplyChangesetOutput(cmd.Context(), out); err != nil {
+			return fmt.Errorf("failed to execute proposals on forks: %w", err)
+		}
+	}
+
+	report, err := tracker.Collect(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if prices != nil {
+		report.ApplyPrices(prices)
+	}
+
+	if f.formatAsJSON {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal cost report: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(b))
+
+		return nil
+	}
+
+	return printCostReport(cmd.OutOrStdout(), csKey, report)
+}
+
+// printCostReport prints the cost report as a table.
+func printCostReport(out io.Writer, csKey string, report *cost.Report) error {
+	fmt.Fprintf(out, "\n=== Estimated cost of %s ===\n\n", csKey)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "CHAIN\tSELECTOR\tFAMILY\tTXS\tGAS USED\tFEE (NATIVE)\tFEE (USD)\n")
+	fmt.Fprintf(w, "-----\t--------\t------\t---\t--------\t------------\t---------\n")
+
+	for _, c := range report.Chains {
+		usd := c.USDFee
+		if usd == "" {
+			usd = "-"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\t%s\t%s\n",
+			c.ChainName, c.ChainSelector, c.Family, c.TransactionCount, c.GasUsed, c.NativeFee, usd,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush tabwriter: %w", err)
+	}
+
+	if report.TotalUSD != "" {
+		fmt.Fprintf(out, "\nTotal (USD): %s\n", report.TotalUSD)
+	}
+
+	for _, c := range report.Chains {
+		if c.SimulationFailures > 0 {
+			fmt.Fprintf(out, "\nWarning: %d simulated transactions failed on chain %d, the estimate may be low\n",
+				c.SimulationFailures, c.ChainSelector,
+			)
+		}
+	}
+
+	for _, selector := range report.Unsupported {
+		fmt.Fprintf(out, "\nWarning: cost of chain %d was not estimated, its family is not supported\n", selector)
+	}
+
+	return nil
+}
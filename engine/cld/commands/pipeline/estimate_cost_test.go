@@ -0,0 +1,196 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/smartcontractkit/mcms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	evmprov "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm/provider"
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/cost"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/environment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+// transferChangeset returns a changeset which sends a transfer of 1 wei from the deployer of the
+// EVM chain, and returns the given proposals.
+func transferChangeset(selector uint64, proposals []mcms.TimelockProposal) fdeployment.ChangeSetV2[any] {
+	return fdeployment.CreateChangeSet(
+		func(e fdeployment.Environment, _ any) (fdeployment.ChangesetOutput, error) {
+			c := e.BlockChains.EVMChains()[selector]
+
+			nonce, err := c.Client.PendingNonceAt(e.GetContext(), c.DeployerKey.From)
+			if err != nil {
+				return fdeployment.ChangesetOutput{}, err
+			}
+			gasPrice, err := c.Client.SuggestGasPrice(e.GetContext())
+			if err != nil {
+				return fdeployment.ChangesetOutput{}, err
+			}
+
+			tx, err := c.DeployerKey.Signer(c.DeployerKey.From, types.NewTx(&types.LegacyTx{
+				Nonce: nonce, To: &common.Address{1}, Value: big.NewInt(1), Gas: 21_000, GasPrice: gasPrice,
+			}))
+			if err != nil {
+				return fdeployment.ChangesetOutput{}, err
+			}
+			if err = c.Client.SendTransaction(e.GetContext(), tx); err != nil {
+				return fdeployment.ChangesetOutput{}, err
+			}
+			if _, err = c.Confirm(tx); err != nil {
+				return fdeployment.ChangesetOutput{}, err
+			}
+
+			return fdeployment.ChangesetOutput{MCMSTimelockProposals: proposals}, nil
+		},
+		func(fdeployment.Environment, any) error { return nil },
+	)
+}
+
+// setupEstimateCost creates a workspace with an input file for 0001_test_changeset, and returns a
+// pipeline command whose forked environment is a simulated EVM chain.
+func setupEstimateCost(t *testing.T, proposals []mcms.TimelockProposal) (*bytes.Buffer, func(args ...string) error) {
+	t.Helper()
+
+	const env = "testnet"
+
+	workspaceRoot := t.TempDir()
+	testDomain := domain.NewDomain(filepath.Join(workspaceRoot, domain.DomainsDirName), "test")
+	inputsDir := filepath.Join(workspaceRoot, "domains", testDomain.String(), env, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	yamlContent := `environment: testnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload:
+        value: 1`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "test-input.yaml"), []byte(yamlContent), 0o600))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	selector := chainsel.GETH_TESTNET.Selector
+	bc, err := evmprov.NewSimChainProvider(t, selector, evmprov.SimChainProviderConfig{}).Initialize(t.Context())
+	require.NoError(t, err)
+	simChain, ok := bc.(evm.Chain)
+	require.True(t, ok)
+
+	cfg := &Config{
+		Logger: logger.Test(t),
+		Domain: testDomain,
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			reg.Add("0001_test_changeset", changeset.Configure(transferChangeset(selector, proposals)).WithEnvInput())
+			// Hooks must not run against the fork, so this would abort the estimate
+			reg.AddGlobalPreHooks(changeset.PreHook{
+				HookDefinition: changeset.HookDefinition{Name: "notify"},
+				Func: func(context.Context, changeset.PreHookParams) error {
+					return errors.New("hook ran")
+				},
+			})
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+		Deps: Deps{
+			ForkEnvironmentLoader: func(
+				ctx context.Context, _ domain.Domain, _ string, _ map[uint64]*big.Int, _ ...environment.LoadEnvironmentOption,
+			) (environment.ForkedEnvironment, error) {
+				return environment.ForkedEnvironment{
+					Environment: fdeployment.Environment{
+						Name:        env,
+						Logger:      logger.Test(t),
+						GetContext:  func() context.Context { return ctx },
+						BlockChains: chain.NewBlockChainsFromSlice([]chain.BlockChain{simChain}),
+					},
+				}, nil
+			},
+			UnforkableChainsLoader: func(
+				context.Context, domain.Domain, string, ...environment.LoadEnvironmentOption,
+			) (chain.BlockChains, error) {
+				return chain.NewBlockChains(nil), nil
+			},
+		},
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	return &buf, func(args ...string) error {
+		cmd.SetArgs(append([]string{"estimate-cost", "--environment", env, "--input-file", "test-input.yaml"}, args...))
+		return cmd.Execute()
+	}
+}
+
+//nolint:paralleltest // changes the working directory
+func TestEstimateCostCmd_Table(t *testing.T) {
+	buf, run := setupEstimateCost(t, nil)
+
+	pricesPath := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(pricesPath, []byte("geth-testnet: 2000\n"), 0o600))
+
+	require.NoError(t, run("--price-file", pricesPath))
+
+	output := buf.String()
+	assert.Contains(t, output, "=== Estimated cost of 0001_test_changeset ===")
+	assert.Contains(t, output, "FEE (NATIVE)")
+	assert.Regexp(t, `geth-testnet\s+3379446385462418246\s+evm\s+1\s+21000\s+0\.\d{18}\s+\d+\.\d{2}`, output)
+	assert.Contains(t, output, "Total (USD):")
+}
+
+//nolint:paralleltest // changes the working directory
+func TestEstimateCostCmd_JSON(t *testing.T) {
+	buf, run := setupEstimateCost(t, nil)
+
+	require.NoError(t, run("--json"))
+
+	var report cost.Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.Chains, 1)
+	assert.Equal(t, chainsel.GETH_TESTNET.Selector, report.Chains[0].ChainSelector)
+	assert.Equal(t, 1, report.Chains[0].TransactionCount)
+	assert.Equal(t, uint64(21_000), report.Chains[0].GasUsed)
+	assert.Positive(t, report.Chains[0].Fee.Sign())
+	assert.Empty(t, report.TotalUSD)
+}
+
+//nolint:paralleltest // changes the working directory
+func TestEstimateCostCmd_ExecuteProposals(t *testing.T) {
+	_, run := setupEstimateCost(t, []mcms.TimelockProposal{*testProposal})
+
+	// The simulated chain has no fork client to execute the proposal with
+	err := run("--execute-proposals")
+	require.ErrorContains(t, err, "failed to execute proposals on forks")
+	require.ErrorContains(t, err, "no fork client defined for chain selector")
+}
+
+//nolint:paralleltest // changes the working directory
+func TestEstimateCostCmd_InvalidPriceFile(t *testing.T) {
+	_, run := setupEstimateCost(t, nil)
+
+	err := run("--price-file", filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read price file")
+}
@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// printHookRecords prints a summary of the hooks run by a changeset, so that a hook which failed
+// with the Warn policy or was skipped is visible in the run output. Nothing is printed if no hook
+// ran.
+func printHookRecords(w io.Writer, records []fdeployment.HookRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	counts := make(map[fdeployment.HookStatus]int)
+	for _, r := range records {
+		counts[r.Status]++
+	}
+	fmt.Fprintf(w, "\nHooks: %d passed, %d warned, %d failed, %d skipped\n",
+		counts[fdeployment.HookStatusPassed], counts[fdeployment.HookStatusWarned],
+		counts[fdeployment.HookStatusFailed], counts[fdeployment.HookStatusSkipped],
+	)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "PHASE\tKIND\tNAME\tPOLICY\tSTATUS\tDURATION\tERROR\n")
+	fmt.Fprintf(tw, "-----\t----\t----\t------\t------\t--------\t-----\n")
+
+	for _, r := range records {
+		duration := "-"
+		if r.Status != fdeployment.HookStatusSkipped {
+			duration = r.Duration.Round(time.Millisecond).String()
+		}
+		errMsg := r.Error
+		if errMsg == "" {
+			errMsg = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Phase, r.Kind, r.Name, r.FailurePolicy, r.Status, duration, errMsg,
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush tabwriter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/environment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func Test_printHookRecords(t *testing.T) {
+	t.Parallel()
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, printHookRecords(&buf, []fdeployment.HookRecord{
+			{
+				Name: "check-balance", Phase: fdeployment.HookPhasePre, Kind: "global pre-hook",
+				FailurePolicy: "Abort", Status: fdeployment.HookStatusPassed, Duration: 1500 * time.Millisecond,
+			},
+			{
+				Name: "verify-router", Phase: fdeployment.HookPhasePost, Kind: "changeset post-hook",
+				FailurePolicy: "Warn", Status: fdeployment.HookStatusWarned, Duration: 20 * time.Millisecond,
+				Error: "router not found",
+			},
+			{
+				Name: "notify", Phase: fdeployment.HookPhasePost, Kind: "global post-hook",
+				FailurePolicy: "Abort", Status: fdeployment.HookStatusSkipped, Error: `dependency "verify" failed`,
+			},
+		}))
+
+		output := buf.String()
+		assert.Contains(t, output, "Hooks: 1 passed, 1 warned, 0 failed, 1 skipped")
+		assert.Regexp(t, `pre\s+global pre-hook\s+check-balance\s+Abort\s+passed\s+1.5s\s+-`, output)
+		assert.Regexp(t, `post\s+changeset post-hook\s+verify-router\s+Warn\s+warned\s+20ms\s+router not found`, output)
+		assert.Regexp(t, `post\s+global post-hook\s+notify\s+Abort\s+skipped\s+-\s+dependency "verify" failed`, output)
+	})
+
+	t.Run("no hooks", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, printHookRecords(&buf, nil))
+		assert.Empty(t, buf.String())
+	})
+}
+
+const hookRecordsChangeset = "0001_test_changeset"
+
+// setupHookRecordsRun creates a domain with an input file for a stub changeset, and returns the
+// run command output, a function which runs the changeset with the hooks added by addHooks, and
+// the environment directory. It changes the working directory.
+func setupHookRecordsRun(
+	t *testing.T, addHooks func(reg *changeset.ChangesetsRegistry),
+) (*bytes.Buffer, func() error, string) {
+	t.Helper()
+
+	const env = "testnet"
+
+	workspaceRoot := t.TempDir()
+	testDomain := domain.NewDomain(filepath.Join(workspaceRoot, domain.DomainsDirName), "test")
+	envRoot := filepath.Join(workspaceRoot, domain.DomainsDirName, testDomain.String(), env)
+	inputsDir := filepath.Join(envRoot, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	yamlContent := `environment: testnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload:
+        value: 1`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "test-input.yaml"), []byte(yamlContent), 0o600))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	cfg := &Config{
+		Logger: logger.Test(t),
+		Domain: testDomain,
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			addHooks(reg)
+			reg.Add(hookRecordsChangeset, changeset.Configure(&stubChangeset{}).WithEnvInput())
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+		Deps: Deps{
+			EnvironmentLoader: func(context.Context, domain.Domain, string, ...environment.LoadEnvironmentOption) (fdeployment.Environment, error) {
+				return fdeployment.Environment{Logger: logger.Test(t), GetContext: t.Context}, nil
+			},
+		},
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{
+		"run",
+		"--environment", env,
+		"--input-file", "test-input.yaml",
+	})
+
+	return &buf, cmd.Execute, envRoot
+}
+
+//nolint:paralleltest // changes the working directory
+func TestRunCmd_HookRecords(t *testing.T) {
+	buf, run, envRoot := setupHookRecordsRun(t, func(reg *changeset.ChangesetsRegistry) {
+		reg.AddGlobalPostHooks(changeset.PostHook{
+			HookDefinition: changeset.HookDefinition{Name: "verify-router", FailurePolicy: changeset.Warn},
+			Func: func(context.Context, changeset.PostHookParams) error {
+				return errors.New("router not found")
+			},
+		})
+	})
+	require.NoError(t, run())
+
+	// The summary is printed, and the records are saved with the changeset artifacts
+	assert.Contains(t, buf.String(), "Hooks: 0 passed, 1 warned, 0 failed, 0 skipped")
+
+	files, err := filepath.Glob(filepath.Join(envRoot, domain.ArtifactsDirName, domain.ArtifactsDurablePipelineDirName,
+		hookRecordsChangeset, "*", "*_"+domain.ArtifactHookRecords+".json",
+	))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	records, err := domain.LoadHookRecords(files[0])
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "verify-router", records[0].Name)
+	assert.Equal(t, fdeployment.HookPhasePost, records[0].Phase)
+	assert.Equal(t, fdeployment.HookStatusWarned, records[0].Status)
+	assert.Equal(t, "router not found", records[0].Error)
+}
+
+//nolint:paralleltest // changes the working directory
+func TestRunCmd_HookRecords_Failure(t *testing.T) {
+	buf, run, envRoot := setupHookRecordsRun(t, func(reg *changeset.ChangesetsRegistry) {
+		reg.AddGlobalPreHooks(changeset.PreHook{
+			HookDefinition: changeset.HookDefinition{Name: "check-approvals"},
+			Func: func(context.Context, changeset.PreHookParams) error {
+				return errors.New("missing approval")
+			},
+		})
+	})
+	require.ErrorContains(t, run(), "missing approval")
+	assert.Contains(t, buf.String(), "Hooks: 0 passed, 0 warned, 1 failed, 0 skipped")
+
+	// The changeset has no artifacts, so the records are saved in the hook records log
+	assert.NoDirExists(t, filepath.Join(envRoot, domain.ArtifactsDirName, domain.ArtifactsDurablePipelineDirName,
+		hookRecordsChangeset,
+	))
+
+	records, err := domain.LoadHookRecords(filepath.Join(envRoot, domain.HookRecordsDirName,
+		domain.ArtifactsDurablePipelineDirName, hookRecordsChangeset+"-"+domain.ArtifactHookRecords+".json",
+	))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "check-approvals", records[0].Name)
+	assert.Equal(t, fdeployment.HookPhasePre, records[0].Phase)
+	assert.Equal(t, fdeployment.HookStatusFailed, records[0].Status)
+}
@@ -17,6 +17,10 @@ var (
 
 		Reads an inputs file, resolves each changeset via registered config resolvers,
 		and outputs the resulting config in YAML or JSON.
+
+		When writing to a file, a JSON Schema of the generated config is written next
+		to it (or to --schema-output), and referenced from YAML output so that editors
+		can validate and autocomplete it.
 `
 
 	inputGenerateExample = `
@@ -31,6 +35,13 @@ var (
 		  --inputs inputs.yaml \
 		  --json \
 		  --output config.json
+
+		# Write YAML output and its JSON Schema to explicit paths
+		chainlink-deployments durable-pipeline input-generate \
+		  --environment testnet \
+		  --inputs inputs.yaml \
+		  --output config.yaml \
+		  --schema-output schemas/config.schema.json
 	`
 )
 
@@ -38,6 +49,7 @@ type inputGenerateFlags struct {
 	environment  string
 	inputs       string
 	output       string
+	schemaOutput string
 	formatAsJSON bool
 }
 
@@ -52,6 +64,7 @@ func newInputGenerateCmd(cfg *Config) *cobra.Command {
 				environment:  flags.MustString(cmd.Flags().GetString("environment")),
 				inputs:       flags.MustString(cmd.Flags().GetString("inputs")),
 				output:       flags.MustString(cmd.Flags().GetString("output")),
+				schemaOutput: flags.MustString(cmd.Flags().GetString("schema-output")),
 				formatAsJSON: flags.MustBool(cmd.Flags().GetBool("json")),
 			}
 
@@ -63,6 +76,7 @@ func newInputGenerateCmd(cfg *Config) *cobra.Command {
 	cmd.Flags().StringP("inputs", "i", "", "Inputs file name (required)")
 	cmd.Flags().BoolP("json", "j", false, "Emit JSON instead of YAML")
 	cmd.Flags().StringP("output", "o", "", "Output file path (optional; prints to stdout if omitted)")
+	cmd.Flags().String("schema-output", "", "JSON Schema output file path (optional; defaults to <output>.schema.json when --output is set)")
 
 	_ = cmd.MarkFlagRequired("inputs")
 
@@ -75,19 +89,29 @@ func runInputGenerate(cmd *cobra.Command, cfg *Config, f inputGenerateFlags) err
 		return fmt.Errorf("load changesets registry: %w", err)
 	}
 
+	schemaOutput := f.schemaOutput
+	if schemaOutput == "" && f.output != "" {
+		schemaOutput = input.DefaultSchemaPath(f.output)
+	}
+
 	output, err := input.Generate(input.GenerateOptions{
-		InputsFileName:  f.inputs,
-		Domain:          cfg.Domain,
-		EnvKey:          f.environment,
-		Registry:        registry,
-		ResolverManager: cfg.ConfigResolverManager,
-		FormatAsJSON:    f.formatAsJSON,
-		OutputPath:      f.output,
+		InputsFileName:   f.inputs,
+		Domain:           cfg.Domain,
+		EnvKey:           f.environment,
+		Registry:         registry,
+		ResolverManager:  cfg.ConfigResolverManager,
+		FormatAsJSON:     f.formatAsJSON,
+		OutputPath:       f.output,
+		SchemaOutputPath: schemaOutput,
 	})
 	if err != nil {
 		return err
 	}
 
+	if schemaOutput != "" {
+		cfg.Logger.Infof("JSON Schema of the generated config written to: %s", schemaOutput)
+	}
+
 	if f.output != "" {
 		format := "YAML"
 		if f.formatAsJSON {
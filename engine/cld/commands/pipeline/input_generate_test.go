@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -139,6 +140,12 @@ changesets:
 	data, err := os.ReadFile(outPath)
 	require.NoError(t, err)
 	require.Contains(t, string(data), "environment: testnet")
+
+	// The schema is written next to the output, and referenced from it
+	require.True(t, strings.HasPrefix(string(data), "# yaml-language-server: $schema=out.schema.json\n"))
+	schema, err := os.ReadFile(filepath.Join(filepath.Dir(outPath), "out.schema.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(schema), `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
 }
 
 //nolint:paralleltest
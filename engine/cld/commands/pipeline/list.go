@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
 )
 
@@ -16,18 +17,34 @@ var (
 	listLong = `
 		List durable pipeline info.
 
-		Displays registered changesets (static vs dynamic) and available resolvers
-		for the given environment.
+		Displays registered changesets (static vs dynamic) with their owner, tags and
+		status, and available resolvers for the given environment. Changesets can be
+		filtered by tag and owner, and deprecated changesets can be hidden. Use --wide
+		to also display the environments each changeset is allowed in and its description.
 	`
 
 	listExample = `
 		# List durable pipeline info for testnet
 		chainlink-deployments durable-pipeline list --environment testnet
+
+		# List the changesets mounted under the ccip namespace
+		chainlink-deployments durable-pipeline list --environment testnet --namespace ccip
+
+		# List the non-deprecated changesets of the ccip team tagged router
+		chainlink-deployments durable-pipeline list --environment testnet --owner ccip --tag router --hide-deprecated
+
+		# List the changesets with their allowed environments and descriptions
+		chainlink-deployments durable-pipeline list --environment testnet --wide
 	`
 )
 
 type listFlags struct {
-	environment string
+	environment    string
+	tags           []string
+	owner          string
+	namespace      string
+	hideDeprecated bool
+	wide           bool
 }
 
 func newListCmd(cfg *Config) *cobra.Command {
@@ -37,8 +54,16 @@ func newListCmd(cfg *Config) *cobra.Command {
 		Long:    listLong,
 		Example: listExample,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			hideDeprecated, _ := cmd.Flags().GetBool("hide-deprecated")
+			wide, _ := cmd.Flags().GetBool("wide")
 			f := listFlags{
-				environment: flags.MustString(cmd.Flags().GetString("environment")),
+				environment:    flags.MustString(cmd.Flags().GetString("environment")),
+				tags:           tags,
+				owner:          flags.MustString(cmd.Flags().GetString("owner")),
+				namespace:      flags.MustString(cmd.Flags().GetString("namespace")),
+				hideDeprecated: hideDeprecated,
+				wide:           wide,
 			}
 
 			return runList(cmd, cfg, f)
@@ -46,6 +71,11 @@ func newListCmd(cfg *Config) *cobra.Command {
 	}
 
 	flags.Environment(cmd)
+	cmd.Flags().StringSliceP("tag", "t", nil, "Only list changesets with all the given tags (repeatable)")
+	cmd.Flags().String("owner", "", "Only list changesets of the given owner")
+	cmd.Flags().String("namespace", "", "Only list changesets mounted under the given namespace")
+	cmd.Flags().Bool("hide-deprecated", false, "Do not list deprecated changesets")
+	cmd.Flags().Bool("wide", false, "Also list the allowed environments and description of each changeset")
 
 	return cmd
 }
@@ -56,7 +86,7 @@ func runList(cmd *cobra.Command, cfg *Config, f listFlags) error {
 		return fmt.Errorf("failed to load changesets registry: %w", err)
 	}
 
-	changesets := registry.ListKeys()
+	changesets := registry.ListMetadata()
 	out := cmd.OutOrStdout()
 
 	fmt.Fprintf(out, "\n=== Durable Pipeline Info for %s ===\n", cfg.Domain.String())
@@ -65,28 +95,51 @@ func runList(cmd *cobra.Command, cfg *Config, f listFlags) error {
 	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 
 	fmt.Fprintf(w, "\nRegistered Changesets:\n")
-	fmt.Fprintf(w, "TYPE\tNAME\tCONFIG SOURCE\n")
-	fmt.Fprintf(w, "----\t----\t-------------\n")
+	if f.wide {
+		fmt.Fprintf(w, "TYPE\tNAME\tCONFIG SOURCE\tOWNER\tTAGS\tSTATUS\tENVIRONMENTS\tDESCRIPTION\n")
+		fmt.Fprintf(w, "----\t----\t-------------\t-----\t----\t------\t------------\t-----------\n")
+	} else {
+		fmt.Fprintf(w, "TYPE\tNAME\tCONFIG SOURCE\tOWNER\tTAGS\tSTATUS\n")
+		fmt.Fprintf(w, "----\t----\t-------------\t-----\t----\t------\n")
+	}
+
+	for _, metadata := range changesets {
+		if !metadata.HasTags(f.tags...) ||
+			(f.owner != "" && metadata.Owner != f.owner) ||
+			(f.namespace != "" && !inNamespace(metadata.Key, f.namespace)) ||
+			(f.hideDeprecated && metadata.Deprecated) {
+			continue
+		}
 
-	for _, changeset := range changesets {
-		regCfg, err := registry.GetConfigurations(changeset)
+		regCfg, err := registry.GetConfigurations(metadata.Key)
 		if err != nil {
-			return fmt.Errorf("get configurations for %s: %w", changeset, err)
+			return fmt.Errorf("get configurations for %s: %w", metadata.Key, err)
 		}
 		res := regCfg.ConfigResolver
 
-		if res == nil {
-			fmt.Fprintf(w, "STATIC\t%s\tYAML input file\n", changeset)
-		} else {
+		kind, source := "STATIC", "YAML input file"
+		if res != nil {
 			resolverName := cfg.ConfigResolverManager.NameOf(res)
 			if resolverName == "" {
-				fmt.Fprintf(w, "ERROR\t%s\tResolver not registered\n", changeset)
+				kind, source = "ERROR", "Resolver not registered"
 			} else {
 				parts := strings.Split(resolverName, ".")
-				shortName := parts[len(parts)-1]
-				fmt.Fprintf(w, "DYNAMIC\t%s\t%s\n", changeset, shortName)
+				kind, source = "DYNAMIC", parts[len(parts)-1]
 			}
 		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s",
+			kind, metadata.Key, source, orDash(metadata.Owner), orDash(strings.Join(metadata.Tags, ",")),
+			changesetStatus(metadata, f.environment),
+		)
+		if f.wide {
+			envs := "all"
+			if len(metadata.AllowedEnvironments) > 0 {
+				envs = strings.Join(metadata.AllowedEnvironments, ",")
+			}
+			fmt.Fprintf(w, "\t%s\t%s", envs, orDash(metadata.Description))
+		}
+		fmt.Fprintln(w)
 	}
 
 	if err := w.Flush(); err != nil {
@@ -103,3 +156,33 @@ func runList(cmd *cobra.Command, cfg *Config, f listFlags) error {
 
 	return nil
 }
+
+// changesetStatus returns whether the changeset can be applied in the environment.
+func changesetStatus(metadata changeset.ChangesetMetadata, envName string) string {
+	switch {
+	case !metadata.AllowedIn(envName):
+		return "not allowed in " + envName
+	case metadata.Deprecated && metadata.ReplacedBy != "":
+		return "deprecated, use " + metadata.ReplacedBy
+	case metadata.Deprecated:
+		return "deprecated"
+	default:
+		return "active"
+	}
+}
+
+// inNamespace returns whether the changeset key is qualified by the namespace.
+func inNamespace(key, namespace string) bool {
+	ns, _ := changeset.SplitKey(key)
+
+	return ns == namespace
+}
+
+// orDash returns s, or "-" if s is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -58,6 +58,115 @@ func TestListCmd_Success(t *testing.T) {
 	require.Contains(t, output, "Available Config Resolvers")
 }
 
+func TestListCmd_Metadata(t *testing.T) {
+	t.Parallel()
+
+	loadChangesets := func(string) (*changeset.ChangesetsRegistry, error) {
+		reg := changeset.NewChangesetsRegistry()
+		reg.Add("0001_router", changeset.Configure(&stubChangeset{}).With(1),
+			changeset.WithOwner("ccip"), changeset.WithTags("router", "ccip"),
+			changeset.WithDeprecation("0003_router_v2"), changeset.WithDescription("Deploys the router"),
+		)
+		reg.Add("0002_feeds", changeset.Configure(&stubChangeset{}).With(1),
+			changeset.WithOwner("data-feeds"), changeset.WithTags("feeds"),
+			changeset.WithAllowedEnvironments("mainnet"),
+		)
+		reg.Add("0003_router_v2", changeset.Configure(&stubChangeset{}).With(1),
+			changeset.WithOwner("ccip"), changeset.WithTags("router"),
+		)
+		reg.Add("0004_untagged", changeset.Configure(&stubChangeset{}).With(1))
+
+		ccip := changeset.NewChangesetsRegistry()
+		ccip.Add("0001_lane", changeset.Configure(&stubChangeset{}).With(1))
+		if err := reg.Mount("ccip", ccip); err != nil {
+			return nil, err
+		}
+
+		return reg, nil
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantLines   []string
+		wantMissing []string
+	}{
+		{
+			name: "all",
+			wantLines: []string{
+				"OWNER       TAGS         STATUS",
+				"ccip        router,ccip  deprecated, use 0003_router_v2",
+				"data-feeds  feeds        not allowed in testnet",
+				"ccip        router       active",
+				"-           -            active",
+			},
+		},
+		{
+			name: "wide",
+			args: []string{"--wide"},
+			wantLines: []string{
+				"STATUS                          ENVIRONMENTS  DESCRIPTION",
+				"deprecated, use 0003_router_v2  all           Deploys the router",
+				"not allowed in testnet          mainnet       -",
+			},
+		},
+		{
+			name:        "by tag",
+			args:        []string{"--tag", "router", "--tag", "ccip"},
+			wantLines:   []string{"0001_router"},
+			wantMissing: []string{"0002_feeds", "0003_router_v2", "0004_untagged"},
+		},
+		{
+			name:        "by owner",
+			args:        []string{"--owner", "data-feeds"},
+			wantLines:   []string{"0002_feeds"},
+			wantMissing: []string{"0001_router", "0003_router_v2", "0004_untagged"},
+		},
+		{
+			name:        "by namespace",
+			args:        []string{"--namespace", "ccip"},
+			wantLines:   []string{"ccip/0001_lane"},
+			wantMissing: []string{"0001_router", "0002_feeds", "0003_router_v2", "0004_untagged"},
+		},
+		{
+			name:        "hide deprecated",
+			args:        []string{"--owner", "ccip", "--hide-deprecated"},
+			wantLines:   []string{"0003_router_v2"},
+			wantMissing: []string{"0001_router", "0002_feeds", "0004_untagged"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &Config{
+				Logger:                logger.Test(t),
+				Domain:                domain.NewDomain(t.TempDir(), "test"),
+				LoadChangesets:        loadChangesets,
+				ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+			}
+
+			cmd, err := NewCommand(cfg)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetArgs(append([]string{"list", "--environment", "testnet"}, tt.args...))
+
+			require.NoError(t, cmd.Execute())
+
+			output := buf.String()
+			for _, line := range tt.wantLines {
+				require.Contains(t, output, line)
+			}
+			for _, key := range tt.wantMissing {
+				require.NotContains(t, output, "STATIC  "+key)
+			}
+		})
+	}
+}
+
 func TestListCmd_LoadError(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
+)
+
+var (
+	planShort = "Show the changeset dependency graph and run plan"
+
+	planLong = text.LongDesc(`
+		Show the changeset dependency graph and run plan.
+
+		Displays the prerequisites declared by each registered changeset and the order
+		in which the changesets must be applied so that every changeset runs after its
+		prerequisites. Changesets which already have artifacts in the environment are
+		marked as applied.
+
+		If one or more changesets are given, the plan only contains those changesets and
+		their transitive prerequisites.
+	`)
+
+	planExample = text.Examples(`
+		# Show the run plan of all changesets in testnet
+		chainlink-deployments durable-pipeline plan --environment testnet
+
+		# Show the run plan of a single changeset and its prerequisites
+		chainlink-deployments durable-pipeline plan --environment testnet --changeset 0002_configure_lanes
+	`)
+)
+
+type planFlags struct {
+	environment string
+	changesets  []string
+}
+
+func newPlanCmd(cfg *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "plan",
+		Short:   planShort,
+		Long:    planLong,
+		Example: planExample,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			changesets, _ := cmd.Flags().GetStringSlice("changeset")
+
+			f := planFlags{
+				environment: flags.MustString(cmd.Flags().GetString("environment")),
+				changesets:  changesets,
+			}
+
+			return runPlan(cmd, cfg, f)
+		},
+	}
+
+	flags.Environment(cmd)
+	cmd.Flags().StringSliceP("changeset", "c", nil, "Only plan the given changesets and their prerequisites (repeatable)")
+
+	return cmd
+}
+
+func runPlan(cmd *cobra.Command, cfg *Config, f planFlags) error {
+	registry, err := cfg.LoadChangesets(f.environment)
+	if err != nil {
+		return fmt.Errorf("failed to load changesets registry: %w", err)
+	}
+
+	plan, err := registry.RunPlan(f.changesets...)
+	if err != nil {
+		return fmt.Errorf("failed to build run plan: %w", err)
+	}
+
+	artdir := cfg.Domain.EnvDir(f.environment).ArtifactsDir()
+	if err = artdir.SetDurablePipelines(strconv.FormatInt(time.Now().UnixNano(), 10)); err != nil {
+		return err
+	}
+
+	graph := registry.DependencyGraph()
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "\n=== Changeset Run Plan for %s/%s ===\n", cfg.Domain.String(), f.environment)
+
+	fmt.Fprintf(out, "\nDependency Graph:\n")
+	for _, key := range plan {
+		prereqs := graph[key]
+		if len(prereqs) == 0 {
+			fmt.Fprintf(out, "  %s\n", key)
+			continue
+		}
+		fmt.Fprintf(out, "  %s <- %s\n", key, strings.Join(prereqs, ", "))
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "\nRun Plan:\n")
+	fmt.Fprintf(w, "STEP\tCHANGESET\tSTATUS\n")
+	fmt.Fprintf(w, "----\t---------\t------\n")
+
+	for i, key := range plan {
+		applied, err := artdir.ChangesetArtifactsExist(key)
+		if err != nil {
+			return fmt.Errorf("check artifacts for %s: %w", key, err)
+		}
+
+		status := "PENDING"
+		if applied {
+			status = "APPLIED"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", i+1, key, status)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush tabwriter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package pipeline
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func newPlanTestConfig(t *testing.T, dom domain.Domain, register func(reg *changeset.ChangesetsRegistry)) *Config {
+	t.Helper()
+
+	return &Config{
+		Logger: logger.Test(t),
+		Domain: dom,
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			register(reg)
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+	}
+}
+
+func TestPlanCmd_Success(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "test")
+
+	// Mark the first changeset as applied by a previous durable pipeline run
+	artdir := dom.EnvDir("testnet").ArtifactsDir()
+	require.NoError(t, artdir.SetDurablePipelines("1234567890123456789"))
+	require.NoError(t, artdir.CreateChangesetDir("0001_deploy_ramps"))
+
+	cfg := newPlanTestConfig(t, dom, func(reg *changeset.ChangesetsRegistry) {
+		reg.Add("0002_configure_lanes", changeset.Configure(&stubChangeset{}).With(1),
+			changeset.WithPrerequisites("0001_deploy_ramps"),
+		)
+		reg.Add("0001_deploy_ramps", changeset.Configure(&stubChangeset{}).With(1))
+	})
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"plan", "--environment", "testnet"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := buf.String()
+	require.Contains(t, output, "Changeset Run Plan for test/testnet")
+	require.Contains(t, output, "0002_configure_lanes <- 0001_deploy_ramps")
+	require.Regexp(t, `1\s+0001_deploy_ramps\s+APPLIED`, output)
+	require.Regexp(t, `2\s+0002_configure_lanes\s+PENDING`, output)
+}
+
+func TestPlanCmd_SelectedChangeset(t *testing.T) {
+	t.Parallel()
+
+	cfg := newPlanTestConfig(t, domain.NewDomain(t.TempDir(), "test"), func(reg *changeset.ChangesetsRegistry) {
+		reg.Add("0001_deploy_ramps", changeset.Configure(&stubChangeset{}).With(1))
+		reg.Add("0002_unrelated", changeset.Configure(&stubChangeset{}).With(1))
+		reg.Add("0003_configure_lanes", changeset.Configure(&stubChangeset{}).With(1),
+			changeset.WithPrerequisites("0001_deploy_ramps"),
+		)
+	})
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"plan", "--environment", "testnet", "--changeset", "0003_configure_lanes"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := buf.String()
+	require.Regexp(t, `1\s+0001_deploy_ramps\s+PENDING`, output)
+	require.Regexp(t, `2\s+0003_configure_lanes\s+PENDING`, output)
+	require.NotContains(t, output, "0002_unrelated")
+}
+
+func TestPlanCmd_Cycle(t *testing.T) {
+	t.Parallel()
+
+	cfg := newPlanTestConfig(t, domain.NewDomain(t.TempDir(), "test"), func(reg *changeset.ChangesetsRegistry) {
+		reg.Add("a", changeset.Configure(&stubChangeset{}).With(1), changeset.WithPrerequisites("b"))
+		reg.Add("b", changeset.Configure(&stubChangeset{}).With(1), changeset.WithPrerequisites("a"))
+	})
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	cmd.SetArgs([]string{"plan", "--environment", "testnet"})
+
+	err = cmd.Execute()
+	require.ErrorIs(t, err, changeset.ErrDependencyCycle)
+	require.ErrorContains(t, err, "failed to build run plan")
+}
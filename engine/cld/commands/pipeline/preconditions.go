@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+const (
+	preconditionFormatTable = "table"
+	preconditionFormatJSON  = "json"
+)
+
+// validatePreconditionFormat returns an error if the format of the precondition findings is not
+// supported.
+func validatePreconditionFormat(format string) error {
+	switch format {
+	case preconditionFormatTable, preconditionFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid precondition format %q: must be %q or %q",
+			format, preconditionFormatTable, preconditionFormatJSON,
+		)
+	}
+}
+
+// collectPreconditionWarnings makes the precondition reports of the changesets applied to env hand
+// their warnings to the returned collector, so they can be printed with printPreconditionFindings.
+func collectPreconditionWarnings(env *fdeployment.Environment) *fdeployment.PreconditionWarnings {
+	warnings := &fdeployment.PreconditionWarnings{}
+
+	getContext := env.GetContext
+	if getContext == nil {
+		getContext = context.Background
+	}
+	env.GetContext = func() context.Context {
+		return fdeployment.ContextWithPreconditionWarnings(getContext(), warnings)
+	}
+
+	return warnings
+}
+
+// printPreconditionFindings prints the findings of a precondition failure returned by a changeset,
+// if err holds a *deployment.PreconditionError, so that every problem is reported at once. If err
+// holds none, the collected warnings of the preconditions which passed are printed instead.
+func printPreconditionFindings(w io.Writer, err error, warnings *fdeployment.PreconditionWarnings, format string) error {
+	var findings []fdeployment.PreconditionFinding
+	if perr, ok := fdeployment.AsPreconditionError(err); ok {
+		findings = perr.Findings
+	} else if warnings != nil {
+		findings = warnings.Findings()
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	if format == preconditionFormatJSON {
+		b, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal precondition findings: %w", err)
+		}
+		fmt.Fprintln(w, string(b))
+
+		return nil
+	}
+
+	var errCount int
+	for _, f := range findings {
+		if f.Severity == fdeployment.SeverityError {
+			errCount++
+		}
+	}
+	fmt.Fprintf(w, "\nPrecondition findings: %d error(s), %d total\n", errCount, len(findings))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "SEVERITY\tSCOPE\tPATH\tMESSAGE\n")
+	fmt.Fprintf(tw, "--------\t-----\t----\t-------\n")
+
+	for _, f := range findings {
+		path := f.Path
+		if path == "" {
+			path = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Severity, f.Scope, path, f.Message)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush tabwriter: %w", err)
+	}
+
+	return nil
+}
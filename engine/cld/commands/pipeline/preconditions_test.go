@@ -0,0 +1,262 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/environment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func newTestPreconditionError() error {
+	report := fdeployment.NewPreconditionReport()
+	report.ConfigError(fdeployment.FieldPath("chains", 0, "router"), "router address is required")
+	report.ConfigWarning("fee", "fee is unusually high")
+	report.EnvironmentError("", "timelock not found")
+
+	return report.Err()
+}
+
+func Test_printPreconditionFindings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, printPreconditionFindings(&buf, fmt.Errorf("apply: %w", newTestPreconditionError()), nil, preconditionFormatTable))
+
+		output := buf.String()
+		assert.Contains(t, output, "Precondition findings: 2 error(s), 3 total")
+		assert.Regexp(t, `error\s+config\s+chains\[0\]\.router\s+router address is required`, output)
+		assert.Regexp(t, `warning\s+config\s+fee\s+fee is unusually high`, output)
+		assert.Regexp(t, `error\s+environment\s+-\s+timelock not found`, output)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, printPreconditionFindings(&buf, newTestPreconditionError(), nil, preconditionFormatJSON))
+
+		var findings []fdeployment.PreconditionFinding
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &findings))
+		require.Len(t, findings, 3)
+		assert.Equal(t, fdeployment.PreconditionFinding{
+			Severity: fdeployment.SeverityError,
+			Scope:    fdeployment.ScopeConfig,
+			Path:     "chains[0].router",
+			Message:  "router address is required",
+		}, findings[0])
+	})
+
+	t.Run("not a precondition error", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, printPreconditionFindings(&buf, errors.New("boom"), &fdeployment.PreconditionWarnings{}, preconditionFormatTable))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("warnings of passed preconditions", func(t *testing.T) {
+		t.Parallel()
+
+		env := fdeployment.Environment{}
+		warnings := collectPreconditionWarnings(&env)
+
+		report := fdeployment.NewPreconditionReport()
+		report.ConfigWarning("fee", "fee is unusually high")
+		require.NoError(t, report.Result(env))
+
+		var buf bytes.Buffer
+		require.NoError(t, printPreconditionFindings(&buf, nil, warnings, preconditionFormatTable))
+
+		output := buf.String()
+		assert.Contains(t, output, "Precondition findings: 0 error(s), 1 total")
+		assert.Regexp(t, `warning\s+config\s+fee\s+fee is unusually high`, output)
+	})
+}
+
+func Test_validatePreconditionFormat(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validatePreconditionFormat("table"))
+	require.NoError(t, validatePreconditionFormat("json"))
+	require.ErrorContains(t, validatePreconditionFormat("yaml"), `invalid precondition format "yaml"`)
+}
+
+//nolint:paralleltest // changes the working directory
+func TestRunCmd_PreconditionFindings(t *testing.T) {
+	const env = "testnet"
+
+	workspaceRoot := t.TempDir()
+	testDomain := domain.NewDomain(filepath.Join(workspaceRoot, domain.DomainsDirName), "test")
+	inputsDir := filepath.Join(workspaceRoot, "domains", testDomain.String(), env, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	yamlContent := `environment: testnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload:
+        value: 1`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "test-input.yaml"), []byte(yamlContent), 0o600))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	cs := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, any) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(fdeployment.Environment, any) error { return newTestPreconditionError() },
+	)
+
+	cfg := &Config{
+		Logger: logger.Test(t),
+		Domain: testDomain,
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			reg.Add("0001_test_changeset", changeset.Configure(cs).WithEnvInput())
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+		Deps: Deps{
+			EnvironmentLoader: func(context.Context, domain.Domain, string, ...environment.LoadEnvironmentOption) (fdeployment.Environment, error) {
+				return fdeployment.Environment{}, nil
+			},
+		},
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{
+		"run",
+		"--environment", env,
+		"--input-file", "test-input.yaml",
+		"--precondition-format", "json",
+	})
+
+	err = cmd.Execute()
+	require.ErrorIs(t, err, fdeployment.ErrInvalidConfig)
+	require.ErrorIs(t, err, fdeployment.ErrInvalidEnvironment)
+
+	// The findings are printed as JSON before cobra's usage output
+	var findings []fdeployment.PreconditionFinding
+	require.NoError(t, json.NewDecoder(&buf).Decode(&findings))
+	assert.Len(t, findings, 3)
+}
+
+//nolint:paralleltest // changes the working directory
+func TestRunCmd_PreconditionWarnings(t *testing.T) {
+	const env = "testnet"
+
+	workspaceRoot := t.TempDir()
+	testDomain := domain.NewDomain(filepath.Join(workspaceRoot, domain.DomainsDirName), "test")
+	inputsDir := filepath.Join(workspaceRoot, "domains", testDomain.String(), env, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	yamlContent := `environment: testnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload:
+        value: 1`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "test-input.yaml"), []byte(yamlContent), 0o600))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	cs := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, any) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(e fdeployment.Environment, _ any) error {
+			report := fdeployment.NewPreconditionReport()
+			report.ConfigWarning("fee", "fee is unusually high")
+
+			return report.Result(e)
+		},
+	)
+
+	cfg := &Config{
+		Logger: logger.Test(t),
+		Domain: testDomain,
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			reg.Add("0001_test_changeset", changeset.Configure(cs).WithEnvInput())
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+		Deps: Deps{
+			EnvironmentLoader: func(context.Context, domain.Domain, string, ...environment.LoadEnvironmentOption) (fdeployment.Environment, error) {
+				return fdeployment.Environment{}, nil
+			},
+		},
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{
+		"run",
+		"--environment", env,
+		"--input-file", "test-input.yaml",
+		"--dry-run",
+	})
+
+	require.NoError(t, cmd.Execute())
+
+	// The warnings are printed although the preconditions passed
+	assert.Contains(t, buf.String(), "Precondition findings: 0 error(s), 1 total")
+	assert.Regexp(t, `warning\s+config\s+fee\s+fee is unusually high`, buf.String())
+}
+
+func TestRunCmd_InvalidPreconditionFormat(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Logger:                logger.Test(t),
+		Domain:                domain.NewDomain(t.TempDir(), "test"),
+		LoadChangesets:        func(string) (*changeset.ChangesetsRegistry, error) { return changeset.NewChangesetsRegistry(), nil },
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{
+		"run",
+		"--environment", "testnet",
+		"--input-file", "test-input.yaml",
+		"--precondition-format", "yaml",
+	})
+
+	require.ErrorContains(t, cmd.Execute(), `invalid precondition format "yaml"`)
+}
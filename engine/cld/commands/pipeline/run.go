@@ -29,6 +29,19 @@ var (
 
 		This command applies a changeset against the specified environment,
 		resolves any timelock proposals, and persists artifacts.
+
+		If the changeset preconditions fail with a deployment.PreconditionError, all
+		of its findings are printed, as a table or as JSON with --precondition-format.
+
+		Changesets which describe the address refs they create are skipped if all of
+		them already exist in the datastore, so that their contracts are not deployed
+		again. Use --force to apply them anyway.
+
+		With --dry-run, the changeset is applied against forks of the EVM chains,
+		read-only chains for families which cannot be forked, a read-only JD backend,
+		a read-only CRE runner and an in-memory datastore. Changeset hooks are not run.
+		Nothing is persisted; the artifacts and operations reports which would have been
+		written are printed instead.
 `
 
 	runExample = `
@@ -65,6 +78,11 @@ type runFlags struct {
 	dryRun         bool
 	inputFile      string
 	changesetIndex int
+	skipDSVerify   bool
+	// force applies the changeset even if it has already been applied.
+	force bool
+	// preconditionFormat is the format precondition findings are printed in: table or json.
+	preconditionFormat string
 }
 
 func newRunCmd(cfg *Config) *cobra.Command {
@@ -80,6 +98,10 @@ func newRunCmd(cfg *Config) *cobra.Command {
 				dryRun:         flags.MustBool(cmd.Flags().GetBool("dry-run")),
 				inputFile:      flags.MustString(cmd.Flags().GetString("input-file")),
 				changesetIndex: flags.MustInt(cmd.Flags().GetInt("changeset-index")),
+				skipDSVerify:   flags.MustBool(cmd.Flags().GetBool("skip-datastore-verification")),
+				force:          flags.MustBool(cmd.Flags().GetBool("force")),
+
+				preconditionFormat: flags.MustString(cmd.Flags().GetString("precondition-format")),
 			}
 
 			return runRun(cmd, cfg, f)
@@ -87,10 +109,13 @@ func newRunCmd(cfg *Config) *cobra.Command {
 	}
 
 	flags.Environment(cmd)
-	cmd.Flags().BoolP("dry-run", "d", false, "Use a read-only JD backend. WARNING: still uses real chain clients as of now!")
+	cmd.Flags().BoolP("dry-run", "d", false, "Run against forked (EVM) or read-only chains and a read-only JD backend, and print the artifacts instead of writing them")
 	cmd.Flags().StringP("changeset", "c", "", "changeset to apply by name")
 	cmd.Flags().StringP("input-file", "i", "", "YAML input file name. Not the full path, just the name")
 	cmd.Flags().IntP("changeset-index", "x", 0, "Index of changeset to run by position in array format input file")
+	cmd.Flags().Bool("skip-datastore-verification", false, "Run even if the datastore files do not match the datastore manifest")
+	cmd.Flags().String("precondition-format", preconditionFormatTable, "Format to print precondition findings in when the changeset preconditions fail: table or json")
+	cmd.Flags().Bool("force", false, "Apply the changeset even if all the address refs it would create already exist")
 
 	_ = cmd.MarkFlagRequired("input-file")
 	cmd.MarkFlagsMutuallyExclusive("changeset", "changeset-index")
@@ -129,6 +154,10 @@ func resolveChangesetForRun(
 }
 
 func runRun(cmd *cobra.Command, cfg *Config, f runFlags) error {
+	if err := validatePreconditionFormat(f.preconditionFormat); err != nil {
+		return err
+	}
+
 	envdir := cfg.Domain.EnvDir(f.environment)
 	artdir := envdir.ArtifactsDir()
 
@@ -172,6 +201,9 @@ func runRun(cmd *cobra.Command, cfg *Config, f runFlags) error {
 	reporter := operations.NewMemoryReporter(operations.WithReports(reports))
 
 	envOptions = append(envOptions, environment.WithReporter(reporter))
+	if f.skipDSVerify {
+		envOptions = append(envOptions, environment.WithoutDataStoreManifestVerification())
+	}
 	deps := cfg.deps()
 	env, err := deps.EnvironmentLoader(cmd.Context(), cfg.Domain, f.environment, envOptions...)
 	if err != nil {
@@ -186,12 +218,45 @@ func runRun(cmd *cobra.Command, cfg *Config, f runFlags) error {
 		cfg.Domain, actualChangesetName, indexStr, f.environment,
 	)
 
-	out, err := registry.Apply(actualChangesetName, env)
+	applyOpts := []changeset.ApplyOption{changeset.WithArtifactsChecker(artdir)}
+	if f.force {
+		applyOpts = append(applyOpts, changeset.WithForce())
+	}
+	if f.dryRun {
+		// Hooks may have side effects, such as notifications or audit logs, so they are not run
+		applyOpts = append(applyOpts, changeset.WithoutHooks())
+	}
+
+	warnings := collectPreconditionWarnings(&env)
+	out, err := registry.Apply(actualChangesetName, env, applyOpts...)
+	if errors.Is(err, changeset.ErrAlreadyApplied) {
+		cfg.Logger.Warnf("Skipping changeset %s: %v", actualChangesetName, err)
+		fmt.Fprintf(cmd.OutOrStdout(), "Changeset %s has already been applied, skipping. Use --force to apply it again.\n", actualChangesetName)
+
+		return nil
+	}
+
 	var saveErr error
-	if saveErr = dprun.SaveReports(reporter, originalReportsLen, cfg.Logger, artdir, actualChangesetName); saveErr != nil {
-		cfg.Logger.Errorf("failed to save reports: %v", saveErr)
+	if !f.dryRun {
+		if saveErr = dprun.SaveReports(reporter, originalReportsLen, cfg.Logger, artdir, actualChangesetName); saveErr != nil {
+			cfg.Logger.Errorf("failed to save reports: %v", saveErr)
+		}
+	}
+	if printErr := printPreconditionFindings(cmd.OutOrStdout(), err, warnings, f.preconditionFormat); printErr != nil {
+		cfg.Logger.Errorf("failed to print precondition findings: %v", printErr)
+	}
+	if printErr := printHookRecords(cmd.OutOrStdout(), out.HookRecords); printErr != nil {
+		cfg.Logger.Errorf("failed to print hook records: %v", printErr)
 	}
 	if err != nil {
+		// The output is not saved when the changeset fails, so the hook records are saved on
+		// their own to keep the hooks which failed
+		if !f.dryRun && len(out.HookRecords) > 0 {
+			if saveErr = artdir.AppendHookRecords(actualChangesetName, out.HookRecords); saveErr != nil {
+				cfg.Logger.Errorf("failed to save hook records: %v", saveErr)
+			}
+		}
+
 		return err
 	}
 	if saveErr != nil {
@@ -226,6 +291,15 @@ func runRun(cmd *cobra.Command, cfg *Config, f runFlags) error {
 		}
 	}
 
+	if f.dryRun {
+		latestReports, err := reporter.GetReports()
+		if err != nil {
+			return fmt.Errorf("failed to get operations reports: %w", err)
+		}
+
+		return printDryRunOutput(cmd.OutOrStdout(), actualChangesetName, out, latestReports[originalReportsLen:])
+	}
+
 	if err := artdir.SaveChangesetOutput(actualChangesetName, out); err != nil {
 		cfg.Logger.Errorf("failed to save changeset artifacts: %v", err)
 		return err
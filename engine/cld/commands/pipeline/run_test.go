@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 	chainsel "github.com/smartcontractkit/chain-selectors"
@@ -19,6 +21,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
 	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
@@ -550,6 +553,7 @@ func TestRunCmd_SubcommandExists(t *testing.T) {
 			require.NotNil(t, c.Flags().Lookup("environment"))
 			require.NotNil(t, c.Flags().Lookup("changeset"))
 			require.NotNil(t, c.Flags().Lookup("input-file"))
+			require.NotNil(t, c.Flags().Lookup("skip-datastore-verification"))
 
 			break
 		}
@@ -644,7 +648,6 @@ changesets:
 		"--environment", env,
 		"--changeset", changesetName,
 		"--input-file", yamlFileName,
-		"--dry-run",
 	})
 
 	err = cmd.Execute()
@@ -797,7 +800,6 @@ changesets:
 				"--environment", env,
 				"--changeset", changesetName,
 				"--input-file", yamlFileName,
-				"--dry-run",
 			})
 
 			err = cmd.Execute()
@@ -941,6 +943,117 @@ changesets:
 	require.ErrorContains(t, err, "--changeset (-c) or --changeset-index (-x)")
 }
 
+//nolint:paralleltest
+func TestRunCmd_DryRun(t *testing.T) {
+	generateStableUUIDs(t)
+
+	const (
+		env           = "testnet"
+		changesetName = "0001_test_changeset"
+		yamlFileName  = "test-input.yaml"
+	)
+
+	workspaceRoot := t.TempDir()
+	domainsRoot := filepath.Join(workspaceRoot, "domains")
+	testDomain := domain.NewDomain(domainsRoot, "test")
+	envRoot := filepath.Join(domainsRoot, testDomain.String(), env)
+	inputsDir := filepath.Join(envRoot, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	yamlContent := `environment: testnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload:
+        chain: optimism_sepolia`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, yamlFileName), []byte(yamlContent), 0o600))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	t.Setenv("DURABLE_PIPELINE_INPUT", `{"payload":{"chain":"optimism_sepolia"}}`)
+
+	cs := fdeployment.CreateChangeSet(
+		func(e fdeployment.Environment, _ any) (fdeployment.ChangesetOutput, error) {
+			ds := fdatastore.NewMemoryDataStore()
+			if addErr := ds.Addresses().Add(fdatastore.AddressRef{
+				Address:       "0xrouter",
+				ChainSelector: chainsel.GETH_TESTNET.Selector,
+				Type:          "Router",
+				Version:       semver.MustParse("1.0.0"),
+			}); addErr != nil {
+				return fdeployment.ChangesetOutput{}, addErr
+			}
+
+			return fdeployment.ChangesetOutput{
+				DataStore:             ds,
+				MCMSTimelockProposals: []mcms.TimelockProposal{*testProposal},
+			}, nil
+		},
+		func(fdeployment.Environment, any) error { return nil },
+	)
+
+	// Hooks may have side effects, so they are not run in a dry run
+	hookRan := false
+	cfg := &Config{
+		Logger: logger.Test(t),
+		Domain: testDomain,
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			reg.Add(changesetName, changeset.Configure(cs).WithEnvInput())
+			reg.AddGlobalPreHooks(changeset.PreHook{
+				HookDefinition: changeset.HookDefinition{Name: "notify"},
+				Func: func(context.Context, changeset.PreHookParams) error {
+					hookRan = true
+					return nil
+				},
+			})
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+		Deps: Deps{
+			EnvironmentLoader: func(context.Context, domain.Domain, string, ...environment.LoadEnvironmentOption) (fdeployment.Environment, error) {
+				return fdeployment.Environment{}, nil
+			},
+			TimelockDelayCorrector: func(context.Context, logger.Logger, chain.BlockChains, []mcms.TimelockProposal) error {
+				return nil
+			},
+		},
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{
+		"run",
+		"--environment", env,
+		"--changeset", changesetName,
+		"--input-file", yamlFileName,
+		"--dry-run",
+	})
+
+	require.NoError(t, cmd.Execute())
+
+	output := buf.String()
+	require.Contains(t, output, "Dry run of 0001_test_changeset: nothing was written")
+	require.Contains(t, output, "Operations reports: 0 new")
+	require.Contains(t, output, "datastore:")
+	require.Contains(t, output, "0xrouter")
+	require.Contains(t, output, "mcms_timelock_proposal[0]:")
+	require.Contains(t, output, "changeset:c00e5d67-c275-4389-aded-7d8b151cbd5b")
+
+	require.False(t, hookRan)
+
+	// Nothing is persisted
+	require.NoDirExists(t, filepath.Join(envRoot, "proposals"))
+	require.NoDirExists(t, filepath.Join(envRoot, domain.ArtifactsDirName))
+}
+
 // ----- shared test data -----
 
 var testProposal = lo.Must(mcms.NewTimelockProposalBuilder().
@@ -967,3 +1080,89 @@ func generateStableUUIDs(t *testing.T) {
 	uuid.SetRand(rand.New(rand.NewSource(1234))) //nolint:gosec // not used for security purposes
 	t.Cleanup(func() { uuid.SetRand(nil) })
 }
+
+//nolint:paralleltest // changes the working directory
+func TestRunCmd_AlreadyApplied(t *testing.T) {
+	const env = "testnet"
+
+	workspaceRoot := t.TempDir()
+	testDomain := domain.NewDomain(filepath.Join(workspaceRoot, domain.DomainsDirName), "test")
+	inputsDir := filepath.Join(workspaceRoot, "domains", testDomain.String(), env, "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	yamlContent := `environment: testnet
+domain: test
+changesets:
+  - 0001_test_changeset:
+      payload: {}`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "test-input.yaml"), []byte(yamlContent), 0o600))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(workspaceRoot))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	key := fdatastore.NewAddressRefKey(1, "Router", semver.MustParse("1.0.0"), "")
+	ds := fdatastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(fdatastore.AddressRef{
+		Address: "0x1", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+
+	changesetStub := &stubChangeset{}
+	cs := fdeployment.WithExpectedAddressRefs[any](changesetStub,
+		func(fdeployment.Environment, any) ([]fdatastore.AddressRefKey, error) {
+			return []fdatastore.AddressRefKey{key}, nil
+		},
+	)
+
+	newCmd := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+
+		cfg := &Config{
+			Logger: logger.Test(t),
+			Domain: testDomain,
+			LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+				reg := changeset.NewChangesetsRegistry()
+				reg.Add("0001_test_changeset", changeset.Configure(cs).WithEnvInput())
+
+				return reg, nil
+			},
+			ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+			Deps: Deps{
+				EnvironmentLoader: func(context.Context, domain.Domain, string, ...environment.LoadEnvironmentOption) (fdeployment.Environment, error) {
+					return fdeployment.Environment{DataStore: ds.Seal()}, nil
+				},
+			},
+		}
+
+		cmd, err := NewCommand(cfg)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs(append([]string{
+			"run",
+			"--environment", env,
+			"--input-file", "test-input.yaml",
+			"--dry-run",
+		}, args...))
+
+		err = cmd.Execute()
+
+		return buf.String(), err
+	}
+
+	t.Run("skipped", func(t *testing.T) {
+		output, err := newCmd(t)
+		require.NoError(t, err)
+		require.Contains(t, output, "Changeset 0001_test_changeset has already been applied, skipping. Use --force to apply it again.")
+		require.False(t, changesetStub.ApplyCalled)
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		output, err := newCmd(t, "--force")
+		require.NoError(t, err)
+		require.NotContains(t, output, "has already been applied")
+		require.True(t, changesetStub.ApplyCalled)
+	})
+}
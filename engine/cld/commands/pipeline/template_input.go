@@ -2,11 +2,13 @@ package pipeline
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/pipeline/input"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/pipeline/template"
 )
 
@@ -19,6 +21,9 @@ var (
 		This command helps create YAML input files by analyzing Go struct types
 		from changesets and generating properly formatted YAML templates with
 		example values and comments.
+
+		With --schema-output, a JSON Schema of the input file is also written, and
+		referenced from the template so that editors can validate and autocomplete it.
 	`
 
 	templateInputExample = `
@@ -42,13 +47,20 @@ var (
 		chainlink-deployments durable-pipeline template-input \
 		  --environment testnet \
 		  --changeset test_changeset_dynamic_inputs > example.yaml
+
+		# Save output to file, with a JSON Schema next to it
+		chainlink-deployments durable-pipeline template-input \
+		  --environment testnet \
+		  --changeset test_changeset_dynamic_inputs \
+		  --schema-output example.schema.json > example.yaml
 	`
 )
 
 type templateInputFlags struct {
-	environment string
-	changeset   string
-	depthLimit  int
+	environment  string
+	changeset    string
+	depthLimit   int
+	schemaOutput string
 }
 
 func newTemplateInputCmd(cfg *Config) *cobra.Command {
@@ -59,9 +71,10 @@ func newTemplateInputCmd(cfg *Config) *cobra.Command {
 		Example: templateInputExample,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			f := templateInputFlags{
-				environment: flags.MustString(cmd.Flags().GetString("environment")),
-				changeset:   flags.MustString(cmd.Flags().GetString("changeset")),
-				depthLimit:  flags.MustInt(cmd.Flags().GetInt("depth")),
+				environment:  flags.MustString(cmd.Flags().GetString("environment")),
+				changeset:    flags.MustString(cmd.Flags().GetString("changeset")),
+				depthLimit:   flags.MustInt(cmd.Flags().GetInt("depth")),
+				schemaOutput: flags.MustString(cmd.Flags().GetString("schema-output")),
 			}
 
 			return runTemplateInput(cmd, cfg, f)
@@ -71,6 +84,7 @@ func newTemplateInputCmd(cfg *Config) *cobra.Command {
 	flags.Environment(cmd)
 	cmd.Flags().StringP("changeset", "c", "", "Changeset name(s) to generate YAML template for - comma-separated for multiple (required)")
 	cmd.Flags().IntP("depth", "d", 5, "Maximum recursion depth generation for nested struct, configure this based on your struct complexity")
+	cmd.Flags().String("schema-output", "", "JSON Schema output file path, referenced from the template (optional)")
 
 	_ = cmd.MarkFlagRequired("changeset")
 
@@ -100,6 +114,24 @@ func runTemplateInput(cmd *cobra.Command, cfg *Config, f templateInputFlags) err
 		return fmt.Errorf("generate YAML template: %w", err)
 	}
 
+	if f.schemaOutput != "" {
+		payloadTypes, err := input.ChangesetPayloadTypes(registry, changesetNames, false)
+		if err != nil {
+			return err
+		}
+
+		schema, err := input.GenerateSchema(payloadTypes)
+		if err != nil {
+			return fmt.Errorf("generate JSON schema: %w", err)
+		}
+
+		if err = os.WriteFile(f.schemaOutput, schema, 0o644); err != nil { //nolint:gosec
+			return fmt.Errorf("write schema file: %w", err)
+		}
+
+		yamlTemplate = input.SchemaModeline(f.schemaOutput) + yamlTemplate
+	}
+
 	fmt.Fprint(cmd.OutOrStdout(), yamlTemplate)
 
 	return nil
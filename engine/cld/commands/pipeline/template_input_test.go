@@ -3,6 +3,9 @@ package pipeline
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
 
 	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
 )
@@ -59,6 +63,55 @@ func TestTemplateInputCmd_Success(t *testing.T) {
 	require.Contains(t, buf.String(), "payload:")
 }
 
+func TestTemplateInputCmd_SchemaOutput(t *testing.T) {
+	t.Parallel()
+
+	type templateConfig struct {
+		Router string `json:"router"`
+	}
+	cs := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, templateConfig) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(fdeployment.Environment, templateConfig) error { return nil },
+	)
+
+	cfg := &Config{
+		Logger: logger.Test(t),
+		Domain: domain.NewDomain(t.TempDir(), "test"),
+		LoadChangesets: func(string) (*changeset.ChangesetsRegistry, error) {
+			reg := changeset.NewChangesetsRegistry()
+			reg.Add("0001_test_cs", changeset.Configure(cs).WithEnvInput())
+
+			return reg, nil
+		},
+		ConfigResolverManager: fresolvers.NewConfigResolverManager(),
+	}
+
+	cmd, err := NewCommand(cfg)
+	require.NoError(t, err)
+
+	schemaPath := filepath.Join(t.TempDir(), "input.schema.json")
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{
+		"template-input",
+		"--environment", "testnet",
+		"--changeset", "0001_test_cs",
+		"--schema-output", schemaPath,
+	})
+
+	require.NoError(t, cmd.Execute())
+	require.True(t, strings.HasPrefix(buf.String(), "# yaml-language-server: $schema="+filepath.ToSlash(schemaPath)+"\n"))
+	require.Contains(t, buf.String(), "router:")
+
+	schema, err := os.ReadFile(schemaPath)
+	require.NoError(t, err)
+	require.Contains(t, string(schema), `"0001_test_cs"`)
+	require.Contains(t, string(schema), `"router"`)
+}
+
 func TestTemplateInputCmd_MultipleChangesets(t *testing.T) {
 	t.Parallel()
 
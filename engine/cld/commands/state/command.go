@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
 	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
@@ -32,9 +33,17 @@ type Config struct {
 	Domain domain.Domain
 
 	// ViewState is the function that generates state from an environment.
-	// This is domain-specific and must be provided by the user.
+	// This is domain-specific and must be provided by the user, unless ChainView is set.
 	ViewState ViewStateFunc
 
+	// ChainView generates the view of a single chain. When set instead of ViewState, state is
+	// generated with deployment.NewIncrementalViewState, which only regenerates the chains whose
+	// datastore records or latest block changed since the previous state.
+	ChainView fdeployment.ChainViewFunc
+
+	// ChainViewOptions configures the incremental state generated with ChainView. Optional.
+	ChainViewOptions []fdeployment.IncrementalViewOption
+
 	// Deps holds optional dependencies that can be overridden.
 	// If fields are nil, production defaults are used.
 	Deps Deps
@@ -51,7 +60,7 @@ func (c Config) Validate() error {
 	if c.Domain.RootPath() == "" {
 		missing = append(missing, "Domain")
 	}
-	if c.ViewState == nil {
+	if c.ViewState == nil && c.ChainView == nil {
 		missing = append(missing, "ViewState")
 	}
 
@@ -59,6 +68,10 @@ func (c Config) Validate() error {
 		return errors.New("state.Config: missing required fields: " + strings.Join(missing, ", "))
 	}
 
+	if c.ViewState != nil && c.ChainView != nil {
+		return errors.New("state.Config: ViewState and ChainView are mutually exclusive")
+	}
+
 	return nil
 }
 
@@ -8,9 +8,13 @@ import (
 	"os"
 	"testing"
 
+	chainsel "github.com/smartcontractkit/chain-selectors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/environment"
@@ -498,6 +502,90 @@ func TestGenerate_InvalidDatastoreFlag(t *testing.T) {
 	require.ErrorContains(t, execErr, `--datastore must be "file" or "catalog"`)
 }
 
+// TestGenerate_ChainView verifies that state generated per chain reuses the chain views of the
+// previous state.
+func TestGenerate_ChainView(t *testing.T) {
+	t.Parallel()
+
+	selector := chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector
+	var viewCalls int
+	var saved json.Marshaler
+
+	cmd, err := NewCommand(Config{
+		Logger: logger.Nop(),
+		Domain: domain.NewDomain("/tmp", "testdomain"),
+		ChainView: func(_ fdeployment.Environment, _ uint64, _ json.RawMessage) (json.Marshaler, error) {
+			viewCalls++
+			return json.RawMessage(`{"router":"0x1"}`), nil
+		},
+		Deps: Deps{
+			EnvironmentLoader: func(ctx context.Context, _ domain.Domain, envKey string, _ ...environment.LoadEnvironmentOption) (fdeployment.Environment, error) {
+				return fdeployment.Environment{
+					Name:        envKey,
+					GetContext:  func() context.Context { return ctx },
+					BlockChains: chain.NewBlockChainsFromSlice([]chain.BlockChain{evm.Chain{Selector: selector}}),
+					DataStore:   datastore.NewMemoryDataStore().Seal(),
+				}, nil
+			},
+			StateLoader: func(_ domain.EnvDir) (domain.JSONSerializer, error) {
+				if saved == nil {
+					return nil, os.ErrNotExist
+				}
+				b, err := saved.MarshalJSON()
+				raw := json.RawMessage(b)
+
+				return &raw, err
+			},
+			StateSaver: func(_ domain.EnvDir, _ string, state json.Marshaler) error {
+				saved = state
+				return nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+
+	cmd.SetArgs([]string{"generate", "-e", "staging", "-p", "--print=false"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 1, viewCalls)
+
+	// The latest block of the chain is unknown, so the chain view is regenerated
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 2, viewCalls)
+
+	// The datastore did not change, so the chain view is reused when new blocks are ignored
+	cmd.SetArgs([]string{"generate", "-e", "staging", "-p", "--print=false", "--ignore-new-blocks"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 2, viewCalls)
+
+	b, err := saved.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"router":"0x1"`)
+}
+
+// TestGenerate_IgnoreNewBlocksRequiresChainView verifies that --ignore-new-blocks is rejected for
+// state which is not generated per chain.
+func TestGenerate_IgnoreNewBlocksRequiresChainView(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewCommand(Config{
+		Logger: logger.Nop(),
+		Domain: domain.NewDomain("/tmp", "testdomain"),
+		ViewState: func(_ fdeployment.Environment, _ json.Marshaler) (json.Marshaler, error) {
+			return &mockState{Data: map[string]any{}}, nil
+		},
+	})
+	require.NoError(t, err)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"generate", "-e", "staging", "--ignore-new-blocks"})
+	require.ErrorContains(t, cmd.Execute(), "--ignore-new-blocks requires the domain to generate its state per chain")
+}
+
 // TestGenerate_StateSaveError verifies error handling.
 func TestGenerate_StateSaveError(t *testing.T) {
 	t.Parallel()
@@ -561,6 +649,23 @@ func TestConfig_Validate(t *testing.T) {
 		require.EqualError(t, err, "state.Config: missing required fields: ViewState")
 	})
 
+	t.Run("ViewState and ChainView", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := Config{
+			Logger: logger.Nop(),
+			Domain: domain.NewDomain(t.TempDir(), "test"),
+			ViewState: func(_ fdeployment.Environment, _ json.Marshaler) (json.Marshaler, error) {
+				return json.RawMessage(`{}`), nil
+			},
+			ChainView: func(_ fdeployment.Environment, _ uint64, _ json.RawMessage) (json.Marshaler, error) {
+				return json.RawMessage(`{}`), nil
+			},
+		}
+
+		require.EqualError(t, cfg.Validate(), "state.Config: ViewState and ChainView are mutually exclusive")
+	})
+
 	t.Run("valid config", func(t *testing.T) {
 		t.Parallel()
 
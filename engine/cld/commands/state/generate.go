@@ -3,13 +3,16 @@ package state
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/flags"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/commands/text"
 	cfgdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/domain"
@@ -47,6 +50,11 @@ var (
 
 		Use --datastore file or --datastore catalog to override the datastore
 		source; when omitted, the setting from domain.yaml is used.
+
+		When the domain generates its state per chain, only the chains whose datastore
+		records changed or which produced a new block since the previous state are
+		regenerated. Use --ignore-new-blocks to only regenerate the chains whose datastore
+		records changed.
 	`)
 
 	generateExample = text.Examples(`
@@ -64,16 +72,20 @@ var (
 
 		# Generate using previous state for incremental updates
 		myapp state generate -e mainnet -p --prev /path/to/old-state.json
+
+		# Only regenerate the chains whose datastore records changed since the previous state
+		myapp state generate -e mainnet -p --ignore-new-blocks
 	`)
 )
 
 type generateFlags struct {
-	environment   string
-	persist       bool
-	output        string
-	previousState string
-	print         bool
-	datastore     string // "file", "catalog", or empty for domain default
+	environment     string
+	persist         bool
+	output          string
+	previousState   string
+	print           bool
+	datastore       string // "file", "catalog", or empty for domain default
+	ignoreNewBlocks bool   // only regenerate the chain views whose datastore records changed
 }
 
 // newGenerateCmd creates the "generate" subcommand for generating state.
@@ -85,12 +97,13 @@ func newGenerateCmd(cfg Config) *cobra.Command {
 		Example: generateExample,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			f := generateFlags{
-				environment:   flags.MustString(cmd.Flags().GetString("environment")),
-				persist:       flags.MustBool(cmd.Flags().GetBool("persist")),
-				output:        flags.MustString(cmd.Flags().GetString("out")),
-				previousState: flags.MustString(cmd.Flags().GetString("prev")),
-				print:         flags.MustBool(cmd.Flags().GetBool("print")),
-				datastore:     flags.MustString(cmd.Flags().GetString("datastore")),
+				environment:     flags.MustString(cmd.Flags().GetString("environment")),
+				persist:         flags.MustBool(cmd.Flags().GetBool("persist")),
+				output:          flags.MustString(cmd.Flags().GetString("out")),
+				previousState:   flags.MustString(cmd.Flags().GetString("prev")),
+				print:           flags.MustBool(cmd.Flags().GetBool("print")),
+				datastore:       flags.MustString(cmd.Flags().GetString("datastore")),
+				ignoreNewBlocks: flags.MustBool(cmd.Flags().GetBool("ignore-new-blocks")),
 			}
 
 			return runGenerate(cmd, cfg, f)
@@ -106,6 +119,7 @@ func newGenerateCmd(cfg Config) *cobra.Command {
 	cmd.Flags().BoolP("persist", "p", false, "Persist state to disk")
 	cmd.Flags().StringP("prev", "s", "", "Previous state file path")
 	cmd.Flags().String("datastore", "", "Datastore to use: file or catalog. Defaults to domain.yaml setting when unset.")
+	cmd.Flags().Bool("ignore-new-blocks", false, "Only regenerate the chain views whose datastore records changed (per chain state only)")
 
 	// Deprecated alias: --previousState -> --prev
 	addPreviousStateAlias(cmd)
@@ -127,6 +141,17 @@ func runGenerate(cmd *cobra.Command, cfg Config, f generateFlags) error {
 		return fmt.Errorf("--datastore must be %q or %q, got %q", "file", "catalog", datastoreFlag)
 	}
 
+	viewState := cfg.ViewState
+	if cfg.ChainView != nil {
+		opts := slices.Clone(cfg.ChainViewOptions)
+		if f.ignoreNewBlocks {
+			opts = append(opts, fdeployment.WithChainVersion(nil))
+		}
+		viewState = fdeployment.NewIncrementalViewState(cfg.ChainView, opts...)
+	} else if f.ignoreNewBlocks {
+		return errors.New("--ignore-new-blocks requires the domain to generate its state per chain")
+	}
+
 	deps := cfg.deps()
 	envdir := cfg.Domain.EnvDir(envKey)
 	viewTimeout := 10 * time.Minute
@@ -169,7 +194,7 @@ func runGenerate(cmd *cobra.Command, cfg Config, f generateFlags) error {
 		}
 	}
 
-	state, err := cfg.ViewState(env, prevState)
+	state, err := viewState(env, prevState)
 	if err != nil {
 		return fmt.Errorf("unable to snapshot state: %w", err)
 	}
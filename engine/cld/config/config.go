@@ -33,6 +33,10 @@ type Config struct {
 
 	// DatastoreType specifies the type of datastore to use (either "file" or "catalog").
 	DatastoreType cfgdomain.DatastoreType
+
+	// TrustedManifestSigners are the signers trusted to sign the datastore manifest. When set, the
+	// manifest of a file-based datastore must be signed by one of them.
+	TrustedManifestSigners []string
 }
 
 // Load loads and consolidates all configuration required for a domain environment, including
@@ -63,10 +67,16 @@ func Load(dom fdomain.Domain, env string, lggr logger.Logger) (*Config, error) {
 		return nil, fmt.Errorf("failed to load datastore type config: %w", err)
 	}
 
+	trustedSigners, err := LoadTrustedManifestSigners(dom, env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load datastore manifest config: %w", err)
+	}
+
 	return &Config{
-		Networks:      networks,
-		Env:           envCfg,
-		Jira:          jiraCfg,
-		DatastoreType: datastoreType,
+		Networks:               networks,
+		Env:                    envCfg,
+		Jira:                   jiraCfg,
+		DatastoreType:          datastoreType,
+		TrustedManifestSigners: trustedSigners,
 	}, nil
 }
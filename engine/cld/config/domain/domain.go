@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/jira"
+	fdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
 )
 
 // DatastoreType represents the type of datastore to use for persisting deployment data.
@@ -94,11 +95,44 @@ func (cfg *BinaryConfig) validate() error {
 	return fmt.Errorf("invalid binary provider: %s (must be 'source' or 's3')", cfg.Provider)
 }
 
+// DatastoreManifestConfig represents the datastore manifest verification configuration for an
+// environment.
+type DatastoreManifestConfig struct {
+	// TrustedSigners are the hex encoded ed25519 public keys or EVM addresses which may sign the
+	// datastore manifest. When set, the manifest must be signed by one of them.
+	TrustedSigners []string `mapstructure:"trusted_signers" yaml:"trusted_signers,omitempty"`
+}
+
+func (cfg *DatastoreManifestConfig) validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	for i, signer := range cfg.TrustedSigners {
+		if err := fdomain.ValidateManifestSigner(signer); err != nil {
+			return fmt.Errorf("datastore_manifest.trusted_signers[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
 // Environment represents a single environment configuration.
 type Environment struct {
-	NetworkTypes []string      `mapstructure:"network_types" yaml:"network_types"`
-	Datastore    DatastoreType `mapstructure:"datastore" yaml:"datastore"`
-	CRE          *CREConfig    `mapstructure:"cre" yaml:"cre,omitempty"`
+	NetworkTypes      []string                 `mapstructure:"network_types" yaml:"network_types"`
+	Datastore         DatastoreType            `mapstructure:"datastore" yaml:"datastore"`
+	DatastoreManifest *DatastoreManifestConfig `mapstructure:"datastore_manifest" yaml:"datastore_manifest,omitempty"`
+	CRE               *CREConfig               `mapstructure:"cre" yaml:"cre,omitempty"`
+}
+
+// TrustedManifestSigners returns the signers trusted to sign the datastore manifest of the
+// environment, or nil if none are configured.
+func (e *Environment) TrustedManifestSigners() []string {
+	if e.DatastoreManifest == nil {
+		return nil
+	}
+
+	return e.DatastoreManifest.TrustedSigners
 }
 
 // creDefaultRegistries returns the CRE default registries when CRE is enabled,
@@ -138,6 +172,10 @@ func (e *Environment) validate() error {
 		return fmt.Errorf("invalid datastore value: %s (must be 'file', 'catalog', or 'all')", e.Datastore)
 	}
 
+	if err := e.DatastoreManifest.validate(); err != nil {
+		return err
+	}
+
 	for i, r := range e.creDefaultRegistries() {
 		if err := r.Validate(); err != nil {
 			return fmt.Errorf("cre.default_registries[%d]: %w", i, err)
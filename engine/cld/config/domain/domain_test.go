@@ -182,6 +182,25 @@ func TestEnvironment_Validate(t *testing.T) {
 			},
 			wantErr: "invalid datastore value: invalid (must be 'file', 'catalog', or 'all')",
 		},
+		{
+			name: "valid datastore manifest trusted signers",
+			environment: Environment{
+				NetworkTypes: []string{"testnet"},
+				DatastoreManifest: &DatastoreManifestConfig{
+					TrustedSigners: []string{"0x0000000000000000000000000000000000000001"},
+				},
+			},
+		},
+		{
+			name: "invalid datastore manifest trusted signer",
+			environment: Environment{
+				NetworkTypes: []string{"testnet"},
+				DatastoreManifest: &DatastoreManifestConfig{
+					TrustedSigners: []string{"abc"},
+				},
+			},
+			wantErr: `datastore_manifest.trusted_signers[0]: invalid manifest signer "abc": must be a hex encoded ed25519 public key or an EVM address`,
+		},
 	}
 
 	for _, tt := range tests {
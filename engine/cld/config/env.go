@@ -26,6 +26,22 @@ func LoadDatastoreType(dom fdomain.Domain, env string) (cfgdomain.DatastoreType,
 	return envConfig.Datastore, nil
 }
 
+// LoadTrustedManifestSigners retrieves the signers trusted to sign the datastore manifest for a
+// given domain and environment from the domain configuration file.
+func LoadTrustedManifestSigners(dom fdomain.Domain, env string) ([]string, error) {
+	domainCfg, err := cfgdomain.Load(dom.ConfigDomainFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domain config: %w", err)
+	}
+
+	envConfig, exists := domainCfg.Environments[env]
+	if !exists {
+		return nil, fmt.Errorf("environment %s not found in domain config", env)
+	}
+
+	return envConfig.TrustedManifestSigners(), nil
+}
+
 // LoadEnvConfig retrieves the environment configuration for a given domain and environment.
 //
 // Loading strategy:
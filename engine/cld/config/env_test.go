@@ -295,3 +295,61 @@ func Test_LoadDatastoreType(t *testing.T) {
 		})
 	}
 }
+
+func Test_LoadTrustedManifestSigners(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		domainYAML string
+		want       []string
+		wantErr    string
+	}{
+		{
+			name: "loads trusted signers",
+			domainYAML: `environments:
+  staging_testnet:
+    network_types:
+      - testnet
+    datastore_manifest:
+      trusted_signers:
+        - "0x0000000000000000000000000000000000000001"
+`,
+			want: []string{"0x0000000000000000000000000000000000000001"},
+		},
+		{
+			name: "no trusted signers",
+			domainYAML: `environments:
+  staging_testnet:
+    network_types:
+      - testnet
+`,
+		},
+		{
+			name: "fails when environment not found in domain config",
+			domainYAML: `environments:
+  production:
+    network_types:
+      - mainnet
+`,
+			wantErr: "environment staging_testnet not found in domain config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dom, envKey := setupConfigDirs(t)
+			require.NoError(t, os.WriteFile(dom.ConfigDomainFilePath(), []byte(tt.domainYAML), filePerms))
+
+			got, err := LoadTrustedManifestSigners(dom, envKey)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	aptoslib "github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	aptoscrypto "github.com/aptos-labs/aptos-go-sdk/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/aptos"
+)
+
+var (
+	// errAptosSigningDisabled is returned when signing with the deployer of a simulated Aptos chain.
+	errAptosSigningDisabled = errors.New("signing is disabled while estimating cost, transactions are simulated")
+	// errAptosSubmitDisabled is returned when submitting signed transactions to a simulated Aptos
+	// chain, since only transactions built by BuildSignAndSubmitTransaction can be simulated.
+	errAptosSubmitDisabled = errors.New("submitting signed transactions is disabled while estimating cost, use BuildSignAndSubmitTransaction")
+)
+
+// aptosTracker collects the cost of the transactions sent to an Aptos chain. Aptos chains cannot
+// be forked, so the transactions are simulated instead of submitted, and their cost is the gas used
+// times the gas unit price of the simulation.
+//
+// Simulations do not change the chain state, so a transaction which depends on an earlier
+// transaction of the same run, such as a call to a module it published, may fail to simulate. Its
+// gas is still collected, and it is counted in the simulation failures of the report.
+type aptosTracker struct {
+	selector uint64
+
+	mu        sync.Mutex
+	txCount   int
+	failures  int
+	gasUsed   uint64
+	fee       *big.Int
+	simulated map[api.Hash]*api.UserTransaction
+}
+
+func newAptosTracker(c aptos.Chain) (*aptosTracker, error) {
+	if c.Client == nil {
+		return nil, errors.New("chain client is not set")
+	}
+	if c.DeployerSigner == nil {
+		return nil, errors.New("deployer signer is not set")
+	}
+
+	return &aptosTracker{
+		selector:  c.Selector,
+		fee:       new(big.Int),
+		simulated: make(map[api.Hash]*api.UserTransaction),
+	}, nil
+}
+
+// chain returns a copy of the chain whose client simulates the transactions instead of submitting
+// them. The deployer signer still identifies the deployer, but refuses to sign.
+func (t *aptosTracker) chain(c aptos.Chain) aptos.Chain {
+	confirm := c.Confirm
+
+	c.Client = &aptosSimulatingClient{AptosRpcClient: c.Client, tracker: t}
+	c.DeployerSigner = aptosSimulationSigner{TransactionSigner: c.DeployerSigner}
+	c.Confirm = func(txHash string, opts ...any) error {
+		// Failed simulations are counted in the report instead of failing the changeset
+		if _, ok := t.lookup(txHash); ok {
+			return nil
+		}
+		if confirm == nil {
+			return fmt.Errorf("transaction %s was not simulated", txHash)
+		}
+
+		return confirm(txHash, opts...)
+	}
+
+	return c
+}
+
+// record adds the cost of a simulated transaction, and returns the response of submitting it.
+func (t *aptosTracker) record(tx *api.UserTransaction) *api.SubmitTransactionResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.txCount++
+	if !tx.Success {
+		t.failures++
+	}
+	t.gasUsed += tx.GasUsed
+	t.fee.Add(t.fee, new(big.Int).Mul(new(big.Int).SetUint64(tx.GasUsed), new(big.Int).SetUint64(tx.GasUnitPrice)))
+	t.simulated[tx.Hash] = tx
+
+	return &api.SubmitTransactionResponse{
+		Hash:                    tx.Hash,
+		Sender:                  tx.Sender,
+		SequenceNumber:          tx.SequenceNumber,
+		MaxGasAmount:            tx.MaxGasAmount,
+		GasUnitPrice:            tx.GasUnitPrice,
+		ExpirationTimestampSecs: tx.ExpirationTimestampSecs,
+		Payload:                 tx.Payload,
+		Signature:               tx.Signature,
+	}
+}
+
+// lookup returns the simulated transaction with the hash.
+func (t *aptosTracker) lookup(hash string) (*api.UserTransaction, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tx, ok := t.simulated[hash]
+
+	return tx, ok
+}
+
+func (t *aptosTracker) collect(_ context.Context) (ChainCost, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := newChainCost(t.selector, chainsel.FamilyAptos, t.txCount, t.gasUsed, new(big.Int).Set(t.fee))
+	c.SimulationFailures = t.failures
+
+	return c, nil
+}
+
+// aptosSimulatingClient is an Aptos client which simulates the transactions built by
+// BuildSignAndSubmitTransaction instead of submitting them, and returns the simulated transactions
+// when they are waited for.
+type aptosSimulatingClient struct {
+	aptoslib.AptosRpcClient
+
+	tracker *aptosTracker
+}
+
+// BuildSignAndSubmitTransaction simulates the transaction instead of submitting it.
+func (c *aptosSimulatingClient) BuildSignAndSubmitTransaction(
+	sender aptoslib.TransactionSigner, payload aptoslib.TransactionPayload, options ...any,
+) (*api.SubmitTransactionResponse, error) {
+	rawTxn, err := c.BuildTransaction(sender.AccountAddress(), payload, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := c.SimulateTransaction(rawTxn, sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	if len(txs) == 0 {
+		return nil, errors.New("failed to simulate transaction: no result")
+	}
+
+	return c.tracker.record(txs[0]), nil
+}
+
+// SubmitTransaction implements aptoslib.AptosRpcClient, and always fails.
+func (c *aptosSimulatingClient) SubmitTransaction(*aptoslib.SignedTransaction) (*api.SubmitTransactionResponse, error) {
+	return nil, errAptosSubmitDisabled
+}
+
+// BatchSubmitTransaction implements aptoslib.AptosRpcClient, and always fails.
+func (c *aptosSimulatingClient) BatchSubmitTransaction([]*aptoslib.SignedTransaction) (*api.BatchSubmitTransactionResponse, error) {
+	return nil, errAptosSubmitDisabled
+}
+
+// WaitForTransaction returns the simulated transaction with the hash, or else waits for it on the
+// chain.
+func (c *aptosSimulatingClient) WaitForTransaction(txnHash string, options ...any) (*api.UserTransaction, error) {
+	if tx, ok := c.tracker.lookup(txnHash); ok {
+		return tx, nil
+	}
+
+	return c.AptosRpcClient.WaitForTransaction(txnHash, options...)
+}
+
+// PollForTransaction returns the simulated transaction with the hash, or else polls for it on the
+// chain.
+func (c *aptosSimulatingClient) PollForTransaction(hash string, options ...any) (*api.UserTransaction, error) {
+	if tx, ok := c.tracker.lookup(hash); ok {
+		return tx, nil
+	}
+
+	return c.AptosRpcClient.PollForTransaction(hash, options...)
+}
+
+// aptosSimulationSigner identifies the deployer of a simulated Aptos chain, but refuses to sign, so
+// no transaction of the deployer can be submitted while estimating cost.
+type aptosSimulationSigner struct {
+	aptoslib.TransactionSigner
+}
+
+// Sign implements aptoscrypto.Signer, and always fails.
+func (aptosSimulationSigner) Sign([]byte) (*aptoscrypto.AccountAuthenticator, error) {
+	return nil, errAptosSigningDisabled
+}
+
+// SignMessage implements aptoscrypto.Signer, and always fails.
+func (aptosSimulationSigner) SignMessage([]byte) (aptoscrypto.Signature, error) {
+	return nil, errAptosSigningDisabled
+}
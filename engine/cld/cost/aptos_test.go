@@ -0,0 +1,104 @@
+package cost
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	aptoslib "github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/aptos"
+	aptosmocks "github.com/smartcontractkit/chainlink-deployments-framework/chain/aptos/mocks"
+)
+
+func Test_aptosTracker(t *testing.T) {
+	t.Parallel()
+
+	signer, err := aptoslib.NewEd25519Account()
+	require.NoError(t, err)
+
+	rawTxn := &aptoslib.RawTransaction{Sender: signer.AccountAddress()}
+	client := aptosmocks.NewMockAptosRpcClient(t)
+	client.EXPECT().BuildTransaction(signer.AccountAddress(), mock.Anything).Return(rawTxn, nil).Twice()
+	client.EXPECT().SimulateTransaction(rawTxn, mock.Anything).
+		Return([]*api.UserTransaction{{Hash: "0x1", GasUsed: 1_000, GasUnitPrice: 100, Success: true}}, nil).Once()
+	client.EXPECT().SimulateTransaction(rawTxn, mock.Anything).
+		Return([]*api.UserTransaction{{Hash: "0x2", GasUsed: 500, GasUnitPrice: 150, VmStatus: "MODULE_NOT_FOUND"}}, nil).Once()
+
+	selector := chainsel.APTOS_TESTNET.Selector
+	c := aptos.Chain{Selector: selector, Client: client, DeployerSigner: signer}
+
+	tracker, err := newAptosTracker(c)
+	require.NoError(t, err)
+	tracked := tracker.chain(c)
+
+	// The deployer is kept for simulation, but cannot sign
+	assert.Equal(t, signer.AccountAddress(), tracked.DeployerSigner.AccountAddress())
+	_, err = tracked.DeployerSigner.Sign([]byte("message"))
+	require.ErrorIs(t, err, errAptosSigningDisabled)
+	_, err = tracked.Client.SubmitTransaction(&aptoslib.SignedTransaction{})
+	require.ErrorIs(t, err, errAptosSubmitDisabled)
+
+	for _, hash := range []string{"0x1", "0x2"} {
+		res, submitErr := tracked.Client.BuildSignAndSubmitTransaction(tracked.DeployerSigner, aptoslib.TransactionPayload{})
+		require.NoError(t, submitErr)
+		assert.Equal(t, hash, res.Hash)
+		require.NoError(t, tracked.Confirm(res.Hash))
+	}
+
+	tx, err := tracked.Client.WaitForTransaction("0x2")
+	require.NoError(t, err)
+	assert.Equal(t, "MODULE_NOT_FOUND", tx.VmStatus)
+
+	got, err := tracker.collect(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, selector, got.ChainSelector)
+	assert.Equal(t, chainsel.FamilyAptos, got.Family)
+	assert.Equal(t, 2, got.TransactionCount)
+	assert.Equal(t, 1, got.SimulationFailures)
+	assert.Equal(t, uint64(1_500), got.GasUsed)
+	assert.Equal(t, big.NewInt(175_000), got.Fee)
+	assert.Equal(t, "0.00175000", got.NativeFee)
+}
+
+func Test_aptosTracker_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("client not set", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newAptosTracker(aptos.Chain{})
+		require.ErrorContains(t, err, "chain client is not set")
+	})
+
+	t.Run("deployer signer not set", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newAptosTracker(aptos.Chain{Client: aptosmocks.NewMockAptosRpcClient(t)})
+		require.ErrorContains(t, err, "deployer signer is not set")
+	})
+
+	t.Run("simulation fails", func(t *testing.T) {
+		t.Parallel()
+
+		signer, err := aptoslib.NewEd25519Account()
+		require.NoError(t, err)
+
+		client := aptosmocks.NewMockAptosRpcClient(t)
+		client.EXPECT().BuildTransaction(signer.AccountAddress(), mock.Anything).Return(&aptoslib.RawTransaction{}, nil).Once()
+		client.EXPECT().SimulateTransaction(mock.Anything, mock.Anything).Return(nil, errors.New("unavailable")).Once()
+
+		c := aptos.Chain{Client: client, DeployerSigner: signer}
+		tracker, err := newAptosTracker(c)
+		require.NoError(t, err)
+
+		_, err = tracker.chain(c).Client.BuildSignAndSubmitTransaction(signer, aptoslib.TransactionPayload{})
+		require.ErrorContains(t, err, "failed to simulate transaction: unavailable")
+	})
+}
@@ -0,0 +1,175 @@
+// Package cost estimates the native token cost of applying changesets by collecting the fees paid
+// by the transactions sent to the chains of an environment, typically a forked environment loaded
+// with environment.LoadFork.
+//
+// EVM chains are forked, so the cost of their transactions is read from the blocks mined on the
+// fork. Solana and Aptos chains cannot be forked, so their transactions are not sent: the fee of
+// Solana transactions is read with getFeeForMessage, and Aptos transactions are simulated.
+package cost
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	fchain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+)
+
+// nativeDecimals is the number of decimals of the native token of each supported chain family.
+var nativeDecimals = map[string]int{
+	chainsel.FamilyEVM:    18, // wei
+	chainsel.FamilySolana: 9,  // lamports
+	chainsel.FamilyAptos:  8,  // octas
+}
+
+// ChainCost is the cost of the transactions sent to a single chain.
+type ChainCost struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	ChainName     string `json:"chainName"`
+	Family        string `json:"family"`
+	// TransactionCount is the number of transactions which were paid for.
+	TransactionCount int `json:"transactionCount"`
+	// GasUsed is the gas used by the transactions on EVM and Aptos chains. It is zero on Solana
+	// chains, whose fees are not based on the compute units consumed.
+	GasUsed uint64 `json:"gasUsed"`
+	// SimulationFailures is the number of simulated transactions which failed, on Aptos chains.
+	// Their gas is included, but the estimate may be low if they would succeed when sent.
+	SimulationFailures int `json:"simulationFailures,omitempty"`
+	// Fee is the fee paid in the smallest unit of the native token (wei, lamports or octas).
+	Fee *big.Int `json:"fee"`
+	// NativeFee is the fee paid in native token units, as a decimal string.
+	NativeFee string `json:"nativeFee"`
+	// USDFee is the fee paid in USD, as a decimal string. Empty if no price is known for the chain.
+	USDFee string `json:"usdFee,omitempty"`
+}
+
+// nativeFee returns the fee in native token units.
+func (c ChainCost) nativeFee() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(nativeDecimals[c.Family])), nil)
+
+	return new(big.Rat).SetFrac(c.Fee, denom)
+}
+
+// newChainCost returns a ChainCost with the native fee derived from the fee in the smallest unit.
+func newChainCost(selector uint64, family string, txCount int, gasUsed uint64, fee *big.Int) ChainCost {
+	c := ChainCost{
+		ChainSelector:    selector,
+		Family:           family,
+		TransactionCount: txCount,
+		GasUsed:          gasUsed,
+		Fee:              fee,
+	}
+	if name, err := chainsel.GetChainNameFromSelector(selector); err == nil {
+		c.ChainName = name
+	}
+	c.NativeFee = c.nativeFee().FloatString(nativeDecimals[family])
+
+	return c
+}
+
+// Report is the cost of the transactions sent to the chains of an environment.
+type Report struct {
+	// Chains holds the cost per chain, ordered by chain selector.
+	Chains []ChainCost `json:"chains"`
+	// Unsupported holds the selectors of the chains whose family is not supported, so their cost
+	// was not collected.
+	Unsupported []uint64 `json:"unsupported,omitempty"`
+	// TotalUSD is the sum of the USD fees of the chains which have a price. Empty if no prices
+	// were applied.
+	TotalUSD string `json:"totalUsd,omitempty"`
+}
+
+// chainTracker collects the cost of the transactions sent to a single chain.
+type chainTracker interface {
+	// collect returns the cost of the transactions sent since the tracker was created.
+	collect(ctx context.Context) (ChainCost, error)
+}
+
+// Tracker collects the cost of the transactions sent to the chains of an environment since the
+// tracker was created.
+//
+// Supported families are EVM, Solana and Aptos; other chains are reported as unsupported. On EVM
+// chains the cost of every transaction in the blocks mined since the tracker was created is
+// collected, which on a fork are only the transactions sent by the run. Solana and Aptos chains
+// must be used through BlockChains, whose send functions record the cost of the transactions
+// instead of sending them.
+type Tracker struct {
+	trackers    map[uint64]chainTracker
+	unsupported []uint64
+	blockChains fchain.BlockChains
+}
+
+// NewTracker snapshots the state of each chain, so that Collect returns the cost of the
+// transactions sent after this call.
+func NewTracker(ctx context.Context, blockChains fchain.BlockChains) (*Tracker, error) {
+	t := &Tracker{trackers: make(map[uint64]chainTracker)}
+	chains := maps.Collect(blockChains.All())
+
+	for selector, c := range blockChains.EVMChains() {
+		tracker, err := newEVMTracker(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start cost tracking on chain %d: %w", selector, err)
+		}
+		t.trackers[selector] = tracker
+	}
+
+	for selector, c := range blockChains.SolanaChains() {
+		tracker, err := newSolanaTracker(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start cost tracking on chain %d: %w", selector, err)
+		}
+		if chains[selector], err = tracker.chain(c); err != nil {
+			return nil, fmt.Errorf("failed to start cost tracking on chain %d: %w", selector, err)
+		}
+		t.trackers[selector] = tracker
+	}
+
+	for selector, c := range blockChains.AptosChains() {
+		tracker, err := newAptosTracker(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start cost tracking on chain %d: %w", selector, err)
+		}
+		chains[selector] = tracker.chain(c)
+		t.trackers[selector] = tracker
+	}
+	t.blockChains = fchain.NewBlockChains(chains)
+
+	for selector := range blockChains.All() {
+		if _, ok := t.trackers[selector]; !ok {
+			t.unsupported = append(t.unsupported, selector)
+		}
+	}
+	slices.Sort(t.unsupported)
+
+	return t, nil
+}
+
+// BlockChains returns the chains to apply the changeset to. EVM chains are returned unchanged,
+// while the transactions sent to Solana and Aptos chains are recorded instead of sent. The Solana
+// deployer is replaced by an unfunded key and the Aptos deployer cannot sign, so no transaction
+// can reach the real networks.
+func (t *Tracker) BlockChains() fchain.BlockChains {
+	return t.blockChains
+}
+
+// Collect returns the cost of the transactions sent to each chain since the tracker was created.
+func (t *Tracker) Collect(ctx context.Context) (*Report, error) {
+	report := &Report{
+		Chains:      make([]ChainCost, 0, len(t.trackers)),
+		Unsupported: t.unsupported,
+	}
+
+	for _, selector := range slices.Sorted(maps.Keys(t.trackers)) {
+		c, err := t.trackers[selector].collect(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect cost on chain %d: %w", selector, err)
+		}
+		report.Chains = append(report.Chains, c)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,49 @@
+package cost
+
+import (
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fchain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+)
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+
+	evmChain := newSimChain(t)
+	suiSelector := chainsel.SUI_LOCALNET.Selector
+
+	tracker, err := NewTracker(t.Context(), fchain.NewBlockChains(map[uint64]fchain.BlockChain{
+		evmChain.Selector: evmChain,
+		suiSelector:       sui.Chain{ChainMetadata: sui.ChainMetadata{Selector: suiSelector}},
+	}))
+	require.NoError(t, err)
+
+	receipt := sendTransfer(t, evmChain)
+
+	report, err := tracker.Collect(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, report.Chains, 1)
+	assert.Equal(t, evmChain.Selector, report.Chains[0].ChainSelector)
+	assert.Equal(t, "geth-testnet", report.Chains[0].ChainName)
+	assert.Equal(t, 1, report.Chains[0].TransactionCount)
+	assert.Equal(t, receipt.GasUsed, report.Chains[0].GasUsed)
+	assert.Equal(t, []uint64{suiSelector}, report.Unsupported)
+	assert.Empty(t, report.TotalUSD)
+}
+
+func TestNewTracker_Error(t *testing.T) {
+	t.Parallel()
+
+	selector := chainsel.GETH_TESTNET.Selector
+	_, err := NewTracker(t.Context(), fchain.NewBlockChains(map[uint64]fchain.BlockChain{
+		selector: evm.Chain{Selector: selector},
+	}))
+	require.ErrorContains(t, err, "chain client is not set")
+}
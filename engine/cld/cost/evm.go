@@ -0,0 +1,88 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// evmBlockReader is implemented by EVM clients which can read full blocks, such as the geth
+// ethclient and the simulated backend client.
+type evmBlockReader interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// evmTracker collects the cost of the transactions in the blocks mined after the start block.
+type evmTracker struct {
+	selector   uint64
+	client     evm.OnchainClient
+	blocks     evmBlockReader
+	startBlock uint64
+}
+
+func newEVMTracker(ctx context.Context, c evm.Chain) (*evmTracker, error) {
+	if c.Client == nil {
+		return nil, errors.New("chain client is not set")
+	}
+
+	blocks, ok := c.Client.(evmBlockReader)
+	if !ok {
+		return nil, fmt.Errorf("chain client %T cannot read blocks", c.Client)
+	}
+
+	header, err := c.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	return &evmTracker{
+		selector:   c.Selector,
+		client:     c.Client,
+		blocks:     blocks,
+		startBlock: header.Number.Uint64(),
+	}, nil
+}
+
+func (t *evmTracker) collect(ctx context.Context) (ChainCost, error) {
+	header, err := t.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return ChainCost{}, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	var (
+		txCount int
+		gasUsed uint64
+		fee     = new(big.Int)
+	)
+
+	for n := t.startBlock + 1; n <= header.Number.Uint64(); n++ {
+		block, err := t.blocks.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return ChainCost{}, fmt.Errorf("failed to get block %d: %w", n, err)
+		}
+
+		for _, tx := range block.Transactions() {
+			receipt, err := t.client.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return ChainCost{}, fmt.Errorf("failed to get receipt of transaction %s: %w", tx.Hash(), err)
+			}
+
+			price := receipt.EffectiveGasPrice
+			if price == nil {
+				price = tx.GasPrice()
+			}
+
+			txCount++
+			gasUsed += receipt.GasUsed
+			fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), price))
+		}
+	}
+
+	return newChainCost(t.selector, chainsel.FamilyEVM, txCount, gasUsed, fee), nil
+}
@@ -0,0 +1,95 @@
+package cost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	evmprov "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm/provider"
+)
+
+// newSimChain returns a simulated EVM chain.
+func newSimChain(t *testing.T) evm.Chain {
+	t.Helper()
+
+	bc, err := evmprov.NewSimChainProvider(t, chainsel.GETH_TESTNET.Selector, evmprov.SimChainProviderConfig{}).
+		Initialize(t.Context())
+	require.NoError(t, err)
+
+	c, ok := bc.(evm.Chain)
+	require.True(t, ok)
+
+	return c
+}
+
+// sendTransfer sends and confirms a transfer of 1 wei from the deployer, returning its receipt.
+func sendTransfer(t *testing.T, c evm.Chain) *types.Receipt {
+	t.Helper()
+
+	ctx := t.Context()
+	nonce, err := c.Client.PendingNonceAt(ctx, c.DeployerKey.From)
+	require.NoError(t, err)
+	gasPrice, err := c.Client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+
+	tx, err := c.DeployerKey.Signer(c.DeployerKey.From, types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       &common.Address{1},
+		Value:    big.NewInt(1),
+		Gas:      21_000,
+		GasPrice: gasPrice,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.Client.SendTransaction(ctx, tx))
+
+	_, err = c.Confirm(tx)
+	require.NoError(t, err)
+
+	receipt, err := c.Client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+
+	return receipt
+}
+
+func Test_evmTracker(t *testing.T) {
+	t.Parallel()
+
+	c := newSimChain(t)
+
+	// Transactions sent before the tracker is created are not counted
+	sendTransfer(t, c)
+
+	tracker, err := newEVMTracker(t.Context(), c)
+	require.NoError(t, err)
+
+	r1 := sendTransfer(t, c)
+	r2 := sendTransfer(t, c)
+
+	got, err := tracker.collect(t.Context())
+	require.NoError(t, err)
+
+	wantFee := new(big.Int).Add(
+		new(big.Int).Mul(new(big.Int).SetUint64(r1.GasUsed), r1.EffectiveGasPrice),
+		new(big.Int).Mul(new(big.Int).SetUint64(r2.GasUsed), r2.EffectiveGasPrice),
+	)
+
+	assert.Equal(t, chainsel.GETH_TESTNET.Selector, got.ChainSelector)
+	assert.Equal(t, chainsel.FamilyEVM, got.Family)
+	assert.Equal(t, 2, got.TransactionCount)
+	assert.Equal(t, uint64(42_000), got.GasUsed)
+	assert.Equal(t, wantFee, got.Fee)
+	assert.NotEmpty(t, got.NativeFee)
+}
+
+func Test_newEVMTracker_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := newEVMTracker(t.Context(), evm.Chain{})
+	require.ErrorContains(t, err, "chain client is not set")
+}
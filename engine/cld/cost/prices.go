@@ -0,0 +1,77 @@
+package cost
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// usdDecimals is the number of decimals USD fees are rounded to.
+const usdDecimals = 2
+
+// Prices holds the USD price of one native token, keyed by chain name or chain selector.
+type Prices map[string]*big.Rat
+
+// LoadPrices loads prices from a local YAML or JSON file which maps chain names or chain selectors
+// to the USD price of one native token.
+//
+// Example:
+//
+//	ethereum-mainnet: 3512.40
+//	"5009297550715157269": "3512.40"
+//	solana-mainnet: 152.1
+func LoadPrices(path string) (Prices, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price file: %w", err)
+	}
+
+	var raw map[string]string
+	if err = yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse price file: %w", err)
+	}
+
+	prices := make(Prices, len(raw))
+	for key, value := range raw {
+		price, ok := new(big.Rat).SetString(value)
+		if !ok || price.Sign() < 0 {
+			return nil, fmt.Errorf("invalid price %q for %s", value, key)
+		}
+		prices[key] = price
+	}
+
+	return prices, nil
+}
+
+// priceOf returns the price of the native token of the chain, looked up by chain selector first and
+// then by chain name.
+func (p Prices) priceOf(c ChainCost) (*big.Rat, bool) {
+	if price, ok := p[strconv.FormatUint(c.ChainSelector, 10)]; ok {
+		return price, true
+	}
+	if c.ChainName == "" {
+		return nil, false
+	}
+	price, ok := p[c.ChainName]
+
+	return price, ok
+}
+
+// ApplyPrices sets the USD fee of each chain which has a price, and the USD total of those chains.
+func (r *Report) ApplyPrices(prices Prices) {
+	total := new(big.Rat)
+	for i, c := range r.Chains {
+		price, ok := prices.priceOf(c)
+		if !ok {
+			continue
+		}
+
+		usd := new(big.Rat).Mul(c.nativeFee(), price)
+		r.Chains[i].USDFee = usd.FloatString(usdDecimals)
+		total.Add(total, usd)
+	}
+	r.TotalUSD = total.FloatString(usdDecimals)
+}
@@ -0,0 +1,113 @@
+package cost
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePriceFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func Test_LoadPrices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    Prices
+		wantErr string
+	}{
+		{
+			name:    "yaml with names and selectors",
+			content: "ethereum-mainnet: 3500.5\n\"16015286601757825753\": \"2\"\n",
+			want: Prices{
+				"ethereum-mainnet":     big.NewRat(7001, 2),
+				"16015286601757825753": big.NewRat(2, 1),
+			},
+		},
+		{
+			name:    "json",
+			content: `{"solana-mainnet": "150"}`,
+			want:    Prices{"solana-mainnet": big.NewRat(150, 1)},
+		},
+		{
+			name:    "invalid price",
+			content: "ethereum-mainnet: lots\n",
+			wantErr: `invalid price "lots" for ethereum-mainnet`,
+		},
+		{
+			name:    "negative price",
+			content: "ethereum-mainnet: -1\n",
+			wantErr: `invalid price "-1" for ethereum-mainnet`,
+		},
+		{
+			name:    "invalid file",
+			content: "- a\n- b\n",
+			wantErr: "failed to parse price file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadPrices(writePriceFile(t, tt.content))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_LoadPrices_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadPrices(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read price file")
+}
+
+func TestReport_ApplyPrices(t *testing.T) {
+	t.Parallel()
+
+	ethSelector := chainsel.ETHEREUM_MAINNET.Selector
+	solSelector := chainsel.SOLANA_MAINNET.Selector
+	aptosSelector := chainsel.APTOS_MAINNET.Selector
+
+	report := &Report{Chains: []ChainCost{
+		// 0.01 ETH
+		newChainCost(ethSelector, chainsel.FamilyEVM, 1, 21_000, big.NewInt(10_000_000_000_000_000)),
+		// 0.5 SOL
+		newChainCost(solSelector, chainsel.FamilySolana, 1, 0, big.NewInt(500_000_000)),
+		newChainCost(aptosSelector, chainsel.FamilyAptos, 1, 10, big.NewInt(1_000)),
+	}}
+
+	report.ApplyPrices(Prices{
+		chainsel.ETHEREUM_MAINNET.Name: big.NewRat(3000, 1),
+		// Selectors take precedence over names
+		chainsel.SOLANA_MAINNET.Name:        big.NewRat(1, 1),
+		strconv.FormatUint(solSelector, 10): big.NewRat(150, 1),
+	})
+
+	assert.Equal(t, "0.010000000000000000", report.Chains[0].NativeFee)
+	assert.Equal(t, "30.00", report.Chains[0].USDFee)
+	assert.Equal(t, "75.00", report.Chains[1].USDFee)
+	assert.Empty(t, report.Chains[2].USDFee)
+	assert.Equal(t, "105.00", report.TotalUSD)
+}
@@ -0,0 +1,126 @@
+package cost
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	sollib "github.com/gagliardetto/solana-go"
+	solrpc "github.com/gagliardetto/solana-go/rpc"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	solCommonUtil "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana/provider/rpcclient"
+)
+
+// solanaTxModifier is the type underlying the transaction modifiers of both Solana send functions.
+type solanaTxModifier = func(tx *sollib.Transaction, signers map[sollib.PublicKey]sollib.PrivateKey) error
+
+// solanaTracker collects the fees of the transactions sent to a Solana chain. Solana chains cannot
+// be forked, so the transactions are not sent: the fee of each transaction is read with
+// getFeeForMessage, which includes the signature and priority fees.
+type solanaTracker struct {
+	selector uint64
+	client   *solrpc.Client
+
+	mu      sync.Mutex
+	txCount int
+	fee     *big.Int
+}
+
+func newSolanaTracker(c solana.Chain) (*solanaTracker, error) {
+	if c.Client == nil {
+		return nil, errors.New("chain client is not set")
+	}
+
+	return &solanaTracker{
+		selector: c.Selector,
+		client:   c.Client,
+		fee:      new(big.Int),
+	}, nil
+}
+
+// chain returns a copy of the chain whose send functions record the fee of the transactions
+// instead of sending them. The deployer key is replaced by a random key, so transactions sent
+// directly with the client cannot be paid for, and the keypair used by the Solana CLI is removed.
+func (t *solanaTracker) chain(c solana.Chain) (solana.Chain, error) {
+	key, err := sollib.NewRandomPrivateKey()
+	if err != nil {
+		return solana.Chain{}, fmt.Errorf("failed to generate deployer key: %w", err)
+	}
+	c.DeployerKey = &key
+	c.KeypairPath = ""
+
+	c.SendAndConfirm = func(ctx context.Context, instructions []sollib.Instruction, opts ...rpcclient.TxModifier) error {
+		modifiers := make([]solanaTxModifier, 0, len(opts))
+		for _, opt := range opts {
+			modifiers = append(modifiers, opt)
+		}
+
+		return t.record(ctx, key.PublicKey(), instructions, modifiers)
+	}
+	c.Confirm = func(instructions []sollib.Instruction, opts ...solCommonUtil.TxModifier) error {
+		modifiers := make([]solanaTxModifier, 0, len(opts))
+		for _, opt := range opts {
+			modifiers = append(modifiers, opt)
+		}
+
+		return t.record(context.Background(), key.PublicKey(), instructions, modifiers)
+	}
+
+	return c, nil
+}
+
+// record builds the transaction as the send functions would, and adds its fee to the cost.
+func (t *solanaTracker) record(
+	ctx context.Context, payer sollib.PublicKey, instructions []sollib.Instruction, modifiers []solanaTxModifier,
+) error {
+	blockhash, err := t.client.GetLatestBlockhash(ctx, solana.SolDefaultCommitment)
+	if err != nil {
+		return fmt.Errorf("failed to get latest blockhash: %w", err)
+	}
+
+	tx, err := sollib.NewTransaction(instructions, blockhash.Value.Blockhash, sollib.TransactionPayer(payer))
+	if err != nil {
+		return fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	signers := make(map[sollib.PublicKey]sollib.PrivateKey)
+	for _, modify := range modifiers {
+		if err = modify(tx, signers); err != nil {
+			return err
+		}
+	}
+
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction message: %w", err)
+	}
+
+	res, err := t.client.GetFeeForMessage(ctx, base64.StdEncoding.EncodeToString(msg), solana.SolDefaultCommitment)
+	if err != nil {
+		return fmt.Errorf("failed to get fee for transaction: %w", err)
+	}
+	if res.Value == nil {
+		return errors.New("failed to get fee for transaction: blockhash not found")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.txCount++
+	t.fee.Add(t.fee, new(big.Int).SetUint64(*res.Value))
+
+	return nil
+}
+
+func (t *solanaTracker) collect(_ context.Context) (ChainCost, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return newChainCost(t.selector, chainsel.FamilySolana, t.txCount, 0, new(big.Int).Set(t.fee)), nil
+}
@@ -0,0 +1,117 @@
+package cost
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sollib "github.com/gagliardetto/solana-go"
+	solrpc "github.com/gagliardetto/solana-go/rpc"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana/provider/rpcclient"
+)
+
+// newSolanaRPCServer returns a JSON-RPC server which answers getLatestBlockhash, and
+// getFeeForMessage with the given fee. Sending transactions fails the test.
+func newSolanaRPCServer(t *testing.T, fee *uint64) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "getLatestBlockhash":
+			result = map[string]any{
+				"context": map[string]any{"slot": 1},
+				"value":   map[string]any{"blockhash": sollib.Hash{1}.String(), "lastValidBlockHeight": 100},
+			}
+		case "getFeeForMessage":
+			result = map[string]any{"context": map[string]any{"slot": 1}, "value": fee}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func Test_solanaTracker(t *testing.T) {
+	t.Parallel()
+
+	fee := uint64(5_000)
+	srv := newSolanaRPCServer(t, &fee)
+
+	key, err := sollib.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	selector := chainsel.SOLANA_DEVNET.Selector
+	c := solana.Chain{
+		Selector:    selector,
+		Client:      solrpc.New(srv.URL),
+		DeployerKey: &key,
+		KeypairPath: "/keys/deployer.json",
+	}
+
+	tracker, err := newSolanaTracker(c)
+	require.NoError(t, err)
+	tracked, err := tracker.chain(c)
+	require.NoError(t, err)
+
+	// The deployer is replaced so that transactions cannot be sent with the real key
+	assert.NotEqual(t, key.PublicKey(), tracked.DeployerKey.PublicKey())
+	assert.Empty(t, tracked.KeypairPath)
+
+	ix := sollib.NewInstruction(sollib.SystemProgramID, sollib.AccountMetaSlice{
+		sollib.Meta(tracked.DeployerKey.PublicKey()).SIGNER().WRITE(),
+	}, []byte{1})
+	require.NoError(t, tracked.SendAndConfirm(t.Context(), []sollib.Instruction{ix}, rpcclient.WithComputeUnitPrice(1)))
+	require.NoError(t, tracked.Confirm([]sollib.Instruction{ix}))
+
+	got, err := tracker.collect(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, selector, got.ChainSelector)
+	assert.Equal(t, chainsel.FamilySolana, got.Family)
+	assert.Equal(t, 2, got.TransactionCount)
+	assert.Equal(t, uint64(0), got.GasUsed)
+	assert.Equal(t, big.NewInt(10_000), got.Fee)
+	assert.Equal(t, "0.000010000", got.NativeFee)
+}
+
+func Test_solanaTracker_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := newSolanaTracker(solana.Chain{})
+	require.ErrorContains(t, err, "chain client is not set")
+
+	// A null fee means the blockhash was not found
+	srv := newSolanaRPCServer(t, nil)
+	c := solana.Chain{Client: solrpc.New(srv.URL)}
+	tracker, err := newSolanaTracker(c)
+	require.NoError(t, err)
+	tracked, err := tracker.chain(c)
+	require.NoError(t, err)
+
+	err = tracked.SendAndConfirm(t.Context(), []sollib.Instruction{
+		sollib.NewInstruction(sollib.SystemProgramID, sollib.AccountMetaSlice{}, []byte{1}),
+	})
+	require.ErrorContains(t, err, "failed to get fee for transaction: blockhash not found")
+}
@@ -31,7 +31,7 @@ type addressRefIdentity struct {
 // MigrateAddressBook migrates the address book for the domain's environment directory
 // to the new datastore format. It reads the existing address book and converts its records.
 // When converting address book entries to datastore addressRefs, some assumptions are made to
-// guarantee the conversion is successful.
+// guarantee the conversion is successful. The datastore manifest is rewritten unsigned.
 func (d EnvDir) MigrateAddressBook(opts MigrateAddressBookOptions) error {
 	addrBook, err := d.AddressBook()
 	if err != nil {
@@ -117,7 +117,11 @@ func (d EnvDir) MigrateAddressBook(opts MigrateAddressBookOptions) error {
 	}
 
 	if addressRefsOnly {
-		return d.writeAddressRefs(ds)
+		if err = d.writeAddressRefs(ds); err != nil {
+			return err
+		}
+
+		return d.WriteDataStoreManifest(nil)
 	}
 
 	addressRefs, err := ds.Addresses().Fetch()
@@ -146,7 +150,7 @@ func (d EnvDir) MigrateAddressBook(opts MigrateAddressBookOptions) error {
 		return fmt.Errorf("failed to write environment datastore file: %w", err)
 	}
 
-	return nil
+	return d.WriteDataStoreManifest(nil)
 }
 
 func (d EnvDir) writeAddressRefs(ds fdatastore.MutableDataStore) error {
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/segmentio/ksuid"
 
@@ -34,6 +35,7 @@ const (
 	ArtifactDataStore                    = "datastore"
 	ArtifactJobSpec                      = "jobspecs"
 	ArtifactJobs                         = "jobs"
+	ArtifactHookRecords                  = "hook_records"
 	ArtifactsDurablePipelineDirName      = "durable_pipelines"
 	ArtifactMCMSProposal                 = "mcms_proposal"
 	ArtifactMCMSProposalDecoded          = "mcms_proposal_decoded"
@@ -177,7 +179,8 @@ func (a *ArtifactsDir) EnvKey() string {
 }
 
 // ChangesetDirPath returns the path to the directory containing the artifacts for the specified
-// changeset key.
+// changeset key. The directories of namespaced changeset keys, such as "ccip/deploy-router", are
+// nested in a directory of their namespace.
 func (a *ArtifactsDir) ChangesetDirPath(csKey string) string {
 	return filepath.Join(a.ArtifactsDirPath(), csKey, a.timestamp)
 }
@@ -219,6 +222,21 @@ func (a *ArtifactsDir) ChangesetDirExists(csKey string) (bool, error) {
 	return true, nil
 }
 
+// ChangesetArtifactsExist checks if any artifacts have been saved for the specified changeset key,
+// which means the changeset has been applied to the environment. For durable pipelines, artifacts
+// of any previous run are taken into account.
+func (a *ArtifactsDir) ChangesetArtifactsExist(csKey string) (bool, error) {
+	info, err := os.Stat(filepath.Join(a.ArtifactsDirPath(), csKey))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return info.IsDir(), nil
+}
+
 // OperationsReportsDirExists checks if the operations_reports directory exists.
 func (a *ArtifactsDir) OperationsReportsDirExists() (bool, error) {
 	info, err := os.Stat(a.OperationsReportsDirPath())
@@ -380,6 +398,13 @@ func (a *ArtifactsDir) SaveChangesetOutput(csKey string, output fdeployment.Chan
 		}
 	}
 
+	// Write hook records artifact
+	if len(output.HookRecords) > 0 {
+		if err := a.saveArtifact(id, csKey, ArtifactHookRecords, output.HookRecords); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -402,6 +427,7 @@ func (a *ArtifactsDir) LoadChangesetOutput(csKey string) (fdeployment.ChangesetO
 	jobsRgx := regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9_-]+_%s\.json$`, ArtifactJobs))
 	addressesRgx := regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9_-]+_%s\.json$`, ArtifactAddress))
 	datastoreRgx := regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9_-]+_%s\.json$`, ArtifactDataStore))
+	hookRecordsRgx := regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9_-]+_%s\.json$`, ArtifactHookRecords))
 	mcmsTimelockProposalRgx := regexp.MustCompile(
 		fmt.Sprintf(`^[a-zA-Z0-9_-]+_%s_\d+\.json$`, ArtifactsMCMSTimelockProposal),
 	)
@@ -446,6 +472,12 @@ func (a *ArtifactsDir) LoadChangesetOutput(csKey string) (fdeployment.ChangesetO
 				return fdeployment.ChangesetOutput{}, err1
 			}
 			output.DataStore = ds
+		case hookRecordsRgx.MatchString(name):
+			records, err1 := LoadHookRecords(entryPath)
+			if err1 != nil {
+				return fdeployment.ChangesetOutput{}, err1
+			}
+			output.HookRecords = records
 		}
 	}
 
@@ -490,7 +522,7 @@ func (a *ArtifactsDir) LoadChangesetOutput(csKey string) (fdeployment.ChangesetO
 func (a *ArtifactsDir) LoadAddressBookByChangesetKey(csKey string) (*fdeployment.AddressBookMap, error) {
 	csDirPath := a.ChangesetDirPath(csKey)
 	pattern := fmt.Sprintf("*-%s-%s-%s_%s",
-		a.DomainKey(), a.EnvKey(), csKey, AddressBookFileName,
+		a.DomainKey(), a.EnvKey(), changesetFileKey(csKey), AddressBookFileName,
 	)
 
 	addrBookPath, err := a.findArtifactPath(csDirPath, pattern)
@@ -551,7 +583,7 @@ func (a *ArtifactsDir) SaveOperationsReports(csKey string, reports []foperations
 }
 
 func (a *ArtifactsDir) getOperationsReportsFilePath(csKey string) string {
-	fileName := fmt.Sprintf("%s-reports.%s", csKey, JSONExt)
+	fileName := fmt.Sprintf("%s-reports.%s", changesetFileKey(csKey), JSONExt)
 
 	return filepath.Join(a.OperationsReportsDirPath(), fileName)
 }
@@ -667,7 +699,7 @@ func (a *ArtifactsDir) loadMutableDataStore(dataStorePath string) (fdatastore.Mu
 // saveArtifact writes an artifact as JSON to the specified changeset directory.
 func (a *ArtifactsDir) saveArtifact(k ksuid.KSUID, csKey, name string, v any) error {
 	filename := fmt.Sprintf("%s-%s-%s-%s_%s.%s",
-		k.String(), a.DomainKey(), a.EnvKey(), csKey, name, JSONExt,
+		k.String(), a.DomainKey(), a.EnvKey(), changesetFileKey(csKey), name, JSONExt,
 	)
 
 	return jsonutils.WriteFile(filepath.Join(a.ChangesetDirPath(csKey), filename), v)
@@ -675,7 +707,7 @@ func (a *ArtifactsDir) saveArtifact(k ksuid.KSUID, csKey, name string, v any) er
 
 // saveProposalArtifact writes a proposal artifact as JSON to the proposals directory.
 func (a *ArtifactsDir) saveProposalArtifact(csKey string, name string, index int, v any) error {
-	filename := fmt.Sprintf("%s-%s-%s_%s_%d.%s", a.DomainKey(), a.EnvKey(), csKey, name, index, JSONExt)
+	filename := fmt.Sprintf("%s-%s-%s_%s_%d.%s", a.DomainKey(), a.EnvKey(), changesetFileKey(csKey), name, index, JSONExt)
 	if a.timestamp != "" {
 		filename = fmt.Sprintf("%s-%s", a.timestamp, filename)
 	}
@@ -685,7 +717,9 @@ func (a *ArtifactsDir) saveProposalArtifact(csKey string, name string, index int
 
 // saveDecodedProposalArtifact writes a decoded proposal artifact as JSON to the decoded proposals directory.
 func (a *ArtifactsDir) saveDecodedProposalArtifact(csKey string, name string, index int, data string) error {
-	filename := fmt.Sprintf("%s-%s-%s_%s_%d_decoded.%s", a.DomainKey(), a.EnvKey(), csKey, name, index, TxtExt)
+	filename := fmt.Sprintf("%s-%s-%s_%s_%d_decoded.%s",
+		a.DomainKey(), a.EnvKey(), changesetFileKey(csKey), name, index, TxtExt,
+	)
 	if a.timestamp != "" {
 		filename = fmt.Sprintf("%s-%s", a.timestamp, filename)
 	}
@@ -693,6 +727,12 @@ func (a *ArtifactsDir) saveDecodedProposalArtifact(csKey string, name string, in
 	return os.WriteFile(filepath.Join(a.getDecodedProposalDir(), filename), []byte(data), 0600)
 }
 
+// changesetFileKey returns the changeset key as used in artifact file names, in which the "/"
+// separating the namespace of namespaced changeset keys is replaced with "__".
+func changesetFileKey(csKey string) string {
+	return strings.ReplaceAll(csKey, "/", "__")
+}
+
 // getDirectoryPath returns the directory path for changesets and durable pipelines.
 func (a *ArtifactsDir) getDirectoryPath(basePathFunc func() string) string {
 	return basePathFunc()
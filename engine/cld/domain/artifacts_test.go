@@ -267,6 +267,28 @@ func Test_Artifacts_RemoveChangesetDir(t *testing.T) {
 	assert.False(t, got)
 }
 
+func Test_Artifacts_ChangesetArtifactsExist(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	// Artifacts written by an earlier durable pipeline run
+	prevRun := NewArtifactsDir(rootDir, "ccip", "staging")
+	require.NoError(t, prevRun.SetDurablePipelines("1234567890123456789"))
+	require.NoError(t, prevRun.CreateChangesetDir("0001_initial"))
+
+	arts := NewArtifactsDir(rootDir, "ccip", "staging")
+	require.NoError(t, arts.SetDurablePipelines("1234567890123456790"))
+
+	got, err := arts.ChangesetArtifactsExist("0001_initial")
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = arts.ChangesetArtifactsExist("0002_missing")
+	require.NoError(t, err)
+	assert.False(t, got)
+}
+
 func Test_Artifacts_ChangesetDirExists(t *testing.T) {
 	t.Parallel()
 
@@ -596,6 +618,28 @@ func Test_Artifacts_SaveChangesetOutput_LoadChangesetOutput(t *testing.T) {
 			"0xAeeFF49471aB5B3d14D2FeA4079bF075d452E5F4",
 			"qtest1",
 		)
+
+		hookRecords = []fdeployment.HookRecord{
+			{
+				Name:          "check-balance",
+				Phase:         fdeployment.HookPhasePre,
+				Kind:          "global pre-hook",
+				FailurePolicy: "Abort",
+				Status:        fdeployment.HookStatusPassed,
+				StartedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				Duration:      time.Second,
+			},
+			{
+				Name:          "verify-router",
+				Phase:         fdeployment.HookPhasePost,
+				Kind:          "changeset post-hook",
+				FailurePolicy: "Warn",
+				Status:        fdeployment.HookStatusWarned,
+				StartedAt:     time.Date(2026, 1, 2, 3, 4, 7, 0, time.UTC),
+				Duration:      2 * time.Second,
+				Error:         "router not found",
+			},
+		}
 	)
 
 	tests := []struct {
@@ -657,6 +701,15 @@ func Test_Artifacts_SaveChangesetOutput_LoadChangesetOutput(t *testing.T) {
 				Jobs: []fdeployment.ProposedJob{job1, job2},
 			},
 		},
+		{
+			name: "changeset output with hook records",
+			giveOutput: fdeployment.ChangesetOutput{
+				HookRecords: hookRecords,
+			},
+			want: fdeployment.ChangesetOutput{
+				HookRecords: hookRecords,
+			},
+		},
 		{
 			name: "changeset output with mcms proposals",
 			giveOutput: fdeployment.ChangesetOutput{
@@ -733,6 +786,61 @@ func testArtifactSaveAndLoad(t *testing.T, artsDir *ArtifactsDir, giveOutput fde
 	assert.Equal(t, want, got)
 }
 
+func Test_Artifacts_SaveChangesetOutput_NamespacedKey(t *testing.T) {
+	t.Parallel()
+
+	fixture := setupTestDomainsFS(t)
+	artsDir := fixture.artifactsDir
+
+	addrBook := createAddressBookMap(t,
+		"Contract", version1_0_0,
+		chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector, "0xAeeFF49471aB5B3d14D2FeA4079bF075d452E5F4",
+	)
+	output := fdeployment.ChangesetOutput{
+		AddressBook: addrBook,
+		Jobs:        []fdeployment.ProposedJob{{JobID: "job_123", Node: "node1", Spec: "spec"}},
+	}
+
+	require.NoError(t, artsDir.SaveChangesetOutput("ccip/0001_initial", output))
+	require.NoError(t, artsDir.SaveChangesetOutput("data-feeds/0001_initial", fdeployment.ChangesetOutput{}))
+
+	// The artifacts are nested in a directory of the namespace, with the namespace in file names
+	exists, err := artsDir.ChangesetArtifactsExist("ccip/0001_initial")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	entries, err := os.ReadDir(artsDir.ChangesetDirPath("ccip/0001_initial"))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		if entry.Name() != ".gitkeep" {
+			assert.Contains(t, entry.Name(), "-ccip__0001_initial_")
+		}
+	}
+
+	got, err := artsDir.LoadChangesetOutput("ccip/0001_initial")
+	require.NoError(t, err)
+	assert.Equal(t, output.Jobs, got.Jobs)
+
+	gotAddrBook, err := artsDir.LoadAddressBookByChangesetKey("ccip/0001_initial")
+	require.NoError(t, err)
+	gotAddresses, err := gotAddrBook.AddressesForChain(chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector)
+	require.NoError(t, err)
+	assert.Contains(t, gotAddresses, "0xAeeFF49471aB5B3d14D2FeA4079bF075d452E5F4")
+
+	_, err = artsDir.LoadAddressBookByChangesetKey("data-feeds/0001_initial")
+	require.ErrorIs(t, err, ErrArtifactNotFound)
+
+	report := operations.NewReport[any, any](
+		operations.Definition{ID: "test", Version: semver.MustParse("1.0.0")}, 1, 2, nil, "123",
+	)
+	require.NoError(t, artsDir.SaveOperationsReports("ccip/0001_initial", []operations.Report[any, any]{report}))
+
+	reports, err := artsDir.LoadOperationsReports("ccip/0001_initial")
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, report.ID, reports[0].ID)
+}
+
 func Test_Artifacts_LoadAddressBookByChangesetKey(t *testing.T) {
 	t.Parallel()
 
@@ -859,6 +967,19 @@ func Test_Artifacts_LoadDataStoreByChangesetKey(t *testing.T) {
 			giveCsKey: "0001_initial",
 			want:      dataStore.Seal(),
 		},
+		{
+			name: "namespaced changeset key",
+			beforeFunc: func(t *testing.T, artsDir *ArtifactsDir) {
+				t.Helper()
+
+				err := artsDir.SaveChangesetOutput("ccip/0001_initial", fdeployment.ChangesetOutput{
+					DataStore: dataStore,
+				})
+				require.NoError(t, err)
+			},
+			giveCsKey: "ccip/0001_initial",
+			want:      dataStore.Seal(),
+		},
 		{
 			name:      "changeset dir does not exist",
 			giveCsKey: "invalid",
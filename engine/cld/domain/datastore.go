@@ -18,7 +18,7 @@ import (
 func (a *ArtifactsDir) LoadDataStoreByChangesetKey(csKey string) (fdatastore.DataStore, error) {
 	csDirPath := a.ChangesetDirPath(csKey)
 	pattern := fmt.Sprintf("*-%s-%s-%s_%s",
-		a.DomainKey(), a.EnvKey(), csKey, DataStoreFileName,
+		a.DomainKey(), a.EnvKey(), changesetFileKey(csKey), DataStoreFileName,
 	)
 
 	dataStorePath, err := a.findArtifactPath(csDirPath, pattern)
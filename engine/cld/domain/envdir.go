@@ -206,11 +206,60 @@ func (d EnvDir) ArtifactsDir() *ArtifactsDir {
 	return NewArtifactsDir(d.rootPath, d.domainKey, d.key)
 }
 
+// MergeDataStoreOption configures MergeChangesetDataStore.
+type MergeDataStoreOption func(*mergeDataStoreConfig)
+
+type mergeDataStoreConfig struct {
+	manifestSigner           ManifestSigner
+	trustedSigners           []string
+	skipManifestVerification bool
+}
+
+// WithManifestSigner signs the datastore manifest written by MergeChangesetDataStore.
+func WithManifestSigner(signer ManifestSigner) MergeDataStoreOption {
+	return func(c *mergeDataStoreConfig) {
+		c.manifestSigner = signer
+	}
+}
+
+// WithTrustedManifestSigners requires the existing datastore manifest to be signed by one of the
+// given signers before MergeChangesetDataStore merges into the datastore.
+func WithTrustedManifestSigners(signers ...string) MergeDataStoreOption {
+	return func(c *mergeDataStoreConfig) {
+		c.trustedSigners = signers
+	}
+}
+
+// WithoutManifestVerification allows MergeChangesetDataStore to merge into datastore files which
+// do not match the existing manifest, accepting any changes made to them into the new manifest.
+// Use this option only after reviewing the changes to the datastore files.
+func WithoutManifestVerification() MergeDataStoreOption {
+	return func(c *mergeDataStoreConfig) {
+		c.skipManifestVerification = true
+	}
+}
+
 // MergeChangesetDataStore merges a changeset's DataStore into the local file-based datastore.
 // This method is used when the environment is configured to use file-based datastore persistence.
 // It loads the changeset artifacts, merges them into the existing datastore, and writes the
-// updated datastore back to local JSON files.
-func (d EnvDir) MergeChangesetDataStore(csKey, timestamp string) error {
+// updated datastore back to local JSON files along with a manifest of their content hashes.
+//
+// The datastore files are verified against the existing manifest before merging, so hand edits
+// are not accepted into the new manifest. A missing manifest is accepted unless trusted signers
+// are given, so the first merge into an environment without a manifest writes one.
+func (d EnvDir) MergeChangesetDataStore(csKey, timestamp string, opts ...MergeDataStoreOption) error {
+	cfg := &mergeDataStoreConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if !cfg.skipManifestVerification {
+		err := d.VerifyDataStoreManifest(cfg.trustedSigners...)
+		if err != nil && (!errors.Is(err, ErrDataStoreManifestNotFound) || len(cfg.trustedSigners) > 0) {
+			return fmt.Errorf("refusing to merge into datastore which failed verification: %w", err)
+		}
+	}
+
 	// Get the artifacts directory for the environment
 	artDir := d.ArtifactsDir()
 
@@ -262,14 +311,17 @@ func (d EnvDir) MergeChangesetDataStore(csKey, timestamp string) error {
 		return errors.New("failed to write environment datastore file")
 	}
 
-	return nil
+	return d.WriteDataStoreManifest(cfg.manifestSigner)
 }
 
 // MergeChangesetDataStoreCatalog merges a changeset's DataStore directly into the remote catalog service.
 // This method is used when the environment is configured to use catalog-based datastore persistence.
 // It loads the changeset artifacts and syncs them to the catalog within a transaction.
-// Local files are NOT updated when using catalog mode.
-func (d EnvDir) MergeChangesetDataStoreCatalog(ctx context.Context, csKey, timestamp string, catalog fdatastore.CatalogStore) error {
+// Local files are NOT updated when using catalog mode. The transaction options are passed to the
+// catalog, e.g. to retry the merge on conflict.
+func (d EnvDir) MergeChangesetDataStoreCatalog(
+	ctx context.Context, csKey, timestamp string, catalog fdatastore.CatalogStore, opts ...fdatastore.TransactionOption,
+) error {
 	// Get the artifacts directory for the environment
 	artDir := d.ArtifactsDir()
 
@@ -280,7 +332,7 @@ func (d EnvDir) MergeChangesetDataStoreCatalog(ctx context.Context, csKey, times
 	}
 
 	// Merge the changeset datastore to catalog within a transaction
-	if err = fdatastore.MergeDataStoreToCatalog(ctx, csDataStore, catalog); err != nil {
+	if err = fdatastore.MergeDataStoreToCatalog(ctx, csDataStore, catalog, opts...); err != nil {
 		return fmt.Errorf("failed to merge datastore to catalog: %w", err)
 	}
 
@@ -289,8 +341,11 @@ func (d EnvDir) MergeChangesetDataStoreCatalog(ctx context.Context, csKey, times
 
 // SyncDataStoreToCatalog syncs the entire local datastore state to the catalog service.
 // This is useful for migrating from file-based datastore to catalog service.
-// The operation is performed within a transaction for atomicity.
-func (d EnvDir) SyncDataStoreToCatalog(ctx context.Context, catalog fdatastore.CatalogStore) error {
+// The operation is performed within a transaction for atomicity, configured by the transaction
+// options.
+func (d EnvDir) SyncDataStoreToCatalog(
+	ctx context.Context, catalog fdatastore.CatalogStore, opts ...fdatastore.TransactionOption,
+) error {
 	// Load the current datastore from local files
 	dataStore, err := d.DataStore()
 	if err != nil {
@@ -298,7 +353,7 @@ func (d EnvDir) SyncDataStoreToCatalog(ctx context.Context, catalog fdatastore.C
 	}
 
 	// Sync entire datastore to catalog within a transaction
-	if err = fdatastore.MergeDataStoreToCatalog(ctx, dataStore, catalog); err != nil {
+	if err = fdatastore.MergeDataStoreToCatalog(ctx, dataStore, catalog, opts...); err != nil {
 		return fmt.Errorf("failed to sync datastore to catalog: %w", err)
 	}
 
@@ -964,10 +964,34 @@ func Test_EnvDir_MergeChangesetDataStore(t *testing.T) {
 	err = deletionDatastore.Merge(dataStore2.Seal())
 	require.NoError(t, err)
 
+	// mergeThenEdit merges dataStore1 and then edits the address refs file by hand, before saving
+	// the artifacts of a second changeset.
+	mergeThenEdit := func(t *testing.T, envdir EnvDir) {
+		t.Helper()
+
+		arts := envdir.ArtifactsDir()
+		err := arts.SaveChangesetOutput("0001_initial", fdeployment.ChangesetOutput{
+			DataStore: dataStore1,
+		})
+		require.NoError(t, err)
+
+		err = envdir.MergeChangesetDataStore("0001_initial", "")
+		require.NoError(t, err)
+
+		err = os.WriteFile(envdir.AddressRefsFilePath(), []byte(`[]`), 0600)
+		require.NoError(t, err)
+
+		err = arts.SaveChangesetOutput("0002_second", fdeployment.ChangesetOutput{
+			DataStore: dataStore2,
+		})
+		require.NoError(t, err)
+	}
+
 	tests := []struct {
 		name              string
 		beforeFunc        func(*testing.T, EnvDir)
 		giveChangesetName string
+		giveOpts          []MergeDataStoreOption
 		want              fdatastore.DataStore
 		wantErr           string
 	}{
@@ -1091,6 +1115,34 @@ func Test_EnvDir_MergeChangesetDataStore(t *testing.T) {
 			giveChangesetName: "0001_initial",
 			want:              fdatastore.NewMemoryDataStore().Seal(),
 		},
+		{
+			name:              "success with datastore files edited by hand and verification skipped",
+			beforeFunc:        mergeThenEdit,
+			giveChangesetName: "0002_second",
+			giveOpts:          []MergeDataStoreOption{WithoutManifestVerification()},
+			want:              dataStore2.Seal(),
+		},
+		{
+			name:              "failure with datastore files edited by hand",
+			beforeFunc:        mergeThenEdit,
+			giveChangesetName: "0002_second",
+			wantErr:           "refusing to merge into datastore which failed verification: datastore files do not match the manifest: address_refs.json",
+		},
+		{
+			name: "failure with trusted signers and no manifest",
+			beforeFunc: func(t *testing.T, envdir EnvDir) {
+				t.Helper()
+
+				arts := envdir.ArtifactsDir()
+				err := arts.SaveChangesetOutput("0001_initial", fdeployment.ChangesetOutput{
+					DataStore: dataStore1,
+				})
+				require.NoError(t, err)
+			},
+			giveChangesetName: "0001_initial",
+			giveOpts:          []MergeDataStoreOption{WithTrustedManifestSigners("0x0000000000000000000000000000000000000001")},
+			wantErr:           "refusing to merge into datastore which failed verification: datastore manifest not found",
+		},
 		{
 			name:              "failure when no changeset artifacts directory exists",
 			giveChangesetName: "0001_invalid",
@@ -1112,7 +1164,7 @@ func Test_EnvDir_MergeChangesetDataStore(t *testing.T) {
 			}
 
 			// Merge the changeset's address book into the existing address book
-			err := envDir.MergeChangesetDataStore(tt.giveChangesetName, "")
+			err := envDir.MergeChangesetDataStore(tt.giveChangesetName, "", tt.giveOpts...)
 
 			if tt.wantErr != "" {
 				require.Error(t, err)
@@ -1123,6 +1175,9 @@ func Test_EnvDir_MergeChangesetDataStore(t *testing.T) {
 
 				require.NoError(t, err)
 				assert.Equal(t, tt.want, got)
+
+				// Check the manifest was updated with the merged files
+				require.NoError(t, envDir.VerifyDataStoreManifest())
 			}
 		})
 	}
@@ -1318,7 +1373,7 @@ type mockCatalogStoreForTest struct {
 	withTransactionFn func(ctx context.Context, fn fdatastore.TransactionLogic) error
 }
 
-func (m *mockCatalogStoreForTest) WithTransaction(ctx context.Context, fn fdatastore.TransactionLogic) error {
+func (m *mockCatalogStoreForTest) WithTransaction(ctx context.Context, fn fdatastore.TransactionLogic, _ ...fdatastore.TransactionOption) error {
 	if m.withTransactionFn != nil {
 		return m.withTransactionFn(ctx, fn)
 	}
@@ -1342,6 +1397,10 @@ func (m *mockCatalogStoreForTest) EnvMetadata() fdatastore.MutableUnaryStoreV2[f
 	return nil
 }
 
+func (m *mockCatalogStoreForTest) Watch(_ context.Context, _ fdatastore.WatchFilter) (<-chan fdatastore.ChangeEvent, error) {
+	return nil, nil
+}
+
 func Test_EnvDir_DataStoreDirPath(t *testing.T) {
 	t.Parallel()
 
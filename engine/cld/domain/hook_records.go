@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/internal/fileutils"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/internal/jsonutils"
+)
+
+// LoadHookRecords unmarshals a slice of `fdeployment.HookRecord` structs from a file.
+func LoadHookRecords(hookRecordsFilePath string) ([]fdeployment.HookRecord, error) {
+	records := make([]fdeployment.HookRecord, 0)
+	b, err := os.ReadFile(hookRecordsFilePath)
+	if err != nil {
+		return records, err
+	}
+
+	if err = json.Unmarshal(b, &records); err != nil {
+		return records, fmt.Errorf("unable to unmarshal data: %w", err)
+	}
+
+	return records, nil
+}
+
+// LoadHookRecordsByChangesetKey searches for a hook records file in the changeset directory and
+// returns the records of the hooks run by the changeset.
+//
+// The search will look for a hook records file with a matching name as the domain, env and
+// changeset key, returning the last matching file. An error wrapping ErrArtifactNotFound is
+// returned if the changeset directory does not exist or no matches are found.
+//
+// Pattern format: "*-<domain>-<env>-<csKey>_hook_records.json".
+func (a *ArtifactsDir) LoadHookRecordsByChangesetKey(csKey string) ([]fdeployment.HookRecord, error) {
+	csDirPath := a.ChangesetDirPath(csKey)
+	pattern := fmt.Sprintf("*-%s-%s-%s_%s.%s",
+		a.DomainKey(), a.EnvKey(), changesetFileKey(csKey), ArtifactHookRecords, JSONExt,
+	)
+
+	if _, err := os.Stat(csDirPath); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: changeset directory %s does not exist", ErrArtifactNotFound, csDirPath)
+	}
+
+	hookRecordsPath, err := a.findArtifactPath(csDirPath, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadHookRecords(hookRecordsPath)
+}
+
+// HookRecordsDirPath returns the path to the directory containing the hook records which are not
+// saved with the changeset artifacts: the records of changeset runs which failed, and of
+// post-proposal hooks.
+func (a *ArtifactsDir) HookRecordsDirPath() string {
+	return filepath.Join(a.rootPath, a.domainKey, a.envKey, HookRecordsDirName, a.durablePipelineDir)
+}
+
+// hookRecordsLogFilePath returns the path of the hook records log of the changeset.
+func (a *ArtifactsDir) hookRecordsLogFilePath(csKey string) string {
+	fileName := fmt.Sprintf("%s-%s.%s", changesetFileKey(csKey), ArtifactHookRecords, JSONExt)
+
+	return filepath.Join(a.HookRecordsDirPath(), fileName)
+}
+
+// AppendHookRecords appends the records to the hook records log of the changeset in the hook
+// records directory, creating the log if needed. The log keeps the records of the hooks which
+// are not saved with the changeset artifacts, such as the hooks of a run which failed, or the
+// post-proposal hooks.
+func (a *ArtifactsDir) AppendHookRecords(csKey string, records []fdeployment.HookRecord) error {
+	existing, err := a.LoadHookRecordsLog(csKey)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err = fileutils.MkdirAllGitKeep(a.HookRecordsDirPath()); err != nil {
+		return err
+	}
+
+	return jsonutils.WriteFile(a.hookRecordsLogFilePath(csKey), append(existing, records...))
+}
+
+// LoadHookRecordsLog reads the hook records log of the changeset written by AppendHookRecords. An
+// error wrapping fs.ErrNotExist is returned if the changeset has no log.
+func (a *ArtifactsDir) LoadHookRecordsLog(csKey string) ([]fdeployment.HookRecord, error) {
+	return LoadHookRecords(a.hookRecordsLogFilePath(csKey))
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+func Test_Artifacts_LoadHookRecordsByChangesetKey(t *testing.T) {
+	t.Parallel()
+
+	record := func(name string, status fdeployment.HookStatus) fdeployment.HookRecord {
+		return fdeployment.HookRecord{
+			Name:          name,
+			Phase:         fdeployment.HookPhasePost,
+			Kind:          "changeset post-hook",
+			FailurePolicy: "Warn",
+			Status:        status,
+			StartedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Duration:      time.Second,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		giveTimestamp string
+		beforeFunc    func(*testing.T, *ArtifactsDir)
+		giveCsKey     string
+		want          []fdeployment.HookRecord
+		wantErr       string
+	}{
+		{
+			name: "success",
+			beforeFunc: func(t *testing.T, artsDir *ArtifactsDir) {
+				t.Helper()
+
+				err := artsDir.SaveChangesetOutput("0001_initial", fdeployment.ChangesetOutput{
+					HookRecords: []fdeployment.HookRecord{record("verify", fdeployment.HookStatusPassed)},
+				})
+				require.NoError(t, err)
+			},
+			giveCsKey: "0001_initial",
+			want:      []fdeployment.HookRecord{record("verify", fdeployment.HookStatusPassed)},
+		},
+		{
+			name: "namespaced changeset key",
+			beforeFunc: func(t *testing.T, artsDir *ArtifactsDir) {
+				t.Helper()
+
+				err := artsDir.SaveChangesetOutput("ccip/0001_initial", fdeployment.ChangesetOutput{
+					HookRecords: []fdeployment.HookRecord{record("verify", fdeployment.HookStatusPassed)},
+				})
+				require.NoError(t, err)
+			},
+			giveCsKey: "ccip/0001_initial",
+			want:      []fdeployment.HookRecord{record("verify", fdeployment.HookStatusPassed)},
+		},
+		{
+			name:          "durable pipeline run",
+			giveTimestamp: "1700000000000000001",
+			beforeFunc: func(t *testing.T, artsDir *ArtifactsDir) {
+				t.Helper()
+
+				err := artsDir.SaveChangesetOutput("0001_initial", fdeployment.ChangesetOutput{
+					HookRecords: []fdeployment.HookRecord{record("verify", fdeployment.HookStatusWarned)},
+				})
+				require.NoError(t, err)
+			},
+			giveCsKey: "0001_initial",
+			want:      []fdeployment.HookRecord{record("verify", fdeployment.HookStatusWarned)},
+		},
+		{
+			name:      "changeset dir does not exist",
+			giveCsKey: "invalid",
+			wantErr:   "artifact not found: changeset directory",
+		},
+		{
+			name: "artifact does not exist",
+			beforeFunc: func(t *testing.T, artsDir *ArtifactsDir) {
+				t.Helper()
+
+				err := artsDir.SaveChangesetOutput("0001_no_hooks", fdeployment.ChangesetOutput{})
+				require.NoError(t, err)
+			},
+			giveCsKey: "0001_no_hooks",
+			wantErr:   "no files found matching pattern",
+		},
+		{
+			name: "hook records are malformed JSON",
+			beforeFunc: func(t *testing.T, artsDir *ArtifactsDir) {
+				t.Helper()
+
+				err := artsDir.CreateChangesetDir("0001_malformed")
+				require.NoError(t, err)
+
+				err = os.WriteFile(
+					filepath.Join(artsDir.ChangesetDirPath("0001_malformed"), "xxx-ccip-staging-0001_malformed_hook_records.json"),
+					[]byte("malformed"),
+					0600,
+				)
+				require.NoError(t, err)
+			},
+			giveCsKey: "0001_malformed",
+			wantErr:   "unable to unmarshal data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture := setupTestDomainsFS(t)
+			artsDir := fixture.artifactsDir
+
+			if tt.giveTimestamp != "" {
+				require.NoError(t, artsDir.SetDurablePipelines(tt.giveTimestamp))
+			}
+
+			if tt.beforeFunc != nil {
+				tt.beforeFunc(t, artsDir)
+			}
+
+			got, err := artsDir.LoadHookRecordsByChangesetKey(tt.giveCsKey)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Artifacts_AppendHookRecords(t *testing.T) {
+	t.Parallel()
+
+	fixture := setupTestDomainsFS(t)
+	artsDir := fixture.artifactsDir
+
+	_, err := artsDir.LoadHookRecordsLog("0001_initial")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	first := fdeployment.HookRecord{Name: "check", Phase: fdeployment.HookPhasePre, Status: fdeployment.HookStatusFailed}
+	second := fdeployment.HookRecord{Name: "notify", Phase: fdeployment.HookPhasePostProposal, Status: fdeployment.HookStatusPassed}
+
+	require.NoError(t, artsDir.AppendHookRecords("0001_initial", []fdeployment.HookRecord{first}))
+	require.NoError(t, artsDir.AppendHookRecords("0001_initial", []fdeployment.HookRecord{second}))
+
+	got, err := artsDir.LoadHookRecordsLog("0001_initial")
+	require.NoError(t, err)
+	assert.Equal(t, []fdeployment.HookRecord{first, second}, got)
+
+	// The log is not saved in the changeset dir, so the changeset is not considered applied
+	exists, err := artsDir.ChangesetArtifactsExist("0001_initial")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,330 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/internal/jsonutils"
+)
+
+var (
+	// ErrDataStoreManifestNotFound is returned when an environment has no datastore manifest.
+	ErrDataStoreManifestNotFound = errors.New("datastore manifest not found")
+	// ErrDataStoreManifestMismatch is returned when the datastore files do not match the hashes
+	// recorded in the manifest, e.g. because a file was edited by hand.
+	ErrDataStoreManifestMismatch = errors.New("datastore files do not match the manifest")
+	// ErrDataStoreManifestSignature is returned when the manifest signature is missing, invalid or
+	// made by a signer which is not trusted.
+	ErrDataStoreManifestSignature = errors.New("invalid datastore manifest signature")
+)
+
+const (
+	// ManifestSchemeEd25519 identifies manifest signatures made with an ed25519 key. The signer is
+	// the hex encoded public key.
+	ManifestSchemeEd25519 = "ed25519"
+	// ManifestSchemeEVM identifies manifest signatures made with a secp256k1 key over the
+	// keccak256 hash of the payload, such as those produced by the EVM KMS signer. The signer is
+	// the hex encoded address.
+	ManifestSchemeEVM = "evm"
+)
+
+// dataStoreManifestHashPrefix prefixes the hex encoded file hashes in the manifest.
+const dataStoreManifestHashPrefix = "sha256:"
+
+// DataStoreManifest records the content hashes of an environment's datastore files. It is written
+// whenever the framework writes the datastore files, such as by MergeChangesetDataStore, so any
+// other change to the datastore files is detected by VerifyDataStoreManifest.
+type DataStoreManifest struct {
+	// Files maps the datastore file names to their content hashes.
+	Files map[string]string `json:"files"`
+	// Signature is the optional signature over the Files.
+	Signature *ManifestSignature `json:"signature,omitempty"`
+}
+
+// ManifestSignature is a signature over the files of a DataStoreManifest.
+type ManifestSignature struct {
+	// Scheme is the signature scheme, ManifestSchemeEd25519 or ManifestSchemeEVM.
+	Scheme string `json:"scheme"`
+	// Signer identifies the key which made the signature.
+	Signer string `json:"signer"`
+	// Signature is the hex encoded signature.
+	Signature string `json:"signature"`
+}
+
+// payload returns the bytes which are signed. json.Marshal sorts map keys, which makes the
+// payload deterministic.
+func (m DataStoreManifest) payload() ([]byte, error) {
+	return json.Marshal(m.Files)
+}
+
+// ManifestSigner signs datastore manifests.
+type ManifestSigner interface {
+	// SignManifest signs the manifest payload.
+	SignManifest(payload []byte) (ManifestSignature, error)
+}
+
+// ed25519ManifestSigner signs manifests with a local ed25519 key.
+type ed25519ManifestSigner struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519ManifestSigner returns a ManifestSigner which signs with a local ed25519 key.
+func NewEd25519ManifestSigner(key ed25519.PrivateKey) (ManifestSigner, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ed25519 private key length %d", len(key))
+	}
+
+	return &ed25519ManifestSigner{key: key}, nil
+}
+
+// SignManifest implements ManifestSigner.
+func (s *ed25519ManifestSigner) SignManifest(payload []byte) (ManifestSignature, error) {
+	pub, ok := s.key.Public().(ed25519.PublicKey)
+	if !ok {
+		return ManifestSignature{}, errors.New("failed to derive ed25519 public key")
+	}
+
+	return ManifestSignature{
+		Scheme:    ManifestSchemeEd25519,
+		Signer:    hex.EncodeToString(pub),
+		Signature: hex.EncodeToString(ed25519.Sign(s.key, payload)),
+	}, nil
+}
+
+// EVMHashSigner signs a hash with a secp256k1 key, returning a 65 byte [R || S || V] signature.
+// It is implemented by the EVM KMS signer.
+type EVMHashSigner interface {
+	SignHash(hash []byte) ([]byte, error)
+	GetAddress() (common.Address, error)
+}
+
+// evmManifestSigner signs manifests with an EVMHashSigner.
+type evmManifestSigner struct {
+	signer EVMHashSigner
+}
+
+// NewEVMManifestSigner returns a ManifestSigner which signs the keccak256 hash of the manifest
+// with an EVM signer, such as the KMS signer.
+func NewEVMManifestSigner(signer EVMHashSigner) ManifestSigner {
+	return &evmManifestSigner{signer: signer}
+}
+
+// SignManifest implements ManifestSigner.
+func (s *evmManifestSigner) SignManifest(payload []byte) (ManifestSignature, error) {
+	addr, err := s.signer.GetAddress()
+	if err != nil {
+		return ManifestSignature{}, fmt.Errorf("failed to get signer address: %w", err)
+	}
+
+	sig, err := s.signer.SignHash(crypto.Keccak256(payload))
+	if err != nil {
+		return ManifestSignature{}, fmt.Errorf("failed to sign manifest: %w", err)
+	}
+
+	return ManifestSignature{
+		Scheme:    ManifestSchemeEVM,
+		Signer:    addr.Hex(),
+		Signature: hex.EncodeToString(sig),
+	}, nil
+}
+
+// verify checks that the signature is valid for the payload.
+func (s ManifestSignature) verify(payload []byte) error {
+	sig, err := hex.DecodeString(s.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	switch s.Scheme {
+	case ManifestSchemeEd25519:
+		pub, decodeErr := hex.DecodeString(s.Signer)
+		if decodeErr != nil || len(pub) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid ed25519 public key %q", s.Signer)
+		}
+		if !ed25519.Verify(pub, payload, sig) {
+			return errors.New("ed25519 signature does not match")
+		}
+
+		return nil
+	case ManifestSchemeEVM:
+		if !common.IsHexAddress(s.Signer) {
+			return fmt.Errorf("invalid address %q", s.Signer)
+		}
+		pub, recoverErr := crypto.SigToPub(crypto.Keccak256(payload), sig)
+		if recoverErr != nil {
+			return fmt.Errorf("failed to recover signer: %w", recoverErr)
+		}
+		if crypto.PubkeyToAddress(*pub) != common.HexToAddress(s.Signer) {
+			return errors.New("evm signature was not made by the signer")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown signature scheme %q", s.Scheme)
+	}
+}
+
+// DataStoreManifestFilePath returns the path to the datastore manifest file for the domain's
+// environment directory.
+func (d EnvDir) DataStoreManifestFilePath() string {
+	return filepath.Join(d.DirPath(), DatastoreDirName, DataStoreManifestFileName)
+}
+
+// dataStoreFilePaths returns the paths of the datastore files covered by the manifest.
+func (d EnvDir) dataStoreFilePaths() []string {
+	return []string{
+		d.AddressRefsFilePath(),
+		d.ChainMetadataFilePath(),
+		d.ContractMetadataFilePath(),
+		d.EnvMetadataFilePath(),
+	}
+}
+
+// ComputeDataStoreManifest hashes the current datastore files of the environment. The returned
+// manifest is unsigned.
+func (d EnvDir) ComputeDataStoreManifest() (DataStoreManifest, error) {
+	m := DataStoreManifest{Files: make(map[string]string)}
+	for _, path := range d.dataStoreFilePaths() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return DataStoreManifest{}, fmt.Errorf("failed to read datastore file %s: %w", path, err)
+		}
+
+		sum := sha256.Sum256(b)
+		m.Files[filepath.Base(path)] = dataStoreManifestHashPrefix + hex.EncodeToString(sum[:])
+	}
+
+	return m, nil
+}
+
+// LoadDataStoreManifest reads the datastore manifest of the environment. It returns
+// ErrDataStoreManifestNotFound if the environment has no manifest.
+func (d EnvDir) LoadDataStoreManifest() (DataStoreManifest, error) {
+	b, err := os.ReadFile(d.DataStoreManifestFilePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return DataStoreManifest{}, ErrDataStoreManifestNotFound
+		}
+
+		return DataStoreManifest{}, fmt.Errorf("failed to read datastore manifest: %w", err)
+	}
+
+	var m DataStoreManifest
+	if err = json.Unmarshal(b, &m); err != nil {
+		return DataStoreManifest{}, fmt.Errorf("failed to unmarshal datastore manifest: %w", err)
+	}
+
+	return m, nil
+}
+
+// WriteDataStoreManifest hashes the current datastore files and writes the manifest, signing it
+// if a signer is given.
+func (d EnvDir) WriteDataStoreManifest(signer ManifestSigner) error {
+	m, err := d.ComputeDataStoreManifest()
+	if err != nil {
+		return err
+	}
+
+	if signer != nil {
+		payload, payloadErr := m.payload()
+		if payloadErr != nil {
+			return fmt.Errorf("failed to marshal manifest payload: %w", payloadErr)
+		}
+
+		sig, signErr := signer.SignManifest(payload)
+		if signErr != nil {
+			return fmt.Errorf("failed to sign datastore manifest: %w", signErr)
+		}
+		m.Signature = &sig
+	}
+
+	if err = jsonutils.WriteFile(d.DataStoreManifestFilePath(), m); err != nil {
+		return fmt.Errorf("failed to write datastore manifest: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyDataStoreManifest checks the datastore files of the environment against its manifest.
+//
+// It returns ErrDataStoreManifestNotFound if there is no manifest, and
+// ErrDataStoreManifestMismatch if any file differs from its recorded hash.
+//
+// The signature is only checked when trusted signers are given, in which case the manifest must
+// be validly signed by one of them, otherwise ErrDataStoreManifestSignature is returned. Without
+// trusted signers the signature proves nothing, since anyone who can edit the datastore files can
+// recompute the hashes and sign them with a key of their own, so it is ignored. Signers are
+// compared without regard to case, so EVM addresses may be given with or without checksums.
+func (d EnvDir) VerifyDataStoreManifest(trustedSigners ...string) error {
+	want, err := d.LoadDataStoreManifest()
+	if err != nil {
+		return err
+	}
+
+	got, err := d.ComputeDataStoreManifest()
+	if err != nil {
+		return err
+	}
+
+	var mismatched []string
+	for name, hash := range got.Files {
+		if want.Files[name] != hash {
+			mismatched = append(mismatched, name)
+		}
+	}
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+
+		return fmt.Errorf("%w: %s", ErrDataStoreManifestMismatch, strings.Join(mismatched, ", "))
+	}
+
+	if len(trustedSigners) == 0 {
+		return nil
+	}
+
+	if want.Signature == nil {
+		return fmt.Errorf("%w: manifest is not signed", ErrDataStoreManifestSignature)
+	}
+
+	payload, err := want.payload()
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest payload: %w", err)
+	}
+	if err = want.Signature.verify(payload); err != nil {
+		return fmt.Errorf("%w: %w", ErrDataStoreManifestSignature, err)
+	}
+
+	if !slices.ContainsFunc(trustedSigners, func(s string) bool {
+		return strings.EqualFold(s, want.Signature.Signer)
+	}) {
+		return fmt.Errorf("%w: signer %s is not trusted", ErrDataStoreManifestSignature, want.Signature.Signer)
+	}
+
+	return nil
+}
+
+// ValidateManifestSigner checks that a trusted signer is a hex encoded ed25519 public key or an
+// EVM address.
+func ValidateManifestSigner(signer string) error {
+	if common.IsHexAddress(signer) {
+		return nil
+	}
+
+	if pub, err := hex.DecodeString(signer); err == nil && len(pub) == ed25519.PublicKeySize {
+		return nil
+	}
+
+	return fmt.Errorf("invalid manifest signer %q: must be a hex encoded ed25519 public key or an EVM address", signer)
+}
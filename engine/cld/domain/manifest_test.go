@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localEVMSigner is an EVMHashSigner backed by a local secp256k1 key.
+type localEVMSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s localEVMSigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+func (s localEVMSigner) GetAddress() (common.Address, error) {
+	return crypto.PubkeyToAddress(s.key.PublicKey), nil
+}
+
+func Test_EnvDir_DataStoreManifest(t *testing.T) {
+	t.Parallel()
+
+	edKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	edSigner, err := NewEd25519ManifestSigner(edKey)
+	require.NoError(t, err)
+	edPub := hex.EncodeToString(edKey.Public().(ed25519.PublicKey))
+
+	evmKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	evmSigner := NewEVMManifestSigner(localEVMSigner{key: evmKey})
+	evmAddr := crypto.PubkeyToAddress(evmKey.PublicKey).Hex()
+
+	tests := []struct {
+		name           string
+		signer         ManifestSigner
+		beforeVerify   func(*testing.T, EnvDir)
+		trustedSigners []string
+		wantErr        error
+		wantErrMsg     string
+	}{
+		{
+			name: "unsigned manifest matches",
+		},
+		{
+			name:           "ed25519 signed by trusted signer",
+			signer:         edSigner,
+			trustedSigners: []string{edPub},
+		},
+		{
+			name:           "evm signed by trusted signer with different case",
+			signer:         evmSigner,
+			trustedSigners: []string{"0x" + hex.EncodeToString(common.HexToAddress(evmAddr).Bytes())},
+		},
+		{
+			name: "no manifest",
+			beforeVerify: func(t *testing.T, envDir EnvDir) {
+				t.Helper()
+				require.NoError(t, os.Remove(envDir.DataStoreManifestFilePath()))
+			},
+			wantErr: ErrDataStoreManifestNotFound,
+		},
+		{
+			name:   "edited file",
+			signer: edSigner,
+			beforeVerify: func(t *testing.T, envDir EnvDir) {
+				t.Helper()
+				require.NoError(t, os.WriteFile(envDir.ContractMetadataFilePath(), []byte(`[{}]`), 0o600))
+			},
+			wantErr:    ErrDataStoreManifestMismatch,
+			wantErrMsg: ContractMetadataFileName,
+		},
+		{
+			name:           "unsigned manifest with trusted signers",
+			trustedSigners: []string{edPub},
+			wantErr:        ErrDataStoreManifestSignature,
+			wantErrMsg:     "manifest is not signed",
+		},
+		{
+			name:           "untrusted signer",
+			signer:         evmSigner,
+			trustedSigners: []string{edPub},
+			wantErr:        ErrDataStoreManifestSignature,
+			wantErrMsg:     "is not trusted",
+		},
+		{
+			name:   "tampered signature",
+			signer: edSigner,
+			beforeVerify: func(t *testing.T, envDir EnvDir) {
+				t.Helper()
+
+				// Regenerate the hashes after editing a file, keeping the old signature
+				m, err := envDir.LoadDataStoreManifest()
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(envDir.AddressRefsFilePath(), []byte(`[{}]`), 0o600))
+				require.NoError(t, envDir.WriteDataStoreManifest(staticSigner{sig: *m.Signature}))
+			},
+			trustedSigners: []string{edPub},
+			wantErr:        ErrDataStoreManifestSignature,
+			wantErrMsg:     "ed25519 signature does not match",
+		},
+		{
+			name:   "tampered signature is ignored without trusted signers",
+			signer: edSigner,
+			beforeVerify: func(t *testing.T, envDir EnvDir) {
+				t.Helper()
+
+				require.NoError(t, os.WriteFile(envDir.AddressRefsFilePath(), []byte(`[{}]`), 0o600))
+				require.NoError(t, envDir.WriteDataStoreManifest(staticSigner{sig: ManifestSignature{
+					Scheme: ManifestSchemeEd25519, Signer: edPub, Signature: "00",
+				}}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			envDir := setupTestDomainsFS(t).envDir
+			require.NoError(t, envDir.WriteDataStoreManifest(tt.signer))
+
+			if tt.beforeVerify != nil {
+				tt.beforeVerify(t, envDir)
+			}
+
+			err := envDir.VerifyDataStoreManifest(tt.trustedSigners...)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// staticSigner returns a fixed signature regardless of the payload.
+type staticSigner struct {
+	sig ManifestSignature
+}
+
+func (s staticSigner) SignManifest(_ []byte) (ManifestSignature, error) {
+	return s.sig, nil
+}
+
+func Test_NewEd25519ManifestSigner_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEd25519ManifestSigner(ed25519.PrivateKey{1, 2, 3})
+	require.ErrorContains(t, err, "invalid ed25519 private key length 3")
+}
+
+func Test_ValidateManifestSigner(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateManifestSigner("0x"+hex.EncodeToString(bytes.Repeat([]byte{1}, common.AddressLength))))
+	require.NoError(t, ValidateManifestSigner(hex.EncodeToString(bytes.Repeat([]byte{1}, ed25519.PublicKeySize))))
+	require.ErrorContains(t, ValidateManifestSigner("abc"), `invalid manifest signer "abc"`)
+}
@@ -38,6 +38,10 @@ const (
 	// ContractMetadataFileName is the name of the file containing the contract metadata.
 	ContractMetadataFileName = "contract_metadata.json"
 
+	// DataStoreManifestFileName is the name of the file containing the content hashes of the
+	// datastore files.
+	DataStoreManifestFileName = "manifest.json"
+
 	// DomainConfigDirName is the name of the directory containing the domain's config directory.
 	DomainConfigDirName = ".config"
 
@@ -73,6 +77,10 @@ const (
 	// OperationsReportsDirName is the name of the directory containing operations reports.[
 	OperationsReportsDirName = "operations_reports"
 
+	// HookRecordsDirName is the name of the directory containing the hook records which are not
+	// saved with the changeset artifacts.
+	HookRecordsDirName = "hook_records"
+
 	// ViewStateFileName is the name of the file containing the view state of the
 	// environment.
 	ViewStateFileName = "state.json"
@@ -0,0 +1,159 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	fchain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/cre"
+	fdatastore "github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/chains"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config"
+	fdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+// LoadDryRun loads a deployment environment which changesets can be applied to without side
+// effects on real networks, the Job Distributor or the datastore.
+//
+//   - EVM chains are forks of the real networks, loaded with LoadFork.
+//   - Chains of families which cannot be forked are loaded as read-only chains, so any attempt to
+//     send a transaction on them fails instead of reaching the network.
+//   - The Job Distributor is a dry-run client which performs read operations only.
+//   - The CRE runner is read-only: its CLI only runs cre.DefaultReadOnlyCommands and it has no Go
+//     API client.
+//   - The datastore is an in-memory copy of the environment datastore, so nothing written to it
+//     is persisted.
+func LoadDryRun(
+	ctx context.Context,
+	domain fdomain.Domain,
+	envKey string,
+	opts ...LoadEnvironmentOption,
+) (fdeployment.Environment, error) {
+	loadcfg, err := newLoadConfig()
+	if err != nil {
+		return fdeployment.Environment{}, err
+	}
+	loadcfg.Configure(opts)
+
+	lggr := loadcfg.lggr
+
+	cfg, err := config.Load(domain, envKey, lggr)
+	if err != nil {
+		return fdeployment.Environment{}, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	forkOpts := append(append([]LoadEnvironmentOption{}, opts...), WithDryRunJobDistributor())
+	fork, err := LoadFork(ctx, domain, envKey, nil, forkOpts...)
+	if err != nil {
+		return fdeployment.Environment{}, fmt.Errorf("failed to load forked environment: %w", err)
+	}
+
+	blockChains, err := withReadOnlyUnforkedChains(ctx, cfg, loadcfg, fork.BlockChains)
+	if err != nil {
+		return fdeployment.Environment{}, err
+	}
+
+	ds := fdatastore.NewMemoryDataStore()
+	if fork.DataStore != nil {
+		if err = ds.Merge(fork.DataStore); err != nil {
+			return fdeployment.Environment{}, fmt.Errorf("failed to copy datastore: %w", err)
+		}
+	}
+
+	env := fork.Environment
+	env.Name = envKey
+	env.BlockChains = blockChains
+	env.DataStore = ds.Seal()
+	env.CRERunner = cre.NewReadOnlyRunner(fork.CRERunner)
+	env.OperationsBundle = operations.NewBundle(env.GetContext, lggr, loadcfg.reporter,
+		operations.WithOperationRegistry(loadcfg.operationRegistry),
+	)
+
+	return env, nil
+}
+
+// withReadOnlyUnforkedChains adds read-only chains for the selectors to load which are of a family
+// that cannot be forked.
+func withReadOnlyUnforkedChains(
+	ctx context.Context, cfg *config.Config, loadcfg *LoadConfig, forked fchain.BlockChains,
+) (fchain.BlockChains, error) {
+	loaded, err := loadUnforkableChains(ctx, cfg, loadcfg)
+	if err != nil {
+		return fchain.BlockChains{}, err
+	}
+
+	all := maps.Collect(forked.All())
+	if len(loaded.ListChainSelectors()) == 0 {
+		return fchain.NewBlockChains(all), nil
+	}
+
+	readOnly, err := loaded.ReadOnly()
+	if err != nil {
+		return fchain.BlockChains{}, err
+	}
+	maps.Insert(all, readOnly.All())
+
+	return fchain.NewBlockChains(all), nil
+}
+
+// LoadUnforkableChains loads the chains of the environment whose family cannot be forked, such as
+// Solana and Aptos chains, from their real networks. Transactions sent on the returned chains
+// reach the real networks, so callers must make them read-only, as LoadDryRun does, or replace
+// their transaction functions, as cost.NewTracker does.
+func LoadUnforkableChains(
+	ctx context.Context,
+	domain fdomain.Domain,
+	envKey string,
+	opts ...LoadEnvironmentOption,
+) (fchain.BlockChains, error) {
+	loadcfg, err := newLoadConfig()
+	if err != nil {
+		return fchain.BlockChains{}, err
+	}
+	loadcfg.Configure(opts)
+
+	cfg, err := config.Load(domain, envKey, loadcfg.lggr)
+	if err != nil {
+		return fchain.BlockChains{}, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return loadUnforkableChains(ctx, cfg, loadcfg)
+}
+
+// loadUnforkableChains loads the chains for the selectors to load which are of a family that
+// cannot be forked.
+func loadUnforkableChains(ctx context.Context, cfg *config.Config, loadcfg *LoadConfig) (fchain.BlockChains, error) {
+	selectors := cfg.Networks.ChainSelectors()
+	if loadcfg.chainSelectorsToLoad != nil {
+		selectors = loadcfg.chainSelectorsToLoad
+	}
+
+	unforkable := make([]uint64, 0, len(selectors))
+	for _, selector := range selectors {
+		family, err := chainsel.GetSelectorFamily(selector)
+		if err != nil {
+			return fchain.BlockChains{}, fmt.Errorf("failed to get family for chain selector %d: %w", selector, err)
+		}
+		if family != chainsel.FamilyEVM {
+			unforkable = append(unforkable, selector)
+		}
+	}
+
+	if len(unforkable) == 0 {
+		return fchain.NewBlockChains(nil), nil
+	}
+
+	loadcfg.lggr.Infow("Loading chains for families which cannot be forked", "chains", unforkable)
+
+	loaded, err := chains.LoadChains(ctx, loadcfg.lggr, cfg, unforkable)
+	if err != nil {
+		return fchain.BlockChains{}, fmt.Errorf("failed to load chains: %w", err)
+	}
+
+	return loaded, nil
+}
@@ -0,0 +1,65 @@
+package environment
+
+import (
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fchain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config"
+	cfgnet "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/config/network"
+	fdomain "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+func Test_LoadDryRun_InvalidEnvironment(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadDryRun(t.Context(), fdomain.NewDomain("dummy", "test"), "non_existent_env",
+		WithLogger(logger.Test(t)),
+	)
+	require.ErrorContains(t, err, "failed to load config")
+}
+
+func Test_withReadOnlyUnforkedChains(t *testing.T) {
+	t.Parallel()
+
+	evmSelector := chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector
+	forked := fchain.NewBlockChains(map[uint64]fchain.BlockChain{
+		evmSelector: evm.Chain{Selector: evmSelector},
+	})
+	cfg := &config.Config{
+		Networks: cfgnet.NewConfig([]cfgnet.Network{{ChainSelector: evmSelector}}),
+	}
+
+	t.Run("only forkable chains", func(t *testing.T) {
+		t.Parallel()
+
+		loadcfg := &LoadConfig{lggr: logger.Test(t)}
+
+		got, err := withReadOnlyUnforkedChains(t.Context(), cfg, loadcfg, forked)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{evmSelector}, got.ListChainSelectors())
+	})
+
+	t.Run("invalid chain selector", func(t *testing.T) {
+		t.Parallel()
+
+		loadcfg := &LoadConfig{lggr: logger.Test(t), chainSelectorsToLoad: []uint64{1}}
+
+		_, err := withReadOnlyUnforkedChains(t.Context(), cfg, loadcfg, forked)
+		require.ErrorContains(t, err, "failed to get family for chain selector 1")
+	})
+}
+
+func Test_LoadUnforkableChains_InvalidEnvironment(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadUnforkableChains(t.Context(), fdomain.NewDomain("dummy", "test"), "non_existent_env",
+		WithLogger(logger.Test(t)),
+	)
+	require.ErrorContains(t, err, "failed to load config")
+}
@@ -33,6 +33,10 @@ func Load(
 	}
 	loadcfg.Configure(opts)
 
+	if loadcfg.dryRun {
+		return LoadDryRun(ctx, domain, envKey, opts...)
+	}
+
 	var (
 		lggr   = loadcfg.lggr
 		envdir = domain.EnvDir(envKey)
@@ -169,16 +173,57 @@ func LoadDataStore(
 			return nil, fmt.Errorf("catalog GRPC endpoint is required when datastore location is set to '%s'", cfgdomain.DatastoreTypeCatalog)
 		}
 	} else {
-		ds, err = domain.EnvDir(envKey).DataStore()
+		envdir := domain.EnvDir(envKey)
+		ds, err = envdir.DataStore()
 		if err != nil {
 			return nil, err
 		}
+
+		if err = verifyDataStoreManifest(envdir, cfg, loadcfg); err != nil {
+			return nil, err
+		}
 		loadcfg.lggr.Infow("Using file-based datastore")
 	}
 
 	return ds, nil
 }
 
+// verifyDataStoreManifest checks the file-based datastore of the environment against its
+// manifest. The signers trusted to sign the manifest are taken from the load options, falling back
+// to the domain config.
+//
+// A missing manifest is only logged as a warning until one has been written, so environments
+// created before manifests existed still load. When trusted signers are configured the manifest is
+// required, since removing it would otherwise bypass the signature check.
+func verifyDataStoreManifest(envdir clddomain.EnvDir, cfg *config.Config, loadcfg *LoadConfig) error {
+	trustedSigners := loadcfg.trustedManifestSigners
+	if trustedSigners == nil {
+		trustedSigners = cfg.TrustedManifestSigners
+	}
+
+	err := envdir.VerifyDataStoreManifest(trustedSigners...)
+	switch {
+	case err == nil:
+		if len(trustedSigners) == 0 {
+			loadcfg.lggr.Infow("Datastore matches the manifest, signature not verified as no trusted signers are configured", "env", envdir.Key())
+		}
+
+		return nil
+	case loadcfg.skipDataStoreManifestVerification:
+		loadcfg.lggr.Warnw("Override: datastore verification failed", "env", envdir.Key(), "err", err)
+
+		return nil
+	case errors.Is(err, clddomain.ErrDataStoreManifestNotFound) && len(trustedSigners) == 0:
+		loadcfg.lggr.Warnw("Datastore has no manifest, run the datastore write-manifest command after reviewing the datastore files", "env", envdir.Key())
+
+		return nil
+	case errors.Is(err, clddomain.ErrDataStoreManifestNotFound):
+		return fmt.Errorf("failed to verify datastore for %s: %w (run the datastore write-manifest command after reviewing the datastore files)", envdir, err)
+	default:
+		return fmt.Errorf("failed to verify datastore for %s: %w", envdir, err)
+	}
+}
+
 func useCatalog(datastoreType cfgdomain.DatastoreType) bool {
 	return datastoreType == cfgdomain.DatastoreTypeCatalog || datastoreType == cfgdomain.DatastoreTypeAll
 }
@@ -1,6 +1,9 @@
 package environment
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
 	"os"
 	"path/filepath"
 	"testing"
@@ -46,6 +49,106 @@ func Test_Load_DataStoreFailure(t *testing.T) {
 	assert.Contains(t, err.Error(), "address_refs.json: no such file or directory")
 }
 
+func Test_Load_DataStoreManifest(t *testing.T) {
+	t.Parallel()
+
+	signerKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	signerPub := hex.EncodeToString(signerKey.Public().(ed25519.PublicKey))
+
+	removeManifest := func(t *testing.T, domain fdomain.Domain) {
+		t.Helper()
+		require.NoError(t, os.Remove(domain.EnvDir("staging").DataStoreManifestFilePath()))
+	}
+	signManifest := func(t *testing.T, domain fdomain.Domain) {
+		t.Helper()
+		signer, err := fdomain.NewEd25519ManifestSigner(signerKey)
+		require.NoError(t, err)
+		require.NoError(t, domain.EnvDir("staging").WriteDataStoreManifest(signer))
+	}
+	editAddressRefs := func(t *testing.T, domain fdomain.Domain) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(domain.EnvDir("staging").AddressRefsFilePath(), []byte(`[ ]`), 0600))
+	}
+	trustSignerInDomainConfig := func(t *testing.T, domain fdomain.Domain) {
+		t.Helper()
+		domainYAML := "environments:\n" +
+			"  staging:\n" +
+			"    network_types: [testnet]\n" +
+			"    datastore_manifest:\n" +
+			"      trusted_signers: [" + signerPub + "]\n"
+		require.NoError(t, os.WriteFile(domain.ConfigDomainFilePath(), []byte(domainYAML), 0600))
+	}
+
+	tests := []struct {
+		name    string
+		setup   []func(t *testing.T, domain fdomain.Domain)
+		opts    []LoadEnvironmentOption
+		wantErr error
+	}{
+		{
+			name: "matching manifest",
+		},
+		{
+			name:  "missing manifest",
+			setup: []func(t *testing.T, domain fdomain.Domain){removeManifest},
+		},
+		{
+			name:    "missing manifest with trusted signers",
+			setup:   []func(t *testing.T, domain fdomain.Domain){trustSignerInDomainConfig, removeManifest},
+			wantErr: fdomain.ErrDataStoreManifestNotFound,
+		},
+		{
+			name:  "missing manifest with trusted signers and override",
+			setup: []func(t *testing.T, domain fdomain.Domain){trustSignerInDomainConfig, removeManifest},
+			opts:  []LoadEnvironmentOption{WithoutDataStoreManifestVerification()},
+		},
+		{
+			name:    "edited file",
+			setup:   []func(t *testing.T, domain fdomain.Domain){editAddressRefs},
+			wantErr: fdomain.ErrDataStoreManifestMismatch,
+		},
+		{
+			name:  "edited file with override",
+			setup: []func(t *testing.T, domain fdomain.Domain){editAddressRefs},
+			opts:  []LoadEnvironmentOption{WithoutDataStoreManifestVerification()},
+		},
+		{
+			name:    "unsigned manifest with trusted signers from domain config",
+			setup:   []func(t *testing.T, domain fdomain.Domain){trustSignerInDomainConfig},
+			wantErr: fdomain.ErrDataStoreManifestSignature,
+		},
+		{
+			name:  "signed manifest with trusted signers from domain config",
+			setup: []func(t *testing.T, domain fdomain.Domain){trustSignerInDomainConfig, signManifest},
+		},
+		{
+			name:    "trusted signers option overrides domain config",
+			setup:   []func(t *testing.T, domain fdomain.Domain){trustSignerInDomainConfig, signManifest},
+			opts:    []LoadEnvironmentOption{WithTrustedManifestSigners("0x0000000000000000000000000000000000000001")},
+			wantErr: fdomain.ErrDataStoreManifestSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			setup := append([]func(t *testing.T, domain fdomain.Domain){
+				setupTestConfig, setupAddressbook, setupDataStore, setupNodes,
+			}, tt.setup...)
+			domain := setupTest(t, setup...)
+
+			opts := append([]LoadEnvironmentOption{WithoutJD(), OnlyLoadChainsFor([]uint64{})}, tt.opts...)
+			_, err := Load(t.Context(), domain, "staging", opts...)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_Load_LoadNodesFailure(t *testing.T) {
 	t.Parallel()
 
@@ -231,6 +334,8 @@ func setupDataStore(t *testing.T, domain fdomain.Domain) {
 	// Create env metadata file
 	envMetadataPath := filepath.Join(env.DataStoreDirPath(), "env_metadata.json")
 	require.NoError(t, os.WriteFile(envMetadataPath, []byte(envMetadataConfig), 0600))
+
+	require.NoError(t, env.WriteDataStoreManifest(nil))
 }
 
 func setupTestConfigWithCREAPIKey(t *testing.T, domain fdomain.Domain) {
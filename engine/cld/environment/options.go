@@ -46,6 +46,17 @@ type LoadConfig struct {
 	datastoreType *cfgdomain.DatastoreType
 
 	creRunner cre.Runner
+
+	// skipDataStoreManifestVerification allows loading a file-based datastore whose files do not
+	// match the datastore manifest, or which has no manifest.
+	skipDataStoreManifestVerification bool
+
+	// trustedManifestSigners, when set, requires the datastore manifest to be signed by one of
+	// these signers. Overrides the trusted signers from the domain config.
+	trustedManifestSigners []string
+
+	// dryRun makes Load return an environment loaded with LoadDryRun.
+	dryRun bool
 }
 
 // Configure applies a slice of LoadEnvironmentOption functions to the LoadConfig.
@@ -199,3 +210,35 @@ func WithCRERunner(r cre.Runner) LoadEnvironmentOption {
 		o.creRunner = r
 	}
 }
+
+// WithoutDataStoreManifestVerification allows the environment to load a file-based datastore
+// whose files do not match the datastore manifest, or which has no manifest while trusted signers
+// are configured. The failure is logged as a warning instead of failing the load.
+//
+// By default, the datastore files are verified against the manifest written by the datastore merge
+// command, which detects hand edits to files such as address_refs.json. Use this option only after
+// reviewing the changes to the datastore files.
+func WithoutDataStoreManifestVerification() LoadEnvironmentOption {
+	return func(o *LoadConfig) {
+		o.skipDataStoreManifestVerification = true
+	}
+}
+
+// WithTrustedManifestSigners requires the datastore manifest to be signed by one of the given
+// signers: hex encoded ed25519 public keys or EVM addresses. By default the trusted signers are
+// read from the datastore_manifest section of the environment in the domain config, and the
+// manifest signature is not verified if there are none.
+func WithTrustedManifestSigners(signers ...string) LoadEnvironmentOption {
+	return func(o *LoadConfig) {
+		o.trustedManifestSigners = signers
+	}
+}
+
+// WithDryRun configures Load to return an environment which changesets can be applied to without
+// side effects. EVM chains are forked, chains of other families are read-only, the Job
+// Distributor is a dry-run client and the datastore is not persisted. See LoadDryRun.
+func WithDryRun() LoadEnvironmentOption {
+	return func(o *LoadConfig) {
+		o.dryRun = true
+	}
+}
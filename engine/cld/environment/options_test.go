@@ -113,6 +113,18 @@ func Test_WithDryRunJobDistributor(t *testing.T) {
 	assert.True(t, opts.useDryRunJobDistributor)
 }
 
+func Test_WithDryRun(t *testing.T) {
+	t.Parallel()
+
+	opts := &LoadConfig{}
+	require.False(t, opts.dryRun)
+
+	option := WithDryRun()
+	option(opts)
+
+	assert.True(t, opts.dryRun)
+}
+
 func Test_WithCRERunner(t *testing.T) {
 	t.Parallel()
 
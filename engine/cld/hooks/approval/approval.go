@@ -0,0 +1,354 @@
+// Package approval provides a pre-hook which gates changesets on signed
+// approvals of their exact config.
+//
+// Approvers sign the [Digest] of the changeset key, environment name and
+// canonical JSON encoding of the resolved config with an ed25519 or EVM key,
+// and the signatures are stored in an approvals file under the environment
+// directory (see [FilePath]). [Gate] aborts the changeset unless a quorum of
+// the configured approvers signed the digest of the config it is applied
+// with, so any change to the config requires new approvals.
+//
+// Usage:
+//
+//	Configure(myCS).With(cfg).
+//	    WithPreHooks(approval.Gate(dom, approval.Policy{
+//	        Quorum: 2,
+//	        Approvers: []approval.Approver{
+//	            {Name: "alice", Signer: "0x..."},
+//	            {Name: "bob", Signer: "d75a98..."},
+//	        },
+//	    }))
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/internal/jsonutils"
+)
+
+// ErrQuorumNotMet is returned by the Gate hook when fewer approvers than the quorum signed the
+// digest of the changeset config.
+var ErrQuorumNotMet = errors.New("approval quorum not met")
+
+const (
+	// SchemeEd25519 identifies approvals signed with an ed25519 key over the digest. The signer is
+	// the hex encoded public key.
+	SchemeEd25519 = "ed25519"
+	// SchemeEVM identifies approvals signed with a secp256k1 key over the keccak256 hash of the
+	// digest, such as those produced by the EVM KMS signer. The signer is the hex encoded address.
+	SchemeEVM = "evm"
+
+	// ApprovalsDirName is the name of the directory of the approvals files in the environment
+	// directory.
+	ApprovalsDirName = "approvals"
+
+	// digestVersion versions the payload of the digest, so that approvals of a different payload
+	// format never verify.
+	digestVersion = "cld-approval/v1"
+	// digestPrefix prefixes the hex encoded digest.
+	digestPrefix = "sha256:"
+)
+
+// Approver is an engineer who may approve changesets.
+type Approver struct {
+	// Name identifies the approver in error messages.
+	Name string
+	// Signer is the hex encoded ed25519 public key or the EVM address of the approver. It is
+	// compared without regard to case.
+	Signer string
+}
+
+// Policy is the set of approvers of a changeset and the number of them who must approve it.
+type Policy struct {
+	Approvers []Approver
+	// Quorum is the number of distinct approvers who must sign the digest.
+	Quorum int
+}
+
+// validate checks that the quorum can be met by the approvers.
+func (p Policy) validate() error {
+	if p.Quorum < 1 || p.Quorum > len(p.Approvers) {
+		return fmt.Errorf("invalid approval policy: quorum %d must be between 1 and the %d approvers",
+			p.Quorum, len(p.Approvers),
+		)
+	}
+
+	return nil
+}
+
+// Signature is an approval of a digest.
+type Signature struct {
+	// Scheme is the signature scheme, SchemeEd25519 or SchemeEVM.
+	Scheme string `json:"scheme"`
+	// Signer identifies the key which made the signature.
+	Signer string `json:"signer"`
+	// Signature is the hex encoded signature.
+	Signature string `json:"signature"`
+}
+
+// File is the approvals file of a changeset in an environment.
+type File struct {
+	Environment string `json:"environment"`
+	Changeset   string `json:"changeset"`
+	// Digest is the digest the signatures were made over. It is informational: the Gate hook
+	// always verifies the signatures against the digest of the config being applied.
+	Digest     string      `json:"digest"`
+	Signatures []Signature `json:"signatures"`
+}
+
+// AddSignature adds the signature to the file, replacing any previous signature by the same
+// signer.
+func (f *File) AddSignature(sig Signature) {
+	f.Signatures = slices.DeleteFunc(f.Signatures, func(s Signature) bool {
+		return strings.EqualFold(s.Signer, sig.Signer)
+	})
+	f.Signatures = append(f.Signatures, sig)
+}
+
+// FilePath returns the path of the approvals file of the changeset in the environment directory.
+func FilePath(envDir domain.EnvDir, changesetKey string) string {
+	return filepath.Join(envDir.DirPath(), ApprovalsDirName, changesetKey+".json")
+}
+
+// LoadFile loads the approvals file at path. It returns an error wrapping os.ErrNotExist if
+// there is no such file.
+func LoadFile(path string) (File, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read approvals file: %w", err)
+	}
+
+	var f File
+	if err = json.Unmarshal(b, &f); err != nil {
+		return File{}, fmt.Errorf("failed to unmarshal approvals file %s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+// WriteFile writes the approvals file at path, creating its directory if needed.
+func WriteFile(path string, f File) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create approvals directory: %w", err)
+	}
+	if err := jsonutils.WriteFile(path, f); err != nil {
+		return fmt.Errorf("failed to write approvals file: %w", err)
+	}
+
+	return nil
+}
+
+// Digest returns the digest approvers sign to approve applying the changeset with the config in
+// the environment, in the form "sha256:<hex>".
+//
+// It is the SHA-256 hash of a versioned JSON payload of the environment, changeset key and the
+// canonical JSON encoding of the config, in which object keys are sorted, so the digest does not
+// depend on the field order of the config.
+func Digest(envName, changesetKey string, config any) (string, error) {
+	canonical, err := canonicalJSON(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	payload, err := json.Marshal(struct {
+		Version     string          `json:"version"`
+		Environment string          `json:"environment"`
+		Changeset   string          `json:"changeset"`
+		Config      json.RawMessage `json:"config"`
+	}{digestVersion, envName, changesetKey, canonical})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode digest payload: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+
+	return digestPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON encodes v as JSON with sorted object keys. Numbers are preserved as they are
+// encoded.
+func canonicalJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var generic any
+	if err = dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	// json.Marshal sorts map keys
+	return json.Marshal(generic)
+}
+
+// digestBytes decodes a digest returned by Digest.
+func digestBytes(digest string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(digest, digestPrefix))
+	if err != nil || len(b) != sha256.Size || !strings.HasPrefix(digest, digestPrefix) {
+		return nil, fmt.Errorf("invalid digest %q", digest)
+	}
+
+	return b, nil
+}
+
+// verify checks that the signature is valid for the digest payload.
+func (s Signature) verify(payload []byte) error {
+	sig, err := hex.DecodeString(strings.TrimPrefix(s.Signature, "0x"))
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	switch s.Scheme {
+	case SchemeEd25519:
+		pub, decodeErr := hex.DecodeString(s.Signer)
+		if decodeErr != nil || len(pub) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid ed25519 public key %q", s.Signer)
+		}
+		if !ed25519.Verify(pub, payload, sig) {
+			return errors.New("ed25519 signature does not match")
+		}
+
+		return nil
+	case SchemeEVM:
+		if !common.IsHexAddress(s.Signer) {
+			return fmt.Errorf("invalid address %q", s.Signer)
+		}
+		pub, recoverErr := crypto.SigToPub(crypto.Keccak256(payload), sig)
+		if recoverErr != nil {
+			return fmt.Errorf("failed to recover signer: %w", recoverErr)
+		}
+		if crypto.PubkeyToAddress(*pub) != common.HexToAddress(s.Signer) {
+			return errors.New("evm signature was not made by the signer")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown signature scheme %q", s.Scheme)
+	}
+}
+
+// SignatureResult is the result of verifying a signature of an approvals file.
+type SignatureResult struct {
+	Signature Signature
+	// Approver is the name of the approver who made the signature, or empty if the signer is not
+	// an approver of the policy.
+	Approver string
+	// Err is the reason the signature is invalid, or nil if it is valid.
+	Err error
+}
+
+// Result is the result of verifying an approvals file against a digest and policy.
+type Result struct {
+	Signatures []SignatureResult
+	// Approved are the names of the approvers with a valid signature, in policy order.
+	Approved []string
+	// Missing are the names of the approvers without a valid signature, in policy order.
+	Missing []string
+}
+
+// Verify verifies the signatures of the approvals file against the digest, and matches their
+// signers with the approvers of the policy. The policy may have no approvers, in which case the
+// signatures are only checked for validity.
+func Verify(f File, digest string, policy Policy) (Result, error) {
+	payload, err := digestBytes(digest)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	approved := make(map[string]bool, len(policy.Approvers))
+	for _, sig := range f.Signatures {
+		res := SignatureResult{Signature: sig, Err: sig.verify(payload)}
+		if i := slices.IndexFunc(policy.Approvers, func(a Approver) bool {
+			return strings.EqualFold(a.Signer, sig.Signer)
+		}); i >= 0 {
+			res.Approver = policy.Approvers[i].Name
+			if res.Err == nil {
+				approved[res.Approver] = true
+			}
+		}
+		result.Signatures = append(result.Signatures, res)
+	}
+
+	for _, a := range policy.Approvers {
+		if approved[a.Name] {
+			result.Approved = append(result.Approved, a.Name)
+		} else {
+			result.Missing = append(result.Missing, a.Name)
+		}
+	}
+
+	return result, nil
+}
+
+// Gate returns a PreHook which aborts the changeset unless at least the quorum of approvers of
+// the policy signed the digest of the changeset config in the approvals file of the environment
+// of the domain. The error lists the missing approvers.
+func Gate(dom domain.Domain, policy Policy) changeset.PreHook {
+	return changeset.PreHook{
+		HookDefinition: changeset.HookDefinition{
+			Name:          "approval-gate",
+			FailurePolicy: changeset.Abort,
+		},
+		Func: func(_ context.Context, params changeset.PreHookParams) error {
+			if err := policy.validate(); err != nil {
+				return err
+			}
+
+			digest, err := Digest(params.Env.Name, params.ChangesetKey, params.Config)
+			if err != nil {
+				return err
+			}
+
+			path := FilePath(dom.EnvDir(params.Env.Name), params.ChangesetKey)
+			f, err := LoadFile(path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			result, err := Verify(f, digest, policy)
+			if err != nil {
+				return err
+			}
+			for _, sig := range result.Signatures {
+				if sig.Err != nil {
+					params.Env.Logger.Warnw("Ignoring invalid approval signature",
+						"changeset", params.ChangesetKey, "signer", sig.Signature.Signer, "error", sig.Err,
+					)
+				}
+			}
+
+			if len(result.Approved) < policy.Quorum {
+				return fmt.Errorf("%w: changeset %q has %d of %d required approvals of digest %s in %s, missing approvers: %s",
+					ErrQuorumNotMet, params.ChangesetKey, len(result.Approved), policy.Quorum, digest, path,
+					strings.Join(result.Missing, ", "),
+				)
+			}
+
+			params.Env.Logger.Infow("Changeset approved",
+				"changeset", params.ChangesetKey, "approvers", result.Approved,
+			)
+
+			return nil
+		},
+	}
+}
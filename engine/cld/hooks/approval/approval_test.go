@@ -0,0 +1,292 @@
+package approval
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+type testApprover struct {
+	approver Approver
+	signer   Signer
+}
+
+func newEd25519Approver(t *testing.T, name string, seed byte) testApprover {
+	t.Helper()
+
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+	signer, err := NewEd25519Signer(key)
+	require.NoError(t, err)
+
+	return testApprover{
+		approver: Approver{Name: name, Signer: hex.EncodeToString(key.Public().(ed25519.PublicKey))},
+		signer:   signer,
+	}
+}
+
+func newEVMApprover(t *testing.T, name string) testApprover {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	return testApprover{
+		approver: Approver{Name: name, Signer: crypto.PubkeyToAddress(key.PublicKey).Hex()},
+		signer:   NewEVMKeySigner(key),
+	}
+}
+
+func sign(t *testing.T, a testApprover, digest string) Signature {
+	t.Helper()
+
+	sig, err := a.signer.Sign(digest)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		B int    `json:"b"`
+		A string `json:"a"`
+	}
+
+	digest, err := Digest("mainnet", "0001_cs", config{B: 1, A: "x"})
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, digest)
+
+	// The digest only depends on the JSON content of the config
+	same, err := Digest("mainnet", "0001_cs", map[string]any{"a": "x", "b": 1})
+	require.NoError(t, err)
+	assert.Equal(t, digest, same)
+
+	for _, other := range []struct {
+		env, key string
+		config   any
+	}{
+		{"testnet", "0001_cs", config{B: 1, A: "x"}},
+		{"mainnet", "0002_cs", config{B: 1, A: "x"}},
+		{"mainnet", "0001_cs", config{B: 2, A: "x"}},
+	} {
+		got, otherErr := Digest(other.env, other.key, other.config)
+		require.NoError(t, otherErr)
+		assert.NotEqual(t, digest, got)
+	}
+
+	_, err = Digest("mainnet", "0001_cs", func() {})
+	require.ErrorContains(t, err, "failed to encode config")
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	alice := newEd25519Approver(t, "alice", 1)
+	bob := newEVMApprover(t, "bob")
+	carol := newEd25519Approver(t, "carol", 3)
+	mallory := newEd25519Approver(t, "mallory", 4)
+
+	digest, err := Digest("mainnet", "0001_cs", map[string]int{"value": 1})
+	require.NoError(t, err)
+	staleDigest, err := Digest("mainnet", "0001_cs", map[string]int{"value": 2})
+	require.NoError(t, err)
+
+	f := File{Signatures: []Signature{
+		sign(t, alice, digest),
+		sign(t, bob, digest),
+		sign(t, carol, staleDigest),
+		sign(t, mallory, digest),
+	}}
+	policy := Policy{Approvers: []Approver{alice.approver, bob.approver, carol.approver}, Quorum: 2}
+
+	result, err := Verify(f, digest, policy)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, result.Approved)
+	assert.Equal(t, []string{"carol"}, result.Missing)
+
+	require.Len(t, result.Signatures, 4)
+	assert.Equal(t, "carol", result.Signatures[2].Approver)
+	require.EqualError(t, result.Signatures[2].Err, "ed25519 signature does not match")
+	assert.Empty(t, result.Signatures[3].Approver)
+	require.NoError(t, result.Signatures[3].Err)
+
+	_, err = Verify(f, "sha256:zz", policy)
+	require.EqualError(t, err, `invalid digest "sha256:zz"`)
+}
+
+func TestSignature_verify(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte{1}, 32)
+
+	tests := []struct {
+		name    string
+		sig     Signature
+		wantErr string
+	}{
+		{
+			name:    "unknown scheme",
+			sig:     Signature{Scheme: "rsa"},
+			wantErr: `unknown signature scheme "rsa"`,
+		},
+		{
+			name:    "invalid signature encoding",
+			sig:     Signature{Scheme: SchemeEd25519, Signature: "zz"},
+			wantErr: "failed to decode signature",
+		},
+		{
+			name:    "invalid ed25519 key",
+			sig:     Signature{Scheme: SchemeEd25519, Signer: "abcd"},
+			wantErr: `invalid ed25519 public key "abcd"`,
+		},
+		{
+			name:    "invalid evm address",
+			sig:     Signature{Scheme: SchemeEVM, Signer: "0x1"},
+			wantErr: `invalid address "0x1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.ErrorContains(t, tt.sig.verify(payload), tt.wantErr)
+		})
+	}
+}
+
+func TestFile_AddSignature(t *testing.T) {
+	t.Parallel()
+
+	f := File{Signatures: []Signature{
+		{Scheme: SchemeEVM, Signer: "0xABC", Signature: "01"},
+		{Scheme: SchemeEd25519, Signer: "def", Signature: "02"},
+	}}
+	f.AddSignature(Signature{Scheme: SchemeEVM, Signer: "0xabc", Signature: "03"})
+
+	assert.Equal(t, []Signature{
+		{Scheme: SchemeEd25519, Signer: "def", Signature: "02"},
+		{Scheme: SchemeEVM, Signer: "0xabc", Signature: "03"},
+	}, f.Signatures)
+}
+
+func TestWriteFile_LoadFile(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "test")
+	path := FilePath(dom.EnvDir("mainnet"), "0001_cs")
+	assert.Equal(t, filepath.Join(dom.EnvDir("mainnet").DirPath(), "approvals", "0001_cs.json"), path)
+
+	_, err := LoadFile(path)
+	require.ErrorContains(t, err, "failed to read approvals file")
+
+	want := File{
+		Environment: "mainnet",
+		Changeset:   "0001_cs",
+		Digest:      "sha256:00",
+		Signatures:  []Signature{{Scheme: SchemeEd25519, Signer: "ab", Signature: "cd"}},
+	}
+	require.NoError(t, WriteFile(path, want))
+
+	got, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestGate(t *testing.T) {
+	t.Parallel()
+
+	alice := newEd25519Approver(t, "alice", 1)
+	bob := newEVMApprover(t, "bob")
+	carol := newEd25519Approver(t, "carol", 3)
+	policy := Policy{Approvers: []Approver{alice.approver, bob.approver, carol.approver}, Quorum: 2}
+
+	config := map[string]int{"value": 1}
+	digest, err := Digest("mainnet", "0001_cs", config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		policy     Policy
+		signatures func() []Signature
+		config     any
+		wantErr    string
+	}{
+		{
+			name:   "quorum met",
+			policy: policy,
+			signatures: func() []Signature {
+				return []Signature{sign(t, alice, digest), sign(t, bob, digest)}
+			},
+			config: config,
+		},
+		{
+			name:   "quorum not met",
+			policy: policy,
+			signatures: func() []Signature {
+				return []Signature{sign(t, bob, digest)}
+			},
+			config:  config,
+			wantErr: "approval quorum not met: changeset \"0001_cs\" has 1 of 2 required approvals of digest " + digest,
+		},
+		{
+			name:   "config changed since approval",
+			policy: policy,
+			signatures: func() []Signature {
+				return []Signature{sign(t, alice, digest), sign(t, bob, digest)}
+			},
+			config:  map[string]int{"value": 2},
+			wantErr: "missing approvers: alice, bob, carol",
+		},
+		{
+			name:    "no approvals file",
+			policy:  policy,
+			config:  config,
+			wantErr: "has 0 of 2 required approvals",
+		},
+		{
+			name:    "invalid policy",
+			policy:  Policy{Approvers: []Approver{alice.approver}, Quorum: 2},
+			config:  config,
+			wantErr: "invalid approval policy: quorum 2 must be between 1 and the 1 approvers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dom := domain.NewDomain(t.TempDir(), "test")
+			if tt.signatures != nil {
+				path := FilePath(dom.EnvDir("mainnet"), "0001_cs")
+				require.NoError(t, WriteFile(path, File{Signatures: tt.signatures()}))
+			}
+
+			hook := Gate(dom, tt.policy)
+			assert.Equal(t, "approval-gate", hook.Name)
+			assert.Equal(t, changeset.Abort, hook.FailurePolicy)
+
+			err := hook.Func(t.Context(), changeset.PreHookParams{
+				Env:          changeset.HookEnv{Name: "mainnet", Logger: logger.Test(t)},
+				ChangesetKey: "0001_cs",
+				Config:       tt.config,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
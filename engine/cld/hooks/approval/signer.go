@@ -0,0 +1,107 @@
+package approval
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+)
+
+// Signer signs approval digests.
+type Signer interface {
+	// Sign signs the digest returned by Digest.
+	Sign(digest string) (Signature, error)
+}
+
+// ed25519Signer signs digests with a local ed25519 key.
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer returns a Signer which signs with a local ed25519 key.
+func NewEd25519Signer(key ed25519.PrivateKey) (Signer, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ed25519 private key length %d", len(key))
+	}
+
+	return &ed25519Signer{key: key}, nil
+}
+
+// Sign implements Signer.
+func (s *ed25519Signer) Sign(digest string) (Signature, error) {
+	payload, err := digestBytes(digest)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	pub, ok := s.key.Public().(ed25519.PublicKey)
+	if !ok {
+		return Signature{}, errors.New("failed to derive ed25519 public key")
+	}
+
+	return Signature{
+		Scheme:    SchemeEd25519,
+		Signer:    hex.EncodeToString(pub),
+		Signature: hex.EncodeToString(ed25519.Sign(s.key, payload)),
+	}, nil
+}
+
+// evmSigner signs digests with an EVMHashSigner.
+type evmSigner struct {
+	signer domain.EVMHashSigner
+}
+
+// NewEVMSigner returns a Signer which signs the keccak256 hash of the digest with an EVM signer,
+// such as the KMS signer.
+func NewEVMSigner(signer domain.EVMHashSigner) Signer {
+	return &evmSigner{signer: signer}
+}
+
+// Sign implements Signer.
+func (s *evmSigner) Sign(digest string) (Signature, error) {
+	payload, err := digestBytes(digest)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	addr, err := s.signer.GetAddress()
+	if err != nil {
+		return Signature{}, fmt.Errorf("failed to get signer address: %w", err)
+	}
+
+	sig, err := s.signer.SignHash(crypto.Keccak256(payload))
+	if err != nil {
+		return Signature{}, fmt.Errorf("failed to sign digest: %w", err)
+	}
+
+	return Signature{
+		Scheme:    SchemeEVM,
+		Signer:    addr.Hex(),
+		Signature: hex.EncodeToString(sig),
+	}, nil
+}
+
+// localEVMHashSigner is a domain.EVMHashSigner backed by a local secp256k1 key.
+type localEVMHashSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s localEVMHashSigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+func (s localEVMHashSigner) GetAddress() (common.Address, error) {
+	return crypto.PubkeyToAddress(s.key.PublicKey), nil
+}
+
+// NewEVMKeySigner returns a Signer which signs the keccak256 hash of the digest with a local
+// secp256k1 key.
+func NewEVMKeySigner(key *ecdsa.PrivateKey) Signer {
+	return NewEVMSigner(localEVMHashSigner{key: key})
+}
@@ -0,0 +1,195 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// function is a function parsed from a signature such as "balanceOf(address)(uint256)".
+type function struct {
+	name    string
+	inputs  abi.Arguments
+	outputs abi.Arguments
+}
+
+// parseSignature parses a function signature of the form "name(inputs)(outputs)". Tuple types
+// are not supported.
+func parseSignature(sig string) (function, error) {
+	sig = strings.ReplaceAll(sig, " ", "")
+
+	open := strings.Index(sig, "(")
+	closeIdx := strings.Index(sig, ")")
+	if open <= 0 || closeIdx < open {
+		return function{}, fmt.Errorf("invalid signature %q: expected name(inputs)(outputs)", sig)
+	}
+
+	fn := function{name: sig[:open]}
+	rest := sig[closeIdx+1:]
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return function{}, fmt.Errorf("invalid signature %q: expected name(inputs)(outputs)", sig)
+	}
+
+	var err error
+	if fn.inputs, err = parseArguments(sig[open+1 : closeIdx]); err != nil {
+		return function{}, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	if fn.outputs, err = parseArguments(rest[1 : len(rest)-1]); err != nil {
+		return function{}, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+
+	return fn, nil
+}
+
+func parseArguments(types string) (abi.Arguments, error) {
+	if types == "" {
+		return nil, nil
+	}
+
+	var args abi.Arguments
+	for _, t := range strings.Split(types, ",") {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type %q: %w", t, err)
+		}
+		if typ.T == abi.TupleTy {
+			return nil, errors.New("tuple types are not supported")
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+
+	return args, nil
+}
+
+// selector returns the canonical signature of the function, without outputs.
+func (fn function) selector() []byte {
+	types := make([]string, 0, len(fn.inputs))
+	for _, in := range fn.inputs {
+		types = append(types, in.Type.String())
+	}
+
+	return crypto.Keccak256([]byte(fn.name + "(" + strings.Join(types, ",") + ")"))[:4]
+}
+
+// pack returns the call data of the function with the args in their text form.
+func (fn function) pack(args []string) ([]byte, error) {
+	if len(args) != len(fn.inputs) {
+		return nil, fmt.Errorf("%s expects %d args, got %d", fn.name, len(fn.inputs), len(args))
+	}
+
+	values := make([]any, 0, len(args))
+	for i, arg := range args {
+		v, err := parseValue(fn.inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d of %s: %w", i, fn.name, err)
+		}
+		values = append(values, v)
+	}
+
+	data, err := fn.inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode args of %s: %w", fn.name, err)
+	}
+
+	return append(fn.selector(), data...), nil
+}
+
+// parseValue parses the text form of a value of the ABI type into the Go type used by the abi
+// package.
+func parseValue(typ abi.Type, s string) (any, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address %q", s)
+		}
+
+		return common.HexToAddress(s), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+	case abi.StringTy:
+		return s, nil
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(b))
+		}
+		v := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+
+		return v.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		if typ.GetType() == reflect.TypeFor[*big.Int]() {
+			return n, nil
+		}
+		// Integers of up to 64 bits are packed from the Go integer type of their size
+		v := reflect.New(typ.GetType()).Elem()
+		if typ.T == abi.UintTy {
+			if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+				return nil, fmt.Errorf("integer %q overflows %s", s, typ)
+			}
+			v.SetUint(n.Uint64())
+		} else {
+			if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+				return nil, fmt.Errorf("integer %q overflows %s", s, typ)
+			}
+			v.SetInt(n.Int64())
+		}
+
+		return v.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+// equalValue returns whether the decoded value of the ABI type equals the expected value in its
+// text form.
+func equalValue(typ abi.Type, got any, expect string) (bool, error) {
+	want, err := parseValue(typ, expect)
+	if err != nil {
+		return false, fmt.Errorf("invalid expected value: %w", err)
+	}
+	if typ.T == abi.IntTy || typ.T == abi.UintTy {
+		return formatValue(got) == formatValue(want), nil
+	}
+
+	return reflect.DeepEqual(got, want), nil
+}
+
+// formatValue returns the text form of a decoded value.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+
+		return hexutil.Encode(b)
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,403 @@
+// Package assertions provides a declarative post-hook which checks the state of
+// the environment after a changeset is applied, instead of hand-written
+// verification hooks.
+//
+// Assertions are declared in Go or loaded from YAML (see [Parse]), and can
+// check that a datastore address ref exists, that an EVM view call returns an
+// expected value, that an account holds an AccessControl role or that the
+// native balance of an account is above a threshold. Contracts and accounts
+// are given by address or by the address ref of the datastore, which includes
+// the address refs created by the changeset.
+//
+// Usage:
+//
+//	list, err := assertions.LoadFile("assertions.yaml")
+//	...
+//	Configure(myCS).With(cfg).
+//	    WithPostHooks(assertions.PostHook(list))
+//
+// with a file such as:
+//
+//	assertions:
+//	  - name: router is owned by the timelock
+//	    chainSelector: 16015286601757825753
+//	    evmCall:
+//	      contract: {type: Router, version: 1.2.0}
+//	      signature: owner()(address)
+//	      expectAddress: {type: RBACTimelock, version: 1.0.0}
+//	  - chainSelector: 16015286601757825753
+//	    balance:
+//	      account: {address: "0x1234..."}
+//	      min: "1000000000000000000"
+package assertions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+)
+
+// ErrAssertionsFailed is returned by the PostHook when any assertion fails.
+var ErrAssertionsFailed = errors.New("assertions failed")
+
+// Kinds of assertions.
+const (
+	KindAddressRef = "addressRef"
+	KindEVMCall    = "evmCall"
+	KindRole       = "role"
+	KindBalance    = "balance"
+)
+
+// Assertion is a check of the environment state on a chain. Exactly one of AddressRef, EVMCall,
+// Role and Balance must be set.
+type Assertion struct {
+	// Name describes the assertion in the report. It defaults to a description of the check.
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+	ChainSelector uint64 `json:"chainSelector" yaml:"chainSelector"`
+
+	AddressRef *AddressRefAssertion `json:"addressRef,omitempty" yaml:"addressRef,omitempty"`
+	EVMCall    *EVMCallAssertion    `json:"evmCall,omitempty" yaml:"evmCall,omitempty"`
+	Role       *RoleAssertion       `json:"role,omitempty" yaml:"role,omitempty"`
+	Balance    *BalanceAssertion    `json:"balance,omitempty" yaml:"balance,omitempty"`
+}
+
+// Ref identifies a contract or account on the chain of an assertion, either by Address, or by
+// the Type, and optionally the Version and Qualifier, of a datastore address ref. The ref must
+// match exactly one address ref of the chain.
+type Ref struct {
+	Address   string `json:"address,omitempty" yaml:"address,omitempty"`
+	Type      string `json:"type,omitempty" yaml:"type,omitempty"`
+	Version   string `json:"version,omitempty" yaml:"version,omitempty"`
+	Qualifier string `json:"qualifier,omitempty" yaml:"qualifier,omitempty"`
+}
+
+// String returns the address of the ref, or its type, version and qualifier.
+func (r Ref) String() string {
+	if r.Address != "" {
+		return r.Address
+	}
+
+	s := r.Type
+	if r.Version != "" {
+		s += " " + r.Version
+	}
+	if r.Qualifier != "" {
+		s += " (" + r.Qualifier + ")"
+	}
+
+	return s
+}
+
+// AddressRefAssertion checks that the datastore has exactly one address ref matching the Ref
+// on the chain. If the Ref has an Address, the address ref must have that address, and if it
+// has no Type, the address ref is looked up by its Address.
+type AddressRefAssertion struct {
+	Ref `yaml:",inline"`
+}
+
+// EVMCallAssertion checks the result of calling a view function of an EVM contract.
+type EVMCallAssertion struct {
+	Contract Ref `json:"contract" yaml:"contract"`
+	// Signature is the signature of the function with its output, e.g. "owner()(address)" or
+	// "balanceOf(address)(uint256)". The function must have exactly one output.
+	Signature string `json:"signature" yaml:"signature"`
+	// Args are the arguments of the call, in the text form of their type: hex for addresses and
+	// bytes, decimal or 0x-prefixed hex for integers, and true or false for booleans.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Expect is the expected output, in the text form of its type. Exactly one of Expect and
+	// ExpectAddress must be set.
+	Expect string `json:"expect,omitempty" yaml:"expect,omitempty"`
+	// ExpectAddress is the expected address output, such as the owner of a contract.
+	ExpectAddress *Ref `json:"expectAddress,omitempty" yaml:"expectAddress,omitempty"`
+}
+
+// RoleAssertion checks that an account holds an AccessControl role of an EVM contract.
+type RoleAssertion struct {
+	Contract Ref `json:"contract" yaml:"contract"`
+	// Role is the name of the role, e.g. "MINTER_ROLE", whose keccak256 hash is the role, or the
+	// 0x-prefixed hex role itself. DEFAULT_ADMIN_ROLE is the zero role.
+	Role    string `json:"role" yaml:"role"`
+	Account Ref    `json:"account" yaml:"account"`
+}
+
+// BalanceAssertion checks that the native balance of an EVM account is at least Min.
+type BalanceAssertion struct {
+	Account Ref `json:"account" yaml:"account"`
+	// Min is the minimum balance in wei, as a decimal or 0x-prefixed hex integer.
+	Min string `json:"min" yaml:"min"`
+}
+
+// kind returns the kind of the assertion, or an error unless exactly one kind is set.
+func (a Assertion) kind() (string, error) {
+	var kinds []string
+	if a.AddressRef != nil {
+		kinds = append(kinds, KindAddressRef)
+	}
+	if a.EVMCall != nil {
+		kinds = append(kinds, KindEVMCall)
+	}
+	if a.Role != nil {
+		kinds = append(kinds, KindRole)
+	}
+	if a.Balance != nil {
+		kinds = append(kinds, KindBalance)
+	}
+	if len(kinds) != 1 {
+		return "", fmt.Errorf("expected exactly one of %s, %s, %s and %s, got %d",
+			KindAddressRef, KindEVMCall, KindRole, KindBalance, len(kinds),
+		)
+	}
+
+	return kinds[0], nil
+}
+
+// displayName returns the name of the assertion, or a description of it.
+func (a Assertion) displayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+
+	switch {
+	case a.AddressRef != nil:
+		return fmt.Sprintf("address ref %s exists", a.AddressRef.Ref)
+	case a.EVMCall != nil:
+		return fmt.Sprintf("%s.%s", a.EVMCall.Contract, a.EVMCall.Signature)
+	case a.Role != nil:
+		return fmt.Sprintf("%s holds %s of %s", a.Role.Account, a.Role.Role, a.Role.Contract)
+	case a.Balance != nil:
+		return fmt.Sprintf("balance of %s is at least %s", a.Balance.Account, a.Balance.Min)
+	default:
+		return "invalid assertion"
+	}
+}
+
+// Validate checks that the assertions are well formed.
+func Validate(assertions []Assertion) error {
+	var errs []error
+	for i, a := range assertions {
+		if a.ChainSelector == 0 {
+			errs = append(errs, fmt.Errorf("assertion %d (%s): chainSelector is required", i, a.displayName()))
+		}
+		if _, err := a.kind(); err != nil {
+			errs = append(errs, fmt.Errorf("assertion %d (%s): %w", i, a.displayName(), err))
+		}
+		if a.AddressRef != nil && a.AddressRef.Address == "" && a.AddressRef.Type == "" {
+			errs = append(errs, fmt.Errorf("assertion %d (%s): addressRef requires an address or a type",
+				i, a.displayName(),
+			))
+		}
+		if a.EVMCall != nil && (a.EVMCall.Expect == "") == (a.EVMCall.ExpectAddress == nil) {
+			errs = append(errs, fmt.Errorf("assertion %d (%s): expected exactly one of expect and expectAddress",
+				i, a.displayName(),
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// file is the YAML document of assertions.
+type file struct {
+	Assertions []Assertion `yaml:"assertions"`
+}
+
+// Parse parses a YAML document with a list of assertions under the "assertions" key and
+// validates them.
+func Parse(data []byte) ([]Assertion, error) {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse assertions: %w", err)
+	}
+	if err := Validate(f.Assertions); err != nil {
+		return nil, fmt.Errorf("invalid assertions: %w", err)
+	}
+
+	return f.Assertions, nil
+}
+
+// LoadFile parses the YAML assertions file at path. See Parse.
+func LoadFile(path string) ([]Assertion, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read assertions file: %w", err)
+	}
+
+	return Parse(b)
+}
+
+// Result is the outcome of evaluating an assertion.
+type Result struct {
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	ChainSelector uint64 `json:"chainSelector"`
+	Passed        bool   `json:"passed"`
+	// Detail is the observed value, or why the assertion failed or could not be evaluated.
+	Detail string `json:"detail"`
+}
+
+// Report is the outcome of evaluating a list of assertions, in order.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Failed returns the results of the failed assertions.
+func (r Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if !res.Passed {
+			failed = append(failed, res)
+		}
+	}
+
+	return failed
+}
+
+// WriteTable writes the results as a table with a row per assertion.
+func (r Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "STATUS\tASSERTION\tKIND\tCHAIN\tDETAIL\n")
+	fmt.Fprintf(tw, "------\t---------\t----\t-----\t------\n")
+
+	for _, res := range r.Results {
+		status := "PASS"
+		if !res.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", status, res.Name, res.Kind, res.ChainSelector, res.Detail)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush tabwriter: %w", err)
+	}
+
+	return nil
+}
+
+// Option configures the assertions post-hook.
+type Option func(*config)
+
+type config struct {
+	name          string
+	failurePolicy changeset.FailurePolicy
+	timeout       time.Duration
+}
+
+// WithName overrides the hook name, which defaults to "assertions". Use it to register several
+// assertion hooks.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithFailurePolicy overrides the failure policy of the hook, which defaults to changeset.Warn.
+// With changeset.Abort, a failed assertion fails the pipeline and discards the output of the
+// changeset, even though the changeset was applied.
+func WithFailurePolicy(policy changeset.FailurePolicy) Option {
+	return func(c *config) {
+		c.failurePolicy = policy
+	}
+}
+
+// WithTimeout overrides the timeout of the hook, which defaults to changeset.DefaultHookTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// PostHook returns a PostHook which evaluates the assertions against the environment after the
+// changeset is applied, logs the report as a table, and fails with ErrAssertionsFailed if any
+// assertion fails, which is only logged unless the failure policy is changeset.Abort. Address
+// refs are looked up in the datastore output by the changeset and in the environment datastore.
+// The assertions are skipped if the changeset failed.
+func PostHook(assertions []Assertion, opts ...Option) changeset.PostHook {
+	c := &config{
+		name:          "assertions",
+		failurePolicy: changeset.Warn,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return changeset.PostHook{
+		HookDefinition: changeset.HookDefinition{
+			Name:          c.name,
+			FailurePolicy: c.failurePolicy,
+			Timeout:       c.timeout,
+		},
+		Func: func(ctx context.Context, params changeset.PostHookParams) error {
+			if params.Err != nil {
+				params.Env.Logger.Infow("Skipping assertions of failed changeset", "changeset", params.ChangesetKey)
+				return nil
+			}
+			if err := Validate(assertions); err != nil {
+				return fmt.Errorf("invalid assertions: %w", err)
+			}
+
+			var dataStores []datastore.DataStore
+			if params.Output.DataStore != nil {
+				dataStores = append(dataStores, params.Output.DataStore.Seal())
+			}
+			if params.Env.DataStore != nil {
+				dataStores = append(dataStores, params.Env.DataStore)
+			}
+
+			report := Evaluate(ctx, params.Env.BlockChains, assertions, dataStores...)
+
+			var table strings.Builder
+			if err := report.WriteTable(&table); err != nil {
+				return err
+			}
+			params.Env.Logger.Infof("Assertions of changeset %s:\n%s", params.ChangesetKey, table.String())
+
+			if failed := report.Failed(); len(failed) > 0 {
+				return fmt.Errorf("%w: %d of %d assertions failed:\n%s",
+					ErrAssertionsFailed, len(failed), len(report.Results), table.String(),
+				)
+			}
+
+			return nil
+		},
+	}
+}
+
+// Evaluate evaluates the assertions against the chains, looking up address refs in the
+// datastores in order, and returns a report with a result per assertion.
+func Evaluate(
+	ctx context.Context, blockChains chain.BlockChains, assertions []Assertion, dataStores ...datastore.DataStore,
+) Report {
+	e := &evaluator{blockChains: blockChains, dataStores: dataStores}
+
+	report := Report{Results: make([]Result, 0, len(assertions))}
+	for _, a := range assertions {
+		res := Result{Name: a.displayName(), ChainSelector: a.ChainSelector}
+
+		kind, err := a.kind()
+		if err == nil {
+			res.Kind = kind
+			res.Detail, err = e.evaluate(ctx, a)
+		}
+		if err != nil {
+			res.Detail = err.Error()
+		} else {
+			res.Passed = true
+		}
+
+		report.Results = append(report.Results, res)
+	}
+
+	return report
+}
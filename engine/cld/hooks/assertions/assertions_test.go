@@ -0,0 +1,455 @@
+package assertions
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+var (
+	testSelector = chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector
+
+	routerAddr   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	timelockAddr = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	otherAddr    = common.HexToAddress("0x3000000000000000000000000000000000000003")
+)
+
+// encode ABI encodes the values of the types.
+func encode(t *testing.T, types string, values ...any) []byte {
+	t.Helper()
+
+	args, err := parseArguments(types)
+	require.NoError(t, err)
+	b, err := args.Pack(values...)
+	require.NoError(t, err)
+
+	return b
+}
+
+// isCall matches a call to the contract whose data starts with the selector of the signature.
+func isCall(to common.Address, sig string) any {
+	selector := crypto.Keccak256([]byte(sig))[:4]
+
+	return mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.To != nil && *msg.To == to && bytes.HasPrefix(msg.Data, selector)
+	})
+}
+
+// newTestChains returns chains with an EVM chain whose router is owned by the timelock, grants
+// the MINTER_ROLE to the timelock, and where the timelock has a balance of 100 wei.
+func newTestChains(t *testing.T) chain.BlockChains {
+	t.Helper()
+
+	client := evm.NewMockOnchainClient(t)
+	client.EXPECT().CallContract(mock.Anything, isCall(routerAddr, "owner()"), mock.Anything).
+		Return(encode(t, "address", timelockAddr), nil).Maybe()
+	client.EXPECT().CallContract(mock.Anything, isCall(routerAddr, "getFee(uint64)"), mock.Anything).
+		Return(encode(t, "uint256", big.NewInt(42)), nil).Maybe()
+	client.EXPECT().CallContract(mock.Anything, isCall(routerAddr, "hasRole(bytes32,address)"), mock.Anything).
+		RunAndReturn(func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			minter := crypto.Keccak256Hash([]byte("MINTER_ROLE"))
+			want := append(minter.Bytes(), common.LeftPadBytes(timelockAddr.Bytes(), 32)...)
+
+			return encode(t, "bool", bytes.Equal(msg.Data[4:], want)), nil
+		}).Maybe()
+	client.EXPECT().CallContract(mock.Anything, isCall(otherAddr, "owner()"), mock.Anything).
+		Return(nil, errors.New("execution reverted")).Maybe()
+	client.EXPECT().BalanceAt(mock.Anything, timelockAddr, mock.Anything).Return(big.NewInt(100), nil).Maybe()
+
+	return chain.NewBlockChains(map[uint64]chain.BlockChain{
+		testSelector: evm.Chain{Selector: testSelector, Client: client},
+	})
+}
+
+// newTestDataStore returns a datastore with the router and timelock address refs.
+func newTestDataStore(t *testing.T) datastore.DataStore {
+	t.Helper()
+
+	ds := datastore.NewMemoryDataStore()
+	for _, ref := range []datastore.AddressRef{
+		{ChainSelector: testSelector, Type: "Router", Version: semver.MustParse("1.2.0"), Address: routerAddr.Hex()},
+		{ChainSelector: testSelector, Type: "RBACTimelock", Version: semver.MustParse("1.0.0"), Address: timelockAddr.Hex()},
+	} {
+		require.NoError(t, ds.Addresses().Add(ref))
+	}
+
+	return ds.Seal()
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	list, err := Parse([]byte(`
+assertions:
+  - name: router exists
+    chainSelector: 1
+    addressRef: {type: Router, version: 1.2.0}
+  - chainSelector: 1
+    evmCall:
+      contract: {type: Router}
+      signature: owner()(address)
+      expectAddress: {type: RBACTimelock}
+  - chainSelector: 1
+    role:
+      contract: {address: "0x1000000000000000000000000000000000000001"}
+      role: MINTER_ROLE
+      account: {type: RBACTimelock}
+  - chainSelector: 1
+    balance:
+      account: {type: RBACTimelock}
+      min: "100"
+`))
+	require.NoError(t, err)
+	require.Len(t, list, 4)
+
+	assert.Equal(t, Assertion{
+		Name:          "router exists",
+		ChainSelector: 1,
+		AddressRef:    &AddressRefAssertion{Ref: Ref{Type: "Router", Version: "1.2.0"}},
+	}, list[0])
+	assert.Equal(t, &EVMCallAssertion{
+		Contract:      Ref{Type: "Router"},
+		Signature:     "owner()(address)",
+		ExpectAddress: &Ref{Type: "RBACTimelock"},
+	}, list[1].EVMCall)
+	assert.Equal(t, "MINTER_ROLE", list[2].Role.Role)
+	assert.Equal(t, "100", list[3].Balance.Min)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`
+assertions:
+  - addressRef: {type: Router}
+  - chainSelector: 1
+    addressRef: {type: Router}
+    balance: {account: {type: Router}, min: "1"}
+  - chainSelector: 1
+    evmCall: {contract: {type: Router}, signature: owner()(address)}
+  - chainSelector: 1
+    addressRef: {version: 1.0.0}
+`))
+	require.ErrorContains(t, err, "assertion 0 (address ref Router exists): chainSelector is required")
+	require.ErrorContains(t, err, "assertion 1 (address ref Router exists): expected exactly one of addressRef, evmCall, role and balance, got 2")
+	require.ErrorContains(t, err, "assertion 2 (Router.owner()(address)): expected exactly one of expect and expectAddress")
+	require.ErrorContains(t, err, "assertion 3 (address ref  1.0.0 exists): addressRef requires an address or a type")
+
+	_, err = Parse([]byte(`assertions: {}`))
+	require.ErrorContains(t, err, "failed to parse assertions")
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "assertions.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("assertions:\n  - chainSelector: 1\n    addressRef: {type: Router}\n"), 0o600))
+
+	list, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseSignature(t *testing.T) {
+	t.Parallel()
+
+	fn, err := parseSignature("balanceOf(address)(uint256)")
+	require.NoError(t, err)
+	assert.Equal(t, "balanceOf", fn.name)
+	assert.Equal(t, "70a08231", hex.EncodeToString(fn.selector()))
+	require.Len(t, fn.outputs, 1)
+	assert.Equal(t, abi.UintTy, fn.outputs[0].Type.T)
+
+	data, err := fn.pack([]string{routerAddr.Hex()})
+	require.NoError(t, err)
+	assert.Equal(t, append(fn.selector(), common.LeftPadBytes(routerAddr.Bytes(), 32)...), data)
+
+	_, err = fn.pack(nil)
+	require.EqualError(t, err, "balanceOf expects 1 args, got 0")
+
+	for _, sig := range []string{"owner", "owner()", "(address)(bool)", "f((uint8,bool))(bool)", "f(foo)(bool)"} {
+		_, err = parseSignature(sig)
+		require.Error(t, err, sig)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	t.Parallel()
+
+	newType := func(s string) abi.Type {
+		typ, err := abi.NewType(s, "", nil)
+		require.NoError(t, err)
+
+		return typ
+	}
+
+	tests := []struct {
+		typ     string
+		value   string
+		want    any
+		wantErr string
+	}{
+		{typ: "address", value: routerAddr.Hex(), want: routerAddr},
+		{typ: "address", value: "0x12", wantErr: `invalid address "0x12"`},
+		{typ: "bool", value: "true", want: true},
+		{typ: "string", value: "hello", want: "hello"},
+		{typ: "bytes", value: "0x0102", want: []byte{1, 2}},
+		{typ: "bytes4", value: "0x01020304", want: [4]byte{1, 2, 3, 4}},
+		{typ: "bytes4", value: "0x0102", wantErr: "expected 4 bytes, got 2"},
+		{typ: "uint8", value: "255", want: uint8(255)},
+		{typ: "uint8", value: "256", wantErr: `integer "256" overflows uint8`},
+		{typ: "int64", value: "-5", want: int64(-5)},
+		{typ: "uint64", value: "0x10", want: uint64(16)},
+		{typ: "uint256", value: "1000000000000000000000", want: new(big.Int).Exp(big.NewInt(10), big.NewInt(21), nil)},
+		{typ: "uint256", value: "abc", wantErr: `invalid integer "abc"`},
+		{typ: "address[]", value: "[]", wantErr: "unsupported type address[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ+"/"+tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseValue(newType(tt.typ), tt.value)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		assertion  Assertion
+		wantPassed bool
+		wantDetail string
+	}{
+		{
+			name:       "address ref exists",
+			assertion:  Assertion{AddressRef: &AddressRefAssertion{Ref: Ref{Type: "Router", Version: "1.2.0"}}},
+			wantPassed: true,
+			wantDetail: routerAddr.Hex(),
+		},
+		{
+			name:       "address ref missing",
+			assertion:  Assertion{AddressRef: &AddressRefAssertion{Ref: Ref{Type: "OnRamp"}}},
+			wantDetail: "expected exactly one address ref, found 0",
+		},
+		{
+			name:       "address ref with other address",
+			assertion:  Assertion{AddressRef: &AddressRefAssertion{Ref: Ref{Type: "Router", Address: otherAddr.Hex()}}},
+			wantDetail: "address ref has address " + routerAddr.Hex() + ", expected " + otherAddr.Hex(),
+		},
+		{
+			name:       "address ref by address",
+			assertion:  Assertion{AddressRef: &AddressRefAssertion{Ref: Ref{Address: strings.ToLower(routerAddr.Hex())}}},
+			wantPassed: true,
+			wantDetail: routerAddr.Hex(),
+		},
+		{
+			name:       "address ref by missing address",
+			assertion:  Assertion{AddressRef: &AddressRefAssertion{Ref: Ref{Address: otherAddr.Hex()}}},
+			wantDetail: "expected exactly one address ref, found 0",
+		},
+		{
+			name: "owner is the timelock",
+			assertion: Assertion{EVMCall: &EVMCallAssertion{
+				Contract: Ref{Type: "Router"}, Signature: "owner()(address)", ExpectAddress: &Ref{Type: "RBACTimelock"},
+			}},
+			wantPassed: true,
+			wantDetail: timelockAddr.Hex(),
+		},
+		{
+			name: "owner is not the expected address",
+			assertion: Assertion{EVMCall: &EVMCallAssertion{
+				Contract: Ref{Address: routerAddr.Hex()}, Signature: "owner()(address)", Expect: otherAddr.Hex(),
+			}},
+			wantDetail: "got " + timelockAddr.Hex() + ", expected " + otherAddr.Hex(),
+		},
+		{
+			name: "integer output with args",
+			assertion: Assertion{EVMCall: &EVMCallAssertion{
+				Contract: Ref{Type: "Router"}, Signature: "getFee(uint64)(uint256)", Args: []string{"5009297550715157269"}, Expect: "0x2a",
+			}},
+			wantPassed: true,
+			wantDetail: "42",
+		},
+		{
+			name: "call reverts",
+			assertion: Assertion{EVMCall: &EVMCallAssertion{
+				Contract: Ref{Address: otherAddr.Hex()}, Signature: "owner()(address)", Expect: otherAddr.Hex(),
+			}},
+			wantDetail: "call owner on " + otherAddr.Hex() + " failed: execution reverted",
+		},
+		{
+			name: "unknown contract ref",
+			assertion: Assertion{EVMCall: &EVMCallAssertion{
+				Contract: Ref{Type: "OnRamp"}, Signature: "owner()(address)", Expect: otherAddr.Hex(),
+			}},
+			wantDetail: "contract: expected exactly one address ref OnRamp, found 0",
+		},
+		{
+			name: "role is held",
+			assertion: Assertion{Role: &RoleAssertion{
+				Contract: Ref{Type: "Router"}, Role: "MINTER_ROLE", Account: Ref{Type: "RBACTimelock"},
+			}},
+			wantPassed: true,
+			wantDetail: timelockAddr.Hex() + " holds role " + crypto.Keccak256Hash([]byte("MINTER_ROLE")).Hex(),
+		},
+		{
+			name: "role is not held",
+			assertion: Assertion{Role: &RoleAssertion{
+				Contract: Ref{Type: "Router"}, Role: "DEFAULT_ADMIN_ROLE", Account: Ref{Type: "RBACTimelock"},
+			}},
+			wantDetail: timelockAddr.Hex() + " does not hold role " + common.Hash{}.Hex(),
+		},
+		{
+			name:       "balance above threshold",
+			assertion:  Assertion{Balance: &BalanceAssertion{Account: Ref{Type: "RBACTimelock"}, Min: "100"}},
+			wantPassed: true,
+			wantDetail: "100",
+		},
+		{
+			name:       "balance below threshold",
+			assertion:  Assertion{Balance: &BalanceAssertion{Account: Ref{Type: "RBACTimelock"}, Min: "1000"}},
+			wantDetail: "balance 100 is below 1000",
+		},
+		{
+			name:       "unknown chain",
+			assertion:  Assertion{ChainSelector: 1, Balance: &BalanceAssertion{Account: Ref{Address: timelockAddr.Hex()}, Min: "1"}},
+			wantDetail: "EVM chain 1 not found in environment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := tt.assertion
+			if a.ChainSelector == 0 {
+				a.ChainSelector = testSelector
+			}
+
+			report := Evaluate(t.Context(), newTestChains(t), []Assertion{a}, newTestDataStore(t))
+			require.Len(t, report.Results, 1)
+			assert.Equal(t, tt.wantPassed, report.Results[0].Passed)
+			assert.Equal(t, tt.wantDetail, report.Results[0].Detail)
+		})
+	}
+}
+
+func TestReport_WriteTable(t *testing.T) {
+	t.Parallel()
+
+	report := Report{Results: []Result{
+		{Name: "router exists", Kind: KindAddressRef, ChainSelector: 1, Passed: true, Detail: "0x1"},
+		{Name: "owner", Kind: KindEVMCall, ChainSelector: 1, Detail: "got 0x2, expected 0x3"},
+	}}
+	assert.Len(t, report.Failed(), 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteTable(&buf))
+	assert.Equal(t, `STATUS  ASSERTION      KIND        CHAIN  DETAIL
+------  ---------      ----        -----  ------
+PASS    router exists  addressRef  1      0x1
+FAIL    owner          evmCall     1      got 0x2, expected 0x3
+`, buf.String())
+}
+
+func TestPostHook(t *testing.T) {
+	t.Parallel()
+
+	// The timelock ref is only in the datastore output by the changeset
+	envDS := datastore.NewMemoryDataStore()
+	require.NoError(t, envDS.Addresses().Add(datastore.AddressRef{
+		ChainSelector: testSelector, Type: "Router", Version: semver.MustParse("1.2.0"), Address: routerAddr.Hex(),
+	}))
+	outDS := datastore.NewMemoryDataStore()
+	require.NoError(t, outDS.Addresses().Add(datastore.AddressRef{
+		ChainSelector: testSelector, Type: "RBACTimelock", Version: semver.MustParse("1.0.0"), Address: timelockAddr.Hex(),
+	}))
+
+	list := []Assertion{
+		{
+			Name:          "router is owned by the timelock",
+			ChainSelector: testSelector,
+			EVMCall: &EVMCallAssertion{
+				Contract: Ref{Type: "Router"}, Signature: "owner()(address)", ExpectAddress: &Ref{Type: "RBACTimelock"},
+			},
+		},
+	}
+	params := changeset.PostHookParams{
+		Env: changeset.HookEnv{
+			Name: "testnet", Logger: logger.Test(t), BlockChains: newTestChains(t), DataStore: envDS.Seal(),
+		},
+		ChangesetKey: "0001_deploy",
+		Output:       fdeployment.ChangesetOutput{DataStore: outDS},
+	}
+
+	hook := PostHook(list)
+	assert.Equal(t, "assertions", hook.Name)
+	assert.Equal(t, changeset.Warn, hook.FailurePolicy)
+	assert.Equal(t, changeset.Abort, PostHook(list, WithFailurePolicy(changeset.Abort)).FailurePolicy)
+	require.NoError(t, hook.Func(t.Context(), params))
+
+	t.Run("failed assertion", func(t *testing.T) {
+		t.Parallel()
+
+		failing := append(list, Assertion{
+			ChainSelector: testSelector,
+			Balance:       &BalanceAssertion{Account: Ref{Type: "RBACTimelock"}, Min: "1000"},
+		})
+		err := PostHook(failing, WithName("checks")).Func(t.Context(), params)
+		require.ErrorIs(t, err, ErrAssertionsFailed)
+		require.ErrorContains(t, err, "1 of 2 assertions failed")
+		require.ErrorContains(t, err, "balance 100 is below 1000")
+	})
+
+	t.Run("skipped after changeset error", func(t *testing.T) {
+		t.Parallel()
+
+		failedParams := params
+		failedParams.Err = errors.New("apply failed")
+		failedParams.Env.BlockChains = chain.NewBlockChains(nil)
+
+		require.NoError(t, PostHook(list).Func(t.Context(), failedParams))
+	})
+
+	t.Run("invalid assertions", func(t *testing.T) {
+		t.Parallel()
+
+		err := PostHook([]Assertion{{ChainSelector: 1}}).Func(t.Context(), params)
+		require.ErrorContains(t, err, "invalid assertions")
+	})
+}
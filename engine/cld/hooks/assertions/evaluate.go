@@ -0,0 +1,278 @@
+package assertions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+)
+
+// evaluator evaluates assertions against the chains and datastores of an environment.
+type evaluator struct {
+	blockChains chain.BlockChains
+	dataStores  []datastore.DataStore
+}
+
+// evaluate evaluates the assertion, returning the observed value if it passes, or an error
+// describing why it failed.
+func (e *evaluator) evaluate(ctx context.Context, a Assertion) (string, error) {
+	switch {
+	case a.AddressRef != nil:
+		return e.evaluateAddressRef(a.ChainSelector, a.AddressRef)
+	case a.EVMCall != nil:
+		return e.evaluateEVMCall(ctx, a.ChainSelector, a.EVMCall)
+	case a.Role != nil:
+		return e.evaluateRole(ctx, a.ChainSelector, a.Role)
+	default:
+		return e.evaluateBalance(ctx, a.ChainSelector, a.Balance)
+	}
+}
+
+// findRefs returns the address refs of the chain matching the address, type, version and
+// qualifier of the ref, from the first datastore which has any. Addresses are compared without
+// regard to case.
+func (e *evaluator) findRefs(chainSelector uint64, ref Ref) ([]datastore.AddressRef, error) {
+	if ref.Address == "" && ref.Type == "" {
+		return nil, errors.New("ref requires an address or a type")
+	}
+
+	filters := []datastore.FilterFunc[datastore.AddressRefKey, datastore.AddressRef]{
+		datastore.AddressRefByChainSelector(chainSelector),
+	}
+	if ref.Address != "" {
+		filters = append(filters, addressRefByAddressFold(ref.Address))
+	}
+	if ref.Type != "" {
+		filters = append(filters, datastore.AddressRefByType(datastore.ContractType(ref.Type)))
+	}
+	if ref.Version != "" {
+		version, err := semver.NewVersion(ref.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", ref.Version, err)
+		}
+		filters = append(filters, datastore.AddressRefByVersion(version))
+	}
+	if ref.Qualifier != "" {
+		filters = append(filters, datastore.AddressRefByQualifier(ref.Qualifier))
+	}
+
+	for _, ds := range e.dataStores {
+		if refs := ds.Addresses().Filter(filters...); len(refs) > 0 {
+			return refs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// addressRefByAddressFold returns a filter that only includes records with the address, compared
+// without regard to case so that EVM addresses match with or without checksums.
+func addressRefByAddressFold(address string) datastore.FilterFunc[datastore.AddressRefKey, datastore.AddressRef] {
+	return func(records []datastore.AddressRef) []datastore.AddressRef {
+		var filtered []datastore.AddressRef
+		for _, record := range records {
+			if strings.EqualFold(record.Address, address) {
+				filtered = append(filtered, record)
+			}
+		}
+
+		return filtered
+	}
+}
+
+// resolve returns the address of the ref on the chain.
+func (e *evaluator) resolve(chainSelector uint64, ref Ref) (common.Address, error) {
+	if ref.Address != "" {
+		if !common.IsHexAddress(ref.Address) {
+			return common.Address{}, fmt.Errorf("invalid address %q", ref.Address)
+		}
+
+		return common.HexToAddress(ref.Address), nil
+	}
+
+	refs, err := e.findRefs(chainSelector, ref)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(refs) != 1 {
+		return common.Address{}, fmt.Errorf("expected exactly one address ref %s, found %d", ref, len(refs))
+	}
+	if !common.IsHexAddress(refs[0].Address) {
+		return common.Address{}, fmt.Errorf("address ref %s has invalid address %q", ref, refs[0].Address)
+	}
+
+	return common.HexToAddress(refs[0].Address), nil
+}
+
+func (e *evaluator) evmChain(chainSelector uint64) (evm.Chain, error) {
+	c, ok := e.blockChains.EVMChains()[chainSelector]
+	if !ok {
+		return evm.Chain{}, fmt.Errorf("EVM chain %d not found in environment", chainSelector)
+	}
+
+	return c, nil
+}
+
+func (e *evaluator) evaluateAddressRef(chainSelector uint64, a *AddressRefAssertion) (string, error) {
+	// Look the ref up by its address only when it has no type, so that a ref of the type with
+	// another address is reported as such
+	lookup := a.Ref
+	if lookup.Type != "" {
+		lookup.Address = ""
+	}
+
+	refs, err := e.findRefs(chainSelector, lookup)
+	if err != nil {
+		return "", err
+	}
+	if len(refs) != 1 {
+		return "", fmt.Errorf("expected exactly one address ref, found %d", len(refs))
+	}
+	if a.Address != "" && !strings.EqualFold(refs[0].Address, a.Address) {
+		return "", fmt.Errorf("address ref has address %s, expected %s", refs[0].Address, a.Address)
+	}
+
+	return refs[0].Address, nil
+}
+
+func (e *evaluator) evaluateEVMCall(ctx context.Context, chainSelector uint64, a *EVMCallAssertion) (string, error) {
+	fn, err := parseSignature(a.Signature)
+	if err != nil {
+		return "", err
+	}
+	if len(fn.outputs) != 1 {
+		return "", fmt.Errorf("signature %q must have exactly one output", a.Signature)
+	}
+
+	expect := a.Expect
+	if a.ExpectAddress != nil {
+		addr, resolveErr := e.resolve(chainSelector, *a.ExpectAddress)
+		if resolveErr != nil {
+			return "", fmt.Errorf("expected address: %w", resolveErr)
+		}
+		expect = addr.Hex()
+	}
+
+	got, err := e.call(ctx, chainSelector, a.Contract, fn, a.Args)
+	if err != nil {
+		return "", err
+	}
+
+	equal, err := equalValue(fn.outputs[0].Type, got[0], expect)
+	if err != nil {
+		return "", err
+	}
+	observed := formatValue(got[0])
+	if !equal {
+		return "", fmt.Errorf("got %s, expected %s", observed, expect)
+	}
+
+	return observed, nil
+}
+
+func (e *evaluator) evaluateRole(ctx context.Context, chainSelector uint64, a *RoleAssertion) (string, error) {
+	role, err := roleHash(a.Role)
+	if err != nil {
+		return "", err
+	}
+	account, err := e.resolve(chainSelector, a.Account)
+	if err != nil {
+		return "", fmt.Errorf("account: %w", err)
+	}
+
+	fn, err := parseSignature("hasRole(bytes32,address)(bool)")
+	if err != nil {
+		return "", err
+	}
+	got, err := e.call(ctx, chainSelector, a.Contract, fn, []string{role.Hex(), account.Hex()})
+	if err != nil {
+		return "", err
+	}
+	if held, _ := got[0].(bool); !held {
+		return "", fmt.Errorf("%s does not hold role %s", account.Hex(), role.Hex())
+	}
+
+	return fmt.Sprintf("%s holds role %s", account.Hex(), role.Hex()), nil
+}
+
+func (e *evaluator) evaluateBalance(ctx context.Context, chainSelector uint64, a *BalanceAssertion) (string, error) {
+	minBalance, ok := new(big.Int).SetString(a.Min, 0)
+	if !ok {
+		return "", fmt.Errorf("invalid minimum balance %q", a.Min)
+	}
+	account, err := e.resolve(chainSelector, a.Account)
+	if err != nil {
+		return "", fmt.Errorf("account: %w", err)
+	}
+	c, err := e.evmChain(chainSelector)
+	if err != nil {
+		return "", err
+	}
+
+	balance, err := c.Client.BalanceAt(ctx, account, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get balance of %s: %w", account.Hex(), err)
+	}
+	if balance.Cmp(minBalance) < 0 {
+		return "", fmt.Errorf("balance %s is below %s", balance, minBalance)
+	}
+
+	return balance.String(), nil
+}
+
+// call calls the view function of the contract with the args, and returns its outputs.
+func (e *evaluator) call(ctx context.Context, chainSelector uint64, contract Ref, fn function, args []string) ([]any, error) {
+	addr, err := e.resolve(chainSelector, contract)
+	if err != nil {
+		return nil, fmt.Errorf("contract: %w", err)
+	}
+	c, err := e.evmChain(chainSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := fn.pack(args)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.Client.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("call %s on %s failed: %w", fn.name, addr.Hex(), err)
+	}
+
+	values, err := fn.outputs.Unpack(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode output of %s: %w", fn.name, err)
+	}
+
+	return values, nil
+}
+
+// roleHash returns the AccessControl role of the role name or hex role.
+func roleHash(role string) (common.Hash, error) {
+	switch {
+	case role == "DEFAULT_ADMIN_ROLE":
+		return common.Hash{}, nil
+	case strings.HasPrefix(role, "0x"):
+		b := common.FromHex(role)
+		if len(b) != common.HashLength {
+			return common.Hash{}, fmt.Errorf("invalid role %q: expected 32 bytes", role)
+		}
+
+		return common.BytesToHash(b), nil
+	case role == "":
+		return common.Hash{}, errors.New("role is required")
+	default:
+		return crypto.Keccak256Hash([]byte(role)), nil
+	}
+}
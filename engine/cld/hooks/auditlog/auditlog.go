@@ -0,0 +1,461 @@
+// Package auditlog provides global hooks which record changeset executions in a
+// tamper-evident, append-only JSON Lines log under the environment directory
+// (see [FilePath]), independent of git history.
+//
+// Every [Entry] records the operator who ran the changeset, the digest of its
+// config, a summary of its output, the hashes of its proposals and any error.
+// Entries are hash-chained: each one holds the hash of the previous entry and
+// its own hash, so [Verify] detects entries which were edited, removed or
+// reordered. Removing entries from the end of the log cannot be detected from
+// the log alone, so the head hash reported by [Verify] should be kept
+// elsewhere and checked with [Result.Contains] later.
+//
+// Usage:
+//
+//	auditlog.Register(registry, dom)
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/internal/hookevent"
+)
+
+const (
+	// LogDirName is the name of the directory of the audit log in the environment directory.
+	LogDirName = "audit"
+	// LogFileName is the name of the audit log file.
+	LogFileName = "audit.jsonl"
+
+	// OperatorEnvVar is the default environment variable read for the operator identity. When it
+	// is empty, the identity is read from the git config of the user.
+	OperatorEnvVar = "CLD_OPERATOR"
+
+	// UnknownOperator is recorded when the operator identity cannot be determined.
+	UnknownOperator = "unknown"
+
+	hookTimeout = 10 * time.Second
+)
+
+// ErrChainBroken is returned by Verify when the audit log entries do not form an intact hash
+// chain.
+var ErrChainBroken = errors.New("audit log hash chain is broken")
+
+// Event is the kind of hook which appended an Entry.
+type Event = hookevent.Event
+
+const (
+	// EventPreApply is appended by PreHook before a changeset is applied.
+	EventPreApply = hookevent.EventPreApply
+	// EventPostApply is appended by PostHook after a changeset is applied.
+	EventPostApply = hookevent.EventPostApply
+	// EventPostProposal is appended by PostProposalHook after an MCMS proposal of a changeset is
+	// executed.
+	EventPostProposal = hookevent.EventPostProposal
+)
+
+// Entry is a line of the audit log.
+type Entry struct {
+	// Seq is the position of the entry in the log, starting at 1.
+	Seq          uint64    `json:"seq"`
+	Timestamp    time.Time `json:"timestamp"`
+	Event        Event     `json:"event"`
+	Environment  string    `json:"environment"`
+	ChangesetKey string    `json:"changesetKey"`
+	// Operator identifies who ran the changeset. See WithOperator and WithOperatorEnv.
+	Operator string `json:"operator"`
+	// ConfigDigest is the SHA-256 digest of the JSON encoding of the changeset config, in the form
+	// "sha256:<hex>". It is empty if the config cannot be encoded.
+	ConfigDigest string `json:"configDigest,omitempty"`
+	// Output summarizes the changeset output. It is only set by PostHook.
+	Output *OutputSummary `json:"output,omitempty"`
+	// Error is the changeset or proposal execution error, if any.
+	Error string `json:"error,omitempty"`
+	// ProposalHashes are the SHA-256 digests of the JSON encoding of the changeset proposals, in
+	// the form "sha256:<hex>".
+	ProposalHashes []string `json:"proposalHashes,omitempty"`
+	// PrevHash is the Hash of the previous entry, empty for the first entry.
+	PrevHash string `json:"prevHash"`
+	// Hash is the SHA-256 digest of the JSON encoding of the entry without its Hash, in the form
+	// "sha256:<hex>".
+	Hash string `json:"hash"`
+}
+
+// OutputSummary summarizes a changeset output.
+type OutputSummary = hookevent.OutputSummary
+
+// computeHash returns the hash of the entry, ignoring its current Hash.
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal audit log entry: %w", err)
+	}
+	sum := sha256.Sum256(b)
+
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// FilePath returns the path of the audit log of the environment.
+func FilePath(envDir domain.EnvDir) string {
+	return filepath.Join(envDir.DirPath(), LogDirName, LogFileName)
+}
+
+// appendMu serializes appends within the process. Concurrent appends to the same log from
+// several processes are not supported.
+var appendMu sync.Mutex
+
+// Append appends the entry to the audit log at path, creating the log if needed. It sets the Seq,
+// PrevHash and Hash of the entry from the last entry of the log, and returns the appended entry.
+func Append(path string, entry Entry) (Entry, error) {
+	appendMu.Lock()
+	defer appendMu.Unlock()
+
+	last, err := lastEntry(path)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry.Seq = last.Seq + 1
+	entry.PrevHash = last.Hash
+	entry.Hash, err = entry.computeHash()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to marshal audit log entry: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Entry{}, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) //nolint:gosec // the log is not secret
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return Entry{}, fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return Entry{}, fmt.Errorf("failed to close audit log: %w", err)
+	}
+
+	return entry, nil
+}
+
+// lastEntry returns the last entry of the log at path, or a zero Entry if the log does not exist
+// or is empty.
+func lastEntry(path string) (Entry, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Entry{}, nil
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	b = bytes.TrimRight(b, "\n")
+	if len(b) == 0 {
+		return Entry{}, nil
+	}
+	if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
+		b = b[i+1:]
+	}
+
+	entry, err := decodeEntry(b)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to decode last entry of audit log %s: %w", path, err)
+	}
+
+	return entry, nil
+}
+
+// decodeEntry decodes a line of the log, rejecting unknown fields so that added fields are
+// detected as edits.
+func decodeEntry(line []byte) (Entry, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.DisallowUnknownFields()
+
+	var entry Entry
+	if err := dec.Decode(&entry); err != nil {
+		return Entry{}, err
+	}
+
+	return entry, nil
+}
+
+// Result is the result of verifying an audit log.
+type Result struct {
+	// Entries are the entries of the log, in order.
+	Entries []Entry
+}
+
+// Head returns the hash of the last entry, or an empty string if the log is empty.
+func (r Result) Head() string {
+	if len(r.Entries) == 0 {
+		return ""
+	}
+
+	return r.Entries[len(r.Entries)-1].Hash
+}
+
+// Contains returns whether the log contains an entry with the hash, such as a head hash recorded
+// earlier.
+func (r Result) Contains(hash string) bool {
+	return slices.ContainsFunc(r.Entries, func(e Entry) bool { return e.Hash == hash })
+}
+
+// Verify reads the audit log at path and verifies that its entries form an intact hash chain:
+// every entry must have the next sequence number, hold the hash of the previous entry and match
+// its own hash. It returns an error wrapping ErrChainBroken which identifies the first line that
+// breaks the chain, or an error wrapping os.ErrNotExist if there is no log.
+func Verify(path string) (Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		result Result
+		prev   Entry
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16*1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		entry, err := decodeEntry(scanner.Bytes())
+		if err != nil {
+			return result, fmt.Errorf("%w: line %d: invalid entry: %w", ErrChainBroken, lineNum, err)
+		}
+		if err = verifyEntry(entry, prev); err != nil {
+			return result, fmt.Errorf("%w: line %d: %w", ErrChainBroken, lineNum, err)
+		}
+
+		result.Entries = append(result.Entries, entry)
+		prev = entry
+	}
+	if err = scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return result, nil
+}
+
+// verifyEntry verifies that the entry follows prev in the chain.
+func verifyEntry(entry, prev Entry) error {
+	if entry.Seq != prev.Seq+1 {
+		return fmt.Errorf("expected seq %d, got %d", prev.Seq+1, entry.Seq)
+	}
+	if entry.PrevHash != prev.Hash {
+		return fmt.Errorf("seq %d: previous hash %q does not match hash %q of seq %d",
+			entry.Seq, entry.PrevHash, prev.Hash, prev.Seq,
+		)
+	}
+
+	hash, err := entry.computeHash()
+	if err != nil {
+		return err
+	}
+	if entry.Hash != hash {
+		return fmt.Errorf("seq %d: hash %q does not match its content %q", entry.Seq, entry.Hash, hash)
+	}
+
+	return nil
+}
+
+// Option configures the audit log hooks.
+type Option func(*config)
+
+type config struct {
+	failurePolicy changeset.FailurePolicy
+	operator      string
+	operatorEnv   string
+}
+
+// WithFailurePolicy overrides the failure policy of the hooks. The pre-hook defaults to
+// changeset.Abort so that changesets are not applied without being recorded. The post and
+// post-proposal hooks default to changeset.Warn, as failing them would discard the output of a
+// changeset which was already applied.
+func WithFailurePolicy(policy changeset.FailurePolicy) Option {
+	return func(c *config) {
+		c.failurePolicy = policy
+	}
+}
+
+// WithOperator records the operator identity instead of reading it from the environment or the
+// git config.
+func WithOperator(operator string) Option {
+	return func(c *config) {
+		c.operator = operator
+	}
+}
+
+// WithOperatorEnv overrides the environment variable read for the operator identity, which
+// defaults to OperatorEnvVar.
+func WithOperatorEnv(envVar string) Option {
+	return func(c *config) {
+		c.operatorEnv = envVar
+	}
+}
+
+func newConfig(failurePolicy changeset.FailurePolicy, opts []Option) *config {
+	c := &config{
+		failurePolicy: failurePolicy,
+		operatorEnv:   OperatorEnvVar,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *config) definition(name string) changeset.HookDefinition {
+	return changeset.HookDefinition{
+		Name:          name,
+		FailurePolicy: c.failurePolicy,
+		Timeout:       hookTimeout,
+	}
+}
+
+// resolveOperator returns the operator identity: the WithOperator value, the operator environment
+// variable, or "name <email>" from the git config, in that order.
+func (c *config) resolveOperator(ctx context.Context) string {
+	if c.operator != "" {
+		return c.operator
+	}
+	if v := os.Getenv(c.operatorEnv); v != "" {
+		return v
+	}
+
+	name := gitConfig(ctx, "user.name")
+	email := gitConfig(ctx, "user.email")
+	switch {
+	case name != "" && email != "":
+		return name + " <" + email + ">"
+	case name != "":
+		return name
+	case email != "":
+		return email
+	default:
+		return UnknownOperator
+	}
+}
+
+// gitConfig returns the value of the git config key, or an empty string if it is not set or git
+// is not available.
+func gitConfig(ctx context.Context, key string) string {
+	out, err := exec.CommandContext(ctx, "git", "config", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func (c *config) append(ctx context.Context, dom domain.Domain, entry Entry) error {
+	entry.Timestamp = time.Now().UTC()
+	entry.Operator = c.resolveOperator(ctx)
+
+	if _, err := Append(FilePath(dom.EnvDir(entry.Environment)), entry); err != nil {
+		return fmt.Errorf("auditlog: %w", err)
+	}
+
+	return nil
+}
+
+// PreHook returns a PreHook that appends an EventPreApply entry to the audit log of the
+// environment.
+func PreHook(dom domain.Domain, opts ...Option) changeset.PreHook {
+	c := newConfig(changeset.Abort, opts)
+
+	return changeset.PreHook{
+		HookDefinition: c.definition("audit-log-pre"),
+		Func: func(ctx context.Context, params changeset.PreHookParams) error {
+			return c.append(ctx, dom, Entry{
+				Event:        EventPreApply,
+				Environment:  params.Env.Name,
+				ChangesetKey: params.ChangesetKey,
+				ConfigDigest: hookevent.Digest(params.Config),
+			})
+		},
+	}
+}
+
+// PostHook returns a PostHook that appends an EventPostApply entry to the audit log of the
+// environment, with a summary of the changeset output, the hashes of its proposals and its error,
+// if any.
+func PostHook(dom domain.Domain, opts ...Option) changeset.PostHook {
+	c := newConfig(changeset.Warn, opts)
+
+	return changeset.PostHook{
+		HookDefinition: c.definition("audit-log-post"),
+		Func: func(ctx context.Context, params changeset.PostHookParams) error {
+			entry := Entry{
+				Event:        EventPostApply,
+				Environment:  params.Env.Name,
+				ChangesetKey: params.ChangesetKey,
+				ConfigDigest: hookevent.Digest(params.Config),
+				Output:       hookevent.SummarizeOutput(params.Output),
+			}
+			if params.Err != nil {
+				entry.Error = params.Err.Error()
+			}
+			entry.ProposalHashes = hookevent.ProposalHashes(params.Output)
+
+			return c.append(ctx, dom, entry)
+		},
+	}
+}
+
+// PostProposalHook returns a PostProposalHook that appends an EventPostProposal entry to the
+// audit log of the environment, with the hash of the executed proposal and its execution error,
+// if any.
+func PostProposalHook(dom domain.Domain, opts ...Option) changeset.PostProposalHook {
+	c := newConfig(changeset.Warn, opts)
+
+	return changeset.PostProposalHook{
+		HookDefinition: c.definition("audit-log-post-proposal"),
+		Func: func(ctx context.Context, params changeset.PostProposalHookParams) error {
+			entry := Entry{
+				Event:        EventPostProposal,
+				Environment:  params.Env.Name,
+				ChangesetKey: params.ChangesetKey,
+				ConfigDigest: hookevent.Digest(params.Config),
+				Error:        params.Err,
+			}
+			if params.Proposal != nil {
+				entry.ProposalHashes = []string{hookevent.Digest(params.Proposal)}
+			}
+
+			return c.append(ctx, dom, entry)
+		},
+	}
+}
+
+// Register adds the audit log hooks to the registry as global pre, post and post-proposal hooks,
+// so that every changeset of the registry is recorded.
+func Register(r *changeset.ChangesetsRegistry, dom domain.Domain, opts ...Option) {
+	r.AddGlobalPreHooks(PreHook(dom, opts...))
+	r.AddGlobalPostHooks(PostHook(dom, opts...))
+	r.AddGlobalPostProposalHooks(PostProposalHook(dom, opts...))
+}
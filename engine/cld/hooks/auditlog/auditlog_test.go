@@ -0,0 +1,236 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smartcontractkit/mcms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/internal/hookevent"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+// writeTestLog runs the hooks of a changeset execution and returns the path of the log.
+func writeTestLog(t *testing.T) string {
+	t.Helper()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+	ctx := t.Context()
+	cfg := map[string]int{"value": 1}
+	proposal := mcms.TimelockProposal{}
+
+	require.NoError(t, PreHook(dom, WithOperator("alice")).Func(ctx, changeset.PreHookParams{
+		Env:          changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Config:       cfg,
+	}))
+	require.NoError(t, PostHook(dom, WithOperator("alice")).Func(ctx, changeset.PostHookParams{
+		Env:          changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Config:       cfg,
+		Output: fdeployment.ChangesetOutput{
+			MCMSTimelockProposals: []mcms.TimelockProposal{proposal},
+			Jobs:                  []fdeployment.ProposedJob{{}},
+		},
+	}))
+	require.NoError(t, PostProposalHook(dom, WithOperator("bob")).Func(ctx, changeset.PostProposalHookParams{
+		Env:          changeset.ProposalHookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Config:       cfg,
+		Proposal:     &proposal,
+		Err:          "execution failed",
+	}))
+
+	return FilePath(dom.EnvDir("testnet"))
+}
+
+func TestHooks_AppendChain(t *testing.T) {
+	t.Parallel()
+
+	path := writeTestLog(t)
+
+	result, err := Verify(path)
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 3)
+
+	pre, post, postProposal := result.Entries[0], result.Entries[1], result.Entries[2]
+
+	assert.Equal(t, uint64(1), pre.Seq)
+	assert.Equal(t, EventPreApply, pre.Event)
+	assert.Equal(t, "testnet", pre.Environment)
+	assert.Equal(t, "0001_deploy", pre.ChangesetKey)
+	assert.Equal(t, "alice", pre.Operator)
+	assert.Equal(t, hookevent.Digest(map[string]int{"value": 1}), pre.ConfigDigest)
+	assert.Empty(t, pre.PrevHash)
+	assert.NotEmpty(t, pre.Hash)
+
+	assert.Equal(t, uint64(2), post.Seq)
+	assert.Equal(t, EventPostApply, post.Event)
+	assert.Equal(t, &OutputSummary{TimelockProposals: 1, Jobs: 1}, post.Output)
+	assert.Equal(t, []string{hookevent.Digest(mcms.TimelockProposal{})}, post.ProposalHashes)
+	assert.Equal(t, pre.Hash, post.PrevHash)
+
+	assert.Equal(t, uint64(3), postProposal.Seq)
+	assert.Equal(t, EventPostProposal, postProposal.Event)
+	assert.Equal(t, "bob", postProposal.Operator)
+	assert.Equal(t, "execution failed", postProposal.Error)
+	assert.Equal(t, post.Hash, postProposal.PrevHash)
+
+	assert.Equal(t, postProposal.Hash, result.Head())
+	assert.True(t, result.Contains(post.Hash))
+	assert.False(t, result.Contains("sha256:unknown"))
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tamper  func(lines [][]byte) [][]byte
+		wantErr string
+	}{
+		{
+			name: "edited entry",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[1] = bytes.Replace(lines[1], []byte(`"operator":"alice"`), []byte(`"operator":"mallory"`), 1)
+				return lines
+			},
+			wantErr: "line 2: seq 2: hash",
+		},
+		{
+			name: "removed entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines[:1], lines[2:]...)
+			},
+			wantErr: "line 2: expected seq 2, got 3",
+		},
+		{
+			name: "reordered entries",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[0], lines[1] = lines[1], lines[0]
+				return lines
+			},
+			wantErr: "line 1: expected seq 1, got 2",
+		},
+		{
+			name: "added field",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[0] = bytes.Replace(lines[0], []byte(`{`), []byte(`{"note":"x",`), 1)
+				return lines
+			},
+			wantErr: `line 1: invalid entry: json: unknown field "note"`,
+		},
+		{
+			name: "invalid line",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines, []byte("not json"))
+			},
+			wantErr: "line 4: invalid entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeTestLog(t)
+			b, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			lines := tt.tamper(bytes.Split(bytes.TrimRight(b, "\n"), []byte("\n")))
+			require.NoError(t, os.WriteFile(path, append(bytes.Join(lines, []byte("\n")), '\n'), 0o600))
+
+			_, err = Verify(path)
+			require.ErrorIs(t, err, ErrChainBroken)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerify_NotExist(t *testing.T) {
+	t.Parallel()
+
+	_, err := Verify(filepath.Join(t.TempDir(), LogFileName))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAppend_CorruptLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), LogFileName)
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+	_, err := Append(path, Entry{Event: EventPreApply})
+	require.ErrorContains(t, err, "failed to decode last entry of audit log")
+}
+
+//nolint:paralleltest // sets an environment variable
+func TestResolveOperator(t *testing.T) {
+	t.Setenv("TEST_CLD_OPERATOR", "ci-bot")
+
+	assert.Equal(t, "alice", newConfig(changeset.Abort, []Option{WithOperator("alice")}).resolveOperator(t.Context()))
+	assert.Equal(t, "ci-bot", newConfig(changeset.Abort, []Option{WithOperatorEnv("TEST_CLD_OPERATOR")}).resolveOperator(t.Context()))
+
+	// Falls back to the git config, which may not be set in the test environment
+	assert.NotEmpty(t, newConfig(changeset.Abort, []Option{WithOperatorEnv("TEST_CLD_OPERATOR_UNSET")}).resolveOperator(t.Context()))
+}
+
+func TestHooks_Metadata(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+
+	pre := PreHook(dom)
+	assert.Equal(t, "audit-log-pre", pre.Name)
+	assert.Equal(t, changeset.Abort, pre.FailurePolicy)
+	assert.Equal(t, hookTimeout, pre.Timeout)
+
+	post := PostHook(dom)
+	assert.Equal(t, "audit-log-post", post.Name)
+	assert.Equal(t, changeset.Warn, post.FailurePolicy)
+	assert.Equal(t, changeset.Abort, PostHook(dom, WithFailurePolicy(changeset.Abort)).FailurePolicy)
+
+	postProposal := PostProposalHook(dom)
+	assert.Equal(t, "audit-log-post-proposal", postProposal.Name)
+	assert.Equal(t, changeset.Warn, postProposal.FailurePolicy)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	dom := domain.NewDomain(t.TempDir(), "testdomain")
+
+	cs := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, any) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, errors.New("apply failed")
+		},
+		func(fdeployment.Environment, any) error { return nil },
+	)
+
+	r := changeset.NewChangesetsRegistry()
+	r.Add("0001_deploy", changeset.Configure(cs).With(map[string]int{"value": 1}))
+	Register(r, dom, WithOperator("alice"))
+
+	_, err := r.Apply("0001_deploy", fdeployment.Environment{
+		Name:       "testnet",
+		Logger:     logger.Test(t),
+		GetContext: context.Background,
+	})
+	require.ErrorContains(t, err, "apply failed")
+
+	result, err := Verify(FilePath(dom.EnvDir("testnet")))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 2)
+	assert.Equal(t, EventPreApply, result.Entries[0].Event)
+	assert.Equal(t, EventPostApply, result.Entries[1].Event)
+	assert.Equal(t, "apply failed", result.Entries[1].Error)
+}
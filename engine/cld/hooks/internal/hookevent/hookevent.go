@@ -0,0 +1,78 @@
+// Package hookevent provides the event kinds, output summaries and digests shared by the hooks
+// which record or report changeset executions, such as the webhook and audit log hooks.
+package hookevent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// Event is the kind of hook which reported a changeset execution.
+type Event string
+
+const (
+	// EventPreApply is reported by pre-hooks before a changeset is applied.
+	EventPreApply Event = "changeset.pre_apply"
+	// EventPostApply is reported by post-hooks after a changeset is applied.
+	EventPostApply Event = "changeset.post_apply"
+	// EventPostProposal is reported by post-proposal hooks after an MCMS proposal of a changeset
+	// is executed.
+	EventPostProposal Event = "changeset.post_proposal"
+)
+
+// OutputSummary summarizes a changeset output.
+type OutputSummary struct {
+	TimelockProposals int `json:"timelockProposals"`
+	Proposals         int `json:"proposals"`
+	Jobs              int `json:"jobs"`
+	AddressRefs       int `json:"addressRefs"`
+}
+
+// SummarizeOutput returns the summary of the changeset output.
+func SummarizeOutput(out fdeployment.ChangesetOutput) *OutputSummary {
+	summary := &OutputSummary{
+		TimelockProposals: len(out.MCMSTimelockProposals),
+		Proposals:         len(out.MCMSProposals),
+		Jobs:              len(out.Jobs),
+	}
+	if out.DataStore != nil {
+		if refs, err := out.DataStore.Addresses().Fetch(); err == nil {
+			summary.AddressRefs = len(refs)
+		}
+	}
+
+	return summary
+}
+
+// ProposalHashes returns the digests of the timelock proposals of the changeset output, followed
+// by the digests of its proposals.
+func ProposalHashes(out fdeployment.ChangesetOutput) []string {
+	var hashes []string
+	for _, p := range out.MCMSTimelockProposals {
+		hashes = append(hashes, Digest(p))
+	}
+	for _, p := range out.MCMSProposals {
+		hashes = append(hashes, Digest(p))
+	}
+
+	return hashes
+}
+
+// Digest returns the SHA-256 digest of the JSON encoding of v in the form "sha256:<hex>", or an
+// empty string if v is nil or cannot be encoded.
+func Digest(v any) string {
+	if v == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,43 @@
+package hookevent
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/smartcontractkit/mcms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+func TestSummarizeOutput(t *testing.T) {
+	t.Parallel()
+
+	ds := datastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+		Address: "0x1", ChainSelector: 1, Type: "Router", Version: semver.MustParse("1.0.0"),
+	}))
+
+	out := fdeployment.ChangesetOutput{
+		MCMSTimelockProposals: []mcms.TimelockProposal{{}, {}},
+		MCMSProposals:         []mcms.Proposal{{}},
+		DataStore:             ds,
+	}
+
+	assert.Equal(t, &OutputSummary{TimelockProposals: 2, Proposals: 1, AddressRefs: 1}, SummarizeOutput(out))
+	assert.Equal(t,
+		[]string{Digest(mcms.TimelockProposal{}), Digest(mcms.TimelockProposal{}), Digest(mcms.Proposal{})},
+		ProposalHashes(out),
+	)
+	assert.Empty(t, ProposalHashes(fdeployment.ChangesetOutput{}))
+}
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", Digest(map[string]int{}))
+	assert.Empty(t, Digest(nil))
+	assert.Empty(t, Digest(func() {}))
+}
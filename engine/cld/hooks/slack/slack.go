@@ -11,7 +11,11 @@
 //
 //	Configure(myCS).With(cfg).
 //	    WithPreHooks(slack.Notify(channel, "deploying tokens")).
-//	    WithPostHooks(slack.Result(channel))
+//	    WithPostHooks(slack.Result(channel)).
+//	    WithPostProposalHooks(slack.ProposalResult(channel))
+//
+// Notify and Result post independent messages. To group the messages of a
+// changeset in a thread, use the hooks of a [Thread] instead.
 package slack
 
 import (
@@ -62,15 +66,19 @@ type attachment struct {
 }
 
 type chatPostMessagePayload struct {
-	Channel     string       `json:"channel"`
-	Text        string       `json:"text"`
-	Blocks      []blockObj   `json:"blocks,omitempty"`
-	Attachments []attachment `json:"attachments"`
+	Channel        string       `json:"channel"`
+	ThreadTS       string       `json:"thread_ts,omitempty"`
+	ReplyBroadcast bool         `json:"reply_broadcast,omitempty"`
+	Text           string       `json:"text"`
+	Blocks         []blockObj   `json:"blocks,omitempty"`
+	Attachments    []attachment `json:"attachments"`
 }
 
 type chatPostMessageResponse struct {
 	OK    bool   `json:"ok"`
 	Error string `json:"error,omitempty"`
+	// TS is the timestamp of the posted message, which identifies the thread of its replies.
+	TS string `json:"ts,omitempty"`
 }
 
 func newHeader(text string) blockObj {
@@ -120,17 +128,9 @@ func Notify(channel, message string) changeset.PreHook {
 				return nil
 			}
 
-			fallback := fmt.Sprintf("Changeset %s: %s", params.ChangesetKey, message)
-			header := newHeader(":rocket: Changeset Starting")
-			attBlocks := []blockObj{
-				newFields(
-					mrkdwn("Changeset", "`"+params.ChangesetKey+"`"),
-					mrkdwn("Message", message),
-				),
-				newContext("Posted by deployment hooks"),
-			}
+			_, err := post(ctx, token, notifyMessage(channel, params.ChangesetKey, message))
 
-			return post(ctx, token, channel, fallback, colorBlue, header, attBlocks)
+			return err
 		},
 	}
 }
@@ -154,42 +154,45 @@ func Result(channel string) changeset.PostHook {
 				return nil
 			}
 
-			var fallback, color string
-			var header blockObj
-			var attBlocks []blockObj
-
-			if params.Err != nil {
-				fallback = fmt.Sprintf("Changeset %s failed: %v", params.ChangesetKey, params.Err)
-				color = colorRed
-				header = newHeader(":x: Changeset Failed")
-				attBlocks = []blockObj{
-					newFields(
-						mrkdwn("Changeset", "`"+params.ChangesetKey+"`"),
-						mrkdwn("Status", ":x: Failed"),
-					),
-					newSection(fmt.Sprintf("*Error*\n> %v", params.Err)),
-					newContext("Posted by deployment hooks"),
-				}
-			} else {
-				fallback = fmt.Sprintf("Changeset %s succeeded", params.ChangesetKey)
-				color = colorGreen
-				header = newHeader(":white_check_mark: Changeset Succeeded")
-				attBlocks = []blockObj{
-					newFields(
-						mrkdwn("Changeset", "`"+params.ChangesetKey+"`"),
-						mrkdwn("Status", ":white_check_mark: Succeeded"),
-					),
-					newContext("Posted by deployment hooks"),
-				}
+			_, err := post(ctx, token, resultMessage(channel, params.ChangesetKey, params.Err))
+
+			return err
+		},
+	}
+}
+
+// ProposalResult returns a PostProposalHook that posts the per-chain execution
+// status of the proposal of a changeset, from its MCMS timelock execution
+// reports, to channel using the Slack chat.postMessage API.
+// The bot token is read from SLACK_BOT_TOKEN at execution time.
+// Success renders with a green color bar, failure with red.
+// Uses Warn policy with a 10s timeout. No-ops when the env var is empty.
+func ProposalResult(channel string) changeset.PostProposalHook {
+	return changeset.PostProposalHook{
+		HookDefinition: changeset.HookDefinition{
+			Name:          "slack-proposal-result",
+			FailurePolicy: changeset.Warn,
+			Timeout:       hookTimeout,
+		},
+		Func: func(ctx context.Context, params changeset.PostProposalHookParams) error {
+			token := os.Getenv(TokenEnvVar)
+			if token == "" {
+				params.Env.Logger.Warnw("slack hook skipped: token is empty", "hook", "slack-proposal-result", "env", TokenEnvVar)
+				return nil
 			}
 
-			return post(ctx, token, channel, fallback, color, header, attBlocks)
+			msg, _ := proposalMessage(channel, params)
+			_, err := post(ctx, token, msg)
+
+			return err
 		},
 	}
 }
 
-func post(ctx context.Context, token, channel, fallback, color string, header blockObj, attBlocks []blockObj) error {
-	body, err := json.Marshal(chatPostMessagePayload{
+// newMessage returns a message with the header block and the attachment blocks rendered with a
+// color bar.
+func newMessage(channel, fallback, color string, header blockObj, attBlocks []blockObj) chatPostMessagePayload {
+	return chatPostMessagePayload{
 		Channel: channel,
 		Text:    fallback,
 		Blocks:  []blockObj{header},
@@ -197,41 +200,95 @@ func post(ctx context.Context, token, channel, fallback, color string, header bl
 			Color:  color,
 			Blocks: attBlocks,
 		}},
-	})
+	}
+}
+
+// notifyMessage returns the message announcing that the changeset is starting.
+func notifyMessage(channel, changesetKey, message string) chatPostMessagePayload {
+	fallback := fmt.Sprintf("Changeset %s: %s", changesetKey, message)
+	header := newHeader(":rocket: Changeset Starting")
+	attBlocks := []blockObj{
+		newFields(
+			mrkdwn("Changeset", "`"+changesetKey+"`"),
+			mrkdwn("Message", message),
+		),
+		newContext("Posted by deployment hooks"),
+	}
+
+	return newMessage(channel, fallback, colorBlue, header, attBlocks)
+}
+
+// resultMessage returns the message reporting the success or failure of the changeset.
+func resultMessage(channel, changesetKey string, applyErr error) chatPostMessagePayload {
+	var fallback, color string
+	var header blockObj
+	var attBlocks []blockObj
+
+	if applyErr != nil {
+		fallback = fmt.Sprintf("Changeset %s failed: %v", changesetKey, applyErr)
+		color = colorRed
+		header = newHeader(":x: Changeset Failed")
+		attBlocks = []blockObj{
+			newFields(
+				mrkdwn("Changeset", "`"+changesetKey+"`"),
+				mrkdwn("Status", ":x: Failed"),
+			),
+			newSection(fmt.Sprintf("*Error*\n> %v", applyErr)),
+			newContext("Posted by deployment hooks"),
+		}
+	} else {
+		fallback = fmt.Sprintf("Changeset %s succeeded", changesetKey)
+		color = colorGreen
+		header = newHeader(":white_check_mark: Changeset Succeeded")
+		attBlocks = []blockObj{
+			newFields(
+				mrkdwn("Changeset", "`"+changesetKey+"`"),
+				mrkdwn("Status", ":white_check_mark: Succeeded"),
+			),
+			newContext("Posted by deployment hooks"),
+		}
+	}
+
+	return newMessage(channel, fallback, color, header, attBlocks)
+}
+
+// post posts the message with the Slack chat.postMessage API and returns its timestamp.
+func post(ctx context.Context, token string, msg chatPostMessagePayload) (string, error) {
+	body, err := json.Marshal(msg)
 	if err != nil {
-		return fmt.Errorf("slack: marshal payload: %w", err)
+		return "", fmt.Errorf("slack: marshal payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackAPIPostURL, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("slack: create request: %w", err)
+		return "", fmt.Errorf("slack: create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Authorization", "Bearer "+token)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("slack: send request: %w", err)
+		return "", fmt.Errorf("slack: send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("slack: unexpected status %d", resp.StatusCode)
+		return "", fmt.Errorf("slack: unexpected status %d", resp.StatusCode)
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("slack: read response: %w", err)
+		return "", fmt.Errorf("slack: read response: %w", err)
 	}
 
 	var apiResp chatPostMessageResponse
 	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return fmt.Errorf("slack: unmarshal response: %w", err)
+		return "", fmt.Errorf("slack: unmarshal response: %w", err)
 	}
 
 	if !apiResp.OK {
-		return fmt.Errorf("slack: API error: %s", apiResp.Error)
+		return "", fmt.Errorf("slack: API error: %s", apiResp.Error)
 	}
 
-	return nil
+	return apiResp.TS, nil
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
 	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
 	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
 )
@@ -218,3 +220,50 @@ func TestResult_Metadata(t *testing.T) {
 	assert.Equal(t, changeset.Warn, hook.FailurePolicy)
 	assert.Equal(t, 10*time.Second, hook.Timeout)
 }
+
+//nolint:paralleltest // mutates package-level slackAPIPostURL and env var
+func TestProposalResult_PostsPerChainStatus(t *testing.T) {
+	t.Setenv(TokenEnvVar, "xoxb-token")
+	fake := newFakeSlack(t)
+
+	hook := ProposalResult("#deploys")
+	err := hook.Func(t.Context(), proposalParams(t,
+		executeReport(chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector, changeset.StatusSuccess, ""),
+	))
+	require.NoError(t, err)
+
+	messages := fake.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "#deploys", messages[0].Channel)
+	assert.Empty(t, messages[0].ThreadTS)
+	assert.Equal(t, "Proposal of changeset 0001_deploy executed", messages[0].Text)
+
+	require.Len(t, messages[0].Blocks, 1)
+	assert.Contains(t, messages[0].Blocks[0].Text.Text, "Proposal Executed")
+
+	att := messages[0].Attachments[0]
+	assert.Equal(t, colorGreen, att.Color)
+	require.Len(t, att.Blocks, 3)
+	assert.Contains(t, att.Blocks[0].Fields[1].Text, "testnet")
+	assert.Equal(t, "*ethereum-testnet-sepolia*\n:white_check_mark: 1 executed", att.Blocks[1].Fields[0].Text)
+	assert.Equal(t, "context", att.Blocks[2].Type)
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestProposalResult_EmptyToken_Noop(t *testing.T) {
+	t.Setenv(TokenEnvVar, "")
+
+	hook := ProposalResult("#deploys")
+	err := hook.Func(t.Context(), proposalParams(t))
+
+	require.NoError(t, err)
+}
+
+func TestProposalResult_Metadata(t *testing.T) {
+	t.Parallel()
+
+	hook := ProposalResult("#ch")
+	assert.Equal(t, "slack-proposal-result", hook.Name)
+	assert.Equal(t, changeset.Warn, hook.FailurePolicy)
+	assert.Equal(t, 10*time.Second, hook.Timeout)
+}
@@ -0,0 +1,155 @@
+package slack
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+const (
+	// maxListedOperations is the number of operations listed in an operations summary.
+	maxListedOperations = 10
+	// maxFieldsPerSection is the number of fields Slack renders in a section block.
+	maxFieldsPerSection = 10
+)
+
+// operationsMessage returns the message summarizing the operations reports of the changeset
+// output, listing failed operations first.
+func operationsMessage(channel, changesetKey string, reports []operations.Report[any, any]) chatPostMessagePayload {
+	ordered := slices.Clone(reports)
+	slices.SortStableFunc(ordered, func(a, b operations.Report[any, any]) int {
+		switch {
+		case a.Err != nil && b.Err == nil:
+			return -1
+		case a.Err == nil && b.Err != nil:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	failed := 0
+	for _, r := range reports {
+		if r.Err != nil {
+			failed++
+		}
+	}
+
+	lines := make([]string, 0, maxListedOperations+1)
+	for _, r := range ordered[:min(len(ordered), maxListedOperations)] {
+		line := fmt.Sprintf(":white_check_mark: `%s` %s", r.Def.ID, r.Def.Version)
+		if r.Err != nil {
+			line = fmt.Sprintf(":x: `%s` %s: %s", r.Def.ID, r.Def.Version, r.Err.Message)
+		}
+		lines = append(lines, line)
+	}
+	if len(ordered) > maxListedOperations {
+		lines = append(lines, fmt.Sprintf("…and %d more", len(ordered)-maxListedOperations))
+	}
+
+	color := colorGreen
+	if failed > 0 {
+		color = colorRed
+	}
+	fallback := fmt.Sprintf("Changeset %s ran %d operations, %d failed", changesetKey, len(reports), failed)
+	header := newHeader(":gear: Operations")
+	attBlocks := []blockObj{
+		newFields(
+			mrkdwn("Succeeded", fmt.Sprint(len(reports)-failed)),
+			mrkdwn("Failed", fmt.Sprint(failed)),
+		),
+		newSection(strings.Join(lines, "\n")),
+		newContext("Posted by deployment hooks"),
+	}
+
+	return newMessage(channel, fallback, color, header, attBlocks)
+}
+
+// chainStatus is the execution status of the operations of a proposal on one chain.
+type chainStatus struct {
+	selector uint64
+	executed int
+	noOps    int
+	errors   []string
+}
+
+// proposalMessage returns the message reporting the per-chain execution status of the proposal
+// of the changeset, and whether its execution failed.
+func proposalMessage(channel string, params changeset.PostProposalHookParams) (chatPostMessagePayload, bool) {
+	var statuses []*chainStatus
+	byChain := make(map[uint64]*chainStatus)
+	for _, r := range params.Reports {
+		s, ok := byChain[r.Input.ChainSelector]
+		if !ok {
+			s = &chainStatus{selector: r.Input.ChainSelector}
+			byChain[s.selector] = s
+			statuses = append(statuses, s)
+		}
+
+		switch r.Status {
+		case changeset.StatusFailed:
+			s.errors = append(s.errors, r.Error)
+		case changeset.StatusNoOp:
+			s.noOps++
+		default:
+			s.executed++
+		}
+	}
+	slices.SortFunc(statuses, func(a, b *chainStatus) int {
+		return strings.Compare(chainName(a.selector), chainName(b.selector))
+	})
+
+	failed := params.Err != ""
+	fields := make([]textObj, 0, len(statuses))
+	for _, s := range statuses {
+		status := fmt.Sprintf(":white_check_mark: %d executed", s.executed)
+		if s.noOps > 0 {
+			status += fmt.Sprintf(", %d no-op", s.noOps)
+		}
+		if len(s.errors) > 0 {
+			failed = true
+			status = fmt.Sprintf(":x: %d failed: %s", len(s.errors), strings.Join(s.errors, "; "))
+		}
+		fields = append(fields, mrkdwn(chainName(s.selector), status))
+	}
+
+	fallback := fmt.Sprintf("Proposal of changeset %s executed", params.ChangesetKey)
+	color := colorGreen
+	header := newHeader(":white_check_mark: Proposal Executed")
+	if failed {
+		fallback = fmt.Sprintf("Proposal of changeset %s failed", params.ChangesetKey)
+		color = colorRed
+		header = newHeader(":x: Proposal Execution Failed")
+	}
+
+	attBlocks := []blockObj{
+		newFields(
+			mrkdwn("Changeset", "`"+params.ChangesetKey+"`"),
+			mrkdwn("Environment", params.Env.Name),
+		),
+	}
+	for chunk := range slices.Chunk(fields, maxFieldsPerSection) {
+		attBlocks = append(attBlocks, newFields(chunk...))
+	}
+	if params.Err != "" {
+		attBlocks = append(attBlocks, newSection(fmt.Sprintf("*Error*\n> %s", params.Err)))
+	}
+	attBlocks = append(attBlocks, newContext("Posted by deployment hooks"))
+
+	return newMessage(channel, fallback, color, header, attBlocks), failed
+}
+
+// chainName returns the name of the chain, or its selector if it is unknown.
+func chainName(selector uint64) string {
+	name, err := chainsel.GetChainNameFromSelector(selector)
+	if err != nil || name == "" {
+		return fmt.Sprint(selector)
+	}
+
+	return name
+}
@@ -0,0 +1,154 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+var semver100 = semver.MustParse("1.0.0")
+
+func fdeploymentOutput(reports ...operations.Report[any, any]) fdeployment.ChangesetOutput {
+	return fdeployment.ChangesetOutput{Reports: reports}
+}
+
+func executeReport(selector uint64, status changeset.MCMSReportStatus, errMsg string) changeset.MCMSTimelockExecuteReport {
+	return changeset.MCMSTimelockExecuteReport{
+		Type:   changeset.MCMSTimelockExecuteReportType,
+		Status: status,
+		Error:  errMsg,
+		Input:  changeset.MCMSTimelockExecuteReportInput{ChainSelector: selector},
+	}
+}
+
+func proposalParams(t *testing.T, reports ...changeset.MCMSTimelockExecuteReport) changeset.PostProposalHookParams {
+	t.Helper()
+
+	return changeset.PostProposalHookParams{
+		Env:          changeset.ProposalHookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Reports:      reports,
+	}
+}
+
+func TestOperationsMessage(t *testing.T) {
+	t.Parallel()
+
+	reports := make([]operations.Report[any, any], 0, maxListedOperations+2)
+	for i := range maxListedOperations + 1 {
+		reports = append(reports, testReport(fmt.Sprintf("op-%d", i), nil))
+	}
+	reports = append(reports, testReport("failing-op", errors.New("boom")))
+
+	msg := operationsMessage("#ch", "0001_deploy", reports)
+
+	assert.Equal(t, "#ch", msg.Channel)
+	assert.Equal(t, "Changeset 0001_deploy ran 12 operations, 1 failed", msg.Text)
+	att := msg.Attachments[0]
+	assert.Equal(t, colorRed, att.Color)
+	require.Len(t, att.Blocks, 3)
+	assert.Contains(t, att.Blocks[0].Fields[0].Text, "11")
+	assert.Contains(t, att.Blocks[0].Fields[1].Text, "1")
+
+	// Failed operations are listed first, and the list is truncated
+	lines := att.Blocks[1].Text.Text
+	assert.Contains(t, lines, ":x: `failing-op` 1.0.0: boom\n:white_check_mark: `op-0` 1.0.0")
+	assert.NotContains(t, lines, "`op-9`")
+	assert.Contains(t, lines, "…and 2 more")
+}
+
+func TestProposalMessage(t *testing.T) {
+	t.Parallel()
+
+	sepolia := chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector
+	fuji := chainsel.AVALANCHE_TESTNET_FUJI.Selector
+
+	tests := []struct {
+		name       string
+		reports    []changeset.MCMSTimelockExecuteReport
+		execErr    string
+		wantFailed bool
+		wantText   string
+		wantFields map[string]string
+	}{
+		{
+			name: "success",
+			reports: []changeset.MCMSTimelockExecuteReport{
+				executeReport(sepolia, changeset.StatusSuccess, ""),
+				executeReport(sepolia, changeset.StatusNoOp, ""),
+				executeReport(fuji, changeset.StatusSuccess, ""),
+			},
+			wantText: "Proposal of changeset 0001_deploy executed",
+			wantFields: map[string]string{
+				chainName(sepolia): ":white_check_mark: 1 executed, 1 no-op",
+				chainName(fuji):    ":white_check_mark: 1 executed",
+			},
+		},
+		{
+			name: "failed chain",
+			reports: []changeset.MCMSTimelockExecuteReport{
+				executeReport(sepolia, changeset.StatusSuccess, ""),
+				executeReport(fuji, changeset.StatusFailed, "execution reverted"),
+			},
+			wantFailed: true,
+			wantText:   "Proposal of changeset 0001_deploy failed",
+			wantFields: map[string]string{
+				chainName(sepolia): ":white_check_mark: 1 executed",
+				chainName(fuji):    ":x: 1 failed: execution reverted",
+			},
+		},
+		{
+			name:       "execution error",
+			execErr:    "failed to connect",
+			wantFailed: true,
+			wantText:   "Proposal of changeset 0001_deploy failed",
+			wantFields: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := proposalParams(t, tt.reports...)
+			params.Err = tt.execErr
+
+			msg, failed := proposalMessage("#ch", params)
+			assert.Equal(t, tt.wantFailed, failed)
+			assert.Equal(t, tt.wantText, msg.Text)
+
+			att := msg.Attachments[0]
+			fields := make(map[string]string)
+			for _, b := range att.Blocks[1:] {
+				for _, f := range b.Fields {
+					label, value, _ := strings.Cut(f.Text, "\n")
+					fields[strings.Trim(label, "*")] = value
+				}
+			}
+			assert.Equal(t, tt.wantFields, fields)
+
+			if tt.execErr != "" {
+				assert.Contains(t, att.Blocks[len(att.Blocks)-2].Text.Text, tt.execErr)
+			}
+		})
+	}
+}
+
+func TestChainName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ethereum-testnet-sepolia", chainName(chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector))
+	assert.Equal(t, "12345", chainName(12345))
+}
@@ -0,0 +1,175 @@
+package slack
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+)
+
+// Thread posts the notifications of changesets to threads of a channel, so that a pipeline run
+// does not scatter independent messages across the channel: the pre-hook returned by Start starts
+// a thread per changeset, and the hooks returned by Result and ProposalResult reply in it.
+// Failures are also broadcast to the channel.
+//
+// Threads are kept in memory, keyed by environment and changeset, so the hooks only reply to
+// threads started by the same process. Otherwise, such as when a proposal is executed by a later
+// run, they start a new thread.
+//
+// Usage:
+//
+//	thread := slack.NewThread(channel)
+//	Configure(myCS).With(cfg).
+//	    WithPreHooks(thread.Start("deploying tokens")).
+//	    WithPostHooks(thread.Result()).
+//	    WithPostProposalHooks(thread.ProposalResult())
+type Thread struct {
+	channel string
+
+	mu      sync.Mutex
+	threads map[threadKey]string // Timestamps of the messages starting the threads
+}
+
+// threadKey identifies the thread of a changeset in an environment.
+type threadKey struct {
+	envName      string
+	changesetKey string
+}
+
+// NewThread returns a Thread posting to channel.
+func NewThread(channel string) *Thread {
+	return &Thread{
+		channel: channel,
+		threads: make(map[threadKey]string),
+	}
+}
+
+// Start returns a PreHook that starts the thread of the changeset with a message announcing it.
+// Uses Warn policy with a 10s timeout. No-ops when SLACK_BOT_TOKEN is empty.
+func (t *Thread) Start(message string) changeset.PreHook {
+	return changeset.PreHook{
+		HookDefinition: changeset.HookDefinition{
+			Name:          "slack-thread-start",
+			FailurePolicy: changeset.Warn,
+			Timeout:       hookTimeout,
+		},
+		Func: func(ctx context.Context, params changeset.PreHookParams) error {
+			token := os.Getenv(TokenEnvVar)
+			if token == "" {
+				params.Env.Logger.Warnw("slack hook skipped: token is empty", "hook", "slack-thread-start", "env", TokenEnvVar)
+				return nil
+			}
+
+			ts, err := post(ctx, token, notifyMessage(t.channel, params.ChangesetKey, message))
+			if err != nil {
+				return err
+			}
+			t.setThread(params.Env.Name, params.ChangesetKey, ts)
+
+			return nil
+		},
+	}
+}
+
+// Result returns a PostHook that replies in the thread of the changeset with its success or
+// failure, followed by a summary of its operations reports if it has any. Uses Warn policy with a
+// 10s timeout. No-ops when SLACK_BOT_TOKEN is empty.
+func (t *Thread) Result() changeset.PostHook {
+	return changeset.PostHook{
+		HookDefinition: changeset.HookDefinition{
+			Name:          "slack-thread-result",
+			FailurePolicy: changeset.Warn,
+			Timeout:       hookTimeout,
+		},
+		Func: func(ctx context.Context, params changeset.PostHookParams) error {
+			token := os.Getenv(TokenEnvVar)
+			if token == "" {
+				params.Env.Logger.Warnw("slack hook skipped: token is empty", "hook", "slack-thread-result", "env", TokenEnvVar)
+				return nil
+			}
+
+			msg := resultMessage(t.channel, params.ChangesetKey, params.Err)
+			ts, err := t.reply(ctx, token, params.Env.Name, params.ChangesetKey, msg, params.Err != nil)
+			if err != nil {
+				return err
+			}
+
+			if len(params.Output.Reports) == 0 {
+				return nil
+			}
+			msg = operationsMessage(t.channel, params.ChangesetKey, params.Output.Reports)
+			msg.ThreadTS = ts
+			_, err = post(ctx, token, msg)
+
+			return err
+		},
+	}
+}
+
+// ProposalResult returns a PostProposalHook that replies in the thread of the changeset with the
+// per-chain execution status of its proposal. Uses Warn policy with a 10s timeout. No-ops when
+// SLACK_BOT_TOKEN is empty.
+func (t *Thread) ProposalResult() changeset.PostProposalHook {
+	return changeset.PostProposalHook{
+		HookDefinition: changeset.HookDefinition{
+			Name:          "slack-thread-proposal-result",
+			FailurePolicy: changeset.Warn,
+			Timeout:       hookTimeout,
+		},
+		Func: func(ctx context.Context, params changeset.PostProposalHookParams) error {
+			token := os.Getenv(TokenEnvVar)
+			if token == "" {
+				params.Env.Logger.Warnw("slack hook skipped: token is empty", "hook", "slack-thread-proposal-result", "env", TokenEnvVar)
+				return nil
+			}
+
+			msg, failed := proposalMessage(t.channel, params)
+			_, err := t.reply(ctx, token, params.Env.Name, params.ChangesetKey, msg, failed)
+
+			return err
+		},
+	}
+}
+
+// reply posts the message in the thread of the changeset and returns the timestamp of the
+// thread. If the changeset has no thread, the message starts one. Failures in a thread are also
+// broadcast to the channel.
+func (t *Thread) reply(
+	ctx context.Context, token, envName, changesetKey string, msg chatPostMessagePayload, failed bool,
+) (string, error) {
+	ts, ok := t.thread(envName, changesetKey)
+	if !ok {
+		started, err := post(ctx, token, msg)
+		if err != nil {
+			return "", err
+		}
+		t.setThread(envName, changesetKey, started)
+
+		return started, nil
+	}
+
+	msg.ThreadTS = ts
+	msg.ReplyBroadcast = failed
+	if _, err := post(ctx, token, msg); err != nil {
+		return "", err
+	}
+
+	return ts, nil
+}
+
+func (t *Thread) thread(envName, changesetKey string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts, ok := t.threads[threadKey{envName: envName, changesetKey: changesetKey}]
+
+	return ts, ok && ts != ""
+}
+
+func (t *Thread) setThread(envName, changesetKey, ts string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.threads[threadKey{envName: envName, changesetKey: changesetKey}] = ts
+}
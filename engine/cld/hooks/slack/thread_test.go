@@ -0,0 +1,257 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+// fakeMessage is a message received by fakeSlack.
+type fakeMessage struct {
+	chatPostMessagePayload
+
+	TS    string
+	Token string
+}
+
+// fakeSlack is a local fake of the Slack chat.postMessage API. It assigns a timestamp to every
+// message and, like Slack, rejects replies to threads which do not exist.
+type fakeSlack struct {
+	mu       sync.Mutex
+	messages []fakeMessage
+}
+
+func newFakeSlack(t *testing.T) *fakeSlack {
+	t.Helper()
+
+	f := &fakeSlack{}
+	srv := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(srv.Close)
+	withTestURL(t, srv.URL)
+
+	return f
+}
+
+func (f *fakeSlack) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var msg fakeMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg.chatPostMessagePayload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := chatPostMessageResponse{OK: true}
+	if msg.ThreadTS != "" && !f.hasThreadLocked(msg.Channel, msg.ThreadTS) {
+		resp = chatPostMessageResponse{Error: "thread_not_found"}
+	} else {
+		msg.TS = fmt.Sprintf("1700000000.%06d", len(f.messages)+1)
+		msg.Token = r.Header.Get("Authorization")
+		f.messages = append(f.messages, msg)
+		resp.TS = msg.TS
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeSlack) hasThreadLocked(channel, ts string) bool {
+	for _, m := range f.messages {
+		if m.Channel == channel && m.TS == ts && m.ThreadTS == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *fakeSlack) Messages() []fakeMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]fakeMessage(nil), f.messages...)
+}
+
+func testReport(id string, err error) operations.Report[any, any] {
+	return operations.NewReport[any, any](
+		operations.Definition{ID: id, Version: semver100}, nil, nil, err,
+	)
+}
+
+//nolint:paralleltest // mutates package-level slackAPIPostURL and env var
+func TestThread_RepliesInThread(t *testing.T) {
+	t.Setenv(TokenEnvVar, "xoxb-token")
+	fake := newFakeSlack(t)
+
+	thread := NewThread("#deploys")
+	env := changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)}
+
+	require.NoError(t, thread.Start("deploying tokens").Func(t.Context(), changeset.PreHookParams{
+		Env: env, ChangesetKey: "0001_deploy",
+	}))
+	require.NoError(t, thread.Result().Func(t.Context(), changeset.PostHookParams{
+		Env:          env,
+		ChangesetKey: "0001_deploy",
+	}))
+
+	messages := fake.Messages()
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, "Bearer xoxb-token", messages[0].Token)
+	assert.Equal(t, "Changeset 0001_deploy: deploying tokens", messages[0].Text)
+	assert.Empty(t, messages[0].ThreadTS)
+
+	assert.Equal(t, "Changeset 0001_deploy succeeded", messages[1].Text)
+	assert.Equal(t, messages[0].TS, messages[1].ThreadTS)
+	assert.False(t, messages[1].ReplyBroadcast)
+}
+
+//nolint:paralleltest // mutates package-level slackAPIPostURL and env var
+func TestThread_FailureWithOperationsSummary(t *testing.T) {
+	t.Setenv(TokenEnvVar, "xoxb-token")
+	fake := newFakeSlack(t)
+
+	thread := NewThread("#deploys")
+	env := changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)}
+
+	require.NoError(t, thread.Start("deploying tokens").Func(t.Context(), changeset.PreHookParams{
+		Env: env, ChangesetKey: "0001_deploy",
+	}))
+
+	// A changeset in another environment gets its own thread
+	require.NoError(t, thread.Start("deploying tokens").Func(t.Context(), changeset.PreHookParams{
+		Env: changeset.HookEnv{Name: "mainnet", Logger: logger.Test(t)}, ChangesetKey: "0001_deploy",
+	}))
+
+	require.NoError(t, thread.Result().Func(t.Context(), changeset.PostHookParams{
+		Env:          env,
+		ChangesetKey: "0001_deploy",
+		Output: fdeploymentOutput(
+			testReport("deploy-token", nil),
+			testReport("set-pool", errors.New("tx reverted")),
+		),
+		Err: errors.New("set-pool failed"),
+	}))
+
+	messages := fake.Messages()
+	require.Len(t, messages, 4)
+
+	result, summary := messages[2], messages[3]
+	assert.Equal(t, "Changeset 0001_deploy failed: set-pool failed", result.Text)
+	assert.Equal(t, messages[0].TS, result.ThreadTS)
+	assert.True(t, result.ReplyBroadcast)
+
+	assert.Equal(t, "Changeset 0001_deploy ran 2 operations, 1 failed", summary.Text)
+	assert.Equal(t, messages[0].TS, summary.ThreadTS)
+	assert.False(t, summary.ReplyBroadcast)
+	att := summary.Attachments[0]
+	assert.Equal(t, colorRed, att.Color)
+	require.Len(t, att.Blocks, 3)
+	assert.Equal(t, ":x: `set-pool` 1.0.0: tx reverted\n:white_check_mark: `deploy-token` 1.0.0", att.Blocks[1].Text.Text)
+}
+
+//nolint:paralleltest // mutates package-level slackAPIPostURL and env var
+func TestThread_ResultWithoutThreadStartsOne(t *testing.T) {
+	t.Setenv(TokenEnvVar, "xoxb-token")
+	fake := newFakeSlack(t)
+
+	thread := NewThread("#deploys")
+	require.NoError(t, thread.Result().Func(t.Context(), changeset.PostHookParams{
+		Env:          changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Output:       fdeploymentOutput(testReport("deploy-token", nil)),
+	}))
+
+	messages := fake.Messages()
+	require.Len(t, messages, 2)
+	assert.Empty(t, messages[0].ThreadTS)
+	assert.Equal(t, "Changeset 0001_deploy succeeded", messages[0].Text)
+	assert.Equal(t, messages[0].TS, messages[1].ThreadTS)
+}
+
+//nolint:paralleltest // mutates package-level slackAPIPostURL and env var
+func TestThread_ProposalResult(t *testing.T) {
+	t.Setenv(TokenEnvVar, "xoxb-token")
+	fake := newFakeSlack(t)
+
+	thread := NewThread("#deploys")
+	require.NoError(t, thread.Start("deploying tokens").Func(t.Context(), changeset.PreHookParams{
+		Env: changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)}, ChangesetKey: "0001_deploy",
+	}))
+
+	params := proposalParams(t,
+		executeReport(chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector, changeset.StatusFailed, "execution reverted"),
+	)
+	require.NoError(t, thread.ProposalResult().Func(t.Context(), params))
+
+	messages := fake.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, "Proposal of changeset 0001_deploy failed", messages[1].Text)
+	assert.Equal(t, messages[0].TS, messages[1].ThreadTS)
+	assert.True(t, messages[1].ReplyBroadcast)
+}
+
+//nolint:paralleltest // mutates package-level slackAPIPostURL and env var
+func TestThread_APIError(t *testing.T) {
+	t.Setenv(TokenEnvVar, "xoxb-token")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(errResponse(t, "channel_not_found"))
+	}))
+	t.Cleanup(srv.Close)
+	withTestURL(t, srv.URL)
+
+	thread := NewThread("#bad")
+	err := thread.Start("msg").Func(t.Context(), changeset.PreHookParams{
+		Env: changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)}, ChangesetKey: "0001_deploy",
+	})
+	require.ErrorContains(t, err, "channel_not_found")
+
+	// No thread was started, so the result starts one
+	err = thread.Result().Func(t.Context(), changeset.PostHookParams{
+		Env: changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)}, ChangesetKey: "0001_deploy",
+	})
+	require.ErrorContains(t, err, "channel_not_found")
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestThread_EmptyToken_Noop(t *testing.T) {
+	t.Setenv(TokenEnvVar, "")
+
+	thread := NewThread("#deploys")
+	env := changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)}
+
+	require.NoError(t, thread.Start("msg").Func(t.Context(), changeset.PreHookParams{Env: env}))
+	require.NoError(t, thread.Result().Func(t.Context(), changeset.PostHookParams{Env: env}))
+	require.NoError(t, thread.ProposalResult().Func(t.Context(), changeset.PostProposalHookParams{
+		Env: changeset.ProposalHookEnv{Logger: logger.Test(t)},
+	}))
+}
+
+func TestThread_Metadata(t *testing.T) {
+	t.Parallel()
+
+	thread := NewThread("#ch")
+	for name, def := range map[string]changeset.HookDefinition{
+		"slack-thread-start":           thread.Start("msg").HookDefinition,
+		"slack-thread-result":          thread.Result().HookDefinition,
+		"slack-thread-proposal-result": thread.ProposalResult().HookDefinition,
+	} {
+		assert.Equal(t, name, def.Name)
+		assert.Equal(t, changeset.Warn, def.FailurePolicy)
+		assert.Equal(t, 10*time.Second, def.Timeout)
+	}
+}
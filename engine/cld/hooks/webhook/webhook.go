@@ -0,0 +1,420 @@
+// Package webhook provides reference hook implementations that POST a stable,
+// versioned JSON [Envelope] describing changeset executions to any HTTP
+// endpoint, such as incident tooling, chat channels or internal dashboards.
+//
+// Requests can be signed with HMAC-SHA256 (see [Sign]), are retried on
+// transport errors, 429 and 5xx responses, and their body can be rendered from a
+// text/template instead of the default envelope. All hooks use
+// [changeset.Warn] failure policy by default so webhook errors never block the
+// changeset pipeline.
+//
+// Usage:
+//
+//	Configure(myCS).With(cfg).
+//	    WithPreHooks(webhook.PreHook(url)).
+//	    WithPostHooks(webhook.PostHook(url, webhook.WithSecretEnv("WEBHOOK_SECRET")))
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/internal/hookevent"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+const (
+	hookTimeout = 30 * time.Second
+
+	defaultMaxAttempts = 3
+	defaultBackoff     = 500 * time.Millisecond
+
+	// EnvelopeVersion is the version of the Envelope schema. It only changes on breaking changes
+	// to the envelope; fields may be added within a version.
+	EnvelopeVersion = "v1"
+
+	// SignatureHeader is the request header holding the HMAC signature of a signed request, in the
+	// form "sha256=<hex>".
+	SignatureHeader = "X-CLD-Signature"
+	// TimestampHeader is the request header holding the unix timestamp the request was signed at.
+	TimestampHeader = "X-CLD-Timestamp"
+	// EventHeader is the request header holding the Event of the request.
+	EventHeader = "X-CLD-Event"
+	// DeliveryHeader is the request header holding the Envelope ID, which is the same across the
+	// retries of a delivery.
+	DeliveryHeader = "X-CLD-Delivery"
+)
+
+// Event is the kind of hook which sent an Envelope.
+type Event = hookevent.Event
+
+const (
+	// EventPreApply is sent by PreHook before a changeset is applied.
+	EventPreApply = hookevent.EventPreApply
+	// EventPostApply is sent by PostHook after a changeset is applied.
+	EventPostApply = hookevent.EventPostApply
+	// EventPostProposal is sent by PostProposalHook after an MCMS proposal of a changeset is
+	// executed.
+	EventPostProposal = hookevent.EventPostProposal
+)
+
+// Envelope is the JSON body posted by the hooks, and the data of body templates.
+type Envelope struct {
+	Version      string    `json:"version"`
+	ID           string    `json:"id"`
+	Event        Event     `json:"event"`
+	Timestamp    time.Time `json:"timestamp"`
+	ChangesetKey string    `json:"changesetKey"`
+	Environment  string    `json:"environment"`
+	// ConfigDigest is the SHA-256 digest of the JSON encoding of the changeset config, in the form
+	// "sha256:<hex>". It is empty if the config cannot be encoded.
+	ConfigDigest string `json:"configDigest,omitempty"`
+	// Output summarizes the changeset output. It is only set by PostHook.
+	Output *OutputSummary `json:"output,omitempty"`
+	// Error is the changeset or proposal execution error, if any.
+	Error string `json:"error,omitempty"`
+	// ProposalHashes are the SHA-256 digests of the JSON encoding of the changeset proposals, in
+	// the form "sha256:<hex>": the proposals of the output for PostHook, and the executed
+	// proposal for PostProposalHook.
+	ProposalHashes []string `json:"proposalHashes,omitempty"`
+}
+
+// OutputSummary summarizes a changeset output.
+type OutputSummary = hookevent.OutputSummary
+
+// Option configures the webhook hooks.
+type Option func(*config)
+
+type config struct {
+	name          string
+	failurePolicy changeset.FailurePolicy
+	timeout       time.Duration
+	secret        string
+	secretEnv     string
+	maxAttempts   int
+	backoff       time.Duration
+	bodyTemplate  *template.Template
+	headers       map[string]string
+	client        *http.Client
+}
+
+// WithName overrides the hook name, which defaults to "webhook-pre", "webhook-post" or
+// "webhook-post-proposal". Use it to register several webhook hooks of the same kind.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithFailurePolicy overrides the failure policy of the hook, which defaults to changeset.Warn.
+func WithFailurePolicy(policy changeset.FailurePolicy) Option {
+	return func(c *config) {
+		c.failurePolicy = policy
+	}
+}
+
+// WithTimeout overrides the timeout of the hook, which includes all retries. It defaults to 30s.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// WithSecret signs the requests with the secret. See Sign.
+func WithSecret(secret string) Option {
+	return func(c *config) {
+		c.secret = secret
+	}
+}
+
+// WithSecretEnv signs the requests with the secret read from the environment variable at
+// execution time. The requests are not signed if the variable is empty. See Sign.
+func WithSecretEnv(envVar string) Option {
+	return func(c *config) {
+		c.secretEnv = envVar
+	}
+}
+
+// WithRetries sets the maximum number of attempts of a request, and the delay before the first
+// retry, which doubles after each retry. It defaults to 3 attempts starting at 500ms.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(c *config) {
+		c.maxAttempts = max(maxAttempts, 1)
+		c.backoff = backoff
+	}
+}
+
+// WithBodyTemplate renders the request body from the text/template executed with the Envelope,
+// instead of posting the envelope itself. The template can use the json function to encode a
+// value as JSON, for example {"text": {{ json .ChangesetKey }}}. It panics if the template cannot
+// be parsed.
+func WithBodyTemplate(tmpl string) Option {
+	t := template.Must(template.New("body").Funcs(template.FuncMap{"json": templateJSON}).Parse(tmpl))
+
+	return func(c *config) {
+		c.bodyTemplate = t
+	}
+}
+
+// WithHeader sets a header on the requests, overriding the default Content-Type of
+// application/json if needed.
+func WithHeader(key, value string) Option {
+	return func(c *config) {
+		c.headers[key] = value
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests, which defaults to
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+func newConfig(name string, opts []Option) *config {
+	c := &config{
+		name:          name,
+		failurePolicy: changeset.Warn,
+		timeout:       hookTimeout,
+		maxAttempts:   defaultMaxAttempts,
+		backoff:       defaultBackoff,
+		headers:       map[string]string{"Content-Type": "application/json"},
+		client:        http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *config) definition() changeset.HookDefinition {
+	return changeset.HookDefinition{
+		Name:          c.name,
+		FailurePolicy: c.failurePolicy,
+		Timeout:       c.timeout,
+	}
+}
+
+// PreHook returns a PreHook that posts an EventPreApply envelope to url.
+func PreHook(url string, opts ...Option) changeset.PreHook {
+	c := newConfig("webhook-pre", opts)
+
+	return changeset.PreHook{
+		HookDefinition: c.definition(),
+		Func: func(ctx context.Context, params changeset.PreHookParams) error {
+			env := newEnvelope(EventPreApply, params.ChangesetKey, params.Env.Name, params.Config)
+
+			return c.send(ctx, params.Env.Logger, url, env)
+		},
+	}
+}
+
+// PostHook returns a PostHook that posts an EventPostApply envelope to url, with a summary of the
+// changeset output and its error, if any.
+func PostHook(url string, opts ...Option) changeset.PostHook {
+	c := newConfig("webhook-post", opts)
+
+	return changeset.PostHook{
+		HookDefinition: c.definition(),
+		Func: func(ctx context.Context, params changeset.PostHookParams) error {
+			env := newEnvelope(EventPostApply, params.ChangesetKey, params.Env.Name, params.Config)
+			env.Output = hookevent.SummarizeOutput(params.Output)
+			if params.Err != nil {
+				env.Error = params.Err.Error()
+			}
+			env.ProposalHashes = hookevent.ProposalHashes(params.Output)
+
+			return c.send(ctx, params.Env.Logger, url, env)
+		},
+	}
+}
+
+// PostProposalHook returns a PostProposalHook that posts an EventPostProposal envelope to url,
+// with the hash of the executed proposal and its execution error, if any.
+func PostProposalHook(url string, opts ...Option) changeset.PostProposalHook {
+	c := newConfig("webhook-post-proposal", opts)
+
+	return changeset.PostProposalHook{
+		HookDefinition: c.definition(),
+		Func: func(ctx context.Context, params changeset.PostProposalHookParams) error {
+			env := newEnvelope(EventPostProposal, params.ChangesetKey, params.Env.Name, params.Config)
+			env.Error = params.Err
+			if params.Proposal != nil {
+				env.ProposalHashes = []string{hookevent.Digest(params.Proposal)}
+			}
+
+			return c.send(ctx, params.Env.Logger, url, env)
+		},
+	}
+}
+
+func newEnvelope(event Event, key, envName string, cfg any) Envelope {
+	return Envelope{
+		Version:      EnvelopeVersion,
+		ID:           uuid.NewString(),
+		Event:        event,
+		Timestamp:    time.Now().UTC(),
+		ChangesetKey: key,
+		Environment:  envName,
+		ConfigDigest: hookevent.Digest(cfg),
+	}
+}
+
+func templateJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Sign returns the value of the SignatureHeader of a request with the body, signed with the
+// secret at the unix timestamp sent in the TimestampHeader. The signature is the hex-encoded
+// HMAC-SHA256 of "<timestamp>.<body>", so receivers can verify a request by recomputing it and
+// comparing it with hmac.Equal.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *config) body(env Envelope) ([]byte, error) {
+	if c.bodyTemplate == nil {
+		body, err := json.Marshal(env)
+		if err != nil {
+			return nil, fmt.Errorf("webhook: marshal envelope: %w", err)
+		}
+
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := c.bodyTemplate.Execute(&buf, env); err != nil {
+		return nil, fmt.Errorf("webhook: render body template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *config) send(ctx context.Context, lggr logger.Logger, url string, env Envelope) error {
+	body, err := c.body(env)
+	if err != nil {
+		return err
+	}
+
+	secret := c.secret
+	if c.secretEnv != "" {
+		secret = os.Getenv(c.secretEnv)
+	}
+
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		err = c.post(ctx, url, env, body, secret)
+		if err == nil || attempt >= c.maxAttempts || !isRetryable(err) {
+			break
+		}
+
+		lggr.Warnw("webhook request failed, retrying",
+			"hook", c.name, "attempt", attempt, "backoff", backoff, "error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook: %w (last error: %w)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return err
+}
+
+// maxDrainBytes is the most of a response body read before the connection is released.
+const maxDrainBytes = 64 << 10
+
+// statusError is returned for non-2xx responses.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook: unexpected status %d", e.code)
+}
+
+// transportError is returned when the request could not be sent or its response not received.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "webhook: send request: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable returns whether a failed request should be retried: on transport errors, 429 and 5xx
+// responses. Any other error, such as a malformed URL, fails the same way on every attempt.
+func isRetryable(err error) bool {
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code == http.StatusTooManyRequests || se.code >= http.StatusInternalServerError
+	}
+
+	var te *transportError
+	return errors.As(err, &te)
+}
+
+func (c *config) post(ctx context.Context, url string, env Envelope, body []byte, secret string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: create request: %w", err)
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(EventHeader, string(env.Event))
+	req.Header.Set(DeliveryHeader, env.ID)
+	if secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return &transportError{err: err}
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded part of the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &statusError{code: resp.StatusCode}
+	}
+
+	return nil
+}
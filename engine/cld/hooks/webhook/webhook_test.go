@@ -0,0 +1,352 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/mcms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/hooks/internal/hookevent"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newTestServer returns a server which responds with the statuses in order, then 200, and the
+// requests it received.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, func() []receivedRequest) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []receivedRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		status := http.StatusOK
+		if len(requests) < len(statuses) {
+			status = statuses[len(requests)]
+		}
+		requests = append(requests, receivedRequest{header: r.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return requests
+	}
+}
+
+func decodeEnvelope(t *testing.T, body []byte) Envelope {
+	t.Helper()
+
+	var env Envelope
+	require.NoError(t, json.Unmarshal(body, &env))
+
+	return env
+}
+
+func TestPreHook(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t)
+
+	hook := PreHook(srv.URL)
+	err := hook.Func(t.Context(), changeset.PreHookParams{
+		Env:          changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Config:       map[string]int{"value": 1},
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "application/json", reqs[0].header.Get("Content-Type"))
+	assert.Equal(t, string(EventPreApply), reqs[0].header.Get(EventHeader))
+	assert.Empty(t, reqs[0].header.Get(SignatureHeader))
+
+	env := decodeEnvelope(t, reqs[0].body)
+	assert.Equal(t, EnvelopeVersion, env.Version)
+	assert.Equal(t, EventPreApply, env.Event)
+	assert.Equal(t, "0001_deploy", env.ChangesetKey)
+	assert.Equal(t, "testnet", env.Environment)
+	assert.Equal(t, reqs[0].header.Get(DeliveryHeader), env.ID)
+	assert.WithinDuration(t, time.Now(), env.Timestamp, time.Minute)
+	// sha256 of {"value":1}
+	assert.Equal(t, "sha256:48208f9428d64634bd8e28ff345bf0eab60d53c18fa2fbdb0b9bc1e84df2b5f6", env.ConfigDigest)
+	assert.Nil(t, env.Output)
+	assert.Empty(t, env.Error)
+}
+
+func TestPostHook(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t)
+
+	hook := PostHook(srv.URL)
+	err := hook.Func(t.Context(), changeset.PostHookParams{
+		Env:          changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Output: fdeployment.ChangesetOutput{
+			MCMSTimelockProposals: []mcms.TimelockProposal{{}, {}},
+			Jobs:                  []fdeployment.ProposedJob{{}},
+		},
+		Err: errors.New("tx reverted"),
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, string(EventPostApply), reqs[0].header.Get(EventHeader))
+
+	env := decodeEnvelope(t, reqs[0].body)
+	assert.Equal(t, EventPostApply, env.Event)
+	assert.Empty(t, env.ConfigDigest)
+	assert.Equal(t, &OutputSummary{TimelockProposals: 2, Jobs: 1}, env.Output)
+	assert.Equal(t, "tx reverted", env.Error)
+	require.Len(t, env.ProposalHashes, 2)
+	assert.Equal(t, hookevent.Digest(mcms.TimelockProposal{}), env.ProposalHashes[0])
+}
+
+func TestPostProposalHook(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t)
+
+	proposal := &mcms.TimelockProposal{}
+	hook := PostProposalHook(srv.URL)
+	err := hook.Func(t.Context(), changeset.PostProposalHookParams{
+		Env:          changeset.ProposalHookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Proposal:     proposal,
+		Err:          "execution failed",
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+
+	env := decodeEnvelope(t, reqs[0].body)
+	assert.Equal(t, EventPostProposal, env.Event)
+	assert.Equal(t, "execution failed", env.Error)
+	assert.Equal(t, []string{hookevent.Digest(proposal)}, env.ProposalHashes)
+	assert.Nil(t, env.Output)
+}
+
+func TestHook_Signed(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t)
+
+	hook := PreHook(srv.URL, WithSecret("s3cret"))
+	err := hook.Func(t.Context(), changeset.PreHookParams{
+		Env:          changeset.HookEnv{Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+
+	timestamp, err := strconv.ParseInt(reqs[0].header.Get(TimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("s3cret", timestamp, reqs[0].body), reqs[0].header.Get(SignatureHeader))
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestHook_SignedWithSecretEnv(t *testing.T) {
+	t.Setenv("WEBHOOK_TEST_SECRET", "s3cret")
+	srv, requests := newTestServer(t)
+
+	hook := PreHook(srv.URL, WithSecretEnv("WEBHOOK_TEST_SECRET"))
+	err := hook.Func(t.Context(), changeset.PreHookParams{
+		Env: changeset.HookEnv{Logger: logger.Test(t)},
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+
+	timestamp, err := strconv.ParseInt(reqs[0].header.Get(TimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("s3cret", timestamp, reqs[0].body), reqs[0].header.Get(SignatureHeader))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign("secret", 1700000000, []byte("{}")),
+	)
+	assert.NotEqual(t, Sign("secret", 1700000000, []byte("{}")), Sign("secret", 1700000001, []byte("{}")))
+	assert.NotEqual(t, Sign("secret", 1700000000, []byte("{}")), Sign("other", 1700000000, []byte("{}")))
+}
+
+func TestHook_Retries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      string
+		wantRequests int
+	}{
+		{
+			name:         "succeeds after retryable failures",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		{
+			name:         "gives up after max attempts",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			wantErr:      "webhook: unexpected status 502",
+			wantRequests: 3,
+		},
+		{
+			name:         "does not retry client errors",
+			statuses:     []int{http.StatusBadRequest},
+			wantErr:      "webhook: unexpected status 400",
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, requests := newTestServer(t, tt.statuses...)
+
+			hook := PreHook(srv.URL, WithRetries(3, time.Millisecond))
+			err := hook.Func(t.Context(), changeset.PreHookParams{
+				Env: changeset.HookEnv{Logger: logger.Test(t)},
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			reqs := requests()
+			require.Len(t, reqs, tt.wantRequests)
+
+			// Retries are the same delivery
+			for _, req := range reqs {
+				assert.Equal(t, reqs[0].header.Get(DeliveryHeader), req.header.Get(DeliveryHeader))
+				assert.Equal(t, reqs[0].body, req.body)
+			}
+		})
+	}
+}
+
+func TestHook_DoesNotRetryMalformedURL(t *testing.T) {
+	t.Parallel()
+
+	// A retry would wait for the backoff, far longer than the test timeout
+	hook := PreHook("http://[::1", WithRetries(3, time.Hour))
+	err := hook.Func(t.Context(), changeset.PreHookParams{
+		Env: changeset.HookEnv{Logger: logger.Test(t)},
+	})
+	require.ErrorContains(t, err, "webhook: create request")
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "transport error", err: &transportError{err: errors.New("connection refused")}, want: true},
+		{name: "too many requests", err: &statusError{code: http.StatusTooManyRequests}, want: true},
+		{name: "server error", err: &statusError{code: http.StatusBadGateway}, want: true},
+		{name: "client error", err: &statusError{code: http.StatusNotFound}, want: false},
+		{name: "other error", err: errors.New("webhook: create request: bad url"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
+
+func TestHook_BodyTemplate(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newTestServer(t)
+
+	hook := PostHook(srv.URL,
+		WithBodyTemplate(`{"text": {{ json (printf "%s on %s: %s" .ChangesetKey .Environment .Error) }}}`),
+		WithHeader("Content-Type", "application/vnd.test+json"),
+	)
+	err := hook.Func(t.Context(), changeset.PostHookParams{
+		Env:          changeset.HookEnv{Name: "testnet", Logger: logger.Test(t)},
+		ChangesetKey: "0001_deploy",
+		Err:          errors.New(`"quoted" failure`),
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "application/vnd.test+json", reqs[0].header.Get("Content-Type"))
+	assert.JSONEq(t, `{"text": "0001_deploy on testnet: \"quoted\" failure"}`, string(reqs[0].body))
+}
+
+func TestHook_BodyTemplateError(t *testing.T) {
+	t.Parallel()
+
+	hook := PreHook("http://127.0.0.1:0", WithBodyTemplate(`{{ .Missing }}`))
+	err := hook.Func(t.Context(), changeset.PreHookParams{
+		Env: changeset.HookEnv{Logger: logger.Test(t)},
+	})
+	require.ErrorContains(t, err, "webhook: render body template")
+}
+
+func TestWithBodyTemplate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { WithBodyTemplate(`{{ .Missing `) })
+}
+
+func TestHook_Metadata(t *testing.T) {
+	t.Parallel()
+
+	pre := PreHook("http://example.com")
+	assert.Equal(t, "webhook-pre", pre.Name)
+	assert.Equal(t, changeset.Warn, pre.FailurePolicy)
+	assert.Equal(t, 30*time.Second, pre.Timeout)
+
+	post := PostHook("http://example.com", WithName("incidents"), WithFailurePolicy(changeset.Abort), WithTimeout(time.Minute))
+	assert.Equal(t, "incidents", post.Name)
+	assert.Equal(t, changeset.Abort, post.FailurePolicy)
+	assert.Equal(t, time.Minute, post.Timeout)
+
+	proposal := PostProposalHook("http://example.com")
+	assert.Equal(t, "webhook-post-proposal", proposal.Name)
+}
@@ -30,6 +30,9 @@ type GenerateOptions struct {
 	ResolverManager *resolvers.ConfigResolverManager
 	FormatAsJSON    bool
 	OutputPath      string // empty = print to stdout
+	// SchemaOutputPath is the path the JSON Schema of the output is written to. Empty = no schema.
+	// YAML output references the schema so that editors can validate it.
+	SchemaOutputPath string
 }
 
 // Generate resolves the inputs file and outputs the result.
@@ -73,6 +76,7 @@ func Generate(opts GenerateOptions) (string, error) {
 	}
 
 	orderedChangesets = make([]map[string]any, 0, len(dpFile.Changesets.Content))
+	changesetNames := make([]string, 0, len(dpFile.Changesets.Content))
 	for i, itemNode := range dpFile.Changesets.Content {
 		if itemNode.Kind != yaml.MappingNode || len(itemNode.Content) != 2 {
 			return "", fmt.Errorf("invalid changeset array item at index %d: expected a mapping with exactly one key-value pair", i)
@@ -93,6 +97,7 @@ func Generate(opts GenerateOptions) (string, error) {
 			csName: map[string]any{"payload": resolvedCfg},
 		}
 		orderedChangesets = append(orderedChangesets, changesetItem)
+		changesetNames = append(changesetNames, csName)
 	}
 
 	changesetsNode := &yaml.Node{Kind: yaml.SequenceNode}
@@ -139,6 +144,16 @@ func Generate(opts GenerateOptions) (string, error) {
 		return "", fmt.Errorf("encode output: %w", err)
 	}
 
+	if opts.SchemaOutputPath != "" {
+		modeline, schemaErr := writeSchema(opts, changesetNames)
+		if schemaErr != nil {
+			return "", schemaErr
+		}
+		if !opts.FormatAsJSON {
+			outBytes = append([]byte(modeline), outBytes...)
+		}
+	}
+
 	output := string(outBytes)
 
 	if opts.OutputPath != "" {
@@ -150,6 +165,34 @@ func Generate(opts GenerateOptions) (string, error) {
 	return output, nil
 }
 
+// writeSchema writes the JSON Schema of the generated output, in which the payloads are the
+// resolved configs of the changesets, and returns the modeline referencing it.
+func writeSchema(opts GenerateOptions, changesetNames []string) (string, error) {
+	payloadTypes, err := ChangesetPayloadTypes(opts.Registry, changesetNames, true)
+	if err != nil {
+		return "", err
+	}
+
+	schema, err := GenerateSchema(payloadTypes)
+	if err != nil {
+		return "", fmt.Errorf("generate schema: %w", err)
+	}
+
+	if err = os.WriteFile(opts.SchemaOutputPath, schema, 0o644); err != nil { //nolint:gosec
+		return "", fmt.Errorf("write schema file: %w", err)
+	}
+
+	// The schema is referenced relative to the output file, if there is one
+	schemaRef := opts.SchemaOutputPath
+	if opts.OutputPath != "" {
+		if rel, relErr := filepath.Rel(filepath.Dir(opts.OutputPath), opts.SchemaOutputPath); relErr == nil {
+			schemaRef = rel
+		}
+	}
+
+	return SchemaModeline(schemaRef), nil
+}
+
 // anyToYAMLNode converts dynamic resolver output into a YAML node tree while
 // preserving numeric semantics for json.Number values.
 //
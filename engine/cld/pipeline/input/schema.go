@@ -0,0 +1,135 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+
+	cs "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+)
+
+// GenerateSchema returns a JSON Schema of a durable pipeline inputs file containing any of the
+// given changesets, keyed by changeset name, with the payload of each changeset described by its
+// Go type.
+//
+// The schema can be referenced from the inputs file so that editors validate and autocomplete it.
+// See SchemaModeline.
+func GenerateSchema(payloadTypes map[string]reflect.Type) ([]byte, error) {
+	definitions := jsonschema.Definitions{}
+	items := make([]*jsonschema.Schema, 0, len(payloadTypes))
+
+	for _, name := range slices.Sorted(maps.Keys(payloadTypes)) {
+		payload, err := cs.PayloadSchema(payloadTypes[name])
+		if err != nil {
+			return nil, fmt.Errorf("changeset %s: %w", name, err)
+		}
+
+		// Definitions are named by package path, so they can be shared by all changesets
+		maps.Copy(definitions, payload.Definitions)
+		payload.Definitions = nil
+		payload.Version = ""
+
+		items = append(items, changesetSchema(name, payload))
+	}
+
+	root := &jsonschema.Schema{
+		Version:     jsonschema.Version,
+		Title:       "Durable pipeline inputs",
+		Type:        "object",
+		Properties:  jsonschema.NewProperties(),
+		Required:    []string{"environment", "domain", "changesets"},
+		Definitions: definitions,
+	}
+	root.Properties.Set("environment", &jsonschema.Schema{Type: "string"})
+	root.Properties.Set("domain", &jsonschema.Schema{Type: "string"})
+	// Only the array format is described, as the object format keyed by changeset name is no
+	// longer accepted by the pipeline commands
+	changesets := &jsonschema.Schema{
+		Type:        "array",
+		Description: "The changesets to apply, in order. Each item is an object with the changeset name as its only key.",
+	}
+	if len(items) > 0 {
+		changesets.Items = &jsonschema.Schema{AnyOf: items}
+	}
+	root.Properties.Set("changesets", changesets)
+
+	b, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema: %w", err)
+	}
+
+	return append(b, '\n'), nil
+}
+
+// changesetSchema returns the schema of an item of the changesets array, which is an object with
+// the changeset name as its only key.
+func changesetSchema(name string, payload *jsonschema.Schema) *jsonschema.Schema {
+	entry := &jsonschema.Schema{
+		Type:                 "object",
+		Properties:           jsonschema.NewProperties(),
+		Required:             []string{"payload"},
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+	entry.Properties.Set("payload", payload)
+	entry.Properties.Set("chainOverrides", &jsonschema.Schema{
+		Type:  "array",
+		Items: &jsonschema.Schema{Type: "integer", Minimum: "0"},
+	})
+
+	item := &jsonschema.Schema{
+		Type:                 "object",
+		Properties:           jsonschema.NewProperties(),
+		Required:             []string{name},
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+	item.Properties.Set(name, entry)
+
+	return item
+}
+
+// ChangesetPayloadTypes returns the payload types of the changesets in the registry. If resolved
+// is true the config types of the changesets are returned, which is the payload of inputs
+// generated by config resolvers. Otherwise the input types of the config resolvers are returned
+// for the changesets which have one.
+func ChangesetPayloadTypes(registry *cs.ChangesetsRegistry, names []string, resolved bool) (map[string]reflect.Type, error) {
+	payloadTypes := make(map[string]reflect.Type, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+
+		cfg, err := registry.GetConfigurations(name)
+		if err != nil {
+			return nil, fmt.Errorf("get configurations for changeset %s: %w", name, err)
+		}
+
+		t := cfg.PayloadType()
+		if resolved {
+			t = cfg.InputType
+		}
+		if t == nil {
+			continue
+		}
+		payloadTypes[name] = t
+	}
+
+	return payloadTypes, nil
+}
+
+// SchemaModeline returns a YAML comment which associates the file it starts with the schema at
+// schemaPath, for editors using the YAML language server.
+func SchemaModeline(schemaPath string) string {
+	return "# yaml-language-server: $schema=" + filepath.ToSlash(schemaPath) + "\n"
+}
+
+// DefaultSchemaPath returns the path of the schema written next to an output file, which is the
+// output path with its extension replaced by ".schema.json".
+func DefaultSchemaPath(outputPath string) string {
+	return strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".schema.json"
+}
@@ -0,0 +1,178 @@
+package input
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fresolvers "github.com/smartcontractkit/chainlink-deployments-framework/changeset/resolvers"
+	fdeployment "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	cs "github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/changeset"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/cld/domain"
+)
+
+type schemaTestShared struct {
+	Name string `json:"name"`
+}
+
+type schemaTestConfigA struct {
+	Shared schemaTestShared `json:"shared"`
+	Count  int              `json:"count"`
+}
+
+type schemaTestConfigB struct {
+	Shared []schemaTestShared `json:"shared"`
+}
+
+func TestGenerateSchema(t *testing.T) {
+	t.Parallel()
+
+	b, err := GenerateSchema(map[string]reflect.Type{
+		"0002_b": reflect.TypeFor[schemaTestConfigB](),
+		"0001_a": reflect.TypeFor[schemaTestConfigA](),
+	})
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b, &schema))
+
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, []any{"environment", "domain", "changesets"}, schema["required"])
+
+	// The definitions of all changesets are merged into the root of the schema
+	defs, ok := schema["$defs"].(map[string]any)
+	require.True(t, ok)
+	assert.Len(t, defs, 3)
+	assert.Contains(t, defs, "github.com.smartcontractkit.chainlink-deployments-framework.engine.cld.pipeline.input.schemaTestShared")
+
+	// The changesets are sorted by name, and the payloads reference the root definitions
+	items := schema["properties"].(map[string]any)["changesets"].(map[string]any)["items"].(map[string]any)["anyOf"].([]any)
+	require.Len(t, items, 2)
+
+	first := items[0].(map[string]any)
+	assert.Equal(t, []any{"0001_a"}, first["required"])
+	assert.Equal(t, false, first["additionalProperties"])
+
+	entry := first["properties"].(map[string]any)["0001_a"].(map[string]any)
+	assert.Equal(t, []any{"payload"}, entry["required"])
+
+	payload := entry["properties"].(map[string]any)["payload"].(map[string]any)
+	assert.Equal(t, "#/$defs/github.com.smartcontractkit.chainlink-deployments-framework.engine.cld.pipeline.input.schemaTestConfigA", payload["$ref"])
+	assert.NotContains(t, payload, "$schema")
+	assert.NotContains(t, payload, "$defs")
+}
+
+func TestGenerateSchema_Error(t *testing.T) {
+	t.Parallel()
+
+	_, err := GenerateSchema(map[string]reflect.Type{"0001_a": reflect.TypeFor[func()]()})
+	require.ErrorContains(t, err, "changeset 0001_a: generate JSON schema for func()")
+}
+
+func TestChangesetPayloadTypes(t *testing.T) {
+	t.Parallel()
+
+	type resolverInput struct {
+		Value int `json:"value"`
+	}
+	resolver := func(resolverInput) (schemaTestConfigA, error) { return schemaTestConfigA{}, nil }
+
+	changeset := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, schemaTestConfigA) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(fdeployment.Environment, schemaTestConfigA) error { return nil },
+	)
+
+	reg := cs.NewChangesetsRegistry()
+	reg.Add("0001_resolver", cs.Configure(changeset).WithConfigResolver(fresolvers.ConfigResolver(resolver)))
+
+	got, err := ChangesetPayloadTypes(reg, []string{"0001_resolver", ""}, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]reflect.Type{"0001_resolver": reflect.TypeFor[resolverInput]()}, got)
+
+	got, err = ChangesetPayloadTypes(reg, []string{"0001_resolver"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]reflect.Type{"0001_resolver": reflect.TypeFor[schemaTestConfigA]()}, got)
+
+	_, err = ChangesetPayloadTypes(reg, []string{"0002_unknown"}, false)
+	require.ErrorContains(t, err, "get configurations for changeset 0002_unknown")
+}
+
+func TestSchemaModeline(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "# yaml-language-server: $schema=schemas/in.schema.json\n", SchemaModeline(filepath.Join("schemas", "in.schema.json")))
+}
+
+func TestDefaultSchemaPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, filepath.Join("out", "config.schema.json"), DefaultSchemaPath(filepath.Join("out", "config.yaml")))
+	assert.Equal(t, "config.schema.json", DefaultSchemaPath("config"))
+}
+
+func TestGenerate_WithSchemaOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	inputsDir := filepath.Join(dir, "domains", "mydomain", "testnet", "durable_pipelines", "inputs")
+	require.NoError(t, os.MkdirAll(inputsDir, 0o755))
+
+	inputsContent := `environment: testnet
+domain: mydomain
+changesets:
+  - 0001_cs1:
+      payload:
+        count: 1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(inputsDir, "in.yaml"), []byte(inputsContent), 0o600))
+
+	resolver := func(m map[string]any) (schemaTestConfigA, error) { return schemaTestConfigA{Count: 1}, nil }
+	rm := fresolvers.NewConfigResolverManager()
+	rm.Register(resolver, fresolvers.ResolverInfo{Description: "A"})
+
+	changeset := fdeployment.CreateChangeSet(
+		func(fdeployment.Environment, schemaTestConfigA) (fdeployment.ChangesetOutput, error) {
+			return fdeployment.ChangesetOutput{}, nil
+		},
+		func(fdeployment.Environment, schemaTestConfigA) error { return nil },
+	)
+	reg := cs.NewChangesetsRegistry()
+	reg.Add("0001_cs1", cs.Configure(changeset).WithConfigResolver(resolver))
+
+	outDir := filepath.Join(dir, "out")
+	require.NoError(t, os.MkdirAll(filepath.Join(outDir, "schemas"), 0o755))
+
+	outputPath := filepath.Join(outDir, "config.yaml")
+	schemaPath := filepath.Join(outDir, "schemas", "config.schema.json")
+
+	got, err := Generate(GenerateOptions{
+		InputsFileName:   "in.yaml",
+		Domain:           domain.NewDomain(filepath.Join(dir, domain.DomainsDirName), "mydomain"),
+		EnvKey:           "testnet",
+		Registry:         reg,
+		ResolverManager:  rm,
+		OutputPath:       outputPath,
+		SchemaOutputPath: schemaPath,
+	})
+	require.NoError(t, err)
+
+	// The schema is referenced relative to the output file
+	assert.True(t, strings.HasPrefix(got, "# yaml-language-server: $schema=schemas/config.schema.json\n"))
+
+	written, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, got, string(written))
+
+	// The payload of the generated output is the resolved config
+	schema, err := os.ReadFile(schemaPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), `"#/$defs/github.com.smartcontractkit.chainlink-deployments-framework.engine.cld.pipeline.input.schemaTestConfigA"`)
+}
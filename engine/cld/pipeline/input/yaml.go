@@ -54,7 +54,7 @@ func PrepareInputForRunByIndex(inputFileName string, index int, dom domain.Domai
 	}
 
 	if _, isArray := dpYAML.Changesets.([]any); !isArray {
-		return "", fmt.Errorf("--changeset-index can only be used with array format YAML files. Input file %s uses object format. Use --changeset instead", inputFileName)
+		return "", fmt.Errorf("input file %s: invalid 'changesets' format, expected array format", inputFileName)
 	}
 
 	changesets, err := GetAllChangesetsInOrder(dpYAML.Changesets)
@@ -343,6 +343,19 @@ func BuildChangesetInputJSON(changesetName string, changesetData any) (string, e
 		inputJSON["chainOverrides"] = chainOverridesRaw
 	}
 
+	// Other fields are passed through rather than dropped, so that a misspelt field such as
+	// "chainOverride" is rejected when the changeset decodes its input
+	for key, value := range changesetMap {
+		if key == "payload" || key == "chainOverrides" {
+			continue
+		}
+		jsonSafeValue, err := ConvertToJSONSafe(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert field %q to JSON-safe format: %w", key, err)
+		}
+		inputJSON[key] = jsonSafeValue
+	}
+
 	jsonData, err := json.Marshal(inputJSON)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal payload to JSON: %w", err)
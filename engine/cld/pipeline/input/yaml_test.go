@@ -31,6 +31,15 @@ func TestFindChangesetInData(t *testing.T) {
 			csName: "cs_b",
 			want:   map[string]any{"payload": "b"},
 		},
+		{
+			name: "namespaced key",
+			changesets: []any{
+				map[string]any{"ccip/deploy_router": map[string]any{"payload": "a"}},
+				map[string]any{"data-feeds/deploy_router": map[string]any{"payload": "b"}},
+			},
+			csName: "data-feeds/deploy_router",
+			want:   map[string]any{"payload": "b"},
+		},
 		{
 			name: "not found",
 			changesets: []any{
@@ -504,6 +513,18 @@ func TestSetChangesetEnvironmentVariable_Success(t *testing.T) {
 	require.Equal(t, map[string]any{"x": float64(1)}, decoded["payload"])
 }
 
+func TestBuildChangesetInputJSON_UnknownField(t *testing.T) {
+	t.Parallel()
+
+	// Unknown fields are kept, so the changeset rejects them when it decodes its input
+	inputJSON, err := BuildChangesetInputJSON("my_cs", map[string]any{
+		"payload":       map[string]any{"x": 1},
+		"chainOverride": []any{1},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"payload":{"x":1},"chainOverride":[1]}`, inputJSON)
+}
+
 func TestParseDurablePipelineYAML(t *testing.T) {
 	t.Parallel()
 
@@ -41,7 +41,7 @@ func ConfigureEnvironmentOptions(
 	}
 
 	if dryRun {
-		envOptions = append(envOptions, environment.WithDryRunJobDistributor())
+		envOptions = append(envOptions, environment.WithDryRunJobDistributor(), environment.WithDryRun())
 	}
 
 	return envOptions, nil
@@ -145,7 +145,16 @@ func ScaffoldEnvDir(envdir enginedomain.EnvDir) error {
 		}),
 	})
 
-	return scaffold(structure, envdir.DomainDirPath(), renderArgs)
+	if err := scaffold(structure, envdir.DomainDirPath(), renderArgs); err != nil {
+		return err
+	}
+
+	// The empty datastore is the first trusted state of the environment
+	if err := envdir.WriteDataStoreManifest(nil); err != nil {
+		return fmt.Errorf("failed to create %s env directory: %w", envdir.String(), err)
+	}
+
+	return nil
 }
 
 // fsnode represents a file system node, which can be either a directory or a file.
@@ -0,0 +1,81 @@
+package offchain
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	csav1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/csa"
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+)
+
+// ReadOnlyClient is the subset of Client which only reads from job-distributor: the Get and List
+// RPCs of the job, node and CSA services.
+type ReadOnlyClient interface {
+	GetJob(ctx context.Context, in *jobv1.GetJobRequest, opts ...grpc.CallOption) (*jobv1.GetJobResponse, error)
+	GetProposal(ctx context.Context, in *jobv1.GetProposalRequest, opts ...grpc.CallOption) (*jobv1.GetProposalResponse, error)
+	ListJobs(ctx context.Context, in *jobv1.ListJobsRequest, opts ...grpc.CallOption) (*jobv1.ListJobsResponse, error)
+	ListProposals(ctx context.Context, in *jobv1.ListProposalsRequest, opts ...grpc.CallOption) (*jobv1.ListProposalsResponse, error)
+
+	GetNode(ctx context.Context, in *nodev1.GetNodeRequest, opts ...grpc.CallOption) (*nodev1.GetNodeResponse, error)
+	ListNodes(ctx context.Context, in *nodev1.ListNodesRequest, opts ...grpc.CallOption) (*nodev1.ListNodesResponse, error)
+	ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNodeChainConfigsRequest, opts ...grpc.CallOption) (*nodev1.ListNodeChainConfigsResponse, error)
+
+	GetKeypair(ctx context.Context, in *csav1.GetKeypairRequest, opts ...grpc.CallOption) (*csav1.GetKeypairResponse, error)
+	ListKeypairs(ctx context.Context, in *csav1.ListKeypairsRequest, opts ...grpc.CallOption) (*csav1.ListKeypairsResponse, error)
+}
+
+var _ ReadOnlyClient = Client(nil)
+
+// readOnlyClient wraps a Client so that only its read RPCs are reachable. Unlike using the
+// Client as a ReadOnlyClient, it cannot be type asserted back to a Client.
+type readOnlyClient struct {
+	client Client
+}
+
+// NewReadOnlyClient returns a ReadOnlyClient which forwards the read RPCs to the client, or nil if
+// the client is nil.
+func NewReadOnlyClient(client Client) ReadOnlyClient {
+	if client == nil {
+		return nil
+	}
+
+	return &readOnlyClient{client: client}
+}
+
+func (c *readOnlyClient) GetJob(ctx context.Context, in *jobv1.GetJobRequest, opts ...grpc.CallOption) (*jobv1.GetJobResponse, error) {
+	return c.client.GetJob(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) GetProposal(ctx context.Context, in *jobv1.GetProposalRequest, opts ...grpc.CallOption) (*jobv1.GetProposalResponse, error) {
+	return c.client.GetProposal(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) ListJobs(ctx context.Context, in *jobv1.ListJobsRequest, opts ...grpc.CallOption) (*jobv1.ListJobsResponse, error) {
+	return c.client.ListJobs(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) ListProposals(ctx context.Context, in *jobv1.ListProposalsRequest, opts ...grpc.CallOption) (*jobv1.ListProposalsResponse, error) {
+	return c.client.ListProposals(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) GetNode(ctx context.Context, in *nodev1.GetNodeRequest, opts ...grpc.CallOption) (*nodev1.GetNodeResponse, error) {
+	return c.client.GetNode(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) ListNodes(ctx context.Context, in *nodev1.ListNodesRequest, opts ...grpc.CallOption) (*nodev1.ListNodesResponse, error) {
+	return c.client.ListNodes(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) ListNodeChainConfigs(ctx context.Context, in *nodev1.ListNodeChainConfigsRequest, opts ...grpc.CallOption) (*nodev1.ListNodeChainConfigsResponse, error) {
+	return c.client.ListNodeChainConfigs(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) GetKeypair(ctx context.Context, in *csav1.GetKeypairRequest, opts ...grpc.CallOption) (*csav1.GetKeypairResponse, error) {
+	return c.client.GetKeypair(ctx, in, opts...)
+}
+
+func (c *readOnlyClient) ListKeypairs(ctx context.Context, in *csav1.ListKeypairsRequest, opts ...grpc.CallOption) (*csav1.ListKeypairsResponse, error) {
+	return c.client.ListKeypairs(ctx, in, opts...)
+}
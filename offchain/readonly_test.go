@@ -0,0 +1,53 @@
+package offchain_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
+	nodev1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/node"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain"
+	"github.com/smartcontractkit/chainlink-deployments-framework/offchain/jd/memory"
+)
+
+func TestNewReadOnlyClient(t *testing.T) {
+	t.Parallel()
+
+	jd := memory.NewMemoryJobDistributor()
+	node, err := jd.RegisterNode(t.Context(), &nodev1.RegisterNodeRequest{Name: "node-1", PublicKey: "pk-1"})
+	require.NoError(t, err)
+	_, err = jd.ProposeJob(t.Context(), &jobv1.ProposeJobRequest{NodeId: node.Node.Id, Spec: "externalJobID = '" + uuid.NewString() + "'"})
+	require.NoError(t, err)
+
+	client := offchain.NewReadOnlyClient(jd)
+
+	// Writes are not reachable through the read-only client
+	_, ok := client.(offchain.Client)
+	assert.False(t, ok)
+
+	nodes, err := client.ListNodes(t.Context(), &nodev1.ListNodesRequest{})
+	require.NoError(t, err)
+	require.Len(t, nodes.Nodes, 1)
+
+	got, err := client.GetNode(t.Context(), &nodev1.GetNodeRequest{Id: node.Node.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", got.Node.Name)
+
+	jobs, err := client.ListJobs(t.Context(), &jobv1.ListJobsRequest{})
+	require.NoError(t, err)
+	require.Len(t, jobs.Jobs, 1)
+
+	proposals, err := client.ListProposals(t.Context(), &jobv1.ListProposalsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, proposals.Proposals, 1)
+}
+
+func TestNewReadOnlyClient_Nil(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, offchain.NewReadOnlyClient(nil))
+}